@@ -36,7 +36,7 @@ func newMigrateStorageCommand() *cli.Command {
 				Name:    "type",
 				Aliases: []string{"t"},
 				Value:   "",
-				Usage:   "Type of stored files to copy.  Allowed types: 'attachments', 'lfs', 'avatars', 'repo-avatars', 'repo-archivers', 'packages', 'actions-log', 'actions-artifacts'",
+				Usage:   "Type of stored files to copy.  Allowed types: 'attachments', 'lfs', 'avatars', 'repo-avatars', 'repo-archivers', 'packages', 'actions-log', 'actions-artifacts', 'actions-cache'",
 			},
 			&cli.StringFlag{
 				Name:    "storage",
@@ -214,6 +214,25 @@ func migrateActionsArtifacts(ctx context.Context, dstStorage storage.ObjectStora
 	})
 }
 
+func migrateActionsCache(ctx context.Context, dstStorage storage.ObjectStorage) error {
+	return db.Iterate(ctx, nil, func(ctx context.Context, cache *actions_model.ActionCache) error {
+		if !cache.Complete {
+			return nil
+		}
+
+		_, err := storage.Copy(dstStorage, cache.StoragePath(), storage.ActionsCache, cache.StoragePath())
+		if err != nil {
+			// ignore files that do not exist
+			if errors.Is(err, fs.ErrNotExist) {
+				return nil
+			}
+			return err
+		}
+
+		return nil
+	})
+}
+
 func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 	if err := initDB(ctx); err != nil {
 		return err
@@ -295,6 +314,7 @@ func runMigrateStorage(ctx context.Context, cmd *cli.Command) error {
 		"packages":          migratePackages,
 		"actions-log":       migrateActionsLog,
 		"actions-artifacts": migrateActionsArtifacts,
+		"actions-cache":     migrateActionsCache,
 	}
 
 	tp := strings.ToLower(cmd.String("type"))
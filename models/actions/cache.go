@@ -0,0 +1,212 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(ActionCache))
+}
+
+// ActionCache is a cache entry uploaded by "actions/cache", its content is stored in the actions cache storage.
+// A cache entry is scoped by the repository and the git ref of the run which creates it.
+type ActionCache struct {
+	ID            int64  `xorm:"pk autoincr"`
+	RepoID        int64  `xorm:"index(repo_ref)"`
+	Ref           string `xorm:"index(repo_ref)"` // the git ref of the run which creates the cache, e.g. "refs/heads/main"
+	Key           string `xorm:"VARCHAR(512) NOT NULL"`
+	Version       string `xorm:"NOT NULL"` // the hash of the cache paths and compression method calculated by "actions/cache"
+	Size          int64
+	Complete      bool  `xorm:"index"` // whether the content has been uploaded and finalized
+	CreatorTaskID int64 // the task which reserves the cache entry and uploads the content
+
+	CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+	LastUsedUnix timeutil.TimeStamp `xorm:"index"` // the last time the cache was created or restored, used to evict the least recently used caches
+}
+
+// StoragePath returns the path of the cache content in the actions cache storage
+func (c *ActionCache) StoragePath() string {
+	return fmt.Sprintf("%d/%d", c.RepoID, c.ID)
+}
+
+// UploadBlocksPath returns the directory of the uploaded blocks in the actions cache storage, they will be merged when the upload is committed
+func (c *ActionCache) UploadBlocksPath() string {
+	return fmt.Sprintf("tmp-upload/%d/%d", c.RepoID, c.ID)
+}
+
+// CreateCache reserves a new cache entry for the task, it returns an ErrAlreadyExist error
+// if there is an entry with the same key and version in the same scope, whether it is complete or not.
+func CreateCache(ctx context.Context, task *ActionTask, ref, key, version string) (*ActionCache, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (*ActionCache, error) {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{
+			"repo_id": task.RepoID,
+			"ref":     ref,
+			"`key`":   key,
+			"version": version,
+		}).Exist(new(ActionCache))
+		if err != nil {
+			return nil, err
+		}
+		if exist {
+			return nil, util.NewAlreadyExistErrorf("cache entry %q with version %q already exists", key, version)
+		}
+
+		c := &ActionCache{
+			RepoID:        task.RepoID,
+			Ref:           ref,
+			Key:           key,
+			Version:       version,
+			CreatorTaskID: task.ID,
+			LastUsedUnix:  timeutil.TimeStampNow(),
+		}
+		if _, err := db.GetEngine(ctx).Insert(c); err != nil {
+			return nil, err
+		}
+		return c, nil
+	})
+}
+
+// GetReservedCache returns the cache entry reserved by the task which hasn't been finalized
+func GetReservedCache(ctx context.Context, taskID int64, key, version string) (*ActionCache, error) {
+	var c ActionCache
+	has, err := db.GetEngine(ctx).Where(builder.Eq{
+		"creator_task_id": taskID,
+		"`key`":           key,
+		"version":         version,
+		"complete":        false,
+	}).Get(&c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("cache entry %q with version %q doesn't exist", key, version)
+	}
+	return &c, nil
+}
+
+// GetCacheByID returns the cache entry of the repository by id
+func GetCacheByID(ctx context.Context, repoID, id int64) (*ActionCache, error) {
+	var c ActionCache
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "repo_id": repoID}).Get(&c)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("cache entry %d doesn't exist", id)
+	}
+	return &c, nil
+}
+
+// FinalizeCache marks the cache entry as complete
+func FinalizeCache(ctx context.Context, c *ActionCache, size int64) error {
+	c.Size = size
+	c.Complete = true
+	c.LastUsedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols("size", "complete", "last_used_unix").Update(c)
+	return err
+}
+
+// UpdateCacheLastUsed refreshes the last used time of the cache entry, so it won't be evicted soon
+func UpdateCacheLastUsed(ctx context.Context, c *ActionCache) error {
+	c.LastUsedUnix = timeutil.TimeStampNow()
+	_, err := db.GetEngine(ctx).ID(c.ID).Cols("last_used_unix").NoAutoTime().Update(c)
+	return err
+}
+
+// FindCacheToRestore finds the cache entry to restore for the given key and restore keys.
+// The refs are searched in order, and for every ref the key is matched exactly,
+// then the restore keys are matched by prefix, the newest entry wins if there are multiple matches.
+func FindCacheToRestore(ctx context.Context, repoID int64, refs []string, version, key string, restoreKeys []string) (*ActionCache, error) {
+	for _, ref := range refs {
+		cond := builder.Eq{
+			"repo_id":  repoID,
+			"ref":      ref,
+			"version":  version,
+			"complete": true,
+		}
+		if c, err := findNewestCache(ctx, cond.And(builder.Eq{"`key`": key})); err != nil || c != nil {
+			return c, err
+		}
+		for _, prefix := range restoreKeys {
+			keyCond := builder.Expr("`key` LIKE ? ESCAPE '!'", likePrefixEscaper.Replace(prefix)+"%")
+			if c, err := findNewestCache(ctx, cond.And(keyCond)); err != nil || c != nil {
+				return c, err
+			}
+		}
+	}
+	return nil, util.NewNotExistErrorf("cache entry %q with version %q doesn't exist", key, version)
+}
+
+// likePrefixEscaper escapes the wildcards of LIKE with "!", which is a plain character in the string literals of all databases
+var likePrefixEscaper = strings.NewReplacer("!", "!!", "%", "!%", "_", "!_", "[", "![")
+
+// findNewestCache returns the newest cache entry matching the condition, or nil if there is none
+func findNewestCache(ctx context.Context, cond builder.Cond) (*ActionCache, error) {
+	var c ActionCache
+	has, err := db.GetEngine(ctx).Where(cond).Desc("created_unix", "id").Get(&c)
+	if err != nil || !has {
+		return nil, err
+	}
+	return &c, nil
+}
+
+type FindCachesOptions struct {
+	db.ListOptions
+	RepoID         int64
+	Complete       optional.Option[bool]
+	LastUsedBefore timeutil.TimeStamp
+}
+
+func (opts FindCachesOptions) ToOrders() string {
+	return "last_used_unix, id"
+}
+
+var _ db.FindOptionsOrder = (*FindCachesOptions)(nil)
+
+func (opts FindCachesOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.Complete.Has() {
+		cond = cond.And(builder.Eq{"complete": opts.Complete.Value()})
+	}
+	if opts.LastUsedBefore > 0 {
+		cond = cond.And(builder.Lt{"last_used_unix": opts.LastUsedBefore})
+	}
+	return cond
+}
+
+// RepoCacheSize is the total size of the complete cache entries of a repository
+type RepoCacheSize struct {
+	RepoID int64
+	Size   int64
+}
+
+// FindReposExceedingCacheSize returns the repositories whose total cache size exceeds the limit
+func FindReposExceedingCacheSize(ctx context.Context, limit int64) ([]*RepoCacheSize, error) {
+	sizes := make([]*RepoCacheSize, 0, 10)
+	return sizes, db.GetEngine(ctx).Table("action_cache").
+		Where(builder.Eq{"complete": true}).
+		GroupBy("repo_id").
+		Having(fmt.Sprintf("SUM(size) > %d", limit)).
+		Select("repo_id, SUM(size) AS size").
+		Find(&sizes)
+}
+
+// DeleteCacheByID deletes the record of the cache entry, the content in the storage should be deleted by the caller
+func DeleteCacheByID(ctx context.Context, id int64) error {
+	_, err := db.GetEngine(ctx).ID(id).Delete(new(ActionCache))
+	return err
+}
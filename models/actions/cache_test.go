@@ -0,0 +1,107 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCreateAndRestoreCache(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	task := &ActionTask{ID: 1, RepoID: 4}
+	create := func(ref, key string) *ActionCache {
+		c, err := CreateCache(t.Context(), task, ref, key, "v1")
+		require.NoError(t, err)
+		require.NoError(t, FinalizeCache(t.Context(), c, 1024))
+		return c
+	}
+	mainCache := create("refs/heads/main", "linux-deps-aaa")
+	featureCache := create("refs/heads/feature", "linux-deps-bbb")
+
+	_, err := CreateCache(t.Context(), task, "refs/heads/main", "linux-deps-aaa", "v1")
+	assert.ErrorIs(t, err, util.ErrAlreadyExist)
+	_, err = CreateCache(t.Context(), task, "refs/heads/main", "linux-deps-aaa", "v2")
+	assert.NoError(t, err)
+
+	cases := []struct {
+		name        string
+		refs        []string
+		key         string
+		restoreKeys []string
+		expected    *ActionCache
+	}{
+		{
+			name:     "exact key",
+			refs:     []string{"refs/heads/feature", "refs/heads/main"},
+			key:      "linux-deps-aaa",
+			expected: mainCache,
+		},
+		{
+			name:        "restore key in the current ref first",
+			refs:        []string{"refs/heads/feature", "refs/heads/main"},
+			key:         "linux-deps-ccc",
+			restoreKeys: []string{"linux-deps-"},
+			expected:    featureCache,
+		},
+		{
+			name:        "restore key in the default branch",
+			refs:        []string{"refs/heads/other", "refs/heads/main"},
+			key:         "linux-deps-ccc",
+			restoreKeys: []string{"windows-", "linux-"},
+			expected:    mainCache,
+		},
+		{
+			name:        "restore keys are not patterns",
+			refs:        []string{"refs/heads/main"},
+			key:         "linux-deps-ccc",
+			restoreKeys: []string{"linux_", "%deps"},
+		},
+		{
+			name: "other refs are invisible",
+			refs: []string{"refs/heads/other"},
+			key:  "linux-deps-aaa",
+		},
+	}
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			cache, err := FindCacheToRestore(t.Context(), task.RepoID, c.refs, "v1", c.key, c.restoreKeys)
+			if c.expected == nil {
+				assert.ErrorIs(t, err, util.ErrNotExist)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expected.ID, cache.ID)
+		})
+	}
+
+	// the cache with another version is invisible until it is finalized
+	_, err = FindCacheToRestore(t.Context(), task.RepoID, []string{"refs/heads/main"}, "v2", "linux-deps-aaa", nil)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	reserved, err := GetReservedCache(t.Context(), task.ID, "linux-deps-aaa", "v2")
+	require.NoError(t, err)
+	assert.False(t, reserved.Complete)
+}
+
+func TestFindReposExceedingCacheSize(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	for i, repoID := range []int64{1, 1, 2} {
+		c, err := CreateCache(t.Context(), &ActionTask{ID: int64(i + 1), RepoID: repoID}, "refs/heads/main", "key", string(rune('a'+i)))
+		require.NoError(t, err)
+		require.NoError(t, FinalizeCache(t.Context(), c, 600))
+	}
+
+	sizes, err := FindReposExceedingCacheSize(t.Context(), 1000)
+	require.NoError(t, err)
+	require.Len(t, sizes, 1)
+	assert.EqualValues(t, 1, sizes[0].RepoID)
+	assert.EqualValues(t, 1200, sizes[0].Size)
+}
@@ -0,0 +1,213 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// DeploymentReviewStatus represents the review status of a deployment
+type DeploymentReviewStatus int
+
+const (
+	DeploymentReviewNotRequired DeploymentReviewStatus = iota // 0, the environment doesn't require a review
+	DeploymentReviewPending                                   // 1, waiting for a reviewer
+	DeploymentReviewApproved                                  // 2
+	DeploymentReviewRejected                                  // 3
+)
+
+var deploymentReviewStatusNames = map[DeploymentReviewStatus]string{
+	DeploymentReviewNotRequired: "not_required",
+	DeploymentReviewPending:     "pending",
+	DeploymentReviewApproved:    "approved",
+	DeploymentReviewRejected:    "rejected",
+}
+
+// String returns the string name of the DeploymentReviewStatus
+func (s DeploymentReviewStatus) String() string {
+	return deploymentReviewStatusNames[s]
+}
+
+// ActionDeployment is a record of a job deploying to an environment, the records of an environment are its deployment history.
+// The status of the deployment is the status of the job.
+type ActionDeployment struct {
+	ID            int64
+	RepoID        int64              `xorm:"index"`
+	EnvironmentID int64              `xorm:"index"`
+	Environment   *ActionEnvironment `xorm:"-"`
+	RunID         int64              `xorm:"index"`
+	JobID         int64              `xorm:"index"`
+	Job           *ActionRunJob      `xorm:"-"`
+	Ref           string
+	CommitSHA     string
+	URL           string `xorm:"TEXT"` // the "url" of the job's environment
+	TriggerUserID int64
+
+	ReviewStatus  DeploymentReviewStatus `xorm:"NOT NULL DEFAULT 0"`
+	ReviewerID    int64
+	Reviewer      *user_model.User `xorm:"-"`
+	ReviewComment string           `xorm:"TEXT"`
+	// WaitUntil is the time when the wait timer of the environment elapses, the job can't start before it
+	WaitUntil timeutil.TimeStamp `xorm:"index"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionDeployment))
+}
+
+// IsWaiting returns whether the job of the deployment has to wait for a review or the wait timer
+func (d *ActionDeployment) IsWaiting() bool {
+	return d.ReviewStatus == DeploymentReviewPending || d.WaitUntil > timeutil.TimeStampNow()
+}
+
+func (d *ActionDeployment) LoadEnvironment(ctx context.Context) error {
+	if d.Environment != nil {
+		return nil
+	}
+	env, err := GetEnvironmentByID(ctx, d.RepoID, d.EnvironmentID)
+	if err != nil {
+		return err
+	}
+	d.Environment = env
+	return nil
+}
+
+func (d *ActionDeployment) LoadJob(ctx context.Context) error {
+	if d.Job != nil {
+		return nil
+	}
+	job, err := GetRunJobByRepoAndID(ctx, d.RepoID, d.JobID)
+	if err != nil {
+		return err
+	}
+	d.Job = job
+	return nil
+}
+
+func (d *ActionDeployment) LoadReviewer(ctx context.Context) error {
+	if d.Reviewer != nil || d.ReviewerID == 0 {
+		return nil
+	}
+	reviewer, err := user_model.GetPossibleUserByID(ctx, d.ReviewerID)
+	if err != nil {
+		return err
+	}
+	d.Reviewer = reviewer
+	return nil
+}
+
+func (d *ActionDeployment) LoadAttributes(ctx context.Context) error {
+	if err := d.LoadEnvironment(ctx); err != nil {
+		return err
+	}
+	if err := d.LoadJob(ctx); err != nil {
+		return err
+	}
+	return d.LoadReviewer(ctx)
+}
+
+// GetDeploymentByID returns the deployment of the repository by id
+func GetDeploymentByID(ctx context.Context, repoID, id int64) (*ActionDeployment, error) {
+	var d ActionDeployment
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "repo_id": repoID}).Get(&d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("deployment %d doesn't exist", id)
+	}
+	return &d, nil
+}
+
+// GetLatestDeploymentOfJob returns the latest deployment created by the job, a job creates a new deployment every time it is (re)run
+func GetLatestDeploymentOfJob(ctx context.Context, job *ActionRunJob) (*ActionDeployment, error) {
+	var d ActionDeployment
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": job.RepoID, "job_id": job.ID}).Desc("id").Get(&d)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("deployment of job %d doesn't exist", job.ID)
+	}
+	return &d, nil
+}
+
+// UpdateDeployment updates the deployment with the given columns
+func UpdateDeployment(ctx context.Context, d *ActionDeployment, cols ...string) error {
+	_, err := db.GetEngine(ctx).ID(d.ID).Cols(cols...).Update(d)
+	return err
+}
+
+type FindDeploymentsOptions struct {
+	db.ListOptions
+	RepoID        int64
+	EnvironmentID int64
+	RunID         int64
+	ReviewStatus  []DeploymentReviewStatus
+}
+
+func (opts FindDeploymentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.EnvironmentID > 0 {
+		cond = cond.And(builder.Eq{"environment_id": opts.EnvironmentID})
+	}
+	if opts.RunID > 0 {
+		cond = cond.And(builder.Eq{"run_id": opts.RunID})
+	}
+	if len(opts.ReviewStatus) > 0 {
+		cond = cond.And(builder.In("review_status", opts.ReviewStatus))
+	}
+	return cond
+}
+
+func (opts FindDeploymentsOptions) ToOrders() string {
+	return "id DESC"
+}
+
+var _ db.FindOptionsOrder = (*FindDeploymentsOptions)(nil)
+
+// GetLatestDeployments returns the latest deployment of every environment of the repository, the key of the map is the environment id
+func GetLatestDeployments(ctx context.Context, repoID int64) (map[int64]*ActionDeployment, error) {
+	deployments := make([]*ActionDeployment, 0, 10)
+	if err := db.GetEngine(ctx).Where(builder.In("id",
+		builder.Select("MAX(id)").From("action_deployment").
+			Where(builder.Eq{"repo_id": repoID}).
+			GroupBy("environment_id"),
+	)).Find(&deployments); err != nil {
+		return nil, err
+	}
+
+	ret := make(map[int64]*ActionDeployment, len(deployments))
+	for _, d := range deployments {
+		ret[d.EnvironmentID] = d
+	}
+	return ret, nil
+}
+
+// FindReadyDeployments returns the deployments whose jobs are waiting for approval but have been approved or need no review,
+// and their wait timers have elapsed, so the jobs can start now.
+// Only the latest deployment of a job is considered, the older ones were created by the previous attempts of the job.
+func FindReadyDeployments(ctx context.Context, limit int) ([]*ActionDeployment, error) {
+	deployments := make([]*ActionDeployment, 0, limit)
+	return deployments, db.GetEngine(ctx).
+		Join("INNER", "`action_run_job`", "`action_run_job`.id = `action_deployment`.job_id").
+		Where(builder.Eq{"`action_run_job`.status": StatusWaitingApproval}).
+		And(builder.In("`action_deployment`.review_status", DeploymentReviewNotRequired, DeploymentReviewApproved)).
+		And(builder.Lte{"`action_deployment`.wait_until": timeutil.TimeStampNow()}).
+		And("NOT EXISTS (SELECT 1 FROM `action_deployment` AS newer WHERE newer.job_id = `action_deployment`.job_id AND newer.id > `action_deployment`.id)").
+		Asc("`action_deployment`.id").
+		Limit(limit).
+		Find(&deployments)
+}
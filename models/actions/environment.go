@@ -0,0 +1,197 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionEnvironment represents a deployment environment of a repository, which is referenced by the "environment" of jobs.
+// The jobs deploying to an environment are protected by its rules, and have access to its secrets and variables.
+type ActionEnvironment struct {
+	ID              int64
+	RepoID          int64    `xorm:"UNIQUE(repo_name) NOT NULL"`
+	Name            string   `xorm:"UNIQUE(repo_name) NOT NULL"`
+	ReviewerUserIDs []int64  `xorm:"JSON TEXT"`          // the users who can approve the deployments
+	ReviewerTeamIDs []int64  `xorm:"JSON TEXT"`          // the teams whose members can approve the deployments
+	WaitTimer       int64    `xorm:"NOT NULL DEFAULT 0"` // minutes to wait before a job deploying to the environment can start
+	BranchPatterns  []string `xorm:"JSON TEXT"`          // glob patterns of the branches and tags which can deploy, empty means all of them
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// MaxEnvironmentWaitTimer is the maximum wait timer of an environment in minutes, it's the same as GitHub's (30 days)
+const MaxEnvironmentWaitTimer = 43200
+
+func init() {
+	db.RegisterModel(new(ActionEnvironment))
+}
+
+// NeedReview returns whether the deployments to the environment have to be approved by a reviewer
+func (env *ActionEnvironment) NeedReview() bool {
+	return len(env.ReviewerUserIDs) > 0 || len(env.ReviewerTeamIDs) > 0
+}
+
+// IsProtected returns whether the jobs deploying to the environment have to wait before starting
+func (env *ActionEnvironment) IsProtected() bool {
+	return env.NeedReview() || env.WaitTimer > 0
+}
+
+// CanDeployRef returns whether the git ref is allowed to deploy to the environment by the branch patterns
+func (env *ActionEnvironment) CanDeployRef(ref string) bool {
+	return len(env.BranchPatterns) == 0 || refMatchesPatterns(ref, env.BranchPatterns)
+}
+
+// refMatchesPatterns returns whether the name of the branch or tag matches one of the glob patterns,
+// the other refs (like the refs of pull requests) never match
+func refMatchesPatterns(ref string, patterns []string) bool {
+	refName := git.RefName(ref)
+	var name string
+	switch {
+	case refName.IsBranch():
+		name = refName.BranchName()
+	case refName.IsTag():
+		name = refName.TagName()
+	default:
+		return false
+	}
+	return globMatchesPatterns(name, patterns)
+}
+
+// globMatchesPatterns returns whether the string matches one of the glob patterns, the invalid patterns are ignored
+func globMatchesPatterns(s string, patterns []string) bool {
+	for _, pattern := range patterns {
+		g, err := glob.Compile(pattern, '/')
+		if err != nil {
+			log.Warn("Invalid glob pattern %q: %v", pattern, err)
+			continue
+		}
+		if g.Match(s) {
+			return true
+		}
+	}
+	return false
+}
+
+// ValidateEnvironmentName checks the name of an environment, it can't be empty, too long or contain control characters
+func ValidateEnvironmentName(name string) error {
+	if name == "" || len(name) > 255 || strings.ContainsFunc(name, func(r rune) bool { return r < ' ' || r == 0x7f }) {
+		return util.NewInvalidArgumentErrorf("invalid environment name %q", name)
+	}
+	return nil
+}
+
+// ValidateEnvironment checks the protection rules of an environment
+func ValidateEnvironment(env *ActionEnvironment) error {
+	if err := ValidateEnvironmentName(env.Name); err != nil {
+		return err
+	}
+	if env.WaitTimer < 0 || env.WaitTimer > MaxEnvironmentWaitTimer {
+		return util.NewInvalidArgumentErrorf("wait timer must be between 0 and %d minutes", MaxEnvironmentWaitTimer)
+	}
+	for _, pattern := range env.BranchPatterns {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid branch pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// CreateEnvironment creates a new environment for the repository
+func CreateEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	if err := ValidateEnvironment(env); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": env.RepoID, "name": env.Name}).Exist(new(ActionEnvironment))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("environment %q already exists", env.Name)
+		}
+		return db.Insert(ctx, env)
+	})
+}
+
+// GetEnvironmentByName returns the environment of the repository by name
+func GetEnvironmentByName(ctx context.Context, repoID int64, name string) (*ActionEnvironment, error) {
+	var env ActionEnvironment
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": repoID, "name": name}).Get(&env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment %q doesn't exist", name)
+	}
+	return &env, nil
+}
+
+// GetEnvironmentByID returns the environment of the repository by id
+func GetEnvironmentByID(ctx context.Context, repoID, id int64) (*ActionEnvironment, error) {
+	var env ActionEnvironment
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "repo_id": repoID}).Get(&env)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("environment %d doesn't exist", id)
+	}
+	return &env, nil
+}
+
+// UpdateEnvironment updates the protection rules of the environment
+func UpdateEnvironment(ctx context.Context, env *ActionEnvironment, cols ...string) error {
+	if err := ValidateEnvironment(env); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).ID(env.ID).Cols(cols...).Update(env)
+	return err
+}
+
+// DeleteEnvironment deletes the environment with its variables and deployment history, the secrets of the environment should be deleted by the caller
+func DeleteEnvironment(ctx context.Context, env *ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"environment_id": env.ID}).Delete(new(ActionDeployment)); err != nil {
+			return err
+		}
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": env.RepoID, "environment_id": env.ID}).Delete(new(ActionVariable)); err != nil {
+			return err
+		}
+		_, err := db.DeleteByID[ActionEnvironment](ctx, env.ID)
+		return err
+	})
+}
+
+type FindEnvironmentsOptions struct {
+	db.ListOptions
+	RepoID int64
+	IDs    []int64
+}
+
+func (opts FindEnvironmentsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if len(opts.IDs) > 0 {
+		cond = cond.And(builder.In("id", opts.IDs))
+	}
+	return cond
+}
+
+func (opts FindEnvironmentsOptions) ToOrders() string {
+	return "name"
+}
+
+var _ db.FindOptionsOrder = (*FindEnvironmentsOptions)(nil)
@@ -0,0 +1,59 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionEnvironment_CanDeployRef(t *testing.T) {
+	env := &ActionEnvironment{BranchPatterns: []string{"main", "release/*", "v*"}}
+	cases := map[string]bool{
+		"refs/heads/main":          true,
+		"refs/heads/release/1.0":   true,
+		"refs/heads/release/1/fix": false,
+		"refs/heads/feature":       false,
+		"refs/tags/v1.0.0":         true,
+		"refs/pull/1/head":         false,
+	}
+	for ref, expected := range cases {
+		assert.Equal(t, expected, env.CanDeployRef(ref), ref)
+	}
+
+	assert.True(t, (&ActionEnvironment{}).CanDeployRef("refs/pull/1/head"))
+}
+
+func TestCreateAndDeleteEnvironment(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	env := &ActionEnvironment{RepoID: 4, Name: "production", ReviewerUserIDs: []int64{2}, WaitTimer: 10}
+	require.NoError(t, CreateEnvironment(t.Context(), env))
+	assert.True(t, env.NeedReview())
+	assert.True(t, env.IsProtected())
+
+	assert.ErrorIs(t, CreateEnvironment(t.Context(), &ActionEnvironment{RepoID: 4, Name: "production"}), util.ErrAlreadyExist)
+	assert.ErrorIs(t, CreateEnvironment(t.Context(), &ActionEnvironment{RepoID: 4, Name: "staging", WaitTimer: MaxEnvironmentWaitTimer + 1}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, CreateEnvironment(t.Context(), &ActionEnvironment{RepoID: 4, Name: "bad\nname"}), util.ErrInvalidArgument)
+
+	_, err := InsertEnvironmentVariable(t.Context(), env, "TARGET", "prod", "")
+	require.NoError(t, err)
+	require.NoError(t, db.Insert(t.Context(), &ActionDeployment{RepoID: 4, EnvironmentID: env.ID, RunID: 1, JobID: 1}))
+
+	loaded, err := GetEnvironmentByName(t.Context(), 4, "production")
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2}, loaded.ReviewerUserIDs)
+
+	require.NoError(t, DeleteEnvironment(t.Context(), env))
+	_, err = GetEnvironmentByID(t.Context(), 4, env.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+	unittest.AssertNotExistsBean(t, &ActionVariable{EnvironmentID: env.ID})
+	unittest.AssertNotExistsBean(t, &ActionDeployment{EnvironmentID: env.ID})
+}
@@ -12,8 +12,17 @@ import (
 func TestMain(m *testing.M) {
 	unittest.MainTest(m, &unittest.TestOptions{
 		FixtureFiles: []string{
+			"action_cache.yml",
+			"action_environment.yml",
+			"action_required_workflow.yml",
+			"action_deployment.yml",
+			"action_runner_group.yml",
 			"action_runner_token.yml",
 			"action_run.yml",
+			"action_run_job.yml",
+			"action_task_annotation.yml",
+			"action_usage.yml",
+			"action_usage_quota.yml",
 			"repository.yml",
 		},
 	})
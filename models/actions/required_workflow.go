@@ -0,0 +1,196 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionRequiredWorkflow represents a workflow file of a central repository, which is enforced by an org on its repositories.
+// It runs with the push and pull_request events of the matched repositories, besides the workflows of the repositories themselves,
+// and the statuses of its jobs are required to merge the pull requests into the matched branches.
+type ActionRequiredWorkflow struct {
+	ID             int64
+	OwnerID        int64    `xorm:"UNIQUE(owner_name) NOT NULL"`
+	Name           string   `xorm:"UNIQUE(owner_name) NOT NULL"` // it's a part of the commit status contexts of the jobs, see StatusContextName
+	RepoID         int64    `xorm:"INDEX NOT NULL"`              // the central repository where the workflow file is read from, it belongs to the owner
+	WorkflowPath   string   `xorm:"TEXT NOT NULL"`               // the path of the workflow file in the central repository, like ".gitea/workflows/compliance.yml"
+	Ref            string   `xorm:"VARCHAR(255)"`                // the branch or tag of the central repository, empty means the default branch
+	RepoPatterns   []string `xorm:"JSON TEXT"`                   // glob patterns of the names of the repositories which run the workflow, empty means all of them
+	BranchPatterns []string `xorm:"JSON TEXT"`                   // glob patterns of the pushed branches and the base branches of the pull requests, empty means all of them
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionRequiredWorkflow))
+}
+
+// MatchRepoAndBranch returns whether the workflow is required for the branch of the repository
+func (w *ActionRequiredWorkflow) MatchRepoAndBranch(repo *repo_model.Repository, branch string) bool {
+	if repo.OwnerID != w.OwnerID {
+		return false
+	}
+	if len(w.RepoPatterns) > 0 && !globMatchesPatterns(repo.Name, w.RepoPatterns) {
+		return false
+	}
+	return len(w.BranchPatterns) == 0 || globMatchesPatterns(branch, w.BranchPatterns)
+}
+
+// RequiredWorkflowStatusContextPrefix is reserved for the commit status contexts of the jobs of the required workflows.
+// The statuses from other sources can't use it, so they can't satisfy the status checks of the required workflows.
+const RequiredWorkflowStatusContextPrefix = "required-workflow/"
+
+// IsRequiredWorkflowStatusContext returns whether the commit status context is reserved for the required workflows
+func IsRequiredWorkflowStatusContext(context string) bool {
+	return strings.HasPrefix(strings.TrimSpace(context), RequiredWorkflowStatusContextPrefix)
+}
+
+// StatusContextName returns the workflow name in the commit status contexts of the jobs,
+// it's namespaced by the id, so the workflows of different orgs with the same name don't collide.
+func (w *ActionRequiredWorkflow) StatusContextName() string {
+	return fmt.Sprintf("%s%d: %s", RequiredWorkflowStatusContextPrefix, w.ID, w.Name)
+}
+
+// StatusCheckContext returns the glob pattern which matches the commit status contexts of the jobs of the workflow
+func (w *ActionRequiredWorkflow) StatusCheckContext() string {
+	return w.StatusContextName() + " / *"
+}
+
+// ValidateRequiredWorkflow checks the name and the patterns of a required workflow
+func ValidateRequiredWorkflow(w *ActionRequiredWorkflow) error {
+	// the name is a part of the glob pattern of the status check contexts, so the special characters of glob are not allowed
+	if w.Name == "" || len(w.Name) > 255 || strings.ContainsAny(w.Name, `*?[]{}\/`) || strings.ContainsFunc(w.Name, func(r rune) bool { return r < ' ' || r == 0x7f }) {
+		return util.NewInvalidArgumentErrorf("invalid required workflow name %q", w.Name)
+	}
+	if !strings.HasSuffix(w.WorkflowPath, ".yml") && !strings.HasSuffix(w.WorkflowPath, ".yaml") {
+		return util.NewInvalidArgumentErrorf("invalid workflow path %q", w.WorkflowPath)
+	}
+	for _, pattern := range w.RepoPatterns {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid repository pattern %q: %v", pattern, err)
+		}
+	}
+	for _, pattern := range w.BranchPatterns {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid branch pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// CreateRequiredWorkflow creates a new required workflow for the owner
+func CreateRequiredWorkflow(ctx context.Context, w *ActionRequiredWorkflow) error {
+	if err := ValidateRequiredWorkflow(w); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": w.OwnerID, "name": w.Name}).Exist(new(ActionRequiredWorkflow))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("required workflow %q already exists", w.Name)
+		}
+		return db.Insert(ctx, w)
+	})
+}
+
+// GetRequiredWorkflowByID returns the required workflow of the owner by id
+func GetRequiredWorkflowByID(ctx context.Context, ownerID, id int64) (*ActionRequiredWorkflow, error) {
+	var w ActionRequiredWorkflow
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "owner_id": ownerID}).Get(&w)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("required workflow %d doesn't exist", id)
+	}
+	return &w, nil
+}
+
+// UpdateRequiredWorkflow updates the required workflow
+func UpdateRequiredWorkflow(ctx context.Context, w *ActionRequiredWorkflow, cols ...string) error {
+	if err := ValidateRequiredWorkflow(w); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": w.OwnerID, "name": w.Name}).And(builder.Neq{"id": w.ID}).Exist(new(ActionRequiredWorkflow))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("required workflow %q already exists", w.Name)
+		}
+		_, err = db.GetEngine(ctx).ID(w.ID).Cols(cols...).Update(w)
+		return err
+	})
+}
+
+// DeleteRequiredWorkflow deletes the required workflow, the existing runs of it are kept
+func DeleteRequiredWorkflow(ctx context.Context, w *ActionRequiredWorkflow) error {
+	_, err := db.DeleteByID[ActionRequiredWorkflow](ctx, w.ID)
+	return err
+}
+
+// FindRequiredWorkflowsForBranch returns the required workflows which match the branch of the repository
+func FindRequiredWorkflowsForBranch(ctx context.Context, repo *repo_model.Repository, branch string) ([]*ActionRequiredWorkflow, error) {
+	workflows, err := db.Find[ActionRequiredWorkflow](ctx, FindRequiredWorkflowsOptions{OwnerID: repo.OwnerID})
+	if err != nil {
+		return nil, err
+	}
+	ret := make([]*ActionRequiredWorkflow, 0, len(workflows))
+	for _, w := range workflows {
+		if w.MatchRepoAndBranch(repo, branch) {
+			ret = append(ret, w)
+		}
+	}
+	return ret, nil
+}
+
+// GetRequiredWorkflowStatusCheckContexts returns the status check contexts which are required by the required workflows for the branch of the repository
+func GetRequiredWorkflowStatusCheckContexts(ctx context.Context, repo *repo_model.Repository, branch string) ([]string, error) {
+	workflows, err := FindRequiredWorkflowsForBranch(ctx, repo, branch)
+	if err != nil {
+		return nil, err
+	}
+	contexts := make([]string, 0, len(workflows))
+	for _, w := range workflows {
+		contexts = append(contexts, w.StatusCheckContext())
+	}
+	return contexts, nil
+}
+
+type FindRequiredWorkflowsOptions struct {
+	db.ListOptions
+	OwnerID int64
+	RepoID  int64
+}
+
+func (opts FindRequiredWorkflowsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	return cond
+}
+
+func (opts FindRequiredWorkflowsOptions) ToOrders() string {
+	return "name"
+}
+
+var _ db.FindOptionsOrder = (*FindRequiredWorkflowsOptions)(nil)
@@ -0,0 +1,92 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionRequiredWorkflow_MatchRepoAndBranch(t *testing.T) {
+	repo := &repo_model.Repository{OwnerID: 3, Name: "backend-api"}
+
+	assert.True(t, (&ActionRequiredWorkflow{OwnerID: 3}).MatchRepoAndBranch(repo, "feature"))
+	assert.False(t, (&ActionRequiredWorkflow{OwnerID: 2}).MatchRepoAndBranch(repo, "main"))
+
+	w := &ActionRequiredWorkflow{OwnerID: 3, RepoPatterns: []string{"backend-*"}, BranchPatterns: []string{"main", "release/*"}}
+	assert.True(t, w.MatchRepoAndBranch(repo, "main"))
+	assert.True(t, w.MatchRepoAndBranch(repo, "release/1.0"))
+	assert.False(t, w.MatchRepoAndBranch(repo, "feature"))
+	assert.False(t, w.MatchRepoAndBranch(&repo_model.Repository{OwnerID: 3, Name: "frontend"}, "main"))
+}
+
+func TestValidateRequiredWorkflow(t *testing.T) {
+	valid := func() *ActionRequiredWorkflow {
+		return &ActionRequiredWorkflow{Name: "compliance", WorkflowPath: ".gitea/workflows/compliance.yml"}
+	}
+	assert.NoError(t, ValidateRequiredWorkflow(valid()))
+
+	for _, modify := range []func(w *ActionRequiredWorkflow){
+		func(w *ActionRequiredWorkflow) { w.Name = "" },
+		func(w *ActionRequiredWorkflow) { w.Name = "compliance*" },
+		func(w *ActionRequiredWorkflow) { w.Name = "a/b" },
+		func(w *ActionRequiredWorkflow) { w.WorkflowPath = ".gitea/workflows/compliance.txt" },
+		func(w *ActionRequiredWorkflow) { w.RepoPatterns = []string{"[repo"} },
+		func(w *ActionRequiredWorkflow) { w.BranchPatterns = []string{"[main"} },
+	} {
+		w := valid()
+		modify(w)
+		assert.ErrorIs(t, ValidateRequiredWorkflow(w), util.ErrInvalidArgument)
+	}
+}
+
+func TestActionRequiredWorkflow_StatusCheckContext(t *testing.T) {
+	w := &ActionRequiredWorkflow{ID: 5, OwnerID: 3, Name: "compliance"}
+	assert.Equal(t, "required-workflow/5: compliance / *", w.StatusCheckContext())
+
+	gp, err := glob.Compile(w.StatusCheckContext())
+	require.NoError(t, err)
+	assert.True(t, gp.Match("required-workflow/5: compliance / scan (pull_request)"))
+	// the statuses of the workflows of the repository with the same name don't match
+	assert.False(t, gp.Match("compliance / scan (pull_request)"))
+	assert.False(t, gp.Match("required-workflow/6: compliance / scan (pull_request)"))
+
+	assert.True(t, IsRequiredWorkflowStatusContext(" required-workflow/5: compliance / scan (pull_request)"))
+	assert.False(t, IsRequiredWorkflowStatusContext("compliance / scan (pull_request)"))
+}
+
+func TestRequiredWorkflow(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 3})
+
+	w := &ActionRequiredWorkflow{OwnerID: 3, Name: "compliance", RepoID: 5, WorkflowPath: ".gitea/workflows/compliance.yml", BranchPatterns: []string{"main"}}
+	require.NoError(t, CreateRequiredWorkflow(t.Context(), w))
+	assert.ErrorIs(t, CreateRequiredWorkflow(t.Context(), &ActionRequiredWorkflow{OwnerID: 3, Name: "compliance", RepoID: 5, WorkflowPath: "a.yml"}), util.ErrAlreadyExist)
+
+	w2 := &ActionRequiredWorkflow{OwnerID: 3, Name: "secret-scan", RepoID: 5, WorkflowPath: ".gitea/workflows/secret-scan.yml"}
+	require.NoError(t, CreateRequiredWorkflow(t.Context(), w2))
+	w2.Name = "compliance"
+	assert.ErrorIs(t, UpdateRequiredWorkflow(t.Context(), w2, "name"), util.ErrAlreadyExist)
+
+	_, err := GetRequiredWorkflowByID(t.Context(), 2, w.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	contexts, err := GetRequiredWorkflowStatusCheckContexts(t.Context(), repo, "main")
+	require.NoError(t, err)
+	assert.Equal(t, []string{w.StatusCheckContext(), w2.StatusCheckContext()}, contexts)
+	contexts, err = GetRequiredWorkflowStatusCheckContexts(t.Context(), repo, "feature")
+	require.NoError(t, err)
+	assert.Equal(t, []string{w2.StatusCheckContext()}, contexts)
+
+	require.NoError(t, DeleteRequiredWorkflow(t.Context(), w))
+	unittest.AssertNotExistsBean(t, &ActionRequiredWorkflow{ID: w.ID})
+}
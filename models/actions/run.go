@@ -52,6 +52,8 @@ type ActionRun struct {
 	RawConcurrency    string                       // raw concurrency
 	ConcurrencyGroup  string                       `xorm:"index(repo_concurrency) NOT NULL DEFAULT ''"`
 	ConcurrencyCancel bool                         `xorm:"NOT NULL DEFAULT FALSE"`
+	// RequiredWorkflowID is the ActionRequiredWorkflow which the run is created from, 0 means the workflow file is in the repository
+	RequiredWorkflowID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
 	// Started and Stopped is used for recording last run time, if rerun happened, they will be reset to 0
 	Started timeutil.TimeStamp
 	Stopped timeutil.TimeStamp
@@ -233,7 +235,7 @@ func CancelPreviousJobs(ctx context.Context, repoID int64, ref, workflowID strin
 		Ref:          ref,
 		WorkflowID:   workflowID,
 		TriggerEvent: event,
-		Status:       []Status{StatusRunning, StatusWaiting, StatusBlocked},
+		Status:       []Status{StatusRunning, StatusWaiting, StatusBlocked, StatusWaitingApproval},
 	})
 	if err != nil {
 		return nil, err
@@ -435,7 +437,7 @@ func CancelPreviousJobsByRunConcurrency(ctx context.Context, actionRun *ActionRu
 
 	var jobsToCancel []*ActionRunJob
 
-	statusFindOption := []Status{StatusWaiting, StatusBlocked}
+	statusFindOption := []Status{StatusWaiting, StatusBlocked, StatusWaitingApproval}
 	if actionRun.ConcurrencyCancel {
 		statusFindOption = append(statusFindOption, StatusRunning)
 	}
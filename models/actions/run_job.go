@@ -61,6 +61,20 @@ type ActionRunJob struct {
 	// It is JSON-encoded repo_model.ActionsTokenPermissions and may be empty if not specified.
 	TokenPermissions *repo_model.ActionsTokenPermissions `xorm:"JSON TEXT"`
 
+	// Environment is the name of the deployment environment from job YAML's "environment" section, empty if the job doesn't deploy
+	Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+
+	// CalledWorkflowRef is "{owner}/{repo}/{path}@{ref}" of the reusable workflow which defines the job, empty if the job isn't expanded from a reusable workflow
+	CalledWorkflowRef string `xorm:"TEXT"`
+
+	// QuotaExceeded is true if the job failed without running because its owner had used up the monthly minutes quota
+	QuotaExceeded bool `xorm:"NOT NULL DEFAULT FALSE"`
+
+	// RetryCount is the number of the automatic retries after infrastructure failures, it's reset when the job is rerun manually
+	RetryCount int64 `xorm:"NOT NULL DEFAULT 0"`
+	// RetryAfter is the time before which the re-queued job can't be picked up by the runners, 0 means no delay
+	RetryAfter timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+
 	Started timeutil.TimeStamp
 	Stopped timeutil.TimeStamp
 	Created timeutil.TimeStamp `xorm:"created"`
@@ -224,13 +238,14 @@ func UpdateRunJob(ctx context.Context, job *ActionRunJob, cond builder.Cond, col
 func AggregateJobStatus(jobs []*ActionRunJob) Status {
 	allSuccessOrSkipped := len(jobs) != 0
 	allSkipped := len(jobs) != 0
-	var hasFailure, hasCancelled, hasWaiting, hasRunning, hasBlocked bool
+	var hasFailure, hasCancelled, hasWaiting, hasWaitingApproval, hasRunning, hasBlocked bool
 	for _, job := range jobs {
 		allSuccessOrSkipped = allSuccessOrSkipped && (job.Status == StatusSuccess || job.Status == StatusSkipped)
 		allSkipped = allSkipped && job.Status == StatusSkipped
 		hasFailure = hasFailure || job.Status == StatusFailure
 		hasCancelled = hasCancelled || job.Status == StatusCancelled
 		hasWaiting = hasWaiting || job.Status == StatusWaiting
+		hasWaitingApproval = hasWaitingApproval || job.Status == StatusWaitingApproval
 		hasRunning = hasRunning || job.Status == StatusRunning
 		hasBlocked = hasBlocked || job.Status == StatusBlocked
 	}
@@ -245,6 +260,8 @@ func AggregateJobStatus(jobs []*ActionRunJob) Status {
 		return StatusRunning
 	case hasWaiting:
 		return StatusWaiting
+	case hasWaitingApproval:
+		return StatusWaitingApproval
 	case hasFailure:
 		return StatusFailure
 	case hasBlocked:
@@ -265,7 +282,7 @@ func CancelPreviousJobsByJobConcurrency(ctx context.Context, job *ActionRunJob)
 		return nil, nil
 	}
 
-	statusFindOption := []Status{StatusWaiting, StatusBlocked}
+	statusFindOption := []Status{StatusWaiting, StatusBlocked, StatusWaitingApproval}
 	if job.ConcurrencyCancel {
 		statusFindOption = append(statusFindOption, StatusRunning)
 	}
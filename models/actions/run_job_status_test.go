@@ -77,6 +77,15 @@ func TestAggregateJobStatus(t *testing.T) {
 		{[]Status{StatusSkipped, StatusWaiting}, StatusWaiting},
 		{[]Status{StatusSkipped, StatusRunning}, StatusRunning},
 		{[]Status{StatusSkipped, StatusBlocked}, StatusBlocked},
+
+		// waiting for approval with other status
+		{[]Status{StatusWaitingApproval}, StatusWaitingApproval},
+		{[]Status{StatusWaitingApproval, StatusSuccess}, StatusWaitingApproval},
+		{[]Status{StatusWaitingApproval, StatusFailure}, StatusWaitingApproval},
+		{[]Status{StatusWaitingApproval, StatusCancelled}, StatusCancelled},
+		{[]Status{StatusWaitingApproval, StatusWaiting}, StatusWaiting},
+		{[]Status{StatusWaitingApproval, StatusRunning}, StatusRunning},
+		{[]Status{StatusWaitingApproval, StatusBlocked}, StatusWaitingApproval},
 	}
 
 	for _, c := range cases {
@@ -64,6 +64,8 @@ type ActionRunner struct {
 	Ephemeral bool `xorm:"ephemeral NOT NULL DEFAULT false"`
 	// Store if this runner is disabled and should not pick up new jobs
 	IsDisabled bool `xorm:"is_disabled NOT NULL DEFAULT false"`
+	// Store the ActionRunnerGroup which restricts the jobs this runner can pick up, 0 means no restriction
+	GroupID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
 
 	Created timeutil.TimeStamp `xorm:"created"`
 	Updated timeutil.TimeStamp `xorm:"updated"`
@@ -201,6 +203,7 @@ type FindRunnerOptions struct {
 	Filter        string
 	IsOnline      optional.Option[bool]
 	IsDisabled    optional.Option[bool]
+	GroupID       int64
 	WithAvailable bool // not only runners belong to, but also runners can be used
 }
 
@@ -245,6 +248,10 @@ func (opts FindRunnerOptions) ToConds() builder.Cond {
 	if opts.IsDisabled.Has() {
 		cond = cond.And(builder.Eq{"is_disabled": opts.IsDisabled.Value()})
 	}
+
+	if opts.GroupID > 0 {
+		cond = cond.And(builder.Eq{"group_id": opts.GroupID})
+	}
 	return cond
 }
 
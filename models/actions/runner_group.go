@@ -0,0 +1,183 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/glob"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionRunnerGroup represents a group of the runners of an org/user, which restricts the jobs the runners can pick up.
+// A runner without a group (GroupID is 0) can run the jobs of all repositories of its owner.
+type ActionRunnerGroup struct {
+	ID               int64
+	OwnerID          int64    `xorm:"UNIQUE(owner_name) NOT NULL"`
+	Name             string   `xorm:"UNIQUE(owner_name) NOT NULL"`
+	RepoIDs          []int64  `xorm:"JSON TEXT"` // the repositories which can use the runners, empty means all repositories of the owner
+	BranchPatterns   []string `xorm:"JSON TEXT"` // glob patterns of the branches and tags which can use the runners, empty means all of them
+	WorkflowPatterns []string `xorm:"JSON TEXT"` // glob patterns of the workflow files and reusable workflow refs which can use the runners, empty means all of them
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionRunnerGroup))
+}
+
+// CanRunJob returns whether the runners of the group are allowed to run the job, the run of the job should be loaded.
+//
+// The workflow patterns are matched against the workflow file name of the run, like "deploy.yml",
+// and the "{owner}/{repo}/{path}@{ref}" of the reusable workflow if the job is defined in one.
+func (g *ActionRunnerGroup) CanRunJob(job *ActionRunJob) bool {
+	if len(g.RepoIDs) > 0 && !slices.Contains(g.RepoIDs, job.RepoID) {
+		return false
+	}
+	if len(g.BranchPatterns) > 0 && !refMatchesPatterns(job.Run.Ref, g.BranchPatterns) {
+		return false
+	}
+	if len(g.WorkflowPatterns) > 0 {
+		candidates := []string{job.Run.WorkflowID}
+		if job.CalledWorkflowRef != "" {
+			candidates = append(candidates, job.CalledWorkflowRef)
+		}
+		if !slices.ContainsFunc(candidates, func(s string) bool { return globMatchesPatterns(s, g.WorkflowPatterns) }) {
+			return false
+		}
+	}
+	return true
+}
+
+// ValidateRunnerGroup checks the name and the patterns of a runner group
+func ValidateRunnerGroup(g *ActionRunnerGroup) error {
+	if g.Name == "" || len(g.Name) > 255 || strings.ContainsFunc(g.Name, func(r rune) bool { return r < ' ' || r == 0x7f }) {
+		return util.NewInvalidArgumentErrorf("invalid runner group name %q", g.Name)
+	}
+	for _, pattern := range g.BranchPatterns {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid branch pattern %q: %v", pattern, err)
+		}
+	}
+	for _, pattern := range g.WorkflowPatterns {
+		if _, err := glob.Compile(pattern, '/'); err != nil {
+			return util.NewInvalidArgumentErrorf("invalid workflow pattern %q: %v", pattern, err)
+		}
+	}
+	return nil
+}
+
+// CreateRunnerGroup creates a new runner group for the owner
+func CreateRunnerGroup(ctx context.Context, g *ActionRunnerGroup) error {
+	if err := ValidateRunnerGroup(g); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		exist, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": g.OwnerID, "name": g.Name}).Exist(new(ActionRunnerGroup))
+		if err != nil {
+			return err
+		}
+		if exist {
+			return util.NewAlreadyExistErrorf("runner group %q already exists", g.Name)
+		}
+		return db.Insert(ctx, g)
+	})
+}
+
+// GetRunnerGroupByID returns the runner group of the owner by id
+func GetRunnerGroupByID(ctx context.Context, ownerID, id int64) (*ActionRunnerGroup, error) {
+	var g ActionRunnerGroup
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"id": id, "owner_id": ownerID}).Get(&g)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("runner group %d doesn't exist", id)
+	}
+	return &g, nil
+}
+
+// UpdateRunnerGroup updates the name and the policies of the runner group
+func UpdateRunnerGroup(ctx context.Context, g *ActionRunnerGroup, cols ...string) error {
+	if err := ValidateRunnerGroup(g); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if slices.Contains(cols, "name") {
+			exist, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": g.OwnerID, "name": g.Name}).And(builder.Neq{"id": g.ID}).Exist(new(ActionRunnerGroup))
+			if err != nil {
+				return err
+			}
+			if exist {
+				return util.NewAlreadyExistErrorf("runner group %q already exists", g.Name)
+			}
+		}
+		if _, err := db.GetEngine(ctx).ID(g.ID).Cols(cols...).Update(g); err != nil {
+			return err
+		}
+		// the runners may be able to pick up the jobs they couldn't before
+		return IncreaseTaskVersion(ctx, g.OwnerID, 0)
+	})
+}
+
+// DeleteRunnerGroup deletes the runner group, its runners are moved out of it and become unrestricted
+func DeleteRunnerGroup(ctx context.Context, g *ActionRunnerGroup) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"group_id": g.ID}).Cols("group_id").Update(&ActionRunner{GroupID: 0}); err != nil {
+			return err
+		}
+		if _, err := db.DeleteByID[ActionRunnerGroup](ctx, g.ID); err != nil {
+			return err
+		}
+		return IncreaseTaskVersion(ctx, g.OwnerID, 0)
+	})
+}
+
+// SetRunnerGroup moves the runner into the group, groupID 0 means moving it out of its group
+func SetRunnerGroup(ctx context.Context, runner *ActionRunner, groupID int64) error {
+	if runner.GroupID == groupID {
+		return nil
+	}
+	if groupID != 0 && (runner.OwnerID == 0 || runner.RepoID != 0) {
+		return util.NewInvalidArgumentErrorf("only the runners of an org/user can be added to a runner group")
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if groupID != 0 {
+			if _, err := GetRunnerGroupByID(ctx, runner.OwnerID, groupID); err != nil {
+				return err
+			}
+		}
+		runner.GroupID = groupID
+		if err := UpdateRunner(ctx, runner, "group_id"); err != nil {
+			return err
+		}
+		return IncreaseTaskVersion(ctx, runner.OwnerID, runner.RepoID)
+	})
+}
+
+type FindRunnerGroupsOptions struct {
+	db.ListOptions
+	OwnerID int64
+}
+
+func (opts FindRunnerGroupsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	return cond
+}
+
+func (opts FindRunnerGroupsOptions) ToOrders() string {
+	return "name"
+}
+
+var _ db.FindOptionsOrder = (*FindRunnerGroupsOptions)(nil)
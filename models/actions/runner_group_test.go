@@ -0,0 +1,70 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestActionRunnerGroup_CanRunJob(t *testing.T) {
+	newJob := func(repoID int64, ref, workflowID, calledWorkflowRef string) *ActionRunJob {
+		return &ActionRunJob{
+			RepoID:            repoID,
+			CalledWorkflowRef: calledWorkflowRef,
+			Run:               &ActionRun{RepoID: repoID, Ref: ref, WorkflowID: workflowID},
+		}
+	}
+
+	assert.True(t, (&ActionRunnerGroup{}).CanRunJob(newJob(1, "refs/pull/1/head", "test.yml", "")))
+
+	g := &ActionRunnerGroup{
+		RepoIDs:          []int64{1, 2},
+		BranchPatterns:   []string{"main", "release/*"},
+		WorkflowPatterns: []string{"deploy*.yml", "org/shared/.gitea/workflows/deploy.yml@*"},
+	}
+	assert.True(t, g.CanRunJob(newJob(1, "refs/heads/main", "deploy.yml", "")))
+	assert.True(t, g.CanRunJob(newJob(2, "refs/heads/release/1.0", "deploy-prod.yml", "")))
+	assert.True(t, g.CanRunJob(newJob(2, "refs/heads/main", "ci.yml", "org/shared/.gitea/workflows/deploy.yml@v1")))
+	assert.False(t, g.CanRunJob(newJob(3, "refs/heads/main", "deploy.yml", "")))
+	assert.False(t, g.CanRunJob(newJob(1, "refs/heads/feature", "deploy.yml", "")))
+	assert.False(t, g.CanRunJob(newJob(1, "refs/pull/1/head", "deploy.yml", "")))
+	assert.False(t, g.CanRunJob(newJob(1, "refs/heads/main", "ci.yml", "")))
+	assert.False(t, g.CanRunJob(newJob(1, "refs/heads/main", "ci.yml", "other/shared/.gitea/workflows/deploy.yml@v1")))
+}
+
+func TestRunnerGroup(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	g := &ActionRunnerGroup{OwnerID: 3, Name: "production", RepoIDs: []int64{3}, BranchPatterns: []string{"main"}}
+	require.NoError(t, CreateRunnerGroup(t.Context(), g))
+	assert.ErrorIs(t, CreateRunnerGroup(t.Context(), &ActionRunnerGroup{OwnerID: 3, Name: "production"}), util.ErrAlreadyExist)
+	assert.ErrorIs(t, CreateRunnerGroup(t.Context(), &ActionRunnerGroup{OwnerID: 3, Name: "bad", BranchPatterns: []string{"[main"}}), util.ErrInvalidArgument)
+
+	_, err := GetRunnerGroupByID(t.Context(), 2, g.ID)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+
+	runner := &ActionRunner{UUID: "6d0c2e4a-5b0b-4e8b-9d4f-3f8c7a1b2c3d", Name: "deployer", OwnerID: 3, TokenHash: "runner-group-test"}
+	require.NoError(t, CreateRunner(t.Context(), runner))
+	repoRunner := &ActionRunner{UUID: "6d0c2e4a-5b0b-4e8b-9d4f-3f8c7a1b2c3e", Name: "repo-runner", RepoID: 3, TokenHash: "runner-group-test-repo"}
+	require.NoError(t, CreateRunner(t.Context(), repoRunner))
+
+	require.NoError(t, SetRunnerGroup(t.Context(), runner, g.ID))
+	assert.ErrorIs(t, SetRunnerGroup(t.Context(), repoRunner, g.ID), util.ErrInvalidArgument)
+	assert.ErrorIs(t, SetRunnerGroup(t.Context(), &ActionRunner{ID: runner.ID, OwnerID: 2}, g.ID), util.ErrNotExist)
+	runner, err = GetRunnerByID(t.Context(), runner.ID)
+	require.NoError(t, err)
+	assert.Equal(t, g.ID, runner.GroupID)
+
+	require.NoError(t, DeleteRunnerGroup(t.Context(), g))
+	runner, err = GetRunnerByID(t.Context(), runner.ID)
+	require.NoError(t, err)
+	assert.Zero(t, runner.GroupID)
+	unittest.AssertNotExistsBean(t, &ActionRunnerGroup{ID: g.ID})
+}
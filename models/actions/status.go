@@ -15,25 +15,27 @@ import (
 type Status int
 
 const (
-	StatusUnknown   Status = iota // 0, consistent with runnerv1.Result_RESULT_UNSPECIFIED
-	StatusSuccess                 // 1, consistent with runnerv1.Result_RESULT_SUCCESS
-	StatusFailure                 // 2, consistent with runnerv1.Result_RESULT_FAILURE
-	StatusCancelled               // 3, consistent with runnerv1.Result_RESULT_CANCELLED
-	StatusSkipped                 // 4, consistent with runnerv1.Result_RESULT_SKIPPED
-	StatusWaiting                 // 5, isn't a runnerv1.Result
-	StatusRunning                 // 6, isn't a runnerv1.Result
-	StatusBlocked                 // 7, isn't a runnerv1.Result
+	StatusUnknown         Status = iota // 0, consistent with runnerv1.Result_RESULT_UNSPECIFIED
+	StatusSuccess                       // 1, consistent with runnerv1.Result_RESULT_SUCCESS
+	StatusFailure                       // 2, consistent with runnerv1.Result_RESULT_FAILURE
+	StatusCancelled                     // 3, consistent with runnerv1.Result_RESULT_CANCELLED
+	StatusSkipped                       // 4, consistent with runnerv1.Result_RESULT_SKIPPED
+	StatusWaiting                       // 5, isn't a runnerv1.Result
+	StatusRunning                       // 6, isn't a runnerv1.Result
+	StatusBlocked                       // 7, isn't a runnerv1.Result
+	StatusWaitingApproval               // 8, isn't a runnerv1.Result, waiting for the approval of a protected environment
 )
 
 var statusNames = map[Status]string{
-	StatusUnknown:   "unknown",
-	StatusWaiting:   "waiting",
-	StatusRunning:   "running",
-	StatusSuccess:   "success",
-	StatusFailure:   "failure",
-	StatusCancelled: "cancelled",
-	StatusSkipped:   "skipped",
-	StatusBlocked:   "blocked",
+	StatusUnknown:         "unknown",
+	StatusWaiting:         "waiting",
+	StatusRunning:         "running",
+	StatusSuccess:         "success",
+	StatusFailure:         "failure",
+	StatusCancelled:       "cancelled",
+	StatusSkipped:         "skipped",
+	StatusBlocked:         "blocked",
+	StatusWaitingApproval: "waiting_approval",
 }
 
 // String returns the string name of the Status
@@ -88,6 +90,10 @@ func (s Status) IsBlocked() bool {
 	return s == StatusBlocked
 }
 
+func (s Status) IsWaitingApproval() bool {
+	return s == StatusWaitingApproval
+}
+
 // In returns whether s is one of the given statuses
 func (s Status) In(statuses ...Status) bool {
 	return slices.Contains(statuses, s)
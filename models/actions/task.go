@@ -8,6 +8,7 @@ import (
 	"crypto/subtle"
 	"errors"
 	"fmt"
+	"slices"
 	"strings"
 	"time"
 
@@ -250,7 +251,23 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 		jobCond = builder.In("run_id", builder.Select("id").From("action_run").Where(jobCond))
 	}
 
+	var group *ActionRunnerGroup
+	if runner.GroupID != 0 {
+		// the runner can't pick up any job if its group doesn't exist, it's safer than ignoring the restrictions
+		if group, err = GetRunnerGroupByID(ctx, runner.OwnerID, runner.GroupID); err != nil {
+			if errors.Is(err, util.ErrNotExist) {
+				return nil, false, nil
+			}
+			return nil, false, err
+		}
+		if len(group.RepoIDs) > 0 {
+			jobCond = jobCond.And(builder.In("repo_id", group.RepoIDs))
+		}
+	}
+
 	var jobs []*ActionRunJob
+	// the re-queued jobs wait for the retry backoff
+	jobCond = jobCond.And(builder.Lte{"retry_after": timeutil.TimeStampNow()})
 	if err := e.Where("task_id=? AND status=?", 0, StatusWaiting).And(jobCond).Asc("updated", "id").Find(&jobs); err != nil {
 		return nil, false, err
 	}
@@ -259,10 +276,19 @@ func CreateTaskForRunner(ctx context.Context, runner *ActionRunner) (*ActionTask
 	var job *ActionRunJob
 	log.Trace("runner labels: %v", runner.AgentLabels)
 	for _, v := range jobs {
-		if runner.CanMatchLabels(v.RunsOn) {
-			job = v
-			break
+		if !runner.CanMatchLabels(v.RunsOn) {
+			continue
+		}
+		if group != nil {
+			if err := v.LoadRun(ctx); err != nil {
+				return nil, false, err
+			}
+			if !group.CanRunJob(v) {
+				continue
+			}
 		}
+		job = v
+		break
 	}
 	if job == nil {
 		return nil, false, nil
@@ -383,13 +409,22 @@ func UpdateTaskByState(ctx context.Context, runnerID int64, state *runnerv1.Task
 			if err := UpdateTask(ctx, task, "status", "stopped"); err != nil {
 				return nil, err
 			}
-			if _, err := UpdateRunJob(ctx, &ActionRunJob{
-				ID:      task.JobID,
-				RepoID:  task.RepoID,
-				Status:  task.Status,
-				Stopped: task.Stopped,
-			}, nil); err != nil {
-				return nil, err
+			var requeued bool
+			if task.Status.IsFailure() && !hasStartedStep(state) {
+				var err error
+				if requeued, err = requeueJobOfFailedTask(ctx, task, RetryConclusionSetupFailure); err != nil {
+					return nil, err
+				}
+			}
+			if !requeued {
+				if _, err := UpdateRunJob(ctx, &ActionRunJob{
+					ID:      task.JobID,
+					RepoID:  task.RepoID,
+					Status:  task.Status,
+					Stopped: task.Stopped,
+				}, nil); err != nil {
+					return nil, err
+				}
 			}
 		} else {
 			// Force update ActionTask.Updated to avoid the task being judged as a zombie task
@@ -403,6 +438,12 @@ func UpdateTaskByState(ctx context.Context, runnerID int64, state *runnerv1.Task
 			return nil, err
 		}
 
+		if task.Status.IsDone() {
+			if err := RecordTaskUsage(ctx, task); err != nil {
+				return nil, err
+			}
+		}
+
 		for _, step := range task.Steps {
 			var result runnerv1.Result
 			if v, ok := stepStates[step.Index]; ok {
@@ -426,7 +467,23 @@ func UpdateTaskByState(ctx context.Context, runnerID int64, state *runnerv1.Task
 	})
 }
 
+// hasStartedStep returns whether any step of the task has started, the task which fails without it fails to set up the job
+func hasStartedStep(state *runnerv1.TaskState) bool {
+	return slices.ContainsFunc(state.Steps, func(step *runnerv1.StepState) bool {
+		return step.StartedAt != nil || step.Result != runnerv1.Result_RESULT_UNSPECIFIED
+	})
+}
+
 func StopTask(ctx context.Context, taskID int64, status Status) error {
+	return stopTask(ctx, taskID, status, "")
+}
+
+// StopTaskByInfraFailure stops the task as a failure caused by the infrastructure, its job may be re-queued by the retry policy
+func StopTaskByInfraFailure(ctx context.Context, taskID int64, conclusion RetryConclusion) error {
+	return stopTask(ctx, taskID, StatusFailure, conclusion)
+}
+
+func stopTask(ctx context.Context, taskID int64, status Status, conclusion RetryConclusion) error {
 	if !status.IsDone() {
 		return fmt.Errorf("cannot stop task with status %v", status)
 	}
@@ -445,13 +502,22 @@ func StopTask(ctx context.Context, taskID int64, status Status) error {
 	now := timeutil.TimeStampNow()
 	task.Status = status
 	task.Stopped = now
-	if _, err := UpdateRunJob(ctx, &ActionRunJob{
-		ID:      task.JobID,
-		RepoID:  task.RepoID,
-		Status:  task.Status,
-		Stopped: task.Stopped,
-	}, nil); err != nil {
-		return err
+	var requeued bool
+	if conclusion != "" {
+		var err error
+		if requeued, err = requeueJobOfFailedTask(ctx, task, conclusion); err != nil {
+			return err
+		}
+	}
+	if !requeued {
+		if _, err := UpdateRunJob(ctx, &ActionRunJob{
+			ID:      task.JobID,
+			RepoID:  task.RepoID,
+			Status:  task.Status,
+			Stopped: task.Stopped,
+		}, nil); err != nil {
+			return err
+		}
 	}
 
 	if err := UpdateTask(ctx, task, "status", "stopped"); err != nil {
@@ -462,6 +528,10 @@ func StopTask(ctx context.Context, taskID int64, status Status) error {
 		return err
 	}
 
+	if err := RecordTaskUsage(ctx, task); err != nil {
+		return err
+	}
+
 	for _, step := range task.Steps {
 		if !step.Status.IsDone() {
 			step.Status = status
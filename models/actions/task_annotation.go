@@ -0,0 +1,109 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/builder"
+)
+
+// MaxAnnotationsPerTask is the max number of annotations kept for a task, the others are dropped like GitHub
+const MaxAnnotationsPerTask = 50
+
+// AnnotationLevel is the level of an annotation, it's the name of the workflow command which creates it
+type AnnotationLevel string
+
+const (
+	AnnotationLevelNotice  AnnotationLevel = "notice"
+	AnnotationLevelWarning AnnotationLevel = "warning"
+	AnnotationLevelError   AnnotationLevel = "error"
+)
+
+// ActionTaskAnnotation represents an annotation created by the "::error", "::warning" or "::notice" workflow commands of a task
+type ActionTaskAnnotation struct {
+	ID          int64
+	RepoID      int64           `xorm:"INDEX(repo_commit)"`
+	CommitSHA   string          `xorm:"INDEX(repo_commit) VARCHAR(64)"`
+	RunID       int64           `xorm:"INDEX"`
+	JobID       int64           `xorm:"INDEX"`
+	TaskID      int64           `xorm:"INDEX"`
+	Level       AnnotationLevel `xorm:"VARCHAR(20)"`
+	Path        string          `xorm:"TEXT"` // the file path relative to the repository root, empty if the annotation isn't bound to a file
+	StartLine   int
+	EndLine     int
+	StartColumn int
+	EndColumn   int
+	Title       string             `xorm:"VARCHAR(255)"`
+	Message     string             `xorm:"TEXT"`
+	Created     timeutil.TimeStamp `xorm:"created"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionTaskAnnotation))
+}
+
+type FindTaskAnnotationsOptions struct {
+	db.ListOptions
+	RepoID    int64
+	CommitSHA string
+	RunID     int64
+	TaskID    int64
+	HasPath   bool
+}
+
+func (opts FindTaskAnnotationsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.CommitSHA != "" {
+		cond = cond.And(builder.Eq{"commit_sha": opts.CommitSHA})
+	}
+	if opts.RunID > 0 {
+		cond = cond.And(builder.Eq{"run_id": opts.RunID})
+	}
+	if opts.TaskID > 0 {
+		cond = cond.And(builder.Eq{"task_id": opts.TaskID})
+	}
+	if opts.HasPath {
+		cond = cond.And(builder.Neq{"path": ""})
+	}
+	return cond
+}
+
+func (opts FindTaskAnnotationsOptions) ToOrders() string {
+	return "`id` ASC"
+}
+
+// InsertTaskAnnotations inserts the annotations of the task, the ones exceeding MaxAnnotationsPerTask are dropped
+func InsertTaskAnnotations(ctx context.Context, task *ActionTask, annotations []*ActionTaskAnnotation) error {
+	if len(annotations) == 0 {
+		return nil
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		count, err := db.GetEngine(ctx).Where("task_id=?", task.ID).Count(new(ActionTaskAnnotation))
+		if err != nil {
+			return err
+		}
+		remaining := MaxAnnotationsPerTask - int(count)
+		if remaining <= 0 {
+			return nil
+		}
+		if len(annotations) > remaining {
+			annotations = annotations[:remaining]
+		}
+		for _, a := range annotations {
+			a.RepoID = task.RepoID
+			a.CommitSHA = task.CommitSHA
+			a.RunID = task.Job.RunID
+			a.JobID = task.JobID
+			a.TaskID = task.ID
+		}
+		return db.Insert(ctx, annotations)
+	})
+}
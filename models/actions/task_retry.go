@@ -0,0 +1,87 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// RetryConclusion is the cause of a failed task which can count as an infrastructure failure, see [actions] JOB_RETRY_CONCLUSIONS
+type RetryConclusion string
+
+const (
+	RetryConclusionRunnerLost   RetryConclusion = "runner_lost"   // the runner stopped reporting the task
+	RetryConclusionTimeout      RetryConclusion = "timeout"       // the task ran longer than the endless task timeout
+	RetryConclusionSetupFailure RetryConclusion = "setup_failure" // the task failed before any step started
+)
+
+// maxRetryBackoffShift limits the exponential growth of the retry backoff
+const maxRetryBackoffShift = 10
+
+// requeueJobOfFailedTask re-queues the job of the failed task if the retry policy counts the conclusion as an infrastructure failure
+// and the job hasn't used up the retries. It returns false if the job should fail.
+// The failed task is kept with its logs, and the job gets a new task with the next attempt when a runner picks it up again.
+func requeueJobOfFailedTask(ctx context.Context, task *ActionTask, conclusion RetryConclusion) (bool, error) {
+	if setting.Actions.JobRetryMaxAttempts <= 0 || !slices.Contains(setting.Actions.JobRetryConclusions, string(conclusion)) {
+		return false, nil
+	}
+
+	job, err := GetRunJobByRepoAndID(ctx, task.RepoID, task.JobID)
+	if err != nil {
+		return false, err
+	}
+	if job.TaskID != task.ID || job.Status.IsDone() || job.RetryCount >= int64(setting.Actions.JobRetryMaxAttempts) {
+		return false, nil
+	}
+
+	backoff := setting.Actions.JobRetryBackoff << min(job.RetryCount, maxRetryBackoffShift)
+	job.RetryCount++
+	job.RetryAfter = timeutil.TimeStampNow().AddDuration(backoff)
+	job.TaskID = 0
+	job.Status = StatusWaiting
+	job.Started = 0
+	job.Stopped = 0
+	n, err := UpdateRunJob(ctx, job, builder.Eq{"task_id": task.ID}, "task_id", "status", "started", "stopped", "retry_count", "retry_after")
+	if err != nil {
+		return false, fmt.Errorf("requeue job %d: %w", job.ID, err)
+	}
+	if n == 0 {
+		return false, nil
+	}
+	log.Info("Job %d is re-queued after %s because task %d failed with %s, retry %d of %d", job.ID, backoff, task.ID, conclusion, job.RetryCount, setting.Actions.JobRetryMaxAttempts)
+	return true, nil
+}
+
+// FindRetryingJobsToQueue returns the re-queued jobs whose retry backoff has elapsed
+func FindRetryingJobsToQueue(ctx context.Context, limit int) ([]*ActionRunJob, error) {
+	jobs := make([]*ActionRunJob, 0, limit)
+	return jobs, db.GetEngine(ctx).
+		Where(builder.Eq{"status": StatusWaiting, "task_id": 0}).
+		And(builder.Gt{"retry_after": 0}).
+		And(builder.Lte{"retry_after": timeutil.TimeStampNow()}).
+		Limit(limit).
+		Find(&jobs)
+}
+
+// GetTaskByJobAndAttempt returns the task of the job which ran the attempt
+func GetTaskByJobAndAttempt(ctx context.Context, jobID, attempt int64) (*ActionTask, error) {
+	var task ActionTask
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"job_id": jobID, "attempt": attempt}).Get(&task)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("attempt %d of job %d doesn't exist", attempt, jobID)
+	}
+	return &task, nil
+}
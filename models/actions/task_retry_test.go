@@ -0,0 +1,69 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestStopTaskByInfraFailure(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+	defer test.MockVariableValue(&setting.Actions.JobRetryMaxAttempts, 1)()
+	defer test.MockVariableValue(&setting.Actions.JobRetryBackoff, time.Minute)()
+	defer test.MockVariableValue(&setting.Actions.JobRetryConclusions, []string{string(RetryConclusionRunnerLost)})()
+
+	job := &ActionRunJob{RunID: 791, RepoID: 4, OwnerID: 1, Name: "build", JobID: "build", Status: StatusRunning, Attempt: 1}
+	require.NoError(t, db.Insert(t.Context(), job))
+	runTask := func() *ActionTask {
+		task := &ActionTask{JobID: job.ID, RepoID: 4, OwnerID: 1, Attempt: job.Attempt, Status: StatusRunning, Started: timeutil.TimeStampNow(), TokenHash: job.Name + time.Now().String()}
+		require.NoError(t, db.Insert(t.Context(), task))
+		job.TaskID = task.ID
+		job.Status = StatusRunning
+		_, err := db.GetEngine(t.Context()).ID(job.ID).Cols("task_id", "status").Update(job)
+		require.NoError(t, err)
+		return task
+	}
+
+	// the timeout doesn't count as an infrastructure failure
+	task := runTask()
+	require.NoError(t, StopTaskByInfraFailure(t.Context(), task.ID, RetryConclusionTimeout))
+	job = unittest.AssertExistsAndLoadBean(t, &ActionRunJob{ID: job.ID})
+	assert.Equal(t, StatusFailure, job.Status)
+
+	job.Attempt++
+	task = runTask()
+	require.NoError(t, StopTaskByInfraFailure(t.Context(), task.ID, RetryConclusionRunnerLost))
+	job = unittest.AssertExistsAndLoadBean(t, &ActionRunJob{ID: job.ID})
+	assert.Equal(t, StatusWaiting, job.Status)
+	assert.Zero(t, job.TaskID)
+	assert.EqualValues(t, 1, job.RetryCount)
+	assert.Greater(t, job.RetryAfter, timeutil.TimeStampNow())
+	task = unittest.AssertExistsAndLoadBean(t, &ActionTask{ID: task.ID})
+	assert.Equal(t, StatusFailure, task.Status)
+
+	jobs, err := FindRetryingJobsToQueue(t.Context(), 10)
+	require.NoError(t, err)
+	assert.Empty(t, jobs)
+
+	// the job has used up the retries
+	job.Attempt++
+	task = runTask()
+	require.NoError(t, StopTaskByInfraFailure(t.Context(), task.ID, RetryConclusionRunnerLost))
+	job = unittest.AssertExistsAndLoadBean(t, &ActionRunJob{ID: job.ID})
+	assert.Equal(t, StatusFailure, job.Status)
+
+	task, err = GetTaskByJobAndAttempt(t.Context(), job.ID, 2)
+	require.NoError(t, err)
+	assert.Equal(t, StatusFailure, task.Status)
+}
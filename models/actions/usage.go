@@ -0,0 +1,230 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"slices"
+	"strings"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ActionUsage is the runner time used in a month by the finished tasks of a repository which have the same "runs-on" labels.
+// The rows are kept after the repository or the owner is deleted, so the past usage reports are still complete.
+type ActionUsage struct {
+	ID        int64
+	OwnerID   int64  `xorm:"UNIQUE(usage) INDEX NOT NULL"`
+	RepoID    int64  `xorm:"UNIQUE(usage) NOT NULL"`
+	Labels    string `xorm:"UNIQUE(usage) VARCHAR(255) NOT NULL"` // the sorted "runs-on" labels of the jobs, joined by ","
+	Month     int    `xorm:"UNIQUE(usage) INDEX NOT NULL"`        // the month when the tasks stopped, like 202601, in the default UI location
+	Seconds   int64  `xorm:"NOT NULL DEFAULT 0"`
+	TaskCount int64  `xorm:"NOT NULL DEFAULT 0"`
+
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+// ActionUsageQuota limits the runner minutes which the tasks of an owner can use in a month
+type ActionUsageQuota struct {
+	ID             int64
+	OwnerID        int64 `xorm:"UNIQUE NOT NULL"`
+	MonthlyMinutes int64 `xorm:"NOT NULL DEFAULT 0"`
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+}
+
+func init() {
+	db.RegisterModel(new(ActionUsage))
+	db.RegisterModel(new(ActionUsageQuota))
+}
+
+// UsageMonth returns the month of the time in the format of ActionUsage.Month
+func UsageMonth(t time.Time) int {
+	t = t.In(setting.DefaultUILocation)
+	return t.Year()*100 + int(t.Month())
+}
+
+// ParseUsageMonth parses a month like "2026-01" to the format of ActionUsage.Month
+func ParseUsageMonth(s string) (int, error) {
+	t, err := time.Parse("2006-01", s)
+	if err != nil {
+		return 0, util.NewInvalidArgumentErrorf("invalid month %q, it should be like 2006-01", s)
+	}
+	return t.Year()*100 + int(t.Month()), nil
+}
+
+func usageLabels(runsOn []string) string {
+	labels := slices.Clone(runsOn)
+	slices.Sort(labels)
+	return util.EllipsisDisplayString(strings.Join(labels, ","), 255)
+}
+
+// RecordTaskUsage adds the duration of the stopped task to the usage of its repository, the job of the task should be loaded
+func RecordTaskUsage(ctx context.Context, task *ActionTask) error {
+	if task.Started == 0 || task.Stopped < task.Started {
+		// the task was cancelled before it started, it didn't use any runner time
+		return nil
+	}
+	usage := &ActionUsage{
+		OwnerID:   task.OwnerID,
+		RepoID:    task.RepoID,
+		Labels:    usageLabels(task.Job.RunsOn),
+		Month:     UsageMonth(task.Stopped.AsTime()),
+		Seconds:   int64(task.Stopped - task.Started),
+		TaskCount: 1,
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		e := db.GetEngine(ctx)
+		// the same safe upsert as the user settings, the UPDATE always changes the existing row because task_count is increased
+		n, err := e.Where(builder.Eq{"owner_id": usage.OwnerID, "repo_id": usage.RepoID, "labels": usage.Labels, "month": usage.Month}).
+			Incr("seconds", usage.Seconds).
+			Incr("task_count", usage.TaskCount).
+			Update(new(ActionUsage))
+		if err != nil || n > 0 {
+			return err
+		}
+		_, err = e.Insert(usage)
+		return err
+	})
+}
+
+// UsageGroupBy is the dimension which the usage is aggregated by
+type UsageGroupBy string
+
+const (
+	UsageGroupByOwner  UsageGroupBy = "owner"
+	UsageGroupByRepo   UsageGroupBy = "repo"
+	UsageGroupByLabels UsageGroupBy = "labels"
+)
+
+var usageGroupByColumns = map[UsageGroupBy][]string{
+	UsageGroupByOwner:  {"owner_id"},
+	UsageGroupByRepo:   {"owner_id", "repo_id"},
+	UsageGroupByLabels: {"owner_id", "labels"},
+}
+
+// IsValid returns whether the usage can be aggregated by the dimension
+func (g UsageGroupBy) IsValid() bool {
+	_, ok := usageGroupByColumns[g]
+	return ok
+}
+
+// ActionUsageStat is the aggregated usage, the fields which aren't in the dimension are zero values
+type ActionUsageStat struct {
+	OwnerID   int64
+	RepoID    int64
+	Labels    string
+	Seconds   int64
+	TaskCount int64
+}
+
+type FindUsageStatsOptions struct {
+	OwnerID   int64
+	RepoID    int64
+	FromMonth int // inclusive, 0 means no limit
+	ToMonth   int // inclusive, 0 means no limit
+	GroupBy   UsageGroupBy
+}
+
+func (opts FindUsageStatsOptions) ToConds() builder.Cond {
+	cond := builder.NewCond()
+	if opts.OwnerID > 0 {
+		cond = cond.And(builder.Eq{"owner_id": opts.OwnerID})
+	}
+	if opts.RepoID > 0 {
+		cond = cond.And(builder.Eq{"repo_id": opts.RepoID})
+	}
+	if opts.FromMonth > 0 {
+		cond = cond.And(builder.Gte{"month": opts.FromMonth})
+	}
+	if opts.ToMonth > 0 {
+		cond = cond.And(builder.Lte{"month": opts.ToMonth})
+	}
+	return cond
+}
+
+// GetUsageStats returns the usage aggregated by the dimension of the options, the most used ones come first
+func GetUsageStats(ctx context.Context, opts FindUsageStatsOptions) ([]*ActionUsageStat, error) {
+	columns, ok := usageGroupByColumns[opts.GroupBy]
+	if !ok {
+		return nil, util.NewInvalidArgumentErrorf("invalid usage group %q", opts.GroupBy)
+	}
+	groupBy := strings.Join(columns, ", ")
+
+	stats := make([]*ActionUsageStat, 0, 10)
+	err := db.GetEngine(ctx).Table("action_usage").
+		Select(groupBy + ", SUM(seconds) AS seconds, SUM(task_count) AS task_count").
+		Where(opts.ToConds()).
+		GroupBy(groupBy).
+		OrderBy("seconds DESC, " + groupBy).
+		Find(&stats)
+	return stats, err
+}
+
+// GetOwnerMonthUsageSeconds returns the runner seconds which the tasks of the owner used in the month
+func GetOwnerMonthUsageSeconds(ctx context.Context, ownerID int64, month int) (int64, error) {
+	return db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID, "month": month}).SumInt(new(ActionUsage), "seconds")
+}
+
+// GetUsageQuota returns the quota of the owner, it returns a NotExist error if the owner has no quota
+func GetUsageQuota(ctx context.Context, ownerID int64) (*ActionUsageQuota, error) {
+	var quota ActionUsageQuota
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID}).Get(&quota)
+	if err != nil {
+		return nil, err
+	} else if !has {
+		return nil, util.NewNotExistErrorf("usage quota of owner %d doesn't exist", ownerID)
+	}
+	return &quota, nil
+}
+
+// SetUsageQuota creates or updates the monthly minutes quota of the owner
+func SetUsageQuota(ctx context.Context, ownerID, monthlyMinutes int64) (*ActionUsageQuota, error) {
+	if monthlyMinutes <= 0 {
+		return nil, util.NewInvalidArgumentErrorf("monthly minutes must be positive")
+	}
+	return db.WithTx2(ctx, func(ctx context.Context) (*ActionUsageQuota, error) {
+		quota, err := GetUsageQuota(ctx, ownerID)
+		if errors.Is(err, util.ErrNotExist) {
+			quota = &ActionUsageQuota{OwnerID: ownerID, MonthlyMinutes: monthlyMinutes}
+			return quota, db.Insert(ctx, quota)
+		} else if err != nil {
+			return nil, err
+		}
+		quota.MonthlyMinutes = monthlyMinutes
+		_, err = db.GetEngine(ctx).ID(quota.ID).Cols("monthly_minutes").Update(quota)
+		return quota, err
+	})
+}
+
+// DeleteUsageQuota removes the quota of the owner, its tasks can use unlimited runner minutes
+func DeleteUsageQuota(ctx context.Context, ownerID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID}).Delete(new(ActionUsageQuota))
+	return err
+}
+
+// IsUsageQuotaExceeded returns whether the owner has used up the minutes quota of the current month.
+// Only the finished tasks are counted, so the running tasks can make the usage exceed the quota a little.
+func IsUsageQuotaExceeded(ctx context.Context, ownerID int64) (bool, error) {
+	quota, err := GetUsageQuota(ctx, ownerID)
+	if errors.Is(err, util.ErrNotExist) {
+		return false, nil
+	} else if err != nil {
+		return false, err
+	}
+	seconds, err := GetOwnerMonthUsageSeconds(ctx, ownerID, UsageMonth(time.Now()))
+	if err != nil {
+		return false, err
+	}
+	return seconds >= quota.MonthlyMinutes*60, nil
+}
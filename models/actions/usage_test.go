@@ -0,0 +1,86 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseUsageMonth(t *testing.T) {
+	month, err := ParseUsageMonth("2026-01")
+	require.NoError(t, err)
+	assert.Equal(t, 202601, month)
+
+	for _, s := range []string{"", "2026", "2026-13", "202601"} {
+		_, err = ParseUsageMonth(s)
+		assert.ErrorIs(t, err, util.ErrInvalidArgument, s)
+	}
+}
+
+func TestUsage(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	now := timeutil.TimeStampNow()
+	newTask := func(repoID, seconds int64, runsOn ...string) *ActionTask {
+		return &ActionTask{OwnerID: 3, RepoID: repoID, Started: now - timeutil.TimeStamp(seconds), Stopped: now, Job: &ActionRunJob{RunsOn: runsOn}}
+	}
+	require.NoError(t, RecordTaskUsage(t.Context(), newTask(3, 90, "ubuntu-latest")))
+	require.NoError(t, RecordTaskUsage(t.Context(), newTask(3, 30, "ubuntu-latest")))
+	require.NoError(t, RecordTaskUsage(t.Context(), newTask(5, 200, "self-hosted", "gpu")))
+	// the task which never started isn't counted
+	require.NoError(t, RecordTaskUsage(t.Context(), &ActionTask{OwnerID: 3, RepoID: 3, Stopped: now, Job: &ActionRunJob{}}))
+
+	month := UsageMonth(time.Now())
+	stats, err := GetUsageStats(t.Context(), FindUsageStatsOptions{OwnerID: 3, FromMonth: month, ToMonth: month, GroupBy: UsageGroupByRepo})
+	require.NoError(t, err)
+	assert.Equal(t, []*ActionUsageStat{
+		{OwnerID: 3, RepoID: 5, Seconds: 200, TaskCount: 1},
+		{OwnerID: 3, RepoID: 3, Seconds: 120, TaskCount: 2},
+	}, stats)
+
+	stats, err = GetUsageStats(t.Context(), FindUsageStatsOptions{OwnerID: 3, GroupBy: UsageGroupByLabels})
+	require.NoError(t, err)
+	assert.Equal(t, []*ActionUsageStat{
+		{OwnerID: 3, Labels: "gpu,self-hosted", Seconds: 200, TaskCount: 1},
+		{OwnerID: 3, Labels: "ubuntu-latest", Seconds: 120, TaskCount: 2},
+	}, stats)
+
+	stats, err = GetUsageStats(t.Context(), FindUsageStatsOptions{ToMonth: month - 1, GroupBy: UsageGroupByOwner})
+	require.NoError(t, err)
+	assert.Empty(t, stats)
+
+	_, err = GetUsageStats(t.Context(), FindUsageStatsOptions{GroupBy: "runner"})
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+
+	exceeded, err := IsUsageQuotaExceeded(t.Context(), 3)
+	require.NoError(t, err)
+	assert.False(t, exceeded)
+
+	_, err = SetUsageQuota(t.Context(), 3, 0)
+	assert.ErrorIs(t, err, util.ErrInvalidArgument)
+	_, err = SetUsageQuota(t.Context(), 3, 10)
+	require.NoError(t, err)
+	exceeded, err = IsUsageQuotaExceeded(t.Context(), 3)
+	require.NoError(t, err)
+	assert.False(t, exceeded)
+
+	quota, err := SetUsageQuota(t.Context(), 3, 5)
+	require.NoError(t, err)
+	assert.EqualValues(t, 5, quota.MonthlyMinutes)
+	exceeded, err = IsUsageQuotaExceeded(t.Context(), 3)
+	require.NoError(t, err)
+	assert.True(t, exceeded)
+
+	require.NoError(t, DeleteUsageQuota(t.Context(), 3))
+	_, err = GetUsageQuota(t.Context(), 3)
+	assert.ErrorIs(t, err, util.ErrNotExist)
+}
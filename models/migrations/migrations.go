@@ -405,6 +405,21 @@ func prepareMigrationTasks() []*migration {
 		newMigration(328, "Add TokenPermissions column to ActionRunJob", v1_26.AddTokenPermissionsToActionRunJob),
 		newMigration(329, "Add unique constraint for user badge", v1_26.AddUniqueIndexForUserBadge),
 		newMigration(330, "Add name column to webhook", v1_26.AddNameToWebhook),
+		newMigration(331, "Add action_cache table", v1_26.AddActionCacheTable),
+		newMigration(332, "Add action environments and deployments", v1_26.AddActionEnvironment),
+		newMigration(333, "Add called workflow ref to action run job", v1_26.AddCalledWorkflowRefToActionRunJob),
+		newMigration(334, "Add action task annotation", v1_26.AddActionTaskAnnotation),
+		newMigration(335, "Add action runner group", v1_26.AddActionRunnerGroup),
+		newMigration(336, "Add action required workflow", v1_26.AddActionRequiredWorkflow),
+		newMigration(337, "Add action usage and usage quota", v1_26.AddActionUsage),
+		newMigration(338, "Add retry columns to action run job", v1_26.AddRetryToActionRunJob),
+		newMigration(339, "Add package remote and remote metadata", v1_26.AddPackageRemote),
+		newMigration(340, "Add package virtual member", v1_26.AddPackageVirtualMember),
+		newMigration(341, "Add SBOM document and component tables", v1_26.AddSBOMDocumentAndComponent),
+		newMigration(342, "Add package version channel", v1_26.AddPackageVersionChannel),
+		newMigration(343, "Add package download based cleanup", v1_26.AddPackageDownloadBasedCleanup),
+		newMigration(344, "Add webhook delivery retries", v1_26.AddWebhookDeliveryRetries),
+		newMigration(345, "Add is_filtered to hook_task", v1_26.AddHookTaskIsFiltered),
 	}
 	return preparedMigrations
 }
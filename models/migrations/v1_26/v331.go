@@ -0,0 +1,28 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionCacheTable(x *xorm.Engine) error {
+	type ActionCache struct {
+		ID            int64  `xorm:"pk autoincr"`
+		RepoID        int64  `xorm:"index(repo_ref)"`
+		Ref           string `xorm:"index(repo_ref)"`
+		Key           string `xorm:"VARCHAR(512) NOT NULL"`
+		Version       string `xorm:"NOT NULL"`
+		Size          int64
+		Complete      bool `xorm:"index"`
+		CreatorTaskID int64
+
+		CreatedUnix  timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix  timeutil.TimeStamp `xorm:"updated"`
+		LastUsedUnix timeutil.TimeStamp `xorm:"index"`
+	}
+	return x.Sync(new(ActionCache))
+}
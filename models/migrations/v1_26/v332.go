@@ -0,0 +1,80 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionEnvironment(x *xorm.Engine) error {
+	type ActionEnvironment struct {
+		ID              int64
+		RepoID          int64    `xorm:"UNIQUE(repo_name) NOT NULL"`
+		Name            string   `xorm:"UNIQUE(repo_name) NOT NULL"`
+		ReviewerUserIDs []int64  `xorm:"JSON TEXT"`
+		ReviewerTeamIDs []int64  `xorm:"JSON TEXT"`
+		WaitTimer       int64    `xorm:"NOT NULL DEFAULT 0"`
+		BranchPatterns  []string `xorm:"JSON TEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionDeployment struct {
+		ID            int64
+		RepoID        int64 `xorm:"index"`
+		EnvironmentID int64 `xorm:"index"`
+		RunID         int64 `xorm:"index"`
+		JobID         int64 `xorm:"index"`
+		Ref           string
+		CommitSHA     string
+		URL           string `xorm:"TEXT"`
+		TriggerUserID int64
+
+		ReviewStatus  int `xorm:"NOT NULL DEFAULT 0"`
+		ReviewerID    int64
+		ReviewComment string             `xorm:"TEXT"`
+		WaitUntil     timeutil.TimeStamp `xorm:"index"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionRunJob struct {
+		Environment string `xorm:"VARCHAR(255) NOT NULL DEFAULT ''"`
+	}
+
+	// the unique indexes of secrets and variables are changed to contain the environment,
+	// so the whole tables are synced to drop the old indexes
+	type Secret struct {
+		ID            int64
+		OwnerID       int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL"`
+		RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		EnvironmentID int64              `xorm:"UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+		Data          string             `xorm:"LONGTEXT"`
+		Description   string             `xorm:"TEXT"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+	}
+
+	type ActionVariable struct {
+		ID            int64              `xorm:"pk autoincr"`
+		OwnerID       int64              `xorm:"UNIQUE(owner_repo_name)"`
+		RepoID        int64              `xorm:"INDEX UNIQUE(owner_repo_name)"`
+		EnvironmentID int64              `xorm:"UNIQUE(owner_repo_name) NOT NULL DEFAULT 0"`
+		Name          string             `xorm:"UNIQUE(owner_repo_name) NOT NULL"`
+		Data          string             `xorm:"LONGTEXT NOT NULL"`
+		Description   string             `xorm:"TEXT"`
+		CreatedUnix   timeutil.TimeStamp `xorm:"created NOT NULL"`
+		UpdatedUnix   timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	if err := x.Sync(new(ActionEnvironment), new(ActionDeployment), new(Secret), new(ActionVariable)); err != nil {
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(ActionRunJob))
+	return err
+}
@@ -0,0 +1,32 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionTaskAnnotation(x *xorm.Engine) error {
+	type ActionTaskAnnotation struct {
+		ID          int64
+		RepoID      int64  `xorm:"INDEX(repo_commit)"`
+		CommitSHA   string `xorm:"INDEX(repo_commit) VARCHAR(64)"`
+		RunID       int64  `xorm:"INDEX"`
+		JobID       int64  `xorm:"INDEX"`
+		TaskID      int64  `xorm:"INDEX"`
+		Level       string `xorm:"VARCHAR(20)"`
+		Path        string `xorm:"TEXT"`
+		StartLine   int
+		EndLine     int
+		StartColumn int
+		EndColumn   int
+		Title       string             `xorm:"VARCHAR(255)"`
+		Message     string             `xorm:"TEXT"`
+		Created     timeutil.TimeStamp `xorm:"created"`
+	}
+
+	return x.Sync(new(ActionTaskAnnotation))
+}
@@ -0,0 +1,34 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionRunnerGroup(x *xorm.Engine) error {
+	type ActionRunnerGroup struct {
+		ID               int64
+		OwnerID          int64    `xorm:"UNIQUE(owner_name) NOT NULL"`
+		Name             string   `xorm:"UNIQUE(owner_name) NOT NULL"`
+		RepoIDs          []int64  `xorm:"JSON TEXT"`
+		BranchPatterns   []string `xorm:"JSON TEXT"`
+		WorkflowPatterns []string `xorm:"JSON TEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionRunner struct {
+		GroupID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+
+	if err := x.Sync(new(ActionRunnerGroup)); err != nil {
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(ActionRunner))
+	return err
+}
@@ -0,0 +1,36 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionRequiredWorkflow(x *xorm.Engine) error {
+	type ActionRequiredWorkflow struct {
+		ID             int64
+		OwnerID        int64    `xorm:"UNIQUE(owner_name) NOT NULL"`
+		Name           string   `xorm:"UNIQUE(owner_name) NOT NULL"`
+		RepoID         int64    `xorm:"INDEX NOT NULL"`
+		WorkflowPath   string   `xorm:"TEXT NOT NULL"`
+		Ref            string   `xorm:"VARCHAR(255)"`
+		RepoPatterns   []string `xorm:"JSON TEXT"`
+		BranchPatterns []string `xorm:"JSON TEXT"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionRun struct {
+		RequiredWorkflowID int64 `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+
+	if err := x.Sync(new(ActionRequiredWorkflow)); err != nil {
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(ActionRun))
+	return err
+}
@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddActionUsage(x *xorm.Engine) error {
+	type ActionUsage struct {
+		ID        int64
+		OwnerID   int64  `xorm:"UNIQUE(usage) INDEX NOT NULL"`
+		RepoID    int64  `xorm:"UNIQUE(usage) NOT NULL"`
+		Labels    string `xorm:"UNIQUE(usage) VARCHAR(255) NOT NULL"`
+		Month     int    `xorm:"UNIQUE(usage) INDEX NOT NULL"`
+		Seconds   int64  `xorm:"NOT NULL DEFAULT 0"`
+		TaskCount int64  `xorm:"NOT NULL DEFAULT 0"`
+
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionUsageQuota struct {
+		ID             int64
+		OwnerID        int64 `xorm:"UNIQUE NOT NULL"`
+		MonthlyMinutes int64 `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated"`
+	}
+
+	type ActionRunJob struct {
+		QuotaExceeded bool `xorm:"NOT NULL DEFAULT FALSE"`
+	}
+
+	if err := x.Sync(new(ActionUsage), new(ActionUsageQuota)); err != nil {
+		return err
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(ActionRunJob))
+	return err
+}
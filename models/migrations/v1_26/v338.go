@@ -0,0 +1,19 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddRetryToActionRunJob(x *xorm.Engine) error {
+	type ActionRunJob struct {
+		RetryCount int64              `xorm:"NOT NULL DEFAULT 0"`
+		RetryAfter timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+	}
+	_, err := x.SyncWithOptions(xorm.SyncOptions{IgnoreDropIndices: true}, new(ActionRunJob))
+	return err
+}
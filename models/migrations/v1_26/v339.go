@@ -0,0 +1,35 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageRemote(x *xorm.Engine) error {
+	type PackageRemote struct {
+		ID                int64  `xorm:"pk autoincr"`
+		OwnerID           int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Type              string `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		URL               string `xorm:"TEXT NOT NULL"`
+		Username          string `xorm:"VARCHAR(255)"`
+		PasswordEncrypted string `xorm:"TEXT"`
+		MetadataTTL       int64  `xorm:"NOT NULL DEFAULT 0"`
+
+		CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+		UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+	}
+
+	type PackageRemoteMetadata struct {
+		ID          int64              `xorm:"pk autoincr"`
+		RemoteID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Path        string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"`
+		Content     []byte             `xorm:"LONGBLOB"`
+		FetchedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageRemote), new(PackageRemoteMetadata))
+}
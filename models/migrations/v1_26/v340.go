@@ -0,0 +1,20 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddPackageVirtualMember(x *xorm.Engine) error {
+	type PackageVirtualMember struct {
+		ID       int64  `xorm:"pk autoincr"`
+		OwnerID  int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Type     string `xorm:"UNIQUE(s) NOT NULL"`
+		MemberID int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+		Priority int    `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageVirtualMember))
+}
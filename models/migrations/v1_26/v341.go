@@ -0,0 +1,47 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+type SBOMDocument struct {
+	ID               int64  `xorm:"pk autoincr"`
+	RepoID           int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CommitSHA        string `xorm:"VARCHAR(64) INDEX NOT NULL DEFAULT ''"`
+	ReleaseID        int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	PackageVersionID int64  `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Format           string `xorm:"NOT NULL"`
+	SpecVersion      string
+	Name             string
+	ComponentCount   int                `xorm:"NOT NULL DEFAULT 0"`
+	CreatorID        int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+func (*SBOMDocument) TableName() string {
+	return "sbom_document"
+}
+
+type SBOMComponent struct {
+	ID         int64  `xorm:"pk autoincr"`
+	DocumentID int64  `xorm:"INDEX NOT NULL"`
+	Name       string `xorm:"NOT NULL"`
+	LowerName  string `xorm:"INDEX NOT NULL"`
+	Version    string
+	PURL       string `xorm:"TEXT"`
+	PURLBase   string `xorm:"INDEX"`
+	License    string `xorm:"TEXT"`
+}
+
+func (*SBOMComponent) TableName() string {
+	return "sbom_component"
+}
+
+func AddSBOMDocumentAndComponent(x *xorm.Engine) error {
+	return x.Sync(&SBOMDocument{}, &SBOMComponent{})
+}
@@ -0,0 +1,23 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddPackageDownloadBasedCleanup(x *xorm.Engine) error {
+	type PackageVersion struct {
+		LastDownloadUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	type PackageCleanupRule struct {
+		KeepDownloadedDays int `xorm:"NOT NULL DEFAULT 0"`
+		RemoveUnusedDays   int `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(PackageVersion), new(PackageCleanupRule))
+}
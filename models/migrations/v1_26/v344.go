@@ -0,0 +1,25 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"code.gitea.io/gitea/modules/timeutil"
+
+	"xorm.io/xorm"
+)
+
+func AddWebhookDeliveryRetries(x *xorm.Engine) error {
+	type HookTask struct {
+		Attempts       int                `xorm:"NOT NULL DEFAULT 0"`
+		NextRetryUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`
+		IsDeadLettered bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+		IsManual       bool               `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	type Webhook struct {
+		ConsecutiveFailures int `xorm:"NOT NULL DEFAULT 0"`
+	}
+
+	return x.Sync(new(HookTask), new(Webhook))
+}
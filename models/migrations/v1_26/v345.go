@@ -0,0 +1,16 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package v1_26
+
+import (
+	"xorm.io/xorm"
+)
+
+func AddHookTaskIsFiltered(x *xorm.Engine) error {
+	type HookTask struct {
+		IsFiltered bool `xorm:"NOT NULL DEFAULT false"`
+	}
+
+	return x.Sync(new(HookTask))
+}
@@ -0,0 +1,119 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"regexp"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageVersionChannel))
+}
+
+var (
+	// ErrDuplicatePackageVersionChannel indicates a version is already published to the channel
+	ErrDuplicatePackageVersionChannel = util.NewAlreadyExistErrorf("package version is already published to the channel")
+	// ErrPackageVersionChannelNotExist indicates a version is not published to the channel
+	ErrPackageVersionChannelNotExist = util.NewNotExistErrorf("package version is not published to the channel")
+)
+
+var channelNamePattern = regexp.MustCompile(`^[a-z0-9][a-z0-9._-]{0,49}$`)
+
+// IsValidChannelName checks if the name is a valid channel name
+func IsValidChannelName(name string) bool {
+	return channelNamePattern.MatchString(name)
+}
+
+// PackageVersionChannel publishes a package version to a channel (for example "staging" or "stable").
+// A version can be published to several channels, promoting it to another channel doesn't copy its files.
+type PackageVersionChannel struct {
+	ID           int64              `xorm:"pk autoincr"`
+	VersionID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Channel      string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	PromotedFrom string             `xorm:"NOT NULL DEFAULT ''"` // the channel the version was promoted from, empty if it was published directly
+	CreatorID    int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix  timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// GetChannelsByVersionID gets the channels the version is published to
+func GetChannelsByVersionID(ctx context.Context, versionID int64) ([]*PackageVersionChannel, error) {
+	channels := make([]*PackageVersionChannel, 0, 5)
+	return channels, db.GetEngine(ctx).
+		Where(builder.Eq{"version_id": versionID}).
+		OrderBy("channel").
+		Find(&channels)
+}
+
+// GetVersionChannel gets the entry of the version in the channel
+func GetVersionChannel(ctx context.Context, versionID int64, channel string) (*PackageVersionChannel, error) {
+	pvc, has, err := db.Get[PackageVersionChannel](ctx, builder.Eq{"version_id": versionID, "channel": channel})
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageVersionChannelNotExist
+	}
+	return pvc, nil
+}
+
+// GetVersionsByChannel gets the versions of the package published to the channel ordered from the newest to the oldest
+func GetVersionsByChannel(ctx context.Context, packageID int64, channel string) ([]*PackageVersion, error) {
+	pvs := make([]*PackageVersion, 0, 10)
+	return pvs, db.GetEngine(ctx).
+		Where(builder.Eq{"package_id": packageID, "is_internal": false}).
+		In("id", builder.Select("version_id").From("package_version_channel").Where(builder.Eq{"channel": channel})).
+		OrderBy("created_unix DESC, id DESC").
+		Find(&pvs)
+}
+
+// AddVersionToChannel publishes the version to the channel. If the version is already published to the channel ErrDuplicatePackageVersionChannel is returned
+func AddVersionToChannel(ctx context.Context, pvc *PackageVersionChannel) error {
+	if !IsValidChannelName(pvc.Channel) {
+		return util.NewInvalidArgumentErrorf("invalid channel name %q", pvc.Channel)
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		has, err := db.Exist[PackageVersionChannel](ctx, builder.Eq{"version_id": pvc.VersionID, "channel": pvc.Channel})
+		if err != nil {
+			return err
+		}
+		if has {
+			return ErrDuplicatePackageVersionChannel
+		}
+		return db.Insert(ctx, pvc)
+	})
+}
+
+// RemoveVersionFromChannel removes the version from the channel
+func RemoveVersionFromChannel(ctx context.Context, versionID int64, channel string) error {
+	n, err := db.GetEngine(ctx).Where(builder.Eq{"version_id": versionID, "channel": channel}).Delete(new(PackageVersionChannel))
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrPackageVersionChannelNotExist
+	}
+	return nil
+}
+
+// DeleteChannelsByVersionID removes the version from all channels
+func DeleteChannelsByVersionID(ctx context.Context, versionID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"version_id": versionID}).Delete(new(PackageVersionChannel))
+	return err
+}
+
+// DeleteChannelsByPackageID removes all versions of the package from all channels
+func DeleteChannelsByPackageID(ctx context.Context, packageID int64) error {
+	_, err := db.GetEngine(ctx).
+		In("version_id", builder.Select("id").From("package_version").Where(builder.Eq{"package_id": packageID})).
+		Delete(new(PackageVersionChannel))
+	return err
+}
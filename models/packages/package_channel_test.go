@@ -0,0 +1,52 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestIsValidChannelName(t *testing.T) {
+	for _, name := range []string{"stable", "staging", "release-1.x", "rc_2", "0"} {
+		assert.True(t, packages_model.IsValidChannelName(name), name)
+	}
+	for _, name := range []string{"", "Stable", "-stable", "sta ble", "a/b"} {
+		assert.False(t, packages_model.IsValidChannelName(name), name)
+	}
+}
+
+func TestVersionChannels(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.ErrorIs(t, packages_model.AddVersionToChannel(t.Context(), &packages_model.PackageVersionChannel{VersionID: 1, Channel: "In Valid"}), util.ErrInvalidArgument)
+
+	require.NoError(t, packages_model.AddVersionToChannel(t.Context(), &packages_model.PackageVersionChannel{VersionID: 1, Channel: "staging"}))
+	require.NoError(t, packages_model.AddVersionToChannel(t.Context(), &packages_model.PackageVersionChannel{VersionID: 1, Channel: "stable", PromotedFrom: "staging"}))
+	assert.ErrorIs(t, packages_model.AddVersionToChannel(t.Context(), &packages_model.PackageVersionChannel{VersionID: 1, Channel: "stable"}), packages_model.ErrDuplicatePackageVersionChannel)
+
+	channels, err := packages_model.GetChannelsByVersionID(t.Context(), 1)
+	require.NoError(t, err)
+	require.Len(t, channels, 2)
+	assert.Equal(t, "stable", channels[0].Channel)
+	assert.Equal(t, "staging", channels[0].PromotedFrom)
+	assert.Equal(t, "staging", channels[1].Channel)
+
+	require.NoError(t, packages_model.RemoveVersionFromChannel(t.Context(), 1, "staging"))
+	assert.ErrorIs(t, packages_model.RemoveVersionFromChannel(t.Context(), 1, "staging"), packages_model.ErrPackageVersionChannelNotExist)
+
+	_, err = packages_model.GetVersionChannel(t.Context(), 1, "stable")
+	require.NoError(t, err)
+
+	require.NoError(t, packages_model.DeleteChannelsByVersionID(t.Context(), 1))
+	channels, err = packages_model.GetChannelsByVersionID(t.Context(), 1)
+	require.NoError(t, err)
+	assert.Empty(t, channels)
+}
@@ -30,7 +30,9 @@ type PackageCleanupRule struct {
 	KeepCount            int                `xorm:"NOT NULL DEFAULT 0"`
 	KeepPattern          string             `xorm:"NOT NULL DEFAULT ''"`
 	KeepPatternMatcher   *regexp.Regexp     `xorm:"-"`
+	KeepDownloadedDays   int                `xorm:"NOT NULL DEFAULT 0"`
 	RemoveDays           int                `xorm:"NOT NULL DEFAULT 0"`
+	RemoveUnusedDays     int                `xorm:"NOT NULL DEFAULT 0"`
 	RemovePattern        string             `xorm:"NOT NULL DEFAULT ''"`
 	RemovePatternMatcher *regexp.Regexp     `xorm:"-"`
 	MatchFullName        bool               `xorm:"NOT NULL DEFAULT false"`
@@ -79,6 +81,20 @@ func GetCleanupRuleByID(ctx context.Context, id int64) (*PackageCleanupRule, err
 	return pcr, nil
 }
 
+// GetCleanupRuleByOwnerAndType gets the cleanup rule of the owner for the package type
+func GetCleanupRuleByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageCleanupRule, error) {
+	pcr := &PackageCleanupRule{}
+
+	has, err := db.GetEngine(ctx).Where("owner_id = ? AND type = ?", ownerID, packageType).Get(pcr)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageCleanupRuleNotExist
+	}
+	return pcr, nil
+}
+
 func UpdateCleanupRule(ctx context.Context, pcr *PackageCleanupRule) error {
 	_, err := db.GetEngine(ctx).ID(pcr.ID).AllCols().Update(pcr)
 	return err
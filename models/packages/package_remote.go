@@ -0,0 +1,213 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"net/url"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/secret"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+// ErrPackageRemoteNotExist indicates a package remote not exist error
+var ErrPackageRemoteNotExist = util.NewNotExistErrorf("package remote does not exist")
+
+func init() {
+	db.RegisterModel(new(PackageRemote))
+	db.RegisterModel(new(PackageRemoteMetadata))
+}
+
+// RemoteTypeList are the package types which can fetch the missing packages from an upstream registry
+var RemoteTypeList = []Type{
+	TypeGo,
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// DefaultRemoteMetadataTTL is the default seconds to cache the upstream metadata
+const DefaultRemoteMetadataTTL = 10 * 60
+
+// PackageRemote represents an upstream registry of a package type of an owner.
+// A package which is requested but doesn't exist is fetched from the upstream registry and cached as a normal package version,
+// so it's listed in the package UI and counts toward the quotas of the owner.
+type PackageRemote struct {
+	ID                int64  `xorm:"pk autoincr"`
+	OwnerID           int64  `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type              Type   `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	URL               string `xorm:"TEXT NOT NULL"`
+	Username          string `xorm:"VARCHAR(255)"`
+	PasswordEncrypted string `xorm:"TEXT"`
+	MetadataTTL       int64  `xorm:"NOT NULL DEFAULT 0"` // the seconds to cache the upstream metadata like the version lists, 0 means the default TTL
+
+	CreatedUnix timeutil.TimeStamp `xorm:"created NOT NULL DEFAULT 0"`
+	UpdatedUnix timeutil.TimeStamp `xorm:"updated NOT NULL DEFAULT 0"`
+}
+
+// PackageRemoteMetadata is an upstream metadata response cached for the TTL of the remote
+type PackageRemoteMetadata struct {
+	ID          int64              `xorm:"pk autoincr"`
+	RemoteID    int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Path        string             `xorm:"UNIQUE(s) VARCHAR(255) NOT NULL"` // the path relative to the upstream url
+	Content     []byte             `xorm:"LONGBLOB"`
+	FetchedUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
+}
+
+// IsRemoteType returns whether the packages of the type can be fetched from an upstream registry
+func IsRemoteType(t Type) bool {
+	return slices.Contains(RemoteTypeList, t)
+}
+
+// GetMetadataTTL returns the seconds to cache the upstream metadata
+func (r *PackageRemote) GetMetadataTTL() int64 {
+	if r.MetadataTTL <= 0 {
+		return DefaultRemoteMetadataTTL
+	}
+	return r.MetadataTTL
+}
+
+// Password returns the decrypted password of the upstream registry
+func (r *PackageRemote) Password() (string, error) {
+	if r.PasswordEncrypted == "" {
+		return "", nil
+	}
+	return secret.DecryptSecret(setting.SecretKey, r.PasswordEncrypted)
+}
+
+// SetPassword encrypts and sets the password of the upstream registry
+func (r *PackageRemote) SetPassword(password string) error {
+	if password == "" {
+		r.PasswordEncrypted = ""
+		return nil
+	}
+	encrypted, err := secret.EncryptSecret(setting.SecretKey, password)
+	if err != nil {
+		return err
+	}
+	r.PasswordEncrypted = encrypted
+	return nil
+}
+
+// ValidateRemote checks the type and the upstream url of a remote
+func ValidateRemote(r *PackageRemote) error {
+	if !IsRemoteType(r.Type) {
+		return util.NewInvalidArgumentErrorf("package type %q doesn't support remotes", r.Type)
+	}
+	u, err := url.Parse(r.URL)
+	if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" {
+		return util.NewInvalidArgumentErrorf("invalid upstream url %q", r.URL)
+	}
+	if r.MetadataTTL < 0 {
+		return util.NewInvalidArgumentErrorf("metadata ttl must not be negative")
+	}
+	r.URL = strings.TrimSuffix(r.URL, "/")
+	return nil
+}
+
+// GetRemoteByOwnerAndType returns the remote of the package type of the owner
+func GetRemoteByOwnerAndType(ctx context.Context, ownerID int64, packageType Type) (*PackageRemote, error) {
+	r := &PackageRemote{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID, "type": packageType}).Get(r)
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrPackageRemoteNotExist
+	}
+	return r, nil
+}
+
+// GetRemotesByOwner returns all remotes of the owner
+func GetRemotesByOwner(ctx context.Context, ownerID int64) ([]*PackageRemote, error) {
+	rs := make([]*PackageRemote, 0, len(RemoteTypeList))
+	return rs, db.GetEngine(ctx).Where("owner_id = ?", ownerID).OrderBy("type").Find(&rs)
+}
+
+// SetRemote creates or replaces the remote of the package type of the owner, the cached metadata of the old upstream is removed
+func SetRemote(ctx context.Context, r *PackageRemote) error {
+	if err := ValidateRemote(r); err != nil {
+		return err
+	}
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		old, err := GetRemoteByOwnerAndType(ctx, r.OwnerID, r.Type)
+		if err == ErrPackageRemoteNotExist {
+			return db.Insert(ctx, r)
+		} else if err != nil {
+			return err
+		}
+		r.ID = old.ID
+		if _, err := db.GetEngine(ctx).ID(r.ID).Cols("url", "username", "password_encrypted", "metadata_ttl").Update(r); err != nil {
+			return err
+		}
+		return DeleteRemoteMetadata(ctx, r.ID)
+	})
+}
+
+// DeleteRemote deletes the remote and its cached metadata, the cached packages are kept
+func DeleteRemote(ctx context.Context, r *PackageRemote) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.DeleteByID[PackageRemote](ctx, r.ID); err != nil {
+			return err
+		}
+		return DeleteRemoteMetadata(ctx, r.ID)
+	})
+}
+
+// DeleteRemotesByOwner deletes all remotes of the owner and their cached metadata
+func DeleteRemotesByOwner(ctx context.Context, ownerID int64) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		remoteIDs := builder.Select("id").From("package_remote").Where(builder.Eq{"owner_id": ownerID})
+		if _, err := db.GetEngine(ctx).Where(builder.In("remote_id", remoteIDs)).Delete(new(PackageRemoteMetadata)); err != nil {
+			return err
+		}
+		_, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID}).Delete(new(PackageRemote))
+		return err
+	})
+}
+
+// GetRemoteMetadata returns the cached upstream metadata at the path, it returns nil if it hasn't been cached
+func GetRemoteMetadata(ctx context.Context, remoteID int64, path string) (*PackageRemoteMetadata, error) {
+	m := &PackageRemoteMetadata{}
+	has, err := db.GetEngine(ctx).Where(builder.Eq{"remote_id": remoteID, "path": path}).Get(m)
+	if err != nil || !has {
+		return nil, err
+	}
+	return m, nil
+}
+
+// SetRemoteMetadata caches the upstream metadata at the path
+func SetRemoteMetadata(ctx context.Context, remoteID int64, path string, content []byte) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		m, err := GetRemoteMetadata(ctx, remoteID, path)
+		if err != nil {
+			return err
+		}
+		if m == nil {
+			return db.Insert(ctx, &PackageRemoteMetadata{
+				RemoteID:    remoteID,
+				Path:        path,
+				Content:     content,
+				FetchedUnix: timeutil.TimeStampNow(),
+			})
+		}
+		m.Content = content
+		m.FetchedUnix = timeutil.TimeStampNow()
+		_, err = db.GetEngine(ctx).ID(m.ID).Cols("content", "fetched_unix").Update(m)
+		return err
+	})
+}
+
+// DeleteRemoteMetadata removes the cached upstream metadata of the remote
+func DeleteRemoteMetadata(ctx context.Context, remoteID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"remote_id": remoteID}).Delete(new(PackageRemoteMetadata))
+	return err
+}
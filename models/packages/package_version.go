@@ -26,15 +26,16 @@ func init() {
 
 // PackageVersion represents a package version
 type PackageVersion struct {
-	ID            int64              `xorm:"pk autoincr"`
-	PackageID     int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	CreatorID     int64              `xorm:"NOT NULL DEFAULT 0"`
-	Version       string             `xorm:"NOT NULL"`
-	LowerVersion  string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
-	CreatedUnix   timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
-	IsInternal    bool               `xorm:"INDEX NOT NULL DEFAULT false"`
-	MetadataJSON  string             `xorm:"metadata_json LONGTEXT"`
-	DownloadCount int64              `xorm:"NOT NULL DEFAULT 0"`
+	ID               int64              `xorm:"pk autoincr"`
+	PackageID        int64              `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CreatorID        int64              `xorm:"NOT NULL DEFAULT 0"`
+	Version          string             `xorm:"NOT NULL"`
+	LowerVersion     string             `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+	IsInternal       bool               `xorm:"INDEX NOT NULL DEFAULT false"`
+	MetadataJSON     string             `xorm:"metadata_json LONGTEXT"`
+	DownloadCount    int64              `xorm:"NOT NULL DEFAULT 0"`
+	LastDownloadUnix timeutil.TimeStamp `xorm:"NOT NULL DEFAULT 0"`
 }
 
 // IsPrerelease checks if the version is a prerelease version according to semantic versioning
@@ -73,12 +74,20 @@ func UpdateVersion(ctx context.Context, pv *PackageVersion) error {
 	return err
 }
 
-// IncrementDownloadCounter increments the download counter of a version
+// IncrementDownloadCounter increments the download counter of a version and records the time of the download
 func IncrementDownloadCounter(ctx context.Context, versionID int64) error {
-	_, err := db.GetEngine(ctx).Exec("UPDATE `package_version` SET `download_count` = `download_count` + 1 WHERE `id` = ?", versionID)
+	_, err := db.GetEngine(ctx).Exec("UPDATE `package_version` SET `download_count` = `download_count` + 1, `last_download_unix` = ? WHERE `id` = ?", timeutil.TimeStampNow(), versionID)
 	return err
 }
 
+// LastUsedUnix returns the time the version was downloaded the last time. If it was never downloaded, the time of the creation is returned.
+func (pv *PackageVersion) LastUsedUnix() timeutil.TimeStamp {
+	if pv.LastDownloadUnix > pv.CreatedUnix {
+		return pv.LastDownloadUnix
+	}
+	return pv.CreatedUnix
+}
+
 // GetVersionByID gets a version by id
 func GetVersionByID(ctx context.Context, versionID int64) (*PackageVersion, error) {
 	pv := &PackageVersion{}
@@ -0,0 +1,106 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"slices"
+
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(PackageVirtualMember))
+}
+
+// VirtualTypeList are the package types whose registries can resolve the packages of other owners
+var VirtualTypeList = []Type{
+	TypeMaven,
+	TypeNpm,
+	TypePyPI,
+}
+
+// PackageVirtualMember is an owner whose packages are resolved by the registry of a package type of another owner.
+// The registry resolves the packages of its own owner first, then the ones of the members ordered by the priority.
+type PackageVirtualMember struct {
+	ID       int64 `xorm:"pk autoincr"`
+	OwnerID  int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Type     Type  `xorm:"UNIQUE(s) NOT NULL"`
+	MemberID int64 `xorm:"UNIQUE(s) INDEX NOT NULL"`
+	Priority int   `xorm:"NOT NULL DEFAULT 0"` // the members with lower values are resolved first
+}
+
+// IsVirtualType returns whether the registries of the type can resolve the packages of other owners
+func IsVirtualType(t Type) bool {
+	return slices.Contains(VirtualTypeList, t)
+}
+
+// GetVirtualMembers returns the members of the registry of the package type of the owner ordered by the priority
+func GetVirtualMembers(ctx context.Context, ownerID int64, packageType Type) ([]*PackageVirtualMember, error) {
+	members := make([]*PackageVirtualMember, 0, 5)
+	return members, db.GetEngine(ctx).
+		Where(builder.Eq{"owner_id": ownerID, "type": packageType}).
+		OrderBy("priority, id").
+		Find(&members)
+}
+
+// GetVirtualMembersByOwner returns the members of all registries of the owner
+func GetVirtualMembersByOwner(ctx context.Context, ownerID int64) ([]*PackageVirtualMember, error) {
+	members := make([]*PackageVirtualMember, 0, 5)
+	return members, db.GetEngine(ctx).
+		Where(builder.Eq{"owner_id": ownerID}).
+		OrderBy("type, priority, id").
+		Find(&members)
+}
+
+// SetVirtualMembers replaces the members of the registry of the package type of the owner, the members are resolved in the order of the ids
+func SetVirtualMembers(ctx context.Context, ownerID int64, packageType Type, memberIDs []int64) error {
+	if !IsVirtualType(packageType) {
+		return util.NewInvalidArgumentErrorf("package type %q doesn't support virtual registries", packageType)
+	}
+	seen := make(container.Set[int64], len(memberIDs))
+	for _, memberID := range memberIDs {
+		if memberID == ownerID {
+			return util.NewInvalidArgumentErrorf("the owner can't be a member of its own registry")
+		}
+		if !seen.Add(memberID) {
+			return util.NewInvalidArgumentErrorf("duplicate member %d", memberID)
+		}
+	}
+
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := DeleteVirtualMembers(ctx, ownerID, packageType); err != nil {
+			return err
+		}
+		if len(memberIDs) == 0 {
+			return nil
+		}
+		members := make([]*PackageVirtualMember, 0, len(memberIDs))
+		for i, memberID := range memberIDs {
+			members = append(members, &PackageVirtualMember{
+				OwnerID:  ownerID,
+				Type:     packageType,
+				MemberID: memberID,
+				Priority: i,
+			})
+		}
+		return db.Insert(ctx, members)
+	})
+}
+
+// DeleteVirtualMembers removes all members of the registry of the package type of the owner
+func DeleteVirtualMembers(ctx context.Context, ownerID int64, packageType Type) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID, "type": packageType}).Delete(new(PackageVirtualMember))
+	return err
+}
+
+// DeleteVirtualMembersByOwner removes the members of the registries of the owner and the owner from the registries of other owners
+func DeleteVirtualMembersByOwner(ctx context.Context, ownerID int64) error {
+	_, err := db.GetEngine(ctx).Where(builder.Eq{"owner_id": ownerID}.Or(builder.Eq{"member_id": ownerID})).Delete(new(PackageVirtualMember))
+	return err
+}
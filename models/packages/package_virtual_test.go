@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages_test
+
+import (
+	"testing"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSetVirtualMembers(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	assert.ErrorIs(t, packages_model.SetVirtualMembers(t.Context(), 2, packages_model.TypeGeneric, []int64{3}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, packages_model.SetVirtualMembers(t.Context(), 2, packages_model.TypeNpm, []int64{2}), util.ErrInvalidArgument)
+	assert.ErrorIs(t, packages_model.SetVirtualMembers(t.Context(), 2, packages_model.TypeNpm, []int64{3, 3}), util.ErrInvalidArgument)
+
+	require.NoError(t, packages_model.SetVirtualMembers(t.Context(), 2, packages_model.TypeNpm, []int64{35, 3}))
+	require.NoError(t, packages_model.SetVirtualMembers(t.Context(), 2, packages_model.TypeMaven, []int64{3}))
+	require.NoError(t, packages_model.SetVirtualMembers(t.Context(), 4, packages_model.TypeNpm, []int64{2}))
+
+	members, err := packages_model.GetVirtualMembers(t.Context(), 2, packages_model.TypeNpm)
+	require.NoError(t, err)
+	require.Len(t, members, 2)
+	assert.EqualValues(t, 35, members[0].MemberID)
+	assert.EqualValues(t, 3, members[1].MemberID)
+
+	// the members are replaced
+	require.NoError(t, packages_model.SetVirtualMembers(t.Context(), 2, packages_model.TypeNpm, []int64{3}))
+	members, err = packages_model.GetVirtualMembers(t.Context(), 2, packages_model.TypeNpm)
+	require.NoError(t, err)
+	require.Len(t, members, 1)
+	assert.EqualValues(t, 3, members[0].MemberID)
+
+	// the registries of the owner and its memberships are removed
+	require.NoError(t, packages_model.DeleteVirtualMembersByOwner(t.Context(), 2))
+	members, err = packages_model.GetVirtualMembersByOwner(t.Context(), 2)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+	members, err = packages_model.GetVirtualMembers(t.Context(), 4, packages_model.TypeNpm)
+	require.NoError(t, err)
+	assert.Empty(t, members)
+}
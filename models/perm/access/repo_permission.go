@@ -379,6 +379,32 @@ func GetActionsUserRepoPermission(ctx context.Context, repo *repo_model.Reposito
 	return perm, nil
 }
 
+// CanActionsReadRepo returns whether the workflows of taskRepo can read the target repository without a task,
+// for example, to use a reusable workflow in it. It follows the same cross-repo rules as GetActionsUserRepoPermission.
+func CanActionsReadRepo(ctx context.Context, taskRepo, targetRepo *repo_model.Repository, isForkPR bool) (bool, error) {
+	if taskRepo.ID == targetRepo.ID {
+		return true, nil
+	}
+
+	if checkSameOwnerCrossRepoAccess(ctx, taskRepo, targetRepo, isForkPR) {
+		return true, nil
+	}
+
+	botPerm, err := GetIndividualUserRepoPermission(ctx, targetRepo, user_model.NewActionsUser())
+	if err != nil {
+		return false, err
+	}
+	if botPerm.CanRead(unit.TypeCode) {
+		return true, nil
+	}
+
+	if taskRepo.IsPrivate {
+		actionsUnit := targetRepo.MustGetUnit(ctx, unit.TypeActions)
+		return actionsUnit.ActionsConfig().IsCollaborativeOwner(taskRepo.OwnerID), nil
+	}
+	return false, nil
+}
+
 // GetDoerRepoPermission returns the repository permission for the current actor,
 // dispatching to GetActionsUserRepoPermission when the actor is an Actions token user.
 func GetDoerRepoPermission(ctx context.Context, repo *repo_model.Repository, user *user_model.User) (Permission, error) {
@@ -29,6 +29,8 @@ func (ActionsTokenPermissionMode) EnumValues() []ActionsTokenPermissionMode {
 // ActionsTokenPermissions defines the permissions for different repository units
 type ActionsTokenPermissions struct {
 	UnitAccessModes map[unit.Type]perm.AccessMode `json:"unit_access_modes,omitempty"`
+	// IDToken allows the job to request OIDC ID tokens, it's only granted by "id-token: write" or "write-all" explicitly
+	IDToken bool `json:"id_token,omitempty"`
 }
 
 var ActionsTokenUnitTypes = []unit.Type{
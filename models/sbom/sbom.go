@@ -0,0 +1,234 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"context"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/models/unit"
+	user_model "code.gitea.io/gitea/models/user"
+	sbom_module "code.gitea.io/gitea/modules/sbom"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+
+	"xorm.io/builder"
+)
+
+func init() {
+	db.RegisterModel(new(Document))
+	db.RegisterModel(new(Component))
+}
+
+// ErrDocumentNotExist indicates a SBOM document not exist error
+var ErrDocumentNotExist = util.NewNotExistErrorf("SBOM document does not exist")
+
+// Document is a software bill of materials attached to a package version, a commit or a release.
+// There is at most one document per target.
+type Document struct {
+	ID               int64              `xorm:"pk autoincr"`
+	RepoID           int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	CommitSHA        string             `xorm:"VARCHAR(64) INDEX NOT NULL DEFAULT ''"`
+	ReleaseID        int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	PackageVersionID int64              `xorm:"INDEX NOT NULL DEFAULT 0"`
+	Format           sbom_module.Format `xorm:"NOT NULL"`
+	SpecVersion      string
+	Name             string
+	ComponentCount   int                `xorm:"NOT NULL DEFAULT 0"`
+	CreatorID        int64              `xorm:"NOT NULL DEFAULT 0"`
+	CreatedUnix      timeutil.TimeStamp `xorm:"created INDEX NOT NULL"`
+}
+
+// TableName sets the table name
+func (*Document) TableName() string {
+	return "sbom_document"
+}
+
+// Component is a software component listed in a document
+type Component struct {
+	ID         int64  `xorm:"pk autoincr"`
+	DocumentID int64  `xorm:"INDEX NOT NULL"`
+	Name       string `xorm:"NOT NULL"`
+	LowerName  string `xorm:"INDEX NOT NULL"`
+	Version    string
+	PURL       string `xorm:"TEXT"`
+	PURLBase   string `xorm:"INDEX"` // the lower case package url without version, qualifiers and subpath
+	License    string `xorm:"TEXT"`
+}
+
+// TableName sets the table name
+func (*Component) TableName() string {
+	return "sbom_component"
+}
+
+func (d *Document) targetCond() builder.Cond {
+	return builder.Eq{
+		"repo_id":            d.RepoID,
+		"commit_sha":         d.CommitSHA,
+		"release_id":         d.ReleaseID,
+		"package_version_id": d.PackageVersionID,
+	}
+}
+
+func getDocumentByTarget(ctx context.Context, target *Document) (*Document, error) {
+	doc, has, err := db.Get[Document](ctx, target.targetCond())
+	if err != nil {
+		return nil, err
+	}
+	if !has {
+		return nil, ErrDocumentNotExist
+	}
+	return doc, nil
+}
+
+// GetDocumentByPackageVersionID gets the document attached to the package version
+func GetDocumentByPackageVersionID(ctx context.Context, versionID int64) (*Document, error) {
+	return getDocumentByTarget(ctx, &Document{PackageVersionID: versionID})
+}
+
+// GetDocumentByCommit gets the document attached to the commit of the repository
+func GetDocumentByCommit(ctx context.Context, repoID int64, commitSHA string) (*Document, error) {
+	return getDocumentByTarget(ctx, &Document{RepoID: repoID, CommitSHA: commitSHA})
+}
+
+// GetDocumentByReleaseID gets the document attached to the release of the repository
+func GetDocumentByReleaseID(ctx context.Context, repoID, releaseID int64) (*Document, error) {
+	return getDocumentByTarget(ctx, &Document{RepoID: repoID, ReleaseID: releaseID})
+}
+
+// GetDocumentsByIDs gets the documents with the ids
+func GetDocumentsByIDs(ctx context.Context, ids []int64) (map[int64]*Document, error) {
+	docs := make(map[int64]*Document, len(ids))
+	return docs, db.GetEngine(ctx).In("id", ids).Find(&docs)
+}
+
+// GetComponentsByDocumentID gets all components of the document
+func GetComponentsByDocumentID(ctx context.Context, documentID int64) ([]*Component, error) {
+	components := make([]*Component, 0, 50)
+	return components, db.GetEngine(ctx).
+		Where(builder.Eq{"document_id": documentID}).
+		OrderBy("lower_name, version, id").
+		Find(&components)
+}
+
+// ReplaceDocument stores the document and its components and removes the document previously attached to the same target
+func ReplaceDocument(ctx context.Context, doc *Document, components []*Component) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if err := deleteDocuments(ctx, doc.targetCond()); err != nil {
+			return err
+		}
+
+		doc.ComponentCount = len(components)
+		if err := db.Insert(ctx, doc); err != nil {
+			return err
+		}
+
+		for _, c := range components {
+			c.DocumentID = doc.ID
+			c.LowerName = strings.ToLower(c.Name)
+		}
+
+		const batchSize = 100
+		for len(components) > 0 {
+			n := min(batchSize, len(components))
+			if err := db.Insert(ctx, components[:n]); err != nil {
+				return err
+			}
+			components = components[n:]
+		}
+		return nil
+	})
+}
+
+func deleteDocuments(ctx context.Context, cond builder.Cond) error {
+	if _, err := db.GetEngine(ctx).
+		In("document_id", builder.Select("id").From("sbom_document").Where(cond)).
+		Delete(&Component{}); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Where(cond).Delete(&Document{})
+	return err
+}
+
+// DeleteDocumentByPackageVersionID deletes the document attached to the package version
+func DeleteDocumentByPackageVersionID(ctx context.Context, versionID int64) error {
+	return deleteDocuments(ctx, builder.Eq{"package_version_id": versionID})
+}
+
+// DeleteDocumentsByPackageID deletes the documents attached to the versions of the package
+func DeleteDocumentsByPackageID(ctx context.Context, packageID int64) error {
+	return deleteDocuments(ctx, builder.In("package_version_id", builder.Select("id").From("package_version").Where(builder.Eq{"package_id": packageID})))
+}
+
+// DeleteDocumentByReleaseID deletes the document attached to the release
+func DeleteDocumentByReleaseID(ctx context.Context, releaseID int64) error {
+	return deleteDocuments(ctx, builder.Eq{"release_id": releaseID})
+}
+
+// DeleteDocumentsByRepoID deletes all documents attached to commits and releases of the repository
+func DeleteDocumentsByRepoID(ctx context.Context, repoID int64) error {
+	return deleteDocuments(ctx, builder.Eq{"repo_id": repoID})
+}
+
+// ComponentSearchOptions are the options to search components across the packages and repositories of an owner
+type ComponentSearchOptions struct {
+	db.ListOptions
+	OwnerID      int64
+	Actor        *user_model.User // the repositories of the owner are filtered by the access of the actor
+	IncludeRepos bool
+	PublicOnly   bool   // only search the public repositories
+	Keyword      string // matches the component name
+	PURL         string // matches the package url without version
+	Version      string
+	License      string
+}
+
+// ToConds implements db.FindOptions
+func (opts ComponentSearchOptions) ToConds() builder.Cond {
+	documentCond := builder.In("package_version_id",
+		builder.Select("package_version.id").
+			From("package_version").
+			InnerJoin("package", "package.id = package_version.package_id").
+			Where(builder.Eq{
+				"package.owner_id":            opts.OwnerID,
+				"package.is_internal":         false,
+				"package_version.is_internal": false,
+			}),
+	)
+	if opts.IncludeRepos {
+		repoCond := builder.NewCond().
+			And(builder.Eq{"`repository`.owner_id": opts.OwnerID}).
+			And(repo_model.AccessibleRepositoryCondition(opts.Actor, unit.TypeCode))
+		if opts.PublicOnly {
+			repoCond = repoCond.And(builder.Eq{"`repository`.is_private": false})
+		}
+		documentCond = documentCond.Or(builder.And(
+			builder.Gt{"repo_id": 0},
+			builder.In("repo_id", builder.Select("id").From("repository").Where(repoCond)),
+		))
+	}
+
+	cond := builder.In("document_id", builder.Select("id").From("sbom_document").Where(documentCond))
+
+	if opts.Keyword != "" {
+		cond = cond.And(builder.Like{"lower_name", strings.ToLower(opts.Keyword)})
+	}
+	if opts.PURL != "" {
+		cond = cond.And(builder.Eq{"purl_base": opts.PURL})
+	}
+	if opts.Version != "" {
+		cond = cond.And(builder.Eq{"version": opts.Version})
+	}
+	if opts.License != "" {
+		cond = cond.And(builder.Like{"license", opts.License})
+	}
+	return cond
+}
+
+// ToOrders implements db.FindOptionsOrder
+func (opts ComponentSearchOptions) ToOrders() string {
+	return "lower_name, version, id"
+}
@@ -24,4 +24,7 @@ const (
 	SettingEmailNotificationGiteaActionsDisabled    = "disabled"
 
 	SettingsKeyActionsConfig = "actions.config"
+
+	// SettingsKeyPackagesImmutableVersions is the setting key whether released package versions of the owner can't be overwritten or deleted
+	SettingsKeyPackagesImmutableVersions = "packages.immutable_versions"
 )
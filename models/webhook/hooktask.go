@@ -62,6 +62,16 @@ type HookTask struct {
 	RequestInfo     *HookRequest  `xorm:"-"`
 	ResponseContent string        `xorm:"LONGTEXT"`
 	ResponseInfo    *HookResponse `xorm:"-"`
+
+	// Retry info.
+	Attempts       int                `xorm:"NOT NULL DEFAULT 0"`           // number of delivery attempts
+	NextRetryUnix  timeutil.TimeStamp `xorm:"INDEX NOT NULL DEFAULT 0"`     // when the failed delivery is retried, 0 if no retry is scheduled
+	IsDeadLettered bool               `xorm:"INDEX NOT NULL DEFAULT false"` // all delivery attempts failed and the task is not retried anymore
+	IsManual       bool               `xorm:"NOT NULL DEFAULT false"`       // test deliveries and replays are neither retried nor counted as failures of the webhook
+
+	// IsFiltered is set if the event didn't match the filter expression of the webhook,
+	// the task is only recorded for the delivery history and never delivered.
+	IsFiltered bool `xorm:"NOT NULL DEFAULT false"`
 }
 
 func init() {
@@ -167,10 +177,12 @@ func ReplayHookTask(ctx context.Context, hookID int64, uuid string) (*HookTask,
 		PayloadContent: task.PayloadContent,
 		EventType:      task.EventType,
 		PayloadVersion: task.PayloadVersion,
+		IsManual:       true,
 	})
 }
 
-// FindUndeliveredHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID
+// FindUndeliveredHookTaskIDs will find the next 100 undelivered hook tasks with ID greater than the provided lowerID.
+// Tasks with a retry scheduled in the future are skipped.
 func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, error) {
 	const batchSize = 100
 
@@ -179,12 +191,44 @@ func FindUndeliveredHookTaskIDs(ctx context.Context, lowerID int64) ([]int64, er
 		Select("id").
 		Table(new(HookTask)).
 		Where("is_delivered=?", false).
+		And("next_retry_unix <= ?", timeutil.TimeStampNow()).
+		And("id > ?", lowerID).
+		Asc("id").
+		Limit(batchSize).
+		Find(&tasks)
+}
+
+// FindDueHookTaskRetryIDs will find the next 100 hook tasks with a due retry with ID greater than the provided lowerID
+func FindDueHookTaskRetryIDs(ctx context.Context, lowerID int64) ([]int64, error) {
+	const batchSize = 100
+
+	tasks := make([]int64, 0, batchSize)
+	return tasks, db.GetEngine(ctx).
+		Select("id").
+		Table(new(HookTask)).
+		Where("is_delivered=?", false).
+		And("next_retry_unix > 0 AND next_retry_unix <= ?", timeutil.TimeStampNow()).
 		And("id > ?", lowerID).
 		Asc("id").
 		Limit(batchSize).
 		Find(&tasks)
 }
 
+// FindPendingHookTasksOfWebhook finds up to limit undelivered hook tasks of the webhook which are due,
+// oldest first, excluding the task with excludeID. The tasks of payload version 1 are skipped.
+func FindPendingHookTasksOfWebhook(ctx context.Context, hookID, excludeID int64, limit int) ([]*HookTask, error) {
+	tasks := make([]*HookTask, 0, limit)
+	return tasks, db.GetEngine(ctx).
+		Where("hook_id=?", hookID).
+		And("is_delivered=?", false).
+		And("next_retry_unix <= ?", timeutil.TimeStampNow()).
+		And("payload_version > 1").
+		And("id <> ?", excludeID).
+		Asc("id").
+		Limit(limit).
+		Find(&tasks)
+}
+
 func MarkTaskDelivered(ctx context.Context, task *HookTask) (bool, error) {
 	count, err := db.GetEngine(ctx).ID(task.ID).Where("is_delivered = ?", false).Cols("is_delivered").Update(&HookTask{
 		ID:          task.ID,
@@ -194,6 +238,53 @@ func MarkTaskDelivered(ctx context.Context, task *HookTask) (bool, error) {
 	return count != 0, err
 }
 
+// FindDeadLetteredHookTasksOptions are options to filter dead-lettered hook tasks
+type FindDeadLetteredHookTasksOptions struct {
+	db.ListOptions
+	HookID  int64
+	OwnerID int64 // only tasks of the webhooks of this user or organization and of its repositories
+	IDs     []int64
+}
+
+func (opts FindDeadLetteredHookTasksOptions) ToConds() builder.Cond {
+	cond := builder.NewCond().And(builder.Eq{"hook_task.is_dead_lettered": true})
+	if opts.HookID != 0 {
+		cond = cond.And(builder.Eq{"hook_task.hook_id": opts.HookID})
+	}
+	if opts.OwnerID != 0 {
+		cond = cond.And(builder.In("hook_task.hook_id", builder.Select("id").From("webhook").Where(
+			builder.Eq{"owner_id": opts.OwnerID}.Or(builder.In("repo_id", builder.Select("id").From("repository").Where(builder.Eq{"owner_id": opts.OwnerID}))),
+		)))
+	}
+	if len(opts.IDs) > 0 {
+		cond = cond.And(builder.In("hook_task.id", opts.IDs))
+	}
+	return cond
+}
+
+func (opts FindDeadLetteredHookTasksOptions) ToOrders() string {
+	return "hook_task.id DESC"
+}
+
+// RedeliverDeadLetteredHookTask copies a dead-lettered hook task to get re-delivered and removes the original task from the dead letters
+func RedeliverDeadLetteredHookTask(ctx context.Context, task *HookTask) (*HookTask, error) {
+	return db.WithTx2(ctx, func(ctx context.Context) (*HookTask, error) {
+		n, err := db.GetEngine(ctx).ID(task.ID).Where("is_dead_lettered = ?", true).Cols("is_dead_lettered").Update(&HookTask{IsDeadLettered: false})
+		if err != nil {
+			return nil, err
+		}
+		if n == 0 {
+			return nil, ErrHookTaskNotExist{TaskID: task.ID}
+		}
+		return CreateHookTask(ctx, &HookTask{
+			HookID:         task.HookID,
+			PayloadContent: task.PayloadContent,
+			EventType:      task.EventType,
+			PayloadVersion: task.PayloadVersion,
+		})
+	})
+}
+
 // CleanupHookTaskTable deletes rows from hook_task as needed.
 func CleanupHookTaskTable(ctx context.Context, cleanupType HookTaskCleanupType, olderThan time.Duration, numberToKeep int) error {
 	log.Trace("Doing: CleanupHookTaskTable")
@@ -7,6 +7,7 @@ package webhook
 import (
 	"context"
 	"fmt"
+	"net/url"
 	"strings"
 
 	"code.gitea.io/gitea/models/db"
@@ -136,6 +137,7 @@ type Webhook struct {
 	Type                      webhook_module.HookType   `xorm:"VARCHAR(16) 'type'"`
 	Meta                      string                    `xorm:"TEXT"` // store hook-specific attributes
 	LastStatus                webhook_module.HookStatus // Last delivery status
+	ConsecutiveFailures       int                       `xorm:"NOT NULL DEFAULT 0"` // Number of failed deliveries since the last successful one
 
 	// HeaderAuthorizationEncrypted should be accessed using HeaderAuthorization() and SetHeaderAuthorization()
 	HeaderAuthorizationEncrypted string `xorm:"TEXT"`
@@ -156,6 +158,16 @@ func (w *Webhook) AfterLoad() {
 	}
 }
 
+// HostName returns the host of the webhook URL. Unlike the URL it doesn't contain the credentials or tokens
+// some receivers expect in the path or the query, so it can be shown in notifications.
+func (w *Webhook) HostName() string {
+	u, err := url.Parse(w.URL)
+	if err != nil {
+		return ""
+	}
+	return u.Host
+}
+
 // History returns history of webhook by given conditions.
 func (w *Webhook) History(ctx context.Context, page int) ([]*HookTask, error) {
 	return HookTasks(ctx, w.ID, page)
@@ -317,6 +329,38 @@ func UpdateWebhookLastStatus(ctx context.Context, w *Webhook) error {
 	return err
 }
 
+// IncreaseWebhookConsecutiveFailures increases the number of consecutive failed deliveries of the webhook
+// and loads the new number into it.
+func IncreaseWebhookConsecutiveFailures(ctx context.Context, w *Webhook) error {
+	if _, err := db.GetEngine(ctx).ID(w.ID).Incr("consecutive_failures").Update(new(Webhook)); err != nil {
+		return err
+	}
+	_, err := db.GetEngine(ctx).Table("webhook").ID(w.ID).Cols("consecutive_failures").Get(&w.ConsecutiveFailures)
+	return err
+}
+
+// ResetWebhookConsecutiveFailures resets the number of consecutive failed deliveries of the webhook.
+func ResetWebhookConsecutiveFailures(ctx context.Context, w *Webhook) error {
+	w.ConsecutiveFailures = 0
+	_, err := db.GetEngine(ctx).ID(w.ID).Cols("consecutive_failures").Update(w)
+	return err
+}
+
+// DisableFailingWebhook deactivates the webhook and resets its consecutive failures, so it gets the full
+// number of attempts once it is activated again. It returns false if the webhook was not active anymore.
+func DisableFailingWebhook(ctx context.Context, w *Webhook) (bool, error) {
+	n, err := db.GetEngine(ctx).ID(w.ID).Where("is_active = ?", true).Cols("is_active", "consecutive_failures").Update(&Webhook{
+		IsActive:            false,
+		ConsecutiveFailures: 0,
+	})
+	if err != nil || n == 0 {
+		return false, err
+	}
+	w.IsActive = false
+	w.ConsecutiveFailures = 0
+	return true, nil
+}
+
 // DeleteWebhookByID uses argument bean as query condition,
 // ID must be specified and do not assign unnecessary fields.
 func DeleteWebhookByID(ctx context.Context, id int64) (err error) {
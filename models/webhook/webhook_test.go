@@ -330,3 +330,106 @@ func TestCleanupHookTaskTable_OlderThan_LeavesTaskEarlierThanAgeToDelete(t *test
 	assert.NoError(t, CleanupHookTaskTable(t.Context(), OlderThan, 168*time.Hour, 0))
 	unittest.AssertExistsAndLoadBean(t, hookTask)
 }
+
+func TestFindDueHookTaskRetryIDs(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	due, err := CreateHookTask(t.Context(), &HookTask{
+		HookID:         1,
+		PayloadVersion: 2,
+		Attempts:       1,
+		NextRetryUnix:  timeutil.TimeStampNow().Add(-60),
+	})
+	assert.NoError(t, err)
+	later, err := CreateHookTask(t.Context(), &HookTask{
+		HookID:         1,
+		PayloadVersion: 2,
+		Attempts:       1,
+		NextRetryUnix:  timeutil.TimeStampNow().Add(3600),
+	})
+	assert.NoError(t, err)
+
+	ids, err := FindDueHookTaskRetryIDs(t.Context(), 0)
+	assert.NoError(t, err)
+	assert.Equal(t, []int64{due.ID}, ids)
+
+	ids, err = FindUndeliveredHookTaskIDs(t.Context(), 0)
+	assert.NoError(t, err)
+	assert.Contains(t, ids, due.ID)
+	assert.NotContains(t, ids, later.ID)
+}
+
+func TestRedeliverDeadLetteredHookTask(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	createDeadLetter := func(hookID int64) *HookTask {
+		task, err := CreateHookTask(t.Context(), &HookTask{
+			HookID:         hookID,
+			PayloadContent: "payload",
+			PayloadVersion: 2,
+			IsDelivered:    true,
+			IsDeadLettered: true,
+			Attempts:       3,
+		})
+		assert.NoError(t, err)
+		return task
+	}
+	repoTask := createDeadLetter(1)   // webhook of a repository of user 2
+	orgTask := createDeadLetter(3)    // webhook of org 3
+	systemTask := createDeadLetter(5) // system webhook
+
+	findIDs := func(opts FindDeadLetteredHookTasksOptions) []int64 {
+		tasks, err := db.Find[HookTask](t.Context(), opts)
+		assert.NoError(t, err)
+		ids := make([]int64, 0, len(tasks))
+		for _, task := range tasks {
+			ids = append(ids, task.ID)
+		}
+		return ids
+	}
+	assert.Equal(t, []int64{systemTask.ID, orgTask.ID, repoTask.ID}, findIDs(FindDeadLetteredHookTasksOptions{}))
+	assert.Equal(t, []int64{repoTask.ID}, findIDs(FindDeadLetteredHookTasksOptions{OwnerID: 2}))
+	assert.Equal(t, []int64{orgTask.ID}, findIDs(FindDeadLetteredHookTasksOptions{OwnerID: 3}))
+	assert.Equal(t, []int64{systemTask.ID}, findIDs(FindDeadLetteredHookTasksOptions{HookID: 5}))
+	assert.Empty(t, findIDs(FindDeadLetteredHookTasksOptions{OwnerID: 3, IDs: []int64{repoTask.ID}}))
+
+	newTask, err := RedeliverDeadLetteredHookTask(t.Context(), repoTask)
+	assert.NoError(t, err)
+	assert.NotEqual(t, repoTask.UUID, newTask.UUID)
+	assert.Equal(t, repoTask.PayloadContent, newTask.PayloadContent)
+	assert.False(t, newTask.IsDeadLettered)
+	assert.Zero(t, newTask.Attempts)
+
+	task, err := GetHookTaskByID(t.Context(), repoTask.ID)
+	assert.NoError(t, err)
+	assert.False(t, task.IsDeadLettered)
+	assert.Equal(t, []int64{systemTask.ID, orgTask.ID}, findIDs(FindDeadLetteredHookTasksOptions{}))
+
+	_, err = RedeliverDeadLetteredHookTask(t.Context(), repoTask)
+	assert.True(t, IsErrHookTaskNotExist(err))
+}
+
+func TestWebhookConsecutiveFailures(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	hook := unittest.AssertExistsAndLoadBean(t, &Webhook{ID: 1})
+	assert.NoError(t, IncreaseWebhookConsecutiveFailures(t.Context(), hook))
+	assert.NoError(t, IncreaseWebhookConsecutiveFailures(t.Context(), hook))
+	assert.Equal(t, 2, hook.ConsecutiveFailures)
+
+	assert.NoError(t, ResetWebhookConsecutiveFailures(t.Context(), hook))
+	assert.Zero(t, unittest.AssertExistsAndLoadBean(t, &Webhook{ID: 1}).ConsecutiveFailures)
+
+	assert.NoError(t, IncreaseWebhookConsecutiveFailures(t.Context(), hook))
+	disabled, err := DisableFailingWebhook(t.Context(), hook)
+	assert.NoError(t, err)
+	assert.True(t, disabled)
+
+	hook = unittest.AssertExistsAndLoadBean(t, &Webhook{ID: 1})
+	assert.False(t, hook.IsActive)
+	assert.Zero(t, hook.ConsecutiveFailures)
+
+	disabled, err = DisableFailingWebhook(t.Context(), hook)
+	assert.NoError(t, err)
+	assert.False(t, disabled)
+}
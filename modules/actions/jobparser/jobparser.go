@@ -66,6 +66,9 @@ func Parse(content []byte, options ...ParseOption) ([]*SingleWorkflow, error) {
 				runsOn[i] = evaluator.Interpolate(v)
 			}
 			job.RawRunsOn = encodeRunsOn(runsOn)
+			if envName, envURL := job.Environment(); envName != "" {
+				job.RawEnvironment = encodeEnvironment(evaluator.Interpolate(envName), evaluator.Interpolate(envURL))
+			}
 			swf := &SingleWorkflow{
 				Name:           workflow.Name,
 				RawOn:          workflow.RawOn,
@@ -150,6 +153,16 @@ func encodeRunsOn(runsOn []string) yaml.Node {
 	return node
 }
 
+func encodeEnvironment(name, url string) yaml.Node {
+	node := yaml.Node{}
+	if url == "" {
+		_ = node.Encode(name)
+	} else {
+		_ = node.Encode(map[string]string{"name": name, "url": url})
+	}
+	return node
+}
+
 func nameWithMatrix(name string, m map[string]any, evaluator *ExpressionEvaluator) string {
 	if len(m) == 0 {
 		return name
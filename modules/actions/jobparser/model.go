@@ -21,6 +21,10 @@ type SingleWorkflow struct {
 	Defaults       Defaults          `yaml:"defaults,omitempty"`
 	RawPermissions yaml.Node         `yaml:"permissions,omitempty"`
 	RunName        string            `yaml:"run-name,omitempty"`
+
+	// CalledWorkflowRef is "{owner}/{repo}/{path}@{ref}" of the reusable workflow which defines the job,
+	// it's empty if the job is defined by the workflow of the run. It isn't a part of the workflow payload.
+	CalledWorkflowRef string `yaml:"-"`
 }
 
 func (w *SingleWorkflow) Job() (string, *Job) {
@@ -95,6 +99,7 @@ type Job struct {
 	RawSecrets     yaml.Node                 `yaml:"secrets,omitempty"`
 	RawConcurrency *model.RawConcurrency     `yaml:"concurrency,omitempty"`
 	RawPermissions yaml.Node                 `yaml:"permissions,omitempty"`
+	RawEnvironment yaml.Node                 `yaml:"environment,omitempty"`
 }
 
 func (j *Job) Clone() *Job {
@@ -119,6 +124,7 @@ func (j *Job) Clone() *Job {
 		RawSecrets:     j.RawSecrets,
 		RawConcurrency: j.RawConcurrency,
 		RawPermissions: j.RawPermissions,
+		RawEnvironment: j.RawEnvironment,
 	}
 }
 
@@ -135,6 +141,25 @@ func (j *Job) RunsOn() []string {
 	return (&model.Job{RawRunsOn: j.RawRunsOn}).RunsOn()
 }
 
+// Environment returns the name and the url of the deployment environment the job deploys to,
+// the name is empty if the job doesn't deploy to any environment.
+func (j *Job) Environment() (name, url string) {
+	switch j.RawEnvironment.Kind {
+	case yaml.ScalarNode:
+		return j.RawEnvironment.Value, ""
+	case yaml.MappingNode:
+		var env struct {
+			Name string `yaml:"name"`
+			URL  string `yaml:"url"`
+		}
+		if err := j.RawEnvironment.Decode(&env); err != nil {
+			return "", ""
+		}
+		return env.Name, env.URL
+	}
+	return "", ""
+}
+
 type Step struct {
 	ID               string            `yaml:"id,omitempty"`
 	If               yaml.Node         `yaml:"if,omitempty"`
@@ -196,11 +221,63 @@ type WorkflowDispatchInput struct {
 	Options     []string `yaml:"options"`
 }
 
+// WorkflowCallConfig is the "on.workflow_call" section of a reusable workflow
+type WorkflowCallConfig struct {
+	Inputs  []WorkflowCallInput
+	Secrets []WorkflowCallSecret
+	Outputs []WorkflowCallOutput
+}
+
+type WorkflowCallInput struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+	Default     string `yaml:"default"`
+	Type        string `yaml:"type"`
+}
+
+type WorkflowCallSecret struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Required    bool   `yaml:"required"`
+}
+
+type WorkflowCallOutput struct {
+	Name        string `yaml:"name"`
+	Description string `yaml:"description"`
+	Value       string `yaml:"value"`
+}
+
+func (cfg *WorkflowCallConfig) decode(act string, node *yaml.Node) (err error) {
+	var names []string
+	switch act {
+	case "inputs":
+		names, cfg.Inputs, err = parseMappingNode[WorkflowCallInput](node)
+		for i := range cfg.Inputs {
+			cfg.Inputs[i].Name = names[i]
+		}
+	case "secrets":
+		names, cfg.Secrets, err = parseMappingNode[WorkflowCallSecret](node)
+		for i := range cfg.Secrets {
+			cfg.Secrets[i].Name = names[i]
+		}
+	case "outputs":
+		names, cfg.Outputs, err = parseMappingNode[WorkflowCallOutput](node)
+		for i := range cfg.Outputs {
+			cfg.Outputs[i].Name = names[i]
+		}
+	default:
+		err = fmt.Errorf("unknown workflow_call config %q", act)
+	}
+	return err
+}
+
 type Event struct {
-	Name      string
-	acts      map[string][]string
-	schedules []map[string]string
-	inputs    []WorkflowDispatchInput
+	Name       string
+	acts       map[string][]string
+	schedules  []map[string]string
+	inputs     []WorkflowDispatchInput
+	callConfig *WorkflowCallConfig
 }
 
 func (evt *Event) IsSchedule() bool {
@@ -219,6 +296,11 @@ func (evt *Event) Inputs() []WorkflowDispatchInput {
 	return evt.inputs
 }
 
+// WorkflowCall returns the "on.workflow_call" config, it is nil if the event isn't "workflow_call" or has no config
+func (evt *Event) WorkflowCall() *WorkflowCallConfig {
+	return evt.callConfig
+}
+
 func ReadWorkflowRawConcurrency(content []byte) (*model.RawConcurrency, error) {
 	w := new(model.Workflow)
 	err := yaml.NewDecoder(bytes.NewReader(content)).Decode(w)
@@ -371,6 +453,7 @@ func ParseRawOn(rawOn *yaml.Node) ([]*Event, error) {
 			case yaml.MappingNode:
 				acts := make(map[string][]string, len(v.Content)/2)
 				var inputs []WorkflowDispatchInput
+				var callConfig *WorkflowCallConfig
 				expectedKey := true
 				var act string
 				for _, content := range v.Content {
@@ -400,6 +483,15 @@ func ParseRawOn(rawOn *yaml.Node) ([]*Event, error) {
 							}
 							acts[act] = []string{t}
 						case yaml.MappingNode:
+							if k == "workflow_call" {
+								if callConfig == nil {
+									callConfig = &WorkflowCallConfig{}
+								}
+								if err := callConfig.decode(act, content); err != nil {
+									return nil, err
+								}
+								break
+							}
 							if k != "workflow_dispatch" || act != "inputs" {
 								return nil, fmt.Errorf("map should only for workflow_dispatch but %s: %#v", act, content)
 							}
@@ -440,9 +532,10 @@ func ParseRawOn(rawOn *yaml.Node) ([]*Event, error) {
 					acts = nil
 				}
 				res = append(res, &Event{
-					Name:   k,
-					acts:   acts,
-					inputs: inputs,
+					Name:       k,
+					acts:       acts,
+					inputs:     inputs,
+					callConfig: callConfig,
 				})
 			default:
 				return nil, fmt.Errorf("unknown on type: %v", v.Kind)
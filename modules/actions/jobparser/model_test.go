@@ -254,6 +254,59 @@ func TestParseRawOn(t *testing.T) {
 				},
 			},
 		},
+		{
+			input: `on:
+  workflow_call:
+    inputs:
+      package:
+        description: 'Package to build'
+        required: true
+        type: string
+      debug:
+        type: boolean
+        default: false
+    secrets:
+      token:
+        required: true
+    outputs:
+      version:
+        description: 'Built version'
+        value: ${{ jobs.build.outputs.version }}
+`,
+			result: []*Event{
+				{
+					Name: "workflow_call",
+					callConfig: &WorkflowCallConfig{
+						Inputs: []WorkflowCallInput{
+							{
+								Name:        "package",
+								Description: "Package to build",
+								Required:    true,
+								Type:        "string",
+							},
+							{
+								Name:    "debug",
+								Default: "false",
+								Type:    "boolean",
+							},
+						},
+						Secrets: []WorkflowCallSecret{
+							{
+								Name:     "token",
+								Required: true,
+							},
+						},
+						Outputs: []WorkflowCallOutput{
+							{
+								Name:        "version",
+								Description: "Built version",
+								Value:       "${{ jobs.build.outputs.version }}",
+							},
+						},
+					},
+				},
+			},
+		},
 	}
 	for _, kase := range kases {
 		t.Run(kase.input, func(t *testing.T) {
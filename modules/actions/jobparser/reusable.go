@@ -0,0 +1,461 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package jobparser
+
+import (
+	"errors"
+	"fmt"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/nektos/act/pkg/exprparser"
+	"github.com/nektos/act/pkg/model"
+	"go.yaml.in/yaml/v4"
+)
+
+// ReusableWorkflowJobIDSeparator separates the caller job id and the called job id of a job expanded from a reusable workflow,
+// for example, the job "test" of the workflow called by the job "build" has the id "build/test".
+// A job id in a workflow file can't contain it, so the expanded job ids never conflict with the others.
+const ReusableWorkflowJobIDSeparator = "/"
+
+// ReusableWorkflowRef is a parsed "jobs.<job_id>.uses" which refers to a reusable workflow
+type ReusableWorkflowRef struct {
+	Owner string // empty for a local reusable workflow
+	Repo  string // empty for a local reusable workflow
+	Path  string // the path of the workflow file in the repository
+	Ref   string // empty for a local reusable workflow
+}
+
+// IsLocal returns whether the reusable workflow is in the same repository and commit as the caller workflow
+func (r *ReusableWorkflowRef) IsLocal() bool {
+	return r.Owner == ""
+}
+
+// ParseReusableWorkflowRef parses "jobs.<job_id>.uses", the supported formats are:
+//   - "{owner}/{repo}/{path}@{ref}" for a workflow in another repository
+//   - "./{path}" for a workflow in the same repository
+//
+// The path of a workflow in another repository must be in ".gitea/workflows" or ".github/workflows".
+func ParseReusableWorkflowRef(uses string) (*ReusableWorkflowRef, error) {
+	if uses == "" {
+		return nil, errors.New("empty reusable workflow reference")
+	}
+	if strings.Contains(uses, "://") {
+		return nil, fmt.Errorf("unsupported reusable workflow reference %q: must not be a URL", uses)
+	}
+
+	ref := &ReusableWorkflowRef{}
+	if p, ok := strings.CutPrefix(uses, "./"); ok {
+		if strings.Contains(p, "@") {
+			return nil, fmt.Errorf("invalid reusable workflow reference %q: a local workflow must not have a ref", uses)
+		}
+		ref.Path = p
+	} else {
+		p, gitRef, ok := strings.Cut(uses, "@")
+		if !ok || gitRef == "" {
+			return nil, fmt.Errorf("invalid reusable workflow reference %q: missing ref", uses)
+		}
+		fields := strings.SplitN(p, "/", 3)
+		if len(fields) != 3 || fields[0] == "" || fields[1] == "" {
+			return nil, fmt.Errorf("invalid reusable workflow reference %q: must be {owner}/{repo}/{path}@{ref}", uses)
+		}
+		ref.Owner, ref.Repo, ref.Path, ref.Ref = fields[0], fields[1], fields[2], gitRef
+	}
+
+	ref.Path = path.Clean(ref.Path)
+	if ext := path.Ext(ref.Path); ext != ".yml" && ext != ".yaml" {
+		return nil, fmt.Errorf("invalid reusable workflow reference %q: not a workflow file", uses)
+	}
+	if strings.HasPrefix(ref.Path, "../") || path.IsAbs(ref.Path) {
+		return nil, fmt.Errorf("invalid reusable workflow reference %q: invalid path", uses)
+	}
+	if !ref.IsLocal() && !strings.HasPrefix(ref.Path, ".gitea/workflows/") && !strings.HasPrefix(ref.Path, ".github/workflows/") {
+		return nil, fmt.Errorf("invalid reusable workflow reference %q: the workflow must be in .gitea/workflows or .github/workflows", uses)
+	}
+	return ref, nil
+}
+
+// ReusableWorkflowCallerIDs returns the ids of the caller jobs of a job expanded from reusable workflows, the outermost caller first.
+// It returns nil if the job isn't expanded from a reusable workflow.
+func ReusableWorkflowCallerIDs(jobID string) []string {
+	var ids []string
+	for i := range len(jobID) {
+		if strings.HasPrefix(jobID[i:], ReusableWorkflowJobIDSeparator) {
+			ids = append(ids, jobID[:i])
+		}
+	}
+	return ids
+}
+
+// GetWorkflowCallConfig returns the "on.workflow_call" config of the workflow,
+// it returns an error if the workflow can't be called by other workflows.
+func GetWorkflowCallConfig(rawOn *yaml.Node) (*WorkflowCallConfig, error) {
+	events, err := ParseRawOn(rawOn)
+	if err != nil {
+		return nil, err
+	}
+	for _, evt := range events {
+		if evt.Name == "workflow_call" {
+			if evt.callConfig == nil {
+				return &WorkflowCallConfig{}, nil
+			}
+			return evt.callConfig, nil
+		}
+	}
+	return nil, errors.New(`the workflow isn't reusable: no "workflow_call" trigger`)
+}
+
+// ExpandReusableWorkflow expands the caller job which uses a reusable workflow into the jobs of the called workflow.
+// The options are the context of the caller workflow, they are used to evaluate the "with" of the caller job.
+// The expanded jobs are prefixed with the caller job id, and the ones without "needs" inherit the "needs" of the caller job.
+// Nested reusable workflows are not expanded, the caller should expand the returned jobs again if necessary.
+func ExpandReusableWorkflow(caller *SingleWorkflow, content []byte, options ...ParseOption) ([]*SingleWorkflow, error) {
+	callerID, callerJob := caller.Job()
+	if callerJob == nil {
+		return nil, errors.New("no caller job")
+	}
+
+	pc := &parseContext{}
+	for _, o := range options {
+		o(pc)
+	}
+
+	called := &SingleWorkflow{}
+	if err := yaml.Unmarshal(content, called); err != nil {
+		return nil, fmt.Errorf("yaml.Unmarshal: %w", err)
+	}
+	callConfig, err := GetWorkflowCallConfig(&called.RawOn)
+	if err != nil {
+		return nil, err
+	}
+
+	with, err := evaluateCallerWith(callerID, callerJob, pc)
+	if err != nil {
+		return nil, fmt.Errorf("evaluate with: %w", err)
+	}
+	inputs, err := callConfig.resolveInputs(with)
+	if err != nil {
+		return nil, err
+	}
+	secretNames, err := callConfig.resolveSecrets(&callerJob.RawSecrets)
+	if err != nil {
+		return nil, err
+	}
+
+	children, err := Parse(content, WithGitContext(pc.gitContext), WithVars(pc.vars), WithInputs(inputs))
+	if err != nil {
+		return nil, fmt.Errorf("parse called workflow: %w", err)
+	}
+
+	// like GitHub, the caller job id is used if the caller job has no name
+	callerName := callerJob.Name
+	if callerName == "" {
+		callerName = callerID
+	}
+
+	rewriter := newCallRewriter(inputs, secretNames)
+	ret := make([]*SingleWorkflow, 0, len(children))
+	for _, child := range children {
+		id, job := child.Job()
+		if err := rewriter.rewriteJob(job); err != nil {
+			return nil, fmt.Errorf("job %q: %w", id, err)
+		}
+		// the env map is shared by all jobs of the called workflow, so rewrite a copy
+		env := make(map[string]string, len(child.Env))
+		for k, v := range child.Env {
+			env[k] = rewriter.rewrite(v)
+		}
+		child.Env = env
+
+		needs := job.Needs()
+		if len(needs) == 0 {
+			needs = callerJob.Needs()
+			if callerJob.If.Value != "" {
+				job.If = combineIf(callerJob.If, job.If)
+			}
+		} else {
+			for i, need := range needs {
+				needs[i] = callerID + ReusableWorkflowJobIDSeparator + need
+			}
+		}
+		job.RawNeeds = yaml.Node{}
+		if len(needs) > 0 {
+			if err := job.RawNeeds.Encode(needs); err != nil {
+				return nil, err
+			}
+		}
+
+		job.Name = callerName + " / " + job.Name
+		if child.RawPermissions.IsZero() && job.RawPermissions.IsZero() {
+			child.RawPermissions = callerJob.RawPermissions
+			if child.RawPermissions.IsZero() {
+				child.RawPermissions = caller.RawPermissions
+			}
+		}
+		if err := child.SetJob(callerID+ReusableWorkflowJobIDSeparator+id, job); err != nil {
+			return nil, fmt.Errorf("SetJob: %w", err)
+		}
+		ret = append(ret, child)
+	}
+	return ret, nil
+}
+
+// EvaluateWorkflowCallOutputs evaluates the "on.workflow_call.outputs" of a reusable workflow with the results of its jobs.
+func EvaluateWorkflowCallOutputs(outputs []WorkflowCallOutput, results map[string]*JobResult) map[string]string {
+	needs := make(map[string]exprparser.Needs, len(results))
+	for id, result := range results {
+		needs[id] = exprparser.Needs{
+			Outputs: result.Outputs,
+			Result:  result.Result,
+		}
+	}
+	// The "jobs" context is only available in "on.workflow_call.outputs", it has the same structure as the "needs" context.
+	evaluator := NewExpressionEvaluator(exprparser.NewInterpeter(&exprparser.EvaluationEnvironment{Needs: needs}, exprparser.Config{}))
+
+	ret := make(map[string]string, len(outputs))
+	for _, output := range outputs {
+		ret[output.Name] = evaluator.Interpolate(jobsContextPattern.ReplaceAllString(output.Value, "${1}needs."))
+	}
+	return ret
+}
+
+var jobsContextPattern = regexp.MustCompile(`(^|[^\w.])jobs\.`)
+
+func evaluateCallerWith(callerID string, callerJob *Job, pc *parseContext) (map[string]any, error) {
+	actJob := &model.Job{
+		Strategy: &model.Strategy{
+			FailFastString:    callerJob.Strategy.FailFastString,
+			MaxParallelString: callerJob.Strategy.MaxParallelString,
+			RawMatrix:         callerJob.Strategy.RawMatrix,
+		},
+	}
+	actJob.Strategy.FailFast = actJob.Strategy.GetFailFast()
+	actJob.Strategy.MaxParallel = actJob.Strategy.GetMaxParallel()
+	matrix := make(map[string]any)
+	matrixes, err := actJob.GetMatrixes()
+	if err != nil {
+		return nil, err
+	}
+	if len(matrixes) > 0 {
+		matrix = matrixes[0]
+	}
+
+	// the interpreter looks up the caller job in the results to resolve its "needs"
+	results := map[string]*JobResult{
+		callerID: {Needs: callerJob.Needs(), Result: pc.jobResults[callerID]},
+	}
+	for _, need := range callerJob.Needs() {
+		results[need] = &JobResult{Result: pc.jobResults[need]}
+	}
+	evaluator := NewExpressionEvaluator(NewInterpeter(callerID, actJob, matrix, pc.gitContext, results, pc.vars, pc.inputs))
+	var node yaml.Node
+	if err := node.Encode(callerJob.With); err != nil {
+		return nil, err
+	}
+	if err := evaluator.EvaluateYamlNode(&node); err != nil {
+		return nil, err
+	}
+	var with map[string]any
+	if err := node.Decode(&with); err != nil {
+		return nil, err
+	}
+	return with, nil
+}
+
+// resolveInputs returns the inputs of the called workflow from the "with" of the caller job and the defaults of the inputs
+func (cfg *WorkflowCallConfig) resolveInputs(with map[string]any) (map[string]any, error) {
+	inputs := make(map[string]any, len(cfg.Inputs))
+	for _, input := range cfg.Inputs {
+		value, ok := with[input.Name]
+		if !ok {
+			if input.Required {
+				return nil, fmt.Errorf("input %q is required but not provided", input.Name)
+			}
+			value = input.Default
+		}
+		var err error
+		if inputs[input.Name], err = convertInputValue(input.Type, value); err != nil {
+			return nil, fmt.Errorf("input %q: %w", input.Name, err)
+		}
+	}
+	for name := range with {
+		if _, ok := inputs[name]; !ok {
+			return nil, fmt.Errorf("input %q is not defined in the called workflow", name)
+		}
+	}
+	return inputs, nil
+}
+
+func convertInputValue(typ string, value any) (any, error) {
+	s, isString := value.(string)
+	switch typ {
+	case "boolean":
+		if !isString {
+			if b, ok := value.(bool); ok {
+				return b, nil
+			}
+			return nil, fmt.Errorf("invalid boolean %v", value)
+		} else if s == "" {
+			return false, nil
+		}
+		return strconv.ParseBool(s)
+	case "number":
+		if !isString {
+			switch v := value.(type) {
+			case int:
+				return float64(v), nil
+			case float64:
+				return v, nil
+			}
+			return nil, fmt.Errorf("invalid number %v", value)
+		} else if s == "" {
+			return float64(0), nil
+		}
+		return strconv.ParseFloat(s, 64)
+	default:
+		if isString {
+			return s, nil
+		}
+		return fmt.Sprint(value), nil
+	}
+}
+
+var secretReferencePattern = regexp.MustCompile(`^\$\{\{\s*secrets\.([\w-]+)\s*\}\}$`)
+
+// resolveSecrets returns the mapping from the secret names in the called workflow to the secret names of the caller.
+// It returns nil if the caller job uses "secrets: inherit".
+func (cfg *WorkflowCallConfig) resolveSecrets(rawSecrets *yaml.Node) (map[string]string, error) {
+	if rawSecrets.Kind == yaml.ScalarNode && rawSecrets.Value == "inherit" {
+		return nil, nil //nolint:nilnil // all secrets of the caller are passed through
+	}
+
+	var secrets map[string]string
+	if !rawSecrets.IsZero() {
+		if err := rawSecrets.Decode(&secrets); err != nil {
+			return nil, fmt.Errorf("invalid secrets: %w", err)
+		}
+	}
+	names := make(map[string]string, len(secrets))
+	for name, value := range secrets {
+		m := secretReferencePattern.FindStringSubmatch(value)
+		if m == nil {
+			return nil, fmt.Errorf("secret %q must be a reference to a secret like ${{ secrets.NAME }}", name)
+		}
+		names[name] = m[1]
+	}
+	for _, secret := range cfg.Secrets {
+		if _, ok := names[secret.Name]; !ok && secret.Required {
+			return nil, fmt.Errorf("secret %q is required but not provided", secret.Name)
+		}
+	}
+	return names, nil
+}
+
+// combineIf combines the "if" of the caller job and the called job, both must be satisfied.
+func combineIf(callerIf, calledIf yaml.Node) yaml.Node {
+	unwrap := func(s string) string {
+		s = strings.TrimSpace(s)
+		if strings.HasPrefix(s, "${{") && strings.HasSuffix(s, "}}") {
+			s = strings.TrimSpace(s[3 : len(s)-2])
+		}
+		return s
+	}
+	expr := unwrap(callerIf.Value)
+	if calledIf.Value != "" {
+		expr = fmt.Sprintf("(%s) && (%s)", expr, unwrap(calledIf.Value))
+	}
+	node := yaml.Node{}
+	_ = node.Encode("${{ " + expr + " }}")
+	return node
+}
+
+// callRewriter rewrites the expressions in a called job which refer to the "inputs" and "secrets" contexts,
+// because the runner only knows the contexts of the caller workflow.
+type callRewriter struct {
+	inputs      map[string]any
+	secretNames map[string]string
+}
+
+var callContextPattern = regexp.MustCompile(`(^|[^\w.])(inputs|secrets)\.([\w-]+)`)
+
+func newCallRewriter(inputs map[string]any, secretNames map[string]string) *callRewriter {
+	return &callRewriter{inputs: inputs, secretNames: secretNames}
+}
+
+func (r *callRewriter) rewrite(s string) string {
+	if !strings.Contains(s, "${{") {
+		return s
+	}
+	var sb strings.Builder
+	for {
+		start := strings.Index(s, "${{")
+		if start < 0 {
+			break
+		}
+		end := strings.Index(s[start:], "}}")
+		if end < 0 {
+			break
+		}
+		end += start + 2
+		sb.WriteString(s[:start])
+		sb.WriteString(callContextPattern.ReplaceAllStringFunc(s[start:end], r.replace))
+		s = s[end:]
+	}
+	sb.WriteString(s)
+	return sb.String()
+}
+
+func (r *callRewriter) replace(m string) string {
+	sub := callContextPattern.FindStringSubmatch(m)
+	prefix, ctx, name := sub[1], sub[2], sub[3]
+	switch ctx {
+	case "inputs":
+		value, ok := r.inputs[name]
+		if !ok {
+			return prefix + "null"
+		}
+		switch v := value.(type) {
+		case bool:
+			return prefix + strconv.FormatBool(v)
+		case float64:
+			return prefix + strconv.FormatFloat(v, 'f', -1, 64)
+		default:
+			return prefix + "'" + strings.ReplaceAll(fmt.Sprint(v), "'", "''") + "'"
+		}
+	default: // secrets
+		if r.secretNames == nil || strings.EqualFold(name, "GITHUB_TOKEN") || strings.EqualFold(name, "GITEA_TOKEN") {
+			return m // inherited secrets and the automatic token keep their names
+		}
+		if secretName, ok := r.secretNames[name]; ok {
+			return prefix + "secrets." + secretName
+		}
+		return prefix + "null"
+	}
+}
+
+func (r *callRewriter) rewriteJob(job *Job) error {
+	node := yaml.Node{}
+	if err := node.Encode(job); err != nil {
+		return err
+	}
+	r.rewriteNode(&node)
+	rewritten := &Job{}
+	if err := node.Decode(rewritten); err != nil {
+		return err
+	}
+	*job = *rewritten
+	return nil
+}
+
+func (r *callRewriter) rewriteNode(node *yaml.Node) {
+	if node.Kind == yaml.ScalarNode {
+		node.Value = r.rewrite(node.Value)
+		return
+	}
+	for _, child := range node.Content {
+		r.rewriteNode(child)
+	}
+}
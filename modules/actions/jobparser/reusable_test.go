@@ -0,0 +1,196 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package jobparser
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParseReusableWorkflowRef(t *testing.T) {
+	cases := []struct {
+		uses    string
+		want    *ReusableWorkflowRef
+		wantErr bool
+	}{
+		{
+			uses: "./.gitea/workflows/build.yml",
+			want: &ReusableWorkflowRef{Path: ".gitea/workflows/build.yml"},
+		},
+		{
+			uses: "owner/repo/.gitea/workflows/build.yaml@v1",
+			want: &ReusableWorkflowRef{Owner: "owner", Repo: "repo", Path: ".gitea/workflows/build.yaml", Ref: "v1"},
+		},
+		{
+			uses: "owner/repo/.github/workflows/build.yml@refs/heads/main",
+			want: &ReusableWorkflowRef{Owner: "owner", Repo: "repo", Path: ".github/workflows/build.yml", Ref: "refs/heads/main"},
+		},
+		{uses: "", wantErr: true},
+		{uses: "./.gitea/workflows/build.yml@main", wantErr: true},
+		{uses: "owner/repo/.gitea/workflows/build.yml", wantErr: true},
+		{uses: "owner/.gitea/workflows/build.yml@main", wantErr: true},
+		{uses: "owner/repo/build.yml@main", wantErr: true},
+		{uses: "owner/repo/.gitea/build.yml@main", wantErr: true},
+		{uses: "owner/repo/.gitea/workflows/build.txt@main", wantErr: true},
+		{uses: "./../workflows/build.yml", wantErr: true},
+		{uses: "https://gitea.com/owner/repo/.gitea/workflows/build.yml@main", wantErr: true},
+	}
+	for _, c := range cases {
+		t.Run(c.uses, func(t *testing.T) {
+			ref, err := ParseReusableWorkflowRef(c.uses)
+			if c.wantErr {
+				assert.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.want, ref)
+			assert.Equal(t, c.want.Owner == "", ref.IsLocal())
+		})
+	}
+}
+
+func TestReusableWorkflowCallerIDs(t *testing.T) {
+	assert.Nil(t, ReusableWorkflowCallerIDs("build"))
+	assert.Equal(t, []string{"build"}, ReusableWorkflowCallerIDs("build/test"))
+	assert.Equal(t, []string{"build", "build/test"}, ReusableWorkflowCallerIDs("build/test/lint"))
+}
+
+func TestExpandReusableWorkflow(t *testing.T) {
+	callers, err := Parse([]byte(`
+name: caller
+on: push
+jobs:
+  prepare:
+    runs-on: linux
+    steps:
+      - run: echo prepare
+  call:
+    needs: prepare
+    uses: ./.gitea/workflows/called.yml
+    with:
+      package: service
+      debug: true
+    secrets:
+      token: ${{ secrets.DEPLOY_TOKEN }}
+`))
+	require.NoError(t, err)
+	require.Len(t, callers, 2)
+
+	called := []byte(`
+name: called
+on:
+  workflow_call:
+    inputs:
+      package:
+        type: string
+        required: true
+      debug:
+        type: boolean
+      retries:
+        type: number
+        default: 3
+    secrets:
+      token:
+        required: true
+    outputs:
+      version:
+        value: ${{ jobs.test.outputs.version }}
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: make ${{ inputs.package }} DEBUG=${{ inputs.debug }} RETRIES=${{ inputs.retries }}
+        env:
+          TOKEN: ${{ secrets.token }}
+  test:
+    needs: build
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo test
+`)
+
+	t.Run("expand", func(t *testing.T) {
+		jobs, err := ExpandReusableWorkflow(callers[1], called)
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+
+		id, job := jobs[0].Job()
+		assert.Equal(t, "call/build", id)
+		assert.Equal(t, "call / build", job.Name)
+		assert.Equal(t, []string{"prepare"}, job.Needs())
+		assert.Equal(t, "make ${{ 'service' }} DEBUG=${{ true }} RETRIES=${{ 3 }}", job.Steps[0].Run)
+		assert.Equal(t, "${{ secrets.DEPLOY_TOKEN }}", job.Steps[0].Env.Content[1].Value)
+
+		id, job = jobs[1].Job()
+		assert.Equal(t, "call/test", id)
+		assert.Equal(t, []string{"call/build"}, job.Needs())
+
+		callConfig, err := GetWorkflowCallConfig(&jobs[1].RawOn)
+		require.NoError(t, err)
+		outputs := EvaluateWorkflowCallOutputs(callConfig.Outputs, map[string]*JobResult{
+			"test": {Result: "success", Outputs: map[string]string{"version": "1.2.3"}},
+		})
+		assert.Equal(t, map[string]string{"version": "1.2.3"}, outputs)
+	})
+
+	t.Run("unnamed caller", func(t *testing.T) {
+		callers, err := Parse([]byte(`
+on: push
+jobs:
+  call:
+    uses: ./.gitea/workflows/called.yml
+    with:
+      package: service
+    secrets: inherit
+`))
+		require.NoError(t, err)
+		require.Len(t, callers, 1)
+		id, job := callers[0].Job()
+		job.Name = ""
+		require.NoError(t, callers[0].SetJob(id, job))
+
+		jobs, err := ExpandReusableWorkflow(callers[0], called)
+		require.NoError(t, err)
+		require.Len(t, jobs, 2)
+		_, job = jobs[0].Job()
+		assert.Equal(t, "call / build", job.Name)
+	})
+
+	t.Run("not reusable", func(t *testing.T) {
+		_, err := ExpandReusableWorkflow(callers[1], []byte("on: push\njobs:\n  build:\n    runs-on: linux\n    steps:\n      - run: echo\n"))
+		assert.ErrorContains(t, err, "workflow_call")
+	})
+
+	t.Run("missing required secret", func(t *testing.T) {
+		callers, err := Parse([]byte(`
+on: push
+jobs:
+  call:
+    uses: ./.gitea/workflows/called.yml
+    with:
+      package: service
+`))
+		require.NoError(t, err)
+		_, err = ExpandReusableWorkflow(callers[0], called)
+		assert.ErrorContains(t, err, `secret "token" is required`)
+	})
+
+	t.Run("undefined input", func(t *testing.T) {
+		callers, err := Parse([]byte(`
+on: push
+jobs:
+  call:
+    uses: ./.gitea/workflows/called.yml
+    with:
+      package: service
+      unknown: value
+    secrets: inherit
+`))
+		require.NoError(t, err)
+		_, err = ExpandReusableWorkflow(callers[0], called)
+		assert.ErrorContains(t, err, `input "unknown" is not defined`)
+	})
+}
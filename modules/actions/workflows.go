@@ -26,6 +26,8 @@ type DetectedWorkflow struct {
 	EntryName    string
 	TriggerEvent *jobparser.Event
 	Content      []byte
+	// RequiredWorkflowID is the id of the required workflow of the org if the workflow file is read from its central repository
+	RequiredWorkflowID int64
 }
 
 func init() {
@@ -168,6 +170,33 @@ func DetectWorkflows(
 	return workflows, schedules, nil
 }
 
+// DetectWorkflowContent returns the events of the workflow content which match the triggered event of the commit,
+// it's used for the workflows which are not in the workflow directories of the commit, like the required workflows of orgs
+func DetectWorkflowContent(
+	gitRepo *git.Repository,
+	commit *git.Commit,
+	entryName string,
+	content []byte,
+	triggedEvent webhook_module.HookEventType,
+	payload api.Payloader,
+) ([]*DetectedWorkflow, error) {
+	events, err := GetEventsFromContent(content)
+	if err != nil {
+		return nil, err
+	}
+	var workflows []*DetectedWorkflow
+	for _, evt := range events {
+		if !evt.IsSchedule() && detectMatched(gitRepo, commit, triggedEvent, payload, evt) {
+			workflows = append(workflows, &DetectedWorkflow{
+				EntryName:    entryName,
+				TriggerEvent: evt,
+				Content:      content,
+			})
+		}
+	}
+	return workflows, nil
+}
+
 func DetectScheduledWorkflows(gitRepo *git.Repository, commit *git.Commit) ([]*DetectedWorkflow, error) {
 	_, entries, err := ListWorkflows(commit)
 	if err != nil {
@@ -0,0 +1,190 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"archive/tar"
+	"compress/gzip"
+	"io"
+	"path"
+	"regexp"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+var (
+	ErrMissingManifestFile  = util.NewInvalidArgumentErrorf("MANIFEST.json file is missing")
+	ErrManifestFileTooLarge = util.NewInvalidArgumentErrorf("MANIFEST.json file is too large")
+	ErrInvalidNamespace     = util.NewInvalidArgumentErrorf("collection namespace is invalid")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("collection name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("collection version is invalid")
+)
+
+const (
+	manifestFile        = "MANIFEST.json"
+	maxManifestFileSize = 1024 * 1024
+	maxReadmeSize       = 1024 * 1024
+)
+
+// https://github.com/ansible/ansible/blob/devel/lib/ansible/galaxy/collection/__init__.py
+var namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+
+// Package represents an Ansible collection
+type Package struct {
+	Namespace string
+	Name      string
+	Version   string
+	Metadata  *Metadata
+}
+
+// FullName returns the fully qualified name of the collection which is used as package name
+func (p *Package) FullName() string {
+	return p.Namespace + "." + p.Name
+}
+
+// Metadata represents the metadata of an Ansible collection
+type Metadata struct {
+	Description      string            `json:"description,omitempty"`
+	Authors          []string          `json:"authors,omitempty"`
+	Licenses         []string          `json:"licenses,omitempty"`
+	Tags             []string          `json:"tags,omitempty"`
+	Dependencies     map[string]string `json:"dependencies,omitempty"`
+	ProjectURL       string            `json:"project_url,omitempty"`
+	RepositoryURL    string            `json:"repository_url,omitempty"`
+	DocumentationURL string            `json:"documentation_url,omitempty"`
+	IssuesURL        string            `json:"issues_url,omitempty"`
+	Readme           string            `json:"readme,omitempty"`
+}
+
+// https://docs.ansible.com/ansible/latest/dev_guide/collections_galaxy_meta.html
+type manifest struct {
+	CollectionInfo struct {
+		Namespace     string            `json:"namespace"`
+		Name          string            `json:"name"`
+		Version       string            `json:"version"`
+		Authors       []string          `json:"authors"`
+		Readme        string            `json:"readme"`
+		Tags          []string          `json:"tags"`
+		Description   string            `json:"description"`
+		License       []string          `json:"license"`
+		Dependencies  map[string]string `json:"dependencies"`
+		Repository    string            `json:"repository"`
+		Documentation string            `json:"documentation"`
+		Homepage      string            `json:"homepage"`
+		Issues        string            `json:"issues"`
+	} `json:"collection_info"`
+}
+
+// ParsePackage parses the tarball of an Ansible collection
+func ParsePackage(r io.Reader) (*Package, error) {
+	gzr, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("unable to open the collection archive: %v", err)
+	}
+	defer gzr.Close()
+
+	var p *Package
+	var readmePath string
+	readmes := make(map[string]string)
+
+	tr := tar.NewReader(gzr)
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		name := path.Clean(hd.Name)
+		if name == manifestFile {
+			if hd.Size > maxManifestFileSize {
+				return nil, ErrManifestFileTooLarge
+			}
+			p, readmePath, err = parseManifest(tr)
+			if err != nil {
+				return nil, err
+			}
+		} else if !strings.Contains(name, "/") && strings.HasPrefix(strings.ToLower(name), "readme") && hd.Size <= maxReadmeSize {
+			data, err := util.ReadWithLimit(tr, maxReadmeSize)
+			if err != nil {
+				return nil, err
+			}
+			readmes[name] = string(data)
+		}
+	}
+
+	if p == nil {
+		return nil, ErrMissingManifestFile
+	}
+
+	p.Metadata.Readme = readmes[readmePath]
+
+	return p, nil
+}
+
+// ParseManifest parses the MANIFEST.json file of an Ansible collection
+func ParseManifest(r io.Reader) (*Package, error) {
+	p, _, err := parseManifest(r)
+	return p, err
+}
+
+func parseManifest(r io.Reader) (*Package, string, error) {
+	var m manifest
+	if err := json.NewDecoder(io.LimitReader(r, maxManifestFileSize)).Decode(&m); err != nil {
+		return nil, "", util.NewInvalidArgumentErrorf("unable to parse MANIFEST.json: %v", err)
+	}
+
+	info := m.CollectionInfo
+	if !namePattern.MatchString(info.Namespace) {
+		return nil, "", ErrInvalidNamespace
+	}
+	if !namePattern.MatchString(info.Name) {
+		return nil, "", ErrInvalidName
+	}
+	v, err := version.NewSemver(info.Version)
+	if err != nil {
+		return nil, "", ErrInvalidVersion
+	}
+
+	if !validation.IsValidURL(info.Homepage) {
+		info.Homepage = ""
+	}
+	if !validation.IsValidURL(info.Repository) {
+		info.Repository = ""
+	}
+	if !validation.IsValidURL(info.Documentation) {
+		info.Documentation = ""
+	}
+	if !validation.IsValidURL(info.Issues) {
+		info.Issues = ""
+	}
+
+	return &Package{
+		Namespace: info.Namespace,
+		Name:      info.Name,
+		Version:   v.String(),
+		Metadata: &Metadata{
+			Description:      info.Description,
+			Authors:          info.Authors,
+			Licenses:         info.License,
+			Tags:             info.Tags,
+			Dependencies:     info.Dependencies,
+			ProjectURL:       info.Homepage,
+			RepositoryURL:    info.Repository,
+			DocumentationURL: info.Documentation,
+			IssuesURL:        info.Issues,
+		},
+	}, path.Clean(info.Readme), nil
+}
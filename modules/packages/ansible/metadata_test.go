@@ -0,0 +1,137 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packageNamespace = "gitea"
+	packageName      = "utils"
+	packageVersion   = "1.0.1"
+	description      = "Collection Description"
+	readme           = "# Gitea Utils"
+)
+
+const manifestContent = `{
+  "collection_info": {
+    "namespace": "gitea",
+    "name": "utils",
+    "version": "1.0.1",
+    "authors": ["KN4CK3R"],
+    "readme": "README.md",
+    "tags": ["gitea", "tools"],
+    "description": "Collection Description",
+    "license": ["MIT"],
+    "dependencies": {"community.general": ">=7.0.0"},
+    "repository": "https://gitea.io/gitea/utils",
+    "documentation": "not a link",
+    "homepage": "https://gitea.io",
+    "issues": null
+  },
+  "format": 1
+}`
+
+func createArchive(files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(zw)
+	for name, content := range files {
+		hdr := &tar.Header{
+			Name: name,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		}
+		tw.WriteHeader(hdr)
+		tw.Write([]byte(content))
+	}
+	tw.Close()
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestParsePackage(t *testing.T) {
+	t.Run("InvalidArchive", func(t *testing.T) {
+		p, err := ParsePackage(strings.NewReader("invalid"))
+		assert.Nil(t, p)
+		assert.Error(t, err)
+	})
+
+	t.Run("MissingManifestFile", func(t *testing.T) {
+		data := createArchive(map[string]string{"dummy.txt": ""})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingManifestFile)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		data := createArchive(map[string]string{
+			"MANIFEST.json":     manifestContent,
+			"README.md":         readme,
+			"docs/README.md":    "other readme",
+			"plugins/README.md": "plugin readme",
+		})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, packageNamespace, p.Namespace)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, "gitea.utils", p.FullName())
+		assert.Equal(t, readme, p.Metadata.Readme)
+	})
+}
+
+func TestParseManifest(t *testing.T) {
+	t.Run("InvalidNamespace", func(t *testing.T) {
+		p, err := ParseManifest(strings.NewReader(strings.Replace(manifestContent, `"namespace": "gitea"`, `"namespace": "Gitea"`, 1)))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidNamespace)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		p, err := ParseManifest(strings.NewReader(strings.Replace(manifestContent, `"name": "utils"`, `"name": "gitea-utils"`, 1)))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidName)
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParseManifest(strings.NewReader(strings.Replace(manifestContent, `"version": "1.0.1"`, `"version": "1.x"`, 1)))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParseManifest(strings.NewReader(manifestContent))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, packageNamespace, p.Namespace)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+
+		m := p.Metadata
+		assert.Equal(t, description, m.Description)
+		assert.Equal(t, []string{"KN4CK3R"}, m.Authors)
+		assert.Equal(t, []string{"MIT"}, m.Licenses)
+		assert.Equal(t, []string{"gitea", "tools"}, m.Tags)
+		assert.Equal(t, map[string]string{"community.general": ">=7.0.0"}, m.Dependencies)
+		assert.Equal(t, "https://gitea.io", m.ProjectURL)
+		assert.Equal(t, "https://gitea.io/gitea/utils", m.RepositoryURL)
+		assert.Empty(t, m.DocumentationURL)
+		assert.Empty(t, m.IssuesURL)
+		assert.Empty(t, m.Readme)
+	})
+}
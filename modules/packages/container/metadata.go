@@ -21,6 +21,7 @@ const (
 	PropertyMediaType         = "container.mediatype"
 	PropertyManifestTagged    = "container.manifest.tagged"
 	PropertyManifestReference = "container.manifest.reference"
+	PropertyManifestSubject   = "container.manifest.subject"
 
 	DefaultPlatform = "linux/amd64"
 
@@ -63,6 +64,9 @@ type Metadata struct {
 	Labels           map[string]string `json:"labels,omitempty"`
 	ImageLayers      []string          `json:"layer_creation,omitempty"`
 	Manifests        []*Manifest       `json:"manifests,omitempty"`
+	Subject          string            `json:"subject,omitempty"`
+	ArtifactType     string            `json:"artifact_type,omitempty"`
+	Annotations      map[string]string `json:"annotations,omitempty"`
 }
 
 type Manifest struct {
@@ -71,6 +75,40 @@ type Manifest struct {
 	Size     int64  `json:"size"`
 }
 
+// ArtifactKind is the kind of an artifact which refers to an image
+type ArtifactKind string
+
+const (
+	ArtifactKindSignature   ArtifactKind = "signature"
+	ArtifactKindSBOM        ArtifactKind = "sbom"
+	ArtifactKindAttestation ArtifactKind = "attestation"
+	ArtifactKindOther       ArtifactKind = "other"
+)
+
+// GetArtifactKind classifies an artifact by its artifact type
+func GetArtifactKind(artifactType string) ArtifactKind {
+	at := strings.ToLower(artifactType)
+	switch {
+	case strings.HasPrefix(at, "application/vnd.dev.cosign.artifact.sig"),
+		strings.HasPrefix(at, "application/vnd.dev.cosign.simplesigning"),
+		strings.HasPrefix(at, "application/vnd.dev.sigstore.bundle"),
+		strings.HasPrefix(at, "application/vnd.cncf.notary.signature"):
+		return ArtifactKindSignature
+	case strings.Contains(at, "spdx"),
+		strings.Contains(at, "cyclonedx"),
+		strings.Contains(at, "syft"),
+		strings.Contains(at, "sbom"):
+		return ArtifactKindSBOM
+	case strings.Contains(at, "in-toto"),
+		strings.Contains(at, "dsse"),
+		strings.Contains(at, "attestation"),
+		strings.Contains(at, "provenance"):
+		return ArtifactKindAttestation
+	default:
+		return ArtifactKindOther
+	}
+}
+
 func IsMediaTypeValid(mt string) bool {
 	return strings.HasPrefix(mt, "application/vnd.docker.") || strings.HasPrefix(mt, "application/vnd.oci.")
 }
@@ -66,3 +66,21 @@ func TestParseImageConfig(t *testing.T) {
 	require.NoError(t, err)
 	assert.Equal(t, &Metadata{Platform: "unknown/unknown"}, metadata)
 }
+
+func TestGetArtifactKind(t *testing.T) {
+	cases := map[string]ArtifactKind{
+		"application/vnd.dev.cosign.artifact.sig.v1+json":  ArtifactKindSignature,
+		"application/vnd.dev.sigstore.bundle.v0.3+json":    ArtifactKindSignature,
+		"application/vnd.cncf.notary.signature":            ArtifactKindSignature,
+		"application/spdx+json":                            ArtifactKindSBOM,
+		"application/vnd.cyclonedx+json":                   ArtifactKindSBOM,
+		"application/vnd.dev.cosign.artifact.sbom.v1+json": ArtifactKindSBOM,
+		"application/vnd.in-toto+json":                     ArtifactKindAttestation,
+		"application/vnd.dsse.envelope.v1+json":            ArtifactKindAttestation,
+		"application/vnd.oci.image.config.v1+json":         ArtifactKindOther,
+	}
+	for artifactType, kind := range cases {
+		assert.Equal(t, kind, GetArtifactKind(artifactType), artifactType)
+	}
+	assert.Equal(t, ArtifactKindOther, GetArtifactKind(""))
+}
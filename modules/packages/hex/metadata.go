@@ -0,0 +1,256 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"regexp"
+	"sort"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+var (
+	ErrMissingMetadataFile  = util.NewInvalidArgumentErrorf("metadata.config file is missing")
+	ErrMissingContentsFile  = util.NewInvalidArgumentErrorf("contents.tar.gz file is missing")
+	ErrMetadataFileTooLarge = util.NewInvalidArgumentErrorf("metadata.config file is too large")
+	ErrUnsupportedVersion   = util.NewInvalidArgumentErrorf("tarball version is not supported")
+	ErrInvalidChecksum      = util.NewInvalidArgumentErrorf("tarball checksum is invalid")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("package name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("package version is invalid")
+)
+
+// https://github.com/hexpm/hex_core/blob/main/src/hex_tarball.erl
+const (
+	tarballVersion      = "3"
+	maxMetadataFileSize = 128 * 1024
+
+	fileVersion  = "VERSION"
+	fileChecksum = "CHECKSUM"
+	fileMetadata = "metadata.config"
+	fileContents = "contents.tar.gz"
+)
+
+const (
+	SettingKeyPrivate = "hex.key.private"
+	SettingKeyPublic  = "hex.key.public"
+)
+
+var namePattern = regexp.MustCompile(`\A[a-z][a-z0-9_]*\z`)
+
+// Package represents a Hex package
+type Package struct {
+	Name     string
+	Version  string
+	Metadata *Metadata
+}
+
+// Metadata represents the metadata of a Hex package
+type Metadata struct {
+	App           string            `json:"app,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	Licenses      []string          `json:"licenses,omitempty"`
+	Links         map[string]string `json:"links,omitempty"`
+	BuildTools    []string          `json:"build_tools,omitempty"`
+	Elixir        string            `json:"elixir,omitempty"`
+	Requirements  []*Requirement    `json:"requirements,omitempty"`
+	InnerChecksum string            `json:"inner_checksum"`
+	OuterChecksum string            `json:"outer_checksum"`
+}
+
+// Requirement represents a dependency of a Hex package
+type Requirement struct {
+	Name        string `json:"name"`
+	App         string `json:"app,omitempty"`
+	Requirement string `json:"requirement"`
+	Optional    bool   `json:"optional,omitempty"`
+	Repository  string `json:"repository,omitempty"`
+}
+
+// ParsePackage parses the Hex package tarball and validates its checksum
+func ParsePackage(r io.Reader) (*Package, error) {
+	outer := sha256.New()
+	tr := tar.NewReader(io.TeeReader(r, outer))
+
+	var versionContent, checksumContent, metadataContent []byte
+	var innerChecksum []byte
+
+	for {
+		hd, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if hd.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		switch hd.Name {
+		case fileVersion:
+			versionContent, err = util.ReadWithLimit(tr, 16)
+		case fileChecksum:
+			checksumContent, err = util.ReadWithLimit(tr, 128)
+		case fileMetadata:
+			if hd.Size > maxMetadataFileSize {
+				return nil, ErrMetadataFileTooLarge
+			}
+			metadataContent, err = util.ReadWithLimit(tr, maxMetadataFileSize)
+		case fileContents:
+			// the inner checksum covers the files in a fixed order, the clients write the contents last
+			if versionContent == nil || metadataContent == nil {
+				return nil, ErrMissingMetadataFile
+			}
+			inner := sha256.New()
+			_, _ = inner.Write(versionContent)
+			_, _ = inner.Write(metadataContent)
+			if _, err = io.Copy(inner, tr); err == nil {
+				innerChecksum = inner.Sum(nil)
+			}
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	if string(versionContent) != tarballVersion {
+		return nil, ErrUnsupportedVersion
+	}
+	if metadataContent == nil {
+		return nil, ErrMissingMetadataFile
+	}
+	if innerChecksum == nil {
+		return nil, ErrMissingContentsFile
+	}
+	if !strings.EqualFold(strings.TrimSpace(string(checksumContent)), hex.EncodeToString(innerChecksum)) {
+		return nil, ErrInvalidChecksum
+	}
+
+	// consume the padding of the archive to calculate the checksum of the whole tarball
+	if _, err := io.Copy(io.Discard, r); err != nil {
+		return nil, err
+	}
+
+	p, err := ParseMetadata(metadataContent)
+	if err != nil {
+		return nil, err
+	}
+
+	p.Metadata.InnerChecksum = hex.EncodeToString(innerChecksum)
+	p.Metadata.OuterChecksum = hex.EncodeToString(outer.Sum(nil))
+
+	return p, nil
+}
+
+// ParseMetadata parses the metadata.config file of a Hex package
+func ParseMetadata(content []byte) (*Package, error) {
+	terms, err := parseTerms(string(content))
+	if err != nil {
+		return nil, err
+	}
+
+	fields := make(map[string]any, len(terms))
+	for _, t := range terms {
+		tp, ok := t.(tuple)
+		if !ok || len(tp) != 2 {
+			return nil, ErrInvalidTerm
+		}
+		key, ok := termToString(tp[0])
+		if !ok {
+			return nil, ErrInvalidTerm
+		}
+		fields[key] = tp[1]
+	}
+
+	name, _ := termToString(fields["name"])
+	if !namePattern.MatchString(name) {
+		return nil, ErrInvalidName
+	}
+
+	versionString, _ := termToString(fields["version"])
+	v, err := version.NewSemver(versionString)
+	if err != nil {
+		return nil, ErrInvalidVersion
+	}
+
+	m := &Metadata{
+		Licenses:     termToStrings(fields["licenses"]),
+		BuildTools:   termToStrings(fields["build_tools"]),
+		Requirements: parseRequirements(fields["requirements"]),
+	}
+	m.App, _ = termToString(fields["app"])
+	m.Description, _ = termToString(fields["description"])
+	m.Elixir, _ = termToString(fields["elixir"])
+
+	if links, ok := termToProplist(fields["links"]); ok {
+		m.Links = make(map[string]string, len(links))
+		for title, link := range links {
+			if s, ok := termToString(link); ok && validation.IsValidURL(s) {
+				m.Links[title] = s
+			}
+		}
+	}
+
+	return &Package{
+		Name:     name,
+		Version:  v.String(),
+		Metadata: m,
+	}, nil
+}
+
+// parseRequirements supports the list of proplists of the current clients
+// and the {Name, Proplist} entries of the older ones
+func parseRequirements(t any) []*Requirement {
+	list, ok := t.([]any)
+	if !ok {
+		return nil
+	}
+
+	requirements := make([]*Requirement, 0, len(list))
+	for _, item := range list {
+		var name string
+		var props map[string]any
+
+		if tp, ok := item.(tuple); ok && len(tp) == 2 {
+			name, _ = termToString(tp[0])
+			props, ok = termToProplist(tp[1])
+			if !ok {
+				continue
+			}
+		} else {
+			props, ok = termToProplist(item)
+			if !ok {
+				continue
+			}
+			name, _ = termToString(props["name"])
+		}
+		if name == "" {
+			continue
+		}
+
+		req := &Requirement{Name: name}
+		req.App, _ = termToString(props["app"])
+		req.Requirement, _ = termToString(props["requirement"])
+		req.Repository, _ = termToString(props["repository"])
+		optional, _ := termToString(props["optional"])
+		req.Optional = optional == "true"
+
+		requirements = append(requirements, req)
+	}
+
+	sort.SliceStable(requirements, func(i, j int) bool {
+		return requirements[i].Name < requirements[j].Name
+	})
+
+	return requirements
+}
@@ -0,0 +1,183 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"archive/tar"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packageName    = "gitea_package"
+	packageVersion = "1.0.1"
+	description    = "Package Description"
+)
+
+const metadataConfig = `{<<"app">>,<<"gitea_package">>}.
+{<<"build_tools">>,[<<"mix">>]}.
+{<<"description">>,<<"Package Description">>}.
+{<<"elixir">>,<<"~> 1.15">>}.
+{<<"files">>,[<<"lib">>,<<"lib/gitea_package.ex">>,<<"mix.exs">>]}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"links">>,[{<<"GitHub">>,<<"https://gitea.io/gitea_package">>},{<<"Invalid">>,<<"not a link">>}]}.
+{<<"name">>,<<"gitea_package">>}.
+{<<"requirements">>,
+ [[{<<"app">>,<<"jason">>},
+   {<<"name">>,<<"jason">>},
+   {<<"optional">>,false},
+   {<<"repository">>,<<"hexpm">>},
+   {<<"requirement">>,<<"~> 1.4">>}],
+  [{<<"app">>,<<"decimal">>},
+   {<<"name">>,<<"decimal">>},
+   {<<"optional">>,true},
+   {<<"repository">>,<<"hexpm">>},
+   {<<"requirement">>,<<"~> 2.0">>}]]}.
+{<<"version">>,<<"1.0.1">>}.
+`
+
+func createArchive(files map[string][]byte, order []string) []byte {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+	for _, name := range order {
+		content := files[name]
+		hdr := &tar.Header{
+			Name: name,
+			Mode: 0o600,
+			Size: int64(len(content)),
+		}
+		tw.WriteHeader(hdr)
+		tw.Write(content)
+	}
+	tw.Close()
+	return buf.Bytes()
+}
+
+func createPackage(metadata string) []byte {
+	contents := []byte("contents")
+	h := sha256.New()
+	h.Write([]byte("3"))
+	h.Write([]byte(metadata))
+	h.Write(contents)
+
+	return createArchive(map[string][]byte{
+		"VERSION":         []byte("3"),
+		"CHECKSUM":        []byte(strings.ToUpper(hex.EncodeToString(h.Sum(nil)))),
+		"metadata.config": []byte(metadata),
+		"contents.tar.gz": contents,
+	}, []string{"VERSION", "CHECKSUM", "metadata.config", "contents.tar.gz"})
+}
+
+func TestParsePackage(t *testing.T) {
+	t.Run("InvalidChecksum", func(t *testing.T) {
+		data := createArchive(map[string][]byte{
+			"VERSION":         []byte("3"),
+			"CHECKSUM":        []byte("ABCD"),
+			"metadata.config": []byte(metadataConfig),
+			"contents.tar.gz": []byte("contents"),
+		}, []string{"VERSION", "CHECKSUM", "metadata.config", "contents.tar.gz"})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidChecksum)
+	})
+
+	t.Run("UnsupportedVersion", func(t *testing.T) {
+		data := createArchive(map[string][]byte{
+			"VERSION":         []byte("2"),
+			"metadata.config": []byte(metadataConfig),
+			"contents.tar.gz": []byte("contents"),
+		}, []string{"VERSION", "metadata.config", "contents.tar.gz"})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrUnsupportedVersion)
+	})
+
+	t.Run("MissingContents", func(t *testing.T) {
+		data := createArchive(map[string][]byte{
+			"VERSION":         []byte("3"),
+			"metadata.config": []byte(metadataConfig),
+		}, []string{"VERSION", "metadata.config"})
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingContentsFile)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		data := createPackage(metadataConfig)
+
+		p, err := ParsePackage(bytes.NewReader(data))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, description, p.Metadata.Description)
+		assert.Equal(t, "~> 1.15", p.Metadata.Elixir)
+		assert.Equal(t, []string{"MIT"}, p.Metadata.Licenses)
+		assert.Equal(t, []string{"mix"}, p.Metadata.BuildTools)
+		assert.Equal(t, map[string]string{"GitHub": "https://gitea.io/gitea_package"}, p.Metadata.Links)
+
+		outer := sha256.Sum256(data)
+		assert.Equal(t, hex.EncodeToString(outer[:]), p.Metadata.OuterChecksum)
+		assert.Len(t, p.Metadata.InnerChecksum, 64)
+
+		require.Len(t, p.Metadata.Requirements, 2)
+		assert.Equal(t, &Requirement{Name: "decimal", App: "decimal", Requirement: "~> 2.0", Optional: true, Repository: "hexpm"}, p.Metadata.Requirements[0])
+		assert.Equal(t, &Requirement{Name: "jason", App: "jason", Requirement: "~> 1.4", Repository: "hexpm"}, p.Metadata.Requirements[1])
+	})
+}
+
+func TestParseMetadata(t *testing.T) {
+	t.Run("InvalidName", func(t *testing.T) {
+		for _, name := range []string{"", "Invalid", "in-valid", "1invalid"} {
+			p, err := ParseMetadata([]byte(`{<<"name">>,<<"` + name + `">>}. {<<"version">>,<<"1.0.0">>}.`))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidName)
+		}
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`{<<"name">>,<<"valid">>}. {<<"version">>,<<"v-invalid">>}.`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("InvalidTerm", func(t *testing.T) {
+		for _, content := range []string{`{<<"name">>,<<"valid">>}`, `{<<"name">>,<<"valid>>}.`, `{<<"name">>,[}.`, `<<"name">>.`} {
+			p, err := ParseMetadata([]byte(content))
+			assert.Nil(t, p)
+			assert.ErrorIs(t, err, ErrInvalidTerm)
+		}
+	})
+
+	t.Run("LegacyRequirements", func(t *testing.T) {
+		p, err := ParseMetadata([]byte(`% comment
+{<<"name">>,<<"legacy">>}.
+{<<"version">>,<<"0.1.0">>}.
+{<<"description">>,<<"Façade "/utf8, "\"quoted\""/utf8>>}.
+{<<"requirements">>,[{<<"plug">>,[{<<"app">>,<<"plug">>},{<<"optional">>,false},{<<"requirement">>,<<">= 0.0.0">>}]}]}.`))
+		require.NoError(t, err)
+
+		assert.Equal(t, "legacy", p.Name)
+		assert.Equal(t, "0.1.0", p.Version)
+		assert.Equal(t, "Façade \"quoted\"", p.Metadata.Description)
+		assert.Equal(t, []*Requirement{{Name: "plug", App: "plug", Requirement: ">= 0.0.0"}}, p.Metadata.Requirements)
+	})
+}
+
+func TestEncodeTermMap(t *testing.T) {
+	assert.Equal(t,
+		[]byte{131, 116, 0, 0, 0, 1, 109, 0, 0, 0, 3, 'u', 'r', 'l', 109, 0, 0, 0, 1, 'x'},
+		EncodeTermMap(map[string]string{"url": "x"}),
+	)
+}
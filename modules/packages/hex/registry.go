@@ -0,0 +1,150 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha512"
+
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+// The registry resources are protobuf messages which are signed and gzipped.
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md
+// https://github.com/hexpm/hex_core/tree/main/proto
+
+// NameEntry is a package in the names resource
+type NameEntry struct {
+	Name             string
+	UpdatedAtSeconds int64
+}
+
+// VersionsEntry is a package in the versions resource
+type VersionsEntry struct {
+	Name     string
+	Versions []string
+}
+
+// Release is a version in the package resource
+type Release struct {
+	Version       string
+	InnerChecksum []byte
+	OuterChecksum []byte
+	Dependencies  []*Requirement
+}
+
+// EncodeNames encodes the names resource which lists all packages of the repository
+func EncodeNames(repository string, packages []*NameEntry) []byte {
+	var b []byte
+	for _, p := range packages {
+		var pb []byte
+		pb = protowire.AppendTag(pb, 1, protowire.BytesType)
+		pb = protowire.AppendString(pb, p.Name)
+		if p.UpdatedAtSeconds != 0 {
+			var tb []byte
+			tb = protowire.AppendTag(tb, 1, protowire.VarintType)
+			tb = protowire.AppendVarint(tb, uint64(p.UpdatedAtSeconds))
+			pb = protowire.AppendTag(pb, 2, protowire.BytesType)
+			pb = protowire.AppendBytes(pb, tb)
+		}
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, pb)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+// EncodeVersions encodes the versions resource which lists the versions of all packages of the repository
+func EncodeVersions(repository string, packages []*VersionsEntry) []byte {
+	var b []byte
+	for _, p := range packages {
+		var pb []byte
+		pb = protowire.AppendTag(pb, 1, protowire.BytesType)
+		pb = protowire.AppendString(pb, p.Name)
+		for _, v := range p.Versions {
+			pb = protowire.AppendTag(pb, 2, protowire.BytesType)
+			pb = protowire.AppendString(pb, v)
+		}
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, pb)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+// EncodePackage encodes the package resource which describes the releases of a package
+func EncodePackage(repository, name string, releases []*Release) []byte {
+	var b []byte
+	for _, r := range releases {
+		var rb []byte
+		rb = protowire.AppendTag(rb, 1, protowire.BytesType)
+		rb = protowire.AppendString(rb, r.Version)
+		rb = protowire.AppendTag(rb, 2, protowire.BytesType)
+		rb = protowire.AppendBytes(rb, r.InnerChecksum)
+		for _, dep := range r.Dependencies {
+			rb = protowire.AppendTag(rb, 3, protowire.BytesType)
+			rb = protowire.AppendBytes(rb, encodeDependency(repository, dep))
+		}
+		rb = protowire.AppendTag(rb, 5, protowire.BytesType)
+		rb = protowire.AppendBytes(rb, r.OuterChecksum)
+
+		b = protowire.AppendTag(b, 1, protowire.BytesType)
+		b = protowire.AppendBytes(b, rb)
+	}
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendString(b, name)
+	b = protowire.AppendTag(b, 3, protowire.BytesType)
+	return protowire.AppendString(b, repository)
+}
+
+func encodeDependency(repository string, dep *Requirement) []byte {
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendString(b, dep.Name)
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendString(b, dep.Requirement)
+	if dep.Optional {
+		b = protowire.AppendTag(b, 3, protowire.VarintType)
+		b = protowire.AppendVarint(b, protowire.EncodeBool(true))
+	}
+	if dep.App != "" && dep.App != dep.Name {
+		b = protowire.AppendTag(b, 4, protowire.BytesType)
+		b = protowire.AppendString(b, dep.App)
+	}
+	// the repository is omitted if the dependency is resolved from the same repository
+	if dep.Repository != "" && dep.Repository != repository {
+		b = protowire.AppendTag(b, 5, protowire.BytesType)
+		b = protowire.AppendString(b, dep.Repository)
+	}
+	return b
+}
+
+// SignAndCompress wraps the payload in a message which contains the signature of the payload and gzips it
+func SignAndCompress(payload []byte, key *rsa.PrivateKey) ([]byte, error) {
+	hash := sha512.Sum512(payload)
+	signature, err := rsa.SignPKCS1v15(rand.Reader, key, crypto.SHA512, hash[:])
+	if err != nil {
+		return nil, err
+	}
+
+	var b []byte
+	b = protowire.AppendTag(b, 1, protowire.BytesType)
+	b = protowire.AppendBytes(b, payload)
+	b = protowire.AppendTag(b, 2, protowire.BytesType)
+	b = protowire.AppendBytes(b, signature)
+
+	var buf bytes.Buffer
+	zw := gzip.NewWriter(&buf)
+	if _, err := zw.Write(b); err != nil {
+		return nil, err
+	}
+	if err := zw.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
@@ -0,0 +1,350 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"encoding/binary"
+	"slices"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+// The metadata.config file of a Hex package consists of Erlang terms.
+// Only the subset of the term syntax which is used by the Hex clients is supported:
+// binaries, strings, atoms, integers, lists and tuples.
+
+var ErrInvalidTerm = util.NewInvalidArgumentErrorf("metadata contains an invalid term")
+
+// atom is an Erlang atom like true or false
+type atom string
+
+// tuple is an Erlang tuple
+type tuple []any
+
+type termParser struct {
+	s   string
+	pos int
+}
+
+// parseTerms parses a sequence of terms which are terminated by a dot
+func parseTerms(s string) ([]any, error) {
+	p := &termParser{s: s}
+
+	var terms []any
+	for {
+		p.skipWhitespace()
+		if p.eof() {
+			return terms, nil
+		}
+
+		term, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		terms = append(terms, term)
+
+		p.skipWhitespace()
+		if !p.consume('.') {
+			return nil, ErrInvalidTerm
+		}
+	}
+}
+
+func (p *termParser) eof() bool {
+	return p.pos >= len(p.s)
+}
+
+func (p *termParser) peek() byte {
+	if p.eof() {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+func (p *termParser) consume(c byte) bool {
+	if !p.eof() && p.s[p.pos] == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *termParser) skipWhitespace() {
+	for !p.eof() {
+		switch c := p.s[p.pos]; {
+		case c == '%':
+			for !p.eof() && p.s[p.pos] != '\n' {
+				p.pos++
+			}
+		case c == ' ' || c == '\t' || c == '\r' || c == '\n':
+			p.pos++
+		default:
+			return
+		}
+	}
+}
+
+func (p *termParser) parseTerm() (any, error) {
+	p.skipWhitespace()
+
+	switch c := p.peek(); {
+	case c == '<':
+		return p.parseBinary()
+	case c == '"':
+		return p.parseQuoted('"')
+	case c == '\'':
+		s, err := p.parseQuoted('\'')
+		return atom(s), err
+	case c == '[':
+		p.pos++
+		return p.parseSequence(']')
+	case c == '{':
+		p.pos++
+		items, err := p.parseSequence('}')
+		return tuple(items), err
+	case c == '-' || (c >= '0' && c <= '9'):
+		return p.parseInteger()
+	case c >= 'a' && c <= 'z':
+		start := p.pos
+		for !p.eof() && (isAtomChar(p.s[p.pos])) {
+			p.pos++
+		}
+		return atom(p.s[start:p.pos]), nil
+	}
+	return nil, ErrInvalidTerm
+}
+
+func isAtomChar(c byte) bool {
+	return c == '_' || c == '@' || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || (c >= '0' && c <= '9')
+}
+
+// parseSequence parses the comma separated items of a list or tuple up to the closing character
+func (p *termParser) parseSequence(end byte) ([]any, error) {
+	items := make([]any, 0, 5)
+
+	p.skipWhitespace()
+	if p.consume(end) {
+		return items, nil
+	}
+
+	for {
+		item, err := p.parseTerm()
+		if err != nil {
+			return nil, err
+		}
+		items = append(items, item)
+
+		p.skipWhitespace()
+		if p.consume(end) {
+			return items, nil
+		}
+		if !p.consume(',') {
+			return nil, ErrInvalidTerm
+		}
+	}
+}
+
+func (p *termParser) parseInteger() (int64, error) {
+	start := p.pos
+	p.consume('-')
+	for !p.eof() && p.s[p.pos] >= '0' && p.s[p.pos] <= '9' {
+		p.pos++
+	}
+	i, err := strconv.ParseInt(p.s[start:p.pos], 10, 64)
+	if err != nil {
+		return 0, ErrInvalidTerm
+	}
+	return i, nil
+}
+
+// parseBinary parses binaries like <<"text">>, <<"text"/utf8>> or <<116,101,120,116>>
+func (p *termParser) parseBinary() (string, error) {
+	if !strings.HasPrefix(p.s[p.pos:], "<<") {
+		return "", ErrInvalidTerm
+	}
+	p.pos += 2
+
+	var sb strings.Builder
+	for segments := 0; ; segments++ {
+		p.skipWhitespace()
+		if strings.HasPrefix(p.s[p.pos:], ">>") {
+			p.pos += 2
+			return sb.String(), nil
+		}
+		if segments > 0 && !p.consume(',') {
+			return "", ErrInvalidTerm
+		}
+		p.skipWhitespace()
+
+		if p.peek() == '"' {
+			s, err := p.parseQuoted('"')
+			if err != nil {
+				return "", err
+			}
+			sb.WriteString(s)
+
+			if p.consume('/') {
+				for !p.eof() && isAtomChar(p.s[p.pos]) {
+					p.pos++
+				}
+			}
+		} else {
+			i, err := p.parseInteger()
+			if err != nil || i < 0 || i > 255 {
+				return "", ErrInvalidTerm
+			}
+			sb.WriteByte(byte(i))
+		}
+	}
+}
+
+// parseQuoted parses a quoted string or atom and resolves the escape sequences
+func (p *termParser) parseQuoted(quote byte) (string, error) {
+	if !p.consume(quote) {
+		return "", ErrInvalidTerm
+	}
+
+	var sb strings.Builder
+	for {
+		if p.eof() {
+			return "", ErrInvalidTerm
+		}
+		c := p.s[p.pos]
+		p.pos++
+
+		if c == quote {
+			return sb.String(), nil
+		}
+		if c != '\\' {
+			sb.WriteByte(c)
+			continue
+		}
+
+		if p.eof() {
+			return "", ErrInvalidTerm
+		}
+		c = p.s[p.pos]
+		p.pos++
+
+		switch c {
+		case 'n':
+			sb.WriteByte('\n')
+		case 'r':
+			sb.WriteByte('\r')
+		case 't':
+			sb.WriteByte('\t')
+		case 's':
+			sb.WriteByte(' ')
+		case 'e':
+			sb.WriteByte(0x1b)
+		case 'x':
+			start := p.pos
+			if p.consume('{') {
+				for !p.eof() && p.s[p.pos] != '}' {
+					p.pos++
+				}
+				r, err := strconv.ParseUint(p.s[start+1:p.pos], 16, 32)
+				if err != nil || !p.consume('}') {
+					return "", ErrInvalidTerm
+				}
+				sb.WriteRune(rune(r))
+			} else {
+				p.pos = min(p.pos+2, len(p.s))
+				b, err := strconv.ParseUint(p.s[start:p.pos], 16, 8)
+				if err != nil {
+					return "", ErrInvalidTerm
+				}
+				sb.WriteByte(byte(b))
+			}
+		default:
+			if c >= '0' && c <= '7' {
+				start := p.pos - 1
+				for p.pos < start+3 && !p.eof() && p.s[p.pos] >= '0' && p.s[p.pos] <= '7' {
+					p.pos++
+				}
+				b, err := strconv.ParseUint(p.s[start:p.pos], 8, 8)
+				if err != nil {
+					return "", ErrInvalidTerm
+				}
+				sb.WriteByte(byte(b))
+			} else {
+				sb.WriteByte(c)
+			}
+		}
+	}
+}
+
+// termToString returns the text of a binary, string or atom
+func termToString(t any) (string, bool) {
+	switch v := t.(type) {
+	case string:
+		return v, true
+	case atom:
+		return string(v), true
+	}
+	return "", false
+}
+
+// termToStrings returns the texts of a list of binaries or strings
+func termToStrings(t any) []string {
+	list, ok := t.([]any)
+	if !ok {
+		return nil
+	}
+	values := make([]string, 0, len(list))
+	for _, item := range list {
+		if s, ok := termToString(item); ok {
+			values = append(values, s)
+		}
+	}
+	return values
+}
+
+// termToProplist converts a list of {Key, Value} tuples to a map
+func termToProplist(t any) (map[string]any, bool) {
+	list, ok := t.([]any)
+	if !ok {
+		return nil, false
+	}
+	m := make(map[string]any, len(list))
+	for _, item := range list {
+		tp, ok := item.(tuple)
+		if !ok || len(tp) != 2 {
+			return nil, false
+		}
+		key, ok := termToString(tp[0])
+		if !ok {
+			return nil, false
+		}
+		m[key] = tp[1]
+	}
+	return m, true
+}
+
+// EncodeTermMap encodes a map of binaries in the external term format which is expected by the Hex clients in API responses
+// https://www.erlang.org/doc/apps/erts/erl_ext_dist.html
+func EncodeTermMap(m map[string]string) []byte {
+	appendBinary := func(b []byte, s string) []byte {
+		b = append(b, 109) // BINARY_EXT
+		b = binary.BigEndian.AppendUint32(b, uint32(len(s)))
+		return append(b, s...)
+	}
+
+	b := []byte{131, 116} // version, MAP_EXT
+	b = binary.BigEndian.AppendUint32(b, uint32(len(m)))
+
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	slices.Sort(keys)
+
+	for _, k := range keys {
+		b = appendBinary(b, k)
+		b = appendBinary(b, m[k])
+	}
+	return b
+}
@@ -0,0 +1,259 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bufio"
+	"crypto/ed25519"
+	"encoding/base64"
+	"fmt"
+	"io"
+	"path"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"code.gitea.io/gitea/modules/util"
+)
+
+var (
+	ErrInvalidStorePath = util.NewInvalidArgumentErrorf("store path is invalid")
+	ErrInvalidURL       = util.NewInvalidArgumentErrorf("NAR url is invalid")
+	ErrInvalidHash      = util.NewInvalidArgumentErrorf("hash is invalid")
+	ErrInvalidSize      = util.NewInvalidArgumentErrorf("size is invalid")
+	ErrInvalidReference = util.NewInvalidArgumentErrorf("reference is invalid")
+)
+
+const (
+	SettingKeyPrivate = "nix.key.private"
+	SettingKeyPublic  = "nix.key.public"
+
+	UploadPackage = "_nix"
+	UploadVersion = "_upload"
+
+	StoreDir = "/nix/store"
+
+	maxNarInfoSize = 1024 * 1024
+)
+
+// https://github.com/NixOS/nix/blob/master/src/libutil/hash.cc
+const base32Chars = "0123456789abcdfghijklmnpqrsvwxyz"
+
+var (
+	// https://github.com/NixOS/nix/blob/master/src/libstore/path.cc
+	storePathBasePattern = regexp.MustCompile(`\A[` + base32Chars + `]{32}-[a-zA-Z0-9+\-._?=]+\z`)
+	narFilePattern       = regexp.MustCompile(`\A[` + base32Chars + `]{52}\.nar(\.[a-z0-9]+)?\z`)
+	hashPattern          = regexp.MustCompile(`\Asha256:[` + base32Chars + `]{52}\z`)
+)
+
+// NarInfo represents the description of a store path in a binary cache
+type NarInfo struct {
+	Hash     string
+	Name     string
+	URL      string
+	Metadata *Metadata
+}
+
+// Metadata of a Nix store path
+type Metadata struct {
+	StorePath   string   `json:"store_path"`
+	Compression string   `json:"compression"`
+	FileHash    string   `json:"file_hash,omitempty"`
+	FileSize    int64    `json:"file_size,omitempty"`
+	NarHash     string   `json:"nar_hash"`
+	NarSize     int64    `json:"nar_size"`
+	References  []string `json:"references,omitempty"`
+	Deriver     string   `json:"deriver,omitempty"`
+	System      string   `json:"system,omitempty"`
+	CA          string   `json:"ca,omitempty"`
+	Signatures  []string `json:"signatures,omitempty"`
+}
+
+// ParseStorePathBase splits the base name of a store path into the hash and the name part
+func ParseStorePathBase(base string) (string, string, error) {
+	if !storePathBasePattern.MatchString(base) {
+		return "", "", ErrInvalidStorePath
+	}
+	return base[:32], base[33:], nil
+}
+
+// IsValidNarFilename checks if the filename matches the name of a (compressed) NAR file
+func IsValidNarFilename(filename string) bool {
+	return narFilePattern.MatchString(filename)
+}
+
+// ParseNarInfo parses a narinfo file
+// https://github.com/NixOS/nix/blob/master/src/libstore/nar-info.cc
+func ParseNarInfo(r io.Reader) (*NarInfo, error) {
+	ni := &NarInfo{
+		Metadata: &Metadata{
+			Compression: "bzip2",
+		},
+	}
+	m := ni.Metadata
+
+	var storeDir string
+
+	scanner := bufio.NewScanner(io.LimitReader(r, maxNarInfoSize))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if line == "" {
+			continue
+		}
+
+		key, value, ok := strings.Cut(line, ": ")
+		if !ok {
+			return nil, util.NewInvalidArgumentErrorf("invalid narinfo line: %s", line)
+		}
+
+		var err error
+		switch key {
+		case "StorePath":
+			storeDir = path.Dir(value)
+			ni.Hash, ni.Name, err = ParseStorePathBase(path.Base(value))
+			m.StorePath = value
+		case "URL":
+			ni.URL = value
+		case "Compression":
+			m.Compression = value
+		case "FileHash":
+			m.FileHash = value
+		case "FileSize":
+			m.FileSize, err = parseSize(value)
+		case "NarHash":
+			m.NarHash = value
+		case "NarSize":
+			m.NarSize, err = parseSize(value)
+		case "References":
+			for _, ref := range strings.Fields(value) {
+				if _, _, err := ParseStorePathBase(ref); err != nil {
+					return nil, ErrInvalidReference
+				}
+				m.References = append(m.References, ref)
+			}
+		case "Deriver":
+			if value != "unknown-deriver" {
+				m.Deriver = value
+			}
+		case "System":
+			m.System = value
+		case "Sig":
+			m.Signatures = append(m.Signatures, value)
+		case "CA":
+			m.CA = value
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	if m.StorePath == "" || storeDir != StoreDir {
+		return nil, ErrInvalidStorePath
+	}
+	if !hashPattern.MatchString(m.NarHash) || (m.FileHash != "" && !hashPattern.MatchString(m.FileHash)) {
+		return nil, ErrInvalidHash
+	}
+	if m.NarSize == 0 {
+		return nil, ErrInvalidSize
+	}
+	if !strings.HasPrefix(ni.URL, "nar/") || !IsValidNarFilename(strings.TrimPrefix(ni.URL, "nar/")) {
+		return nil, ErrInvalidURL
+	}
+
+	return ni, nil
+}
+
+func parseSize(s string) (int64, error) {
+	size, err := strconv.ParseInt(s, 10, 64)
+	if err != nil || size < 0 {
+		return 0, ErrInvalidSize
+	}
+	return size, nil
+}
+
+// Fingerprint returns the string which gets signed to verify the store path
+// https://github.com/NixOS/nix/blob/master/src/libstore/path-info.cc
+func (m *Metadata) Fingerprint() string {
+	refs := make([]string, 0, len(m.References))
+	for _, ref := range m.References {
+		refs = append(refs, StoreDir+"/"+ref)
+	}
+	return fmt.Sprintf("1;%s;%s;%d;%s", m.StorePath, m.NarHash, m.NarSize, strings.Join(refs, ","))
+}
+
+// WriteNarInfo writes the narinfo file of a store path with the additional signatures
+func WriteNarInfo(w io.Writer, url string, m *Metadata, signatures ...string) error {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "StorePath: %s\n", m.StorePath)
+	fmt.Fprintf(&sb, "URL: %s\n", url)
+	fmt.Fprintf(&sb, "Compression: %s\n", m.Compression)
+	if m.FileHash != "" {
+		fmt.Fprintf(&sb, "FileHash: %s\n", m.FileHash)
+	}
+	if m.FileSize != 0 {
+		fmt.Fprintf(&sb, "FileSize: %d\n", m.FileSize)
+	}
+	fmt.Fprintf(&sb, "NarHash: %s\n", m.NarHash)
+	fmt.Fprintf(&sb, "NarSize: %d\n", m.NarSize)
+	fmt.Fprintf(&sb, "References: %s\n", strings.Join(m.References, " "))
+	if m.Deriver != "" {
+		fmt.Fprintf(&sb, "Deriver: %s\n", m.Deriver)
+	}
+	if m.System != "" {
+		fmt.Fprintf(&sb, "System: %s\n", m.System)
+	}
+	for _, sig := range append(m.Signatures, signatures...) {
+		fmt.Fprintf(&sb, "Sig: %s\n", sig)
+	}
+	if m.CA != "" {
+		fmt.Fprintf(&sb, "CA: %s\n", m.CA)
+	}
+
+	_, err := io.WriteString(w, sb.String())
+	return err
+}
+
+// GenerateKeyPair generates an ed25519 key pair in the format of "nix-store --generate-binary-cache-key"
+func GenerateKeyPair(name string) (string, string, error) {
+	pub, priv, err := ed25519.GenerateKey(nil)
+	if err != nil {
+		return "", "", err
+	}
+	return name + ":" + base64.StdEncoding.EncodeToString(priv), name + ":" + base64.StdEncoding.EncodeToString(pub), nil
+}
+
+// Sign signs the fingerprint with the private key and returns the signature in the format of the narinfo file
+func Sign(privateKey, fingerprint string) (string, error) {
+	name, encoded, ok := strings.Cut(privateKey, ":")
+	if !ok {
+		return "", util.NewInvalidArgumentErrorf("private key is invalid")
+	}
+	key, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(key) != ed25519.PrivateKeySize {
+		return "", util.NewInvalidArgumentErrorf("private key is invalid")
+	}
+	return name + ":" + base64.StdEncoding.EncodeToString(ed25519.Sign(key, []byte(fingerprint))), nil
+}
+
+// EncodeBase32 encodes the data with the base32 variant used by Nix
+func EncodeBase32(data []byte) string {
+	length := (len(data)*8-1)/5 + 1
+
+	var sb strings.Builder
+	sb.Grow(length)
+	for n := length - 1; n >= 0; n-- {
+		b := n * 5
+		i := b / 8
+		j := b % 8
+		c := data[i] >> j
+		if i+1 < len(data) {
+			c |= data[i+1] << (8 - j)
+		}
+		sb.WriteByte(base32Chars[c&0x1f])
+	}
+	return sb.String()
+}
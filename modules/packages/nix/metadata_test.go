@@ -0,0 +1,134 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	storeHash = "9q4ibwzv2xhhhrpamlfywa7kxbfd4ad2"
+	storeName = "hello-2.12.1"
+	narHash   = "sha256:1bw8xbmqfbrmyy3k0ysfr1fc0ak4j64g0y6b6lrw6kd2a4lql9bk"
+	fileHash  = "sha256:0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73"
+	narURL    = "nar/0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73.nar.xz"
+)
+
+const narInfoContent = `StorePath: /nix/store/` + storeHash + `-` + storeName + `
+URL: ` + narURL + `
+Compression: xz
+FileHash: ` + fileHash + `
+FileSize: 50264
+NarHash: ` + narHash + `
+NarSize: 226560
+References: 9q4ibwzv2xhhhrpamlfywa7kxbfd4ad2-hello-2.12.1 xpxln7rqi3pq4m0xpnawhxb2gs0mn1s0-glibc-2.39-52
+Deriver: 33d7z1rc8mkfk9k4rdzvfhdywxqx3z9a-hello-2.12.1.drv
+System: x86_64-linux
+Sig: cache.nixos.org-1:signature
+`
+
+func TestParseNarInfo(t *testing.T) {
+	t.Run("InvalidStorePath", func(t *testing.T) {
+		ni, err := ParseNarInfo(strings.NewReader(strings.Replace(narInfoContent, "/nix/store/", "/gnu/store/", 1)))
+		assert.Nil(t, ni)
+		assert.ErrorIs(t, err, ErrInvalidStorePath)
+
+		ni, err = ParseNarInfo(strings.NewReader(strings.Replace(narInfoContent, storeHash+"-", "invalid-", 1)))
+		assert.Nil(t, ni)
+		assert.ErrorIs(t, err, ErrInvalidStorePath)
+	})
+
+	t.Run("InvalidHash", func(t *testing.T) {
+		ni, err := ParseNarInfo(strings.NewReader(strings.Replace(narInfoContent, narHash, "sha256:invalid", 1)))
+		assert.Nil(t, ni)
+		assert.ErrorIs(t, err, ErrInvalidHash)
+	})
+
+	t.Run("InvalidURL", func(t *testing.T) {
+		ni, err := ParseNarInfo(strings.NewReader(strings.Replace(narInfoContent, narURL, "../file.nar", 1)))
+		assert.Nil(t, ni)
+		assert.ErrorIs(t, err, ErrInvalidURL)
+	})
+
+	t.Run("InvalidReference", func(t *testing.T) {
+		ni, err := ParseNarInfo(strings.NewReader(strings.Replace(narInfoContent, "References: ", "References: invalid ", 1)))
+		assert.Nil(t, ni)
+		assert.ErrorIs(t, err, ErrInvalidReference)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		ni, err := ParseNarInfo(strings.NewReader(narInfoContent))
+		require.NoError(t, err)
+		require.NotNil(t, ni)
+
+		assert.Equal(t, storeHash, ni.Hash)
+		assert.Equal(t, storeName, ni.Name)
+		assert.Equal(t, narURL, ni.URL)
+
+		m := ni.Metadata
+		assert.Equal(t, "/nix/store/"+storeHash+"-"+storeName, m.StorePath)
+		assert.Equal(t, "xz", m.Compression)
+		assert.Equal(t, fileHash, m.FileHash)
+		assert.EqualValues(t, 50264, m.FileSize)
+		assert.Equal(t, narHash, m.NarHash)
+		assert.EqualValues(t, 226560, m.NarSize)
+		assert.Equal(t, []string{"9q4ibwzv2xhhhrpamlfywa7kxbfd4ad2-hello-2.12.1", "xpxln7rqi3pq4m0xpnawhxb2gs0mn1s0-glibc-2.39-52"}, m.References)
+		assert.Equal(t, "33d7z1rc8mkfk9k4rdzvfhdywxqx3z9a-hello-2.12.1.drv", m.Deriver)
+		assert.Equal(t, "x86_64-linux", m.System)
+		assert.Equal(t, []string{"cache.nixos.org-1:signature"}, m.Signatures)
+	})
+}
+
+func TestWriteNarInfo(t *testing.T) {
+	ni, err := ParseNarInfo(strings.NewReader(narInfoContent))
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	require.NoError(t, WriteNarInfo(&buf, ni.URL, ni.Metadata))
+	assert.Equal(t, narInfoContent, buf.String())
+
+	buf.Reset()
+	require.NoError(t, WriteNarInfo(&buf, ni.URL, ni.Metadata, "gitea-1:signature"))
+	assert.Equal(t, narInfoContent+"Sig: gitea-1:signature\n", buf.String())
+}
+
+func TestSign(t *testing.T) {
+	ni, err := ParseNarInfo(strings.NewReader(narInfoContent))
+	require.NoError(t, err)
+
+	fingerprint := ni.Metadata.Fingerprint()
+	assert.Equal(t, "1;/nix/store/"+storeHash+"-"+storeName+";"+narHash+";226560;/nix/store/9q4ibwzv2xhhhrpamlfywa7kxbfd4ad2-hello-2.12.1,/nix/store/xpxln7rqi3pq4m0xpnawhxb2gs0mn1s0-glibc-2.39-52", fingerprint)
+
+	priv, pub, err := GenerateKeyPair("gitea-1")
+	require.NoError(t, err)
+	assert.True(t, strings.HasPrefix(priv, "gitea-1:"))
+	assert.True(t, strings.HasPrefix(pub, "gitea-1:"))
+
+	sig, err := Sign(priv, fingerprint)
+	require.NoError(t, err)
+
+	name, encoded, _ := strings.Cut(sig, ":")
+	assert.Equal(t, "gitea-1", name)
+	signature, err := base64.StdEncoding.DecodeString(encoded)
+	require.NoError(t, err)
+	key, err := base64.StdEncoding.DecodeString(strings.TrimPrefix(pub, "gitea-1:"))
+	require.NoError(t, err)
+	assert.True(t, ed25519.Verify(key, []byte(fingerprint), signature))
+
+	_, err = Sign("invalid", fingerprint)
+	assert.Error(t, err)
+}
+
+func TestEncodeBase32(t *testing.T) {
+	sum := sha256.Sum256(nil)
+	assert.Equal(t, "0mdqa9w1p6cmli6976v4wi0sw9r4p5prkj7lzfd1877wk11c9c73", EncodeBase32(sum[:]))
+}
@@ -325,3 +325,46 @@ func TestParsePackage(t *testing.T) {
 		require.Equal(t, "MIT", string(p.Metadata.License))
 	})
 }
+
+func TestParseRemotePackage(t *testing.T) {
+	data, _ := base64.StdEncoding.DecodeString("H4sIAAAAAAAA/ytITM5OTE/VL4DQelnF+XkMVAYGBgZmJiYK2MRBwNDcSIHB2NTMwNDQzMwAqA7IMDUxA9LUdgg2UFpcklgEdAql5kD8ogCnhwio5lJQUMpLzE1VslJQcihOzi9I1S9JLS7RhSYIJR2QgrLUouLM/DyQGkM9Az1D3YIiqExKanFyUWZBCVQ2BKhVwQVJDKwosbQkI78IJO/tZ+LsbRykxFXLNdA+HwWjYBSMgpENACgAbtAACAAA")
+
+	t.Run("Integrity", func(t *testing.T) {
+		p, err := ParseRemotePackage(&PackageMetadataVersion{
+			Name:    "@scope/test-package",
+			Version: "v1.0.1",
+			Dist: PackageDistribution{
+				Integrity: "sha512-yA4FJsVhetynGfOC1jFf79BuS+jrHbm0fhh+aHzCQkOaOBXKf9oBnC4a6DnLLnEsHQDRLYd00cwj8sCXpC+wIg==",
+			},
+		}, data)
+		require.NoError(t, err)
+		assert.Equal(t, "@scope/test-package", p.Name)
+		assert.Equal(t, "1.0.1", p.Version)
+		assert.Equal(t, "test-package-1.0.1.tgz", p.Filename)
+		assert.Equal(t, data, p.Data)
+	})
+
+	t.Run("Shasum", func(t *testing.T) {
+		p, err := ParseRemotePackage(&PackageMetadataVersion{
+			Name:    "test-package",
+			Version: "1.0.1",
+			Dist: PackageDistribution{
+				Shasum: "aaa7eaf852a948b0aa05afeda35b1badca155d90",
+			},
+		}, data)
+		require.NoError(t, err)
+		assert.Equal(t, "test-package-1.0.1.tgz", p.Filename)
+	})
+
+	t.Run("InvalidShasum", func(t *testing.T) {
+		p, err := ParseRemotePackage(&PackageMetadataVersion{
+			Name:    "test-package",
+			Version: "1.0.1",
+			Dist: PackageDistribution{
+				Shasum: "0000000000000000000000000000000000000000",
+			},
+		}, data)
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidIntegrity)
+	})
+}
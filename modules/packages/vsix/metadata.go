@@ -0,0 +1,285 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vsix
+
+import (
+	"archive/zip"
+	"encoding/xml"
+	"io"
+	"path"
+	"regexp"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+
+	"github.com/hashicorp/go-version"
+)
+
+var (
+	ErrMissingManifestFile  = util.NewInvalidArgumentErrorf("extension.vsixmanifest file is missing")
+	ErrManifestFileTooLarge = util.NewInvalidArgumentErrorf("extension.vsixmanifest file is too large")
+	ErrInvalidPublisher     = util.NewInvalidArgumentErrorf("extension publisher is invalid")
+	ErrInvalidName          = util.NewInvalidArgumentErrorf("extension name is invalid")
+	ErrInvalidVersion       = util.NewInvalidArgumentErrorf("extension version is invalid")
+)
+
+const (
+	manifestFile        = "extension.vsixmanifest"
+	maxManifestFileSize = 1024 * 1024
+	maxAssetFileSize    = 5 * 1024 * 1024
+	maxReadmeSize       = 1024 * 1024
+
+	TargetPlatformUniversal = "universal"
+)
+
+// The asset types which are stored with the extension, the names match the ones of the Visual Studio Marketplace
+const (
+	AssetTypePackage   = "Microsoft.VisualStudio.Services.VSIXPackage"
+	AssetTypeManifest  = "Microsoft.VisualStudio.Code.Manifest"
+	AssetTypeDetails   = "Microsoft.VisualStudio.Services.Content.Details"
+	AssetTypeChangelog = "Microsoft.VisualStudio.Services.Content.Changelog"
+	AssetTypeLicense   = "Microsoft.VisualStudio.Services.Content.License"
+	AssetTypeIcon      = "Microsoft.VisualStudio.Services.Icons.Default"
+)
+
+var storedAssetTypes = []string{AssetTypeManifest, AssetTypeDetails, AssetTypeChangelog, AssetTypeLicense, AssetTypeIcon}
+
+// https://github.com/eclipse/openvsx/blob/master/server/src/main/java/org/eclipse/openvsx/util/NamingUtil.java
+var namePattern = regexp.MustCompile(`\A[a-zA-Z0-9][a-zA-Z0-9_\-]*\z`)
+
+// Package represents a VS Code extension
+type Package struct {
+	Publisher string
+	Name      string
+	Version   string
+	Metadata  *Metadata
+	Assets    []*Asset
+}
+
+// ID returns the unique identifier of the extension which is used as package name
+func (p *Package) ID() string {
+	return p.Publisher + "." + p.Name
+}
+
+// Asset is a file of the extension which is served separately
+type Asset struct {
+	Type    string
+	Path    string
+	Content []byte
+}
+
+// Metadata represents the metadata of a VS Code extension
+type Metadata struct {
+	DisplayName           string   `json:"display_name,omitempty"`
+	Description           string   `json:"description,omitempty"`
+	Categories            []string `json:"categories,omitempty"`
+	Tags                  []string `json:"tags,omitempty"`
+	License               string   `json:"license,omitempty"`
+	ProjectURL            string   `json:"project_url,omitempty"`
+	RepositoryURL         string   `json:"repository_url,omitempty"`
+	BugsURL               string   `json:"bugs_url,omitempty"`
+	Engine                string   `json:"engine,omitempty"`
+	TargetPlatform        string   `json:"target_platform"`
+	PreRelease            bool     `json:"pre_release,omitempty"`
+	Preview               bool     `json:"preview,omitempty"`
+	ExtensionDependencies []string `json:"extension_dependencies,omitempty"`
+	ExtensionPack         []string `json:"extension_pack,omitempty"`
+	Assets                []string `json:"assets,omitempty"`
+	Readme                string   `json:"readme,omitempty"`
+}
+
+// https://github.com/microsoft/vscode-vsce/blob/main/src/templates/vsixmanifest.xml
+type vsixManifest struct {
+	Metadata struct {
+		Identity struct {
+			ID             string `xml:"Id,attr"`
+			Version        string `xml:"Version,attr"`
+			Publisher      string `xml:"Publisher,attr"`
+			TargetPlatform string `xml:"TargetPlatform,attr"`
+		} `xml:"Identity"`
+		DisplayName  string `xml:"DisplayName"`
+		Description  string `xml:"Description"`
+		Tags         string `xml:"Tags"`
+		Categories   string `xml:"Categories"`
+		GalleryFlags string `xml:"GalleryFlags"`
+		Properties   struct {
+			Property []struct {
+				ID    string `xml:"Id,attr"`
+				Value string `xml:"Value,attr"`
+			} `xml:"Property"`
+		} `xml:"Properties"`
+	} `xml:"Metadata"`
+	Assets struct {
+		Asset []struct {
+			Type string `xml:"Type,attr"`
+			Path string `xml:"Path,attr"`
+		} `xml:"Asset"`
+	} `xml:"Assets"`
+}
+
+// https://code.visualstudio.com/api/references/extension-manifest
+type packageJSON struct {
+	License string `json:"license"`
+}
+
+// ParsePackage parses the VSIX file of a VS Code extension
+func ParsePackage(r io.ReaderAt, size int64) (*Package, error) {
+	archive, err := zip.NewReader(r, size)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("unable to open the extension archive: %v", err)
+	}
+
+	files := make(map[string]*zip.File, len(archive.File))
+	for _, file := range archive.File {
+		files[file.Name] = file
+	}
+
+	mf, ok := files[manifestFile]
+	if !ok {
+		return nil, ErrMissingManifestFile
+	}
+	if mf.UncompressedSize64 > maxManifestFileSize {
+		return nil, ErrManifestFileTooLarge
+	}
+	content, err := readFile(mf, maxManifestFileSize)
+	if err != nil {
+		return nil, err
+	}
+
+	p, manifest, err := parseManifest(content)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, a := range manifest.Assets.Asset {
+		if !slices.Contains(storedAssetTypes, a.Type) || slices.Contains(p.Metadata.Assets, a.Type) {
+			continue
+		}
+		f, ok := files[path.Clean(a.Path)]
+		if !ok || f.UncompressedSize64 > maxAssetFileSize {
+			continue
+		}
+		data, err := readFile(f, maxAssetFileSize)
+		if err != nil {
+			return nil, err
+		}
+
+		p.Assets = append(p.Assets, &Asset{
+			Type:    a.Type,
+			Path:    path.Clean(a.Path),
+			Content: data,
+		})
+		p.Metadata.Assets = append(p.Metadata.Assets, a.Type)
+
+		switch a.Type {
+		case AssetTypeManifest:
+			var pj packageJSON
+			if err := json.Unmarshal(data, &pj); err == nil {
+				p.Metadata.License = pj.License
+			}
+		case AssetTypeDetails:
+			if len(data) <= maxReadmeSize {
+				p.Metadata.Readme = string(data)
+			}
+		}
+	}
+
+	return p, nil
+}
+
+func readFile(f *zip.File, limit int) ([]byte, error) {
+	rc, err := f.Open()
+	if err != nil {
+		return nil, err
+	}
+	defer rc.Close()
+
+	return util.ReadWithLimit(rc, limit)
+}
+
+// ParseManifest parses the extension.vsixmanifest file of a VS Code extension
+func ParseManifest(content []byte) (*Package, error) {
+	p, _, err := parseManifest(content)
+	return p, err
+}
+
+func parseManifest(content []byte) (*Package, *vsixManifest, error) {
+	var manifest vsixManifest
+	if err := xml.Unmarshal(content, &manifest); err != nil {
+		return nil, nil, util.NewInvalidArgumentErrorf("unable to parse extension.vsixmanifest: %v", err)
+	}
+
+	identity := manifest.Metadata.Identity
+	if !namePattern.MatchString(identity.Publisher) {
+		return nil, nil, ErrInvalidPublisher
+	}
+	if !namePattern.MatchString(identity.ID) {
+		return nil, nil, ErrInvalidName
+	}
+	v, err := version.NewSemver(identity.Version)
+	if err != nil {
+		return nil, nil, ErrInvalidVersion
+	}
+
+	m := &Metadata{
+		DisplayName:    manifest.Metadata.DisplayName,
+		Description:    manifest.Metadata.Description,
+		Categories:     splitList(manifest.Metadata.Categories),
+		Tags:           splitList(manifest.Metadata.Tags),
+		TargetPlatform: identity.TargetPlatform,
+		Preview:        strings.Contains(manifest.Metadata.GalleryFlags, "Preview"),
+	}
+	if m.TargetPlatform == "" {
+		m.TargetPlatform = TargetPlatformUniversal
+	}
+
+	for _, property := range manifest.Metadata.Properties.Property {
+		switch property.ID {
+		case "Microsoft.VisualStudio.Code.Engine":
+			m.Engine = property.Value
+		case "Microsoft.VisualStudio.Code.PreRelease":
+			m.PreRelease = property.Value == "true"
+		case "Microsoft.VisualStudio.Code.ExtensionDependencies":
+			m.ExtensionDependencies = splitList(property.Value)
+		case "Microsoft.VisualStudio.Code.ExtensionPack":
+			m.ExtensionPack = splitList(property.Value)
+		case "Microsoft.VisualStudio.Services.Links.Learn":
+			m.ProjectURL = property.Value
+		case "Microsoft.VisualStudio.Services.Links.Source":
+			m.RepositoryURL = property.Value
+		case "Microsoft.VisualStudio.Services.Links.Support":
+			m.BugsURL = property.Value
+		}
+	}
+
+	if !validation.IsValidURL(m.ProjectURL) {
+		m.ProjectURL = ""
+	}
+	if !validation.IsValidURL(m.RepositoryURL) {
+		m.RepositoryURL = ""
+	}
+	if !validation.IsValidURL(m.BugsURL) {
+		m.BugsURL = ""
+	}
+
+	return &Package{
+		Publisher: identity.Publisher,
+		Name:      identity.ID,
+		Version:   v.String(),
+		Metadata:  m,
+	}, &manifest, nil
+}
+
+func splitList(s string) []string {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	return values
+}
@@ -0,0 +1,159 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vsix
+
+import (
+	"archive/zip"
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	packagePublisher = "gitea"
+	packageName      = "test-extension"
+	packageVersion   = "1.0.1"
+	displayName      = "Test Extension"
+	description      = "Extension Description"
+	readme           = "# Test Extension"
+	icon             = "icon"
+)
+
+const manifestContent = `<?xml version="1.0" encoding="utf-8"?>
+<PackageManifest Version="2.0.0" xmlns="http://schemas.microsoft.com/developer/vsx-schema/2011" xmlns:d="http://schemas.microsoft.com/developer/vsx-schema-design/2011">
+	<Metadata>
+		<Identity Language="en-US" Id="test-extension" Version="1.0.1" Publisher="gitea" TargetPlatform="linux-x64" />
+		<DisplayName>Test Extension</DisplayName>
+		<Description xml:space="preserve">Extension Description</Description>
+		<Tags>gitea,test</Tags>
+		<Categories>Programming Languages,Other</Categories>
+		<GalleryFlags>Public Preview</GalleryFlags>
+		<Properties>
+			<Property Id="Microsoft.VisualStudio.Code.Engine" Value="^1.80.0" />
+			<Property Id="Microsoft.VisualStudio.Code.ExtensionDependencies" Value="gitea.dependency" />
+			<Property Id="Microsoft.VisualStudio.Code.ExtensionPack" Value="" />
+			<Property Id="Microsoft.VisualStudio.Code.PreRelease" Value="true" />
+			<Property Id="Microsoft.VisualStudio.Services.Links.Source" Value="https://gitea.io/gitea/test-extension.git" />
+			<Property Id="Microsoft.VisualStudio.Services.Links.Learn" Value="not a link" />
+		</Properties>
+	</Metadata>
+	<Assets>
+		<Asset Type="Microsoft.VisualStudio.Code.Manifest" Path="extension/package.json" Addressable="true" />
+		<Asset Type="Microsoft.VisualStudio.Services.Content.Details" Path="extension/README.md" Addressable="true" />
+		<Asset Type="Microsoft.VisualStudio.Services.Icons.Default" Path="extension/icon.png" Addressable="true" />
+		<Asset Type="Microsoft.VisualStudio.Services.Content.License" Path="extension/LICENSE.txt" Addressable="true" />
+		<Asset Type="Microsoft.VisualStudio.Services.Unknown" Path="extension/unknown.txt" Addressable="true" />
+	</Assets>
+</PackageManifest>`
+
+func createArchive(files map[string]string) []byte {
+	var buf bytes.Buffer
+	zw := zip.NewWriter(&buf)
+	for name, content := range files {
+		w, _ := zw.Create(name)
+		w.Write([]byte(content))
+	}
+	zw.Close()
+	return buf.Bytes()
+}
+
+func TestParsePackage(t *testing.T) {
+	parse := func(data []byte) (*Package, error) {
+		return ParsePackage(bytes.NewReader(data), int64(len(data)))
+	}
+
+	t.Run("InvalidArchive", func(t *testing.T) {
+		p, err := parse([]byte("invalid"))
+		assert.Nil(t, p)
+		assert.Error(t, err)
+	})
+
+	t.Run("MissingManifestFile", func(t *testing.T) {
+		p, err := parse(createArchive(map[string]string{"extension/package.json": "{}"}))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrMissingManifestFile)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		data := createArchive(map[string]string{
+			"extension.vsixmanifest": manifestContent,
+			"extension/package.json": `{"name":"test-extension","license":"MIT"}`,
+			"extension/README.md":    readme,
+			"extension/icon.png":     icon,
+			"extension/unknown.txt":  "unknown",
+		})
+
+		p, err := parse(data)
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, packagePublisher, p.Publisher)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packagePublisher+"."+packageName, p.ID())
+		assert.Equal(t, packageVersion, p.Version)
+		assert.Equal(t, "MIT", p.Metadata.License)
+		assert.Equal(t, readme, p.Metadata.Readme)
+
+		// the license asset is missing in the archive and the unknown asset type is not stored
+		assert.Equal(t, []string{AssetTypeManifest, AssetTypeDetails, AssetTypeIcon}, p.Metadata.Assets)
+		require.Len(t, p.Assets, 3)
+		assert.Equal(t, AssetTypeIcon, p.Assets[2].Type)
+		assert.Equal(t, "extension/icon.png", p.Assets[2].Path)
+		assert.Equal(t, []byte(icon), p.Assets[2].Content)
+	})
+}
+
+func TestParseManifest(t *testing.T) {
+	t.Run("InvalidPublisher", func(t *testing.T) {
+		p, err := ParseManifest([]byte(`<PackageManifest><Metadata><Identity Id="test" Version="1.0.0" Publisher="-invalid" /></Metadata></PackageManifest>`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidPublisher)
+	})
+
+	t.Run("InvalidName", func(t *testing.T) {
+		p, err := ParseManifest([]byte(`<PackageManifest><Metadata><Identity Id="in.valid" Version="1.0.0" Publisher="gitea" /></Metadata></PackageManifest>`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidName)
+	})
+
+	t.Run("InvalidVersion", func(t *testing.T) {
+		p, err := ParseManifest([]byte(`<PackageManifest><Metadata><Identity Id="test" Version="v-invalid" Publisher="gitea" /></Metadata></PackageManifest>`))
+		assert.Nil(t, p)
+		assert.ErrorIs(t, err, ErrInvalidVersion)
+	})
+
+	t.Run("Universal", func(t *testing.T) {
+		p, err := ParseManifest([]byte(`<PackageManifest><Metadata><Identity Id="test" Version="1.0.0" Publisher="gitea" /></Metadata></PackageManifest>`))
+		require.NoError(t, err)
+		assert.Equal(t, TargetPlatformUniversal, p.Metadata.TargetPlatform)
+		assert.False(t, p.Metadata.PreRelease)
+	})
+
+	t.Run("Valid", func(t *testing.T) {
+		p, err := ParseManifest([]byte(manifestContent))
+		require.NoError(t, err)
+		require.NotNil(t, p)
+
+		assert.Equal(t, packagePublisher, p.Publisher)
+		assert.Equal(t, packageName, p.Name)
+		assert.Equal(t, packageVersion, p.Version)
+
+		m := p.Metadata
+		assert.Equal(t, displayName, m.DisplayName)
+		assert.Equal(t, description, m.Description)
+		assert.Equal(t, []string{"gitea", "test"}, m.Tags)
+		assert.Equal(t, []string{"Programming Languages", "Other"}, m.Categories)
+		assert.Equal(t, "linux-x64", m.TargetPlatform)
+		assert.Equal(t, "^1.80.0", m.Engine)
+		assert.Equal(t, []string{"gitea.dependency"}, m.ExtensionDependencies)
+		assert.Empty(t, m.ExtensionPack)
+		assert.True(t, m.PreRelease)
+		assert.True(t, m.Preview)
+		assert.Equal(t, "https://gitea.io/gitea/test-extension.git", m.RepositoryURL)
+		assert.Empty(t, m.ProjectURL)
+		assert.Empty(t, m.Assets)
+	})
+}
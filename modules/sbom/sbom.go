@@ -0,0 +1,237 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"io"
+	"strings"
+
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// Format is the specification a document follows
+type Format string
+
+const (
+	FormatCycloneDX Format = "cyclonedx"
+	FormatSPDX      Format = "spdx"
+)
+
+// MaxDocumentSize is the maximum size of a document which gets parsed
+const MaxDocumentSize = 32 * 1024 * 1024
+
+var (
+	ErrUnsupportedFormat = util.NewInvalidArgumentErrorf("unsupported SBOM format, only CycloneDX and SPDX JSON documents are supported")
+	ErrDocumentTooLarge  = util.NewInvalidArgumentErrorf("SBOM document is too large")
+)
+
+// Document is a parsed software bill of materials
+type Document struct {
+	Format      Format
+	SpecVersion string
+	Name        string
+	Components  []*Component
+}
+
+// Component is a software component listed in a document
+type Component struct {
+	Name    string
+	Version string
+	PURL    string
+	License string
+}
+
+type cycloneDXLicense struct {
+	License *struct {
+		ID   string `json:"id"`
+		Name string `json:"name"`
+	} `json:"license"`
+	Expression string `json:"expression"`
+}
+
+type cycloneDXComponent struct {
+	Group      string                `json:"group"`
+	Name       string                `json:"name"`
+	Version    string                `json:"version"`
+	PURL       string                `json:"purl"`
+	Licenses   []cycloneDXLicense    `json:"licenses"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type cycloneDXDocument struct {
+	BomFormat   string `json:"bomFormat"`
+	SpecVersion string `json:"specVersion"`
+	Metadata    struct {
+		Component *cycloneDXComponent `json:"component"`
+	} `json:"metadata"`
+	Components []*cycloneDXComponent `json:"components"`
+}
+
+type spdxPackage struct {
+	Name             string `json:"name"`
+	VersionInfo      string `json:"versionInfo"`
+	LicenseConcluded string `json:"licenseConcluded"`
+	LicenseDeclared  string `json:"licenseDeclared"`
+	ExternalRefs     []struct {
+		ReferenceType    string `json:"referenceType"`
+		ReferenceLocator string `json:"referenceLocator"`
+	} `json:"externalRefs"`
+}
+
+type spdxDocument struct {
+	SPDXVersion string         `json:"spdxVersion"`
+	Name        string         `json:"name"`
+	Packages    []*spdxPackage `json:"packages"`
+}
+
+// Parse parses a CycloneDX or SPDX JSON document
+func Parse(r io.Reader) (*Document, error) {
+	data, err := util.ReadWithLimit(r, MaxDocumentSize+1)
+	if err != nil {
+		return nil, err
+	}
+	if len(data) > MaxDocumentSize {
+		return nil, ErrDocumentTooLarge
+	}
+
+	var header struct {
+		BomFormat   string `json:"bomFormat"`
+		SPDXVersion string `json:"spdxVersion"`
+	}
+	if err := json.Unmarshal(data, &header); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid SBOM document: %v", err)
+	}
+
+	switch {
+	case strings.EqualFold(header.BomFormat, "CycloneDX"):
+		return parseCycloneDX(data)
+	case strings.HasPrefix(header.SPDXVersion, "SPDX-"):
+		return parseSPDX(data)
+	}
+	return nil, ErrUnsupportedFormat
+}
+
+func parseCycloneDX(data []byte) (*Document, error) {
+	var doc cycloneDXDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid CycloneDX document: %v", err)
+	}
+
+	d := &Document{
+		Format:      FormatCycloneDX,
+		SpecVersion: doc.SpecVersion,
+		Components:  make([]*Component, 0, len(doc.Components)),
+	}
+	if doc.Metadata.Component != nil {
+		d.Name = doc.Metadata.Component.Name
+	}
+
+	// components can be nested, the hierarchy is flattened
+	var walk func([]*cycloneDXComponent)
+	walk = func(components []*cycloneDXComponent) {
+		for _, c := range components {
+			if c == nil {
+				continue
+			}
+			name := c.Name
+			if c.Group != "" {
+				name = c.Group + "/" + c.Name
+			}
+			if name != "" {
+				d.Components = append(d.Components, &Component{
+					Name:    name,
+					Version: c.Version,
+					PURL:    c.PURL,
+					License: cycloneDXLicenseString(c.Licenses),
+				})
+			}
+			walk(c.Components)
+		}
+	}
+	walk(doc.Components)
+
+	return d, nil
+}
+
+func cycloneDXLicenseString(licenses []cycloneDXLicense) string {
+	parts := make([]string, 0, len(licenses))
+	for _, l := range licenses {
+		switch {
+		case l.Expression != "":
+			parts = append(parts, l.Expression)
+		case l.License != nil && l.License.ID != "":
+			parts = append(parts, l.License.ID)
+		case l.License != nil && l.License.Name != "":
+			parts = append(parts, l.License.Name)
+		}
+	}
+	return strings.Join(parts, " AND ")
+}
+
+func parseSPDX(data []byte) (*Document, error) {
+	var doc spdxDocument
+	if err := json.Unmarshal(data, &doc); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid SPDX document: %v", err)
+	}
+
+	d := &Document{
+		Format:      FormatSPDX,
+		SpecVersion: strings.TrimPrefix(doc.SPDXVersion, "SPDX-"),
+		Name:        doc.Name,
+		Components:  make([]*Component, 0, len(doc.Packages)),
+	}
+	for _, p := range doc.Packages {
+		if p == nil || p.Name == "" {
+			continue
+		}
+
+		c := &Component{
+			Name:    p.Name,
+			Version: p.VersionInfo,
+			License: spdxLicenseString(p.LicenseConcluded),
+		}
+		if c.License == "" {
+			c.License = spdxLicenseString(p.LicenseDeclared)
+		}
+		for _, ref := range p.ExternalRefs {
+			if ref.ReferenceType == "purl" {
+				c.PURL = ref.ReferenceLocator
+				break
+			}
+		}
+		d.Components = append(d.Components, c)
+	}
+
+	return d, nil
+}
+
+func spdxLicenseString(license string) string {
+	// NOASSERTION and NONE carry no license information
+	if license == "NOASSERTION" || license == "NONE" {
+		return ""
+	}
+	return license
+}
+
+// SplitPURL splits a package url into the normalized url without version, qualifiers and subpath and the version.
+// The normalized url can be used to find all versions of a component.
+func SplitPURL(purl string) (string, string) {
+	purl = strings.TrimSpace(purl)
+	if i := strings.IndexByte(purl, '#'); i != -1 {
+		purl = purl[:i]
+	}
+	if i := strings.IndexByte(purl, '?'); i != -1 {
+		purl = purl[:i]
+	}
+
+	var version string
+	// the version separator is only valid in the last path segment, npm scopes may start with an unescaped @
+	if i := strings.LastIndexByte(purl, '@'); i != -1 && i > strings.LastIndexByte(purl, '/') {
+		purl, version = purl[:i], purl[i+1:]
+	}
+
+	purl = strings.ReplaceAll(strings.ToLower(purl), "%40", "@")
+	return purl, version
+}
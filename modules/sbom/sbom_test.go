@@ -0,0 +1,108 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"strings"
+	"testing"
+
+	"code.gitea.io/gitea/modules/util"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestParse(t *testing.T) {
+	t.Run("CycloneDX", func(t *testing.T) {
+		content := `{
+	"bomFormat": "CycloneDX",
+	"specVersion": "1.5",
+	"metadata": {"component": {"name": "app"}},
+	"components": [
+		{
+			"name": "lodash",
+			"version": "4.17.21",
+			"purl": "pkg:npm/lodash@4.17.21",
+			"licenses": [{"license": {"id": "MIT"}}],
+			"components": [
+				{"group": "org.example", "name": "nested", "version": "1.0", "licenses": [{"expression": "MIT OR Apache-2.0"}]}
+			]
+		},
+		{"name": "unnamed-license", "licenses": [{"license": {"name": "Custom"}}]}
+	]
+}`
+
+		d, err := Parse(strings.NewReader(content))
+		require.NoError(t, err)
+		assert.Equal(t, FormatCycloneDX, d.Format)
+		assert.Equal(t, "1.5", d.SpecVersion)
+		assert.Equal(t, "app", d.Name)
+		assert.Equal(t, []*Component{
+			{Name: "lodash", Version: "4.17.21", PURL: "pkg:npm/lodash@4.17.21", License: "MIT"},
+			{Name: "org.example/nested", Version: "1.0", License: "MIT OR Apache-2.0"},
+			{Name: "unnamed-license", License: "Custom"},
+		}, d.Components)
+	})
+
+	t.Run("SPDX", func(t *testing.T) {
+		content := `{
+	"spdxVersion": "SPDX-2.3",
+	"name": "app",
+	"packages": [
+		{
+			"name": "requests",
+			"versionInfo": "2.31.0",
+			"licenseConcluded": "NOASSERTION",
+			"licenseDeclared": "Apache-2.0",
+			"externalRefs": [
+				{"referenceCategory": "SECURITY", "referenceType": "cpe23Type", "referenceLocator": "cpe:2.3:a:python:requests:2.31.0:*:*:*:*:*:*:*"},
+				{"referenceCategory": "PACKAGE-MANAGER", "referenceType": "purl", "referenceLocator": "pkg:pypi/requests@2.31.0"}
+			]
+		},
+		{"name": "", "versionInfo": "1.0"}
+	]
+}`
+
+		d, err := Parse(strings.NewReader(content))
+		require.NoError(t, err)
+		assert.Equal(t, FormatSPDX, d.Format)
+		assert.Equal(t, "2.3", d.SpecVersion)
+		assert.Equal(t, "app", d.Name)
+		assert.Equal(t, []*Component{
+			{Name: "requests", Version: "2.31.0", PURL: "pkg:pypi/requests@2.31.0", License: "Apache-2.0"},
+		}, d.Components)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		for _, content := range []string{
+			``,
+			`invalid`,
+			`{"name": "unknown"}`,
+			`{"bomFormat": "Other"}`,
+		} {
+			_, err := Parse(strings.NewReader(content))
+			assert.ErrorIs(t, err, util.ErrInvalidArgument, content)
+		}
+	})
+}
+
+func TestSplitPURL(t *testing.T) {
+	cases := []struct {
+		PURL    string
+		Base    string
+		Version string
+	}{
+		{"pkg:npm/lodash@4.17.21", "pkg:npm/lodash", "4.17.21"},
+		{"pkg:npm/%40Scope/Name@1.0.0?arch=x86#sub/path", "pkg:npm/@scope/name", "1.0.0"},
+		{"pkg:npm/@scope/name@1.0.0", "pkg:npm/@scope/name", "1.0.0"},
+		{"pkg:npm/@scope/name", "pkg:npm/@scope/name", ""},
+		{"pkg:maven/org.apache/commons", "pkg:maven/org.apache/commons", ""},
+	}
+
+	for _, c := range cases {
+		base, version := SplitPURL(c.PURL)
+		assert.Equal(t, c.Base, base, c.PURL)
+		assert.Equal(t, c.Version, version, c.PURL)
+	}
+}
@@ -6,6 +6,7 @@ package setting
 import (
 	"errors"
 	"fmt"
+	"path/filepath"
 	"strings"
 	"time"
 
@@ -21,17 +22,35 @@ var (
 		LogCompression        logCompression    `ini:"LOG_COMPRESSION"`
 		ArtifactStorage       *Storage          // how the created artifacts should be stored
 		ArtifactRetentionDays int64             `ini:"ARTIFACT_RETENTION_DAYS"`
+		CacheEnabled          bool              `ini:"CACHE_ENABLED"`
+		CacheStorage          *Storage          // how the caches uploaded by "actions/cache" should be stored
+		CacheRetentionDays    int64             `ini:"CACHE_RETENTION_DAYS"`
+		CacheMaxSizePerRepo   int64             `ini:"-"`
 		DefaultActionsURL     defaultActionsURL `ini:"DEFAULT_ACTIONS_URL"`
 		ZombieTaskTimeout     time.Duration     `ini:"ZOMBIE_TASK_TIMEOUT"`
 		EndlessTaskTimeout    time.Duration     `ini:"ENDLESS_TASK_TIMEOUT"`
 		AbandonedJobTimeout   time.Duration     `ini:"ABANDONED_JOB_TIMEOUT"`
 		SkipWorkflowStrings   []string          `ini:"SKIP_WORKFLOW_STRINGS"`
 		WorkflowDirs          []string          `ini:"WORKFLOW_DIRS"`
+
+		IDTokenSigningAlgorithm      string        `ini:"ID_TOKEN_SIGNING_ALGORITHM"`
+		IDTokenSigningPrivateKeyFile string        `ini:"ID_TOKEN_SIGNING_PRIVATE_KEY_FILE"`
+		IDTokenExpirationTime        time.Duration `ini:"-"`
+
+		JobRetryMaxAttempts int           `ini:"JOB_RETRY_MAX_ATTEMPTS"`
+		JobRetryBackoff     time.Duration `ini:"-"`
+		JobRetryConclusions []string      `ini:"JOB_RETRY_CONCLUSIONS"`
 	}{
 		Enabled:             true,
+		CacheEnabled:        true,
 		DefaultActionsURL:   defaultActionsURLGitHub,
 		SkipWorkflowStrings: []string{"[skip ci]", "[ci skip]", "[no ci]", "[skip actions]", "[actions skip]"},
 		WorkflowDirs:        []string{".gitea/workflows", ".github/workflows"},
+
+		IDTokenSigningAlgorithm:      "RS256",
+		IDTokenSigningPrivateKeyFile: "actions_id_token/private.pem",
+
+		JobRetryConclusions: []string{"runner_lost", "setup_failure"},
 	}
 )
 
@@ -114,10 +133,44 @@ func loadActionsFrom(rootCfg ConfigProvider) error {
 		Actions.ArtifactRetentionDays = 90
 	}
 
+	cacheSec, _ := rootCfg.GetSection("actions.cache")
+
+	Actions.CacheStorage, err = getStorage(rootCfg, "actions_cache", "", cacheSec)
+	if err != nil {
+		return err
+	}
+
+	// default to 7 days and 10 GiB per repository in Github Actions
+	if Actions.CacheRetentionDays <= 0 {
+		Actions.CacheRetentionDays = 7
+	}
+	sec.Key("CACHE_MAX_SIZE_PER_REPO").MustString("10 GiB")
+	Actions.CacheMaxSizePerRepo = mustBytes(sec, "CACHE_MAX_SIZE_PER_REPO")
+
 	Actions.ZombieTaskTimeout = sec.Key("ZOMBIE_TASK_TIMEOUT").MustDuration(10 * time.Minute)
 	Actions.EndlessTaskTimeout = sec.Key("ENDLESS_TASK_TIMEOUT").MustDuration(3 * time.Hour)
 	Actions.AbandonedJobTimeout = sec.Key("ABANDONED_JOB_TIMEOUT").MustDuration(24 * time.Hour)
 
+	// the ID tokens are verified by third parties with the public key, so symmetric algorithms are not allowed
+	switch Actions.IDTokenSigningAlgorithm {
+	case "RS256", "RS384", "RS512", "ES256", "ES384", "ES512", "EdDSA":
+	default:
+		return fmt.Errorf("unsupported [actions] ID_TOKEN_SIGNING_ALGORITHM: %q", Actions.IDTokenSigningAlgorithm)
+	}
+	if !filepath.IsAbs(Actions.IDTokenSigningPrivateKeyFile) {
+		Actions.IDTokenSigningPrivateKeyFile = filepath.Join(AppDataPath, Actions.IDTokenSigningPrivateKeyFile)
+	}
+	Actions.IDTokenExpirationTime = sec.Key("ID_TOKEN_EXPIRATION_TIME").MustDuration(10 * time.Minute)
+
+	Actions.JobRetryBackoff = sec.Key("JOB_RETRY_BACKOFF").MustDuration(time.Minute)
+	for _, conclusion := range Actions.JobRetryConclusions {
+		switch conclusion {
+		case "runner_lost", "timeout", "setup_failure":
+		default:
+			return fmt.Errorf("unsupported [actions] JOB_RETRY_CONCLUSIONS: %q", conclusion)
+		}
+	}
+
 	if !Actions.LogCompression.IsValid() {
 		return fmt.Errorf("invalid [actions] LOG_COMPRESSION: %q", Actions.LogCompression)
 	}
@@ -6,6 +6,9 @@ package setting
 import (
 	"path/filepath"
 	"testing"
+	"time"
+
+	"code.gitea.io/gitea/modules/test"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -238,3 +241,56 @@ DEFAULT_ACTIONS_URL = gitea
 		})
 	}
 }
+
+func Test_CacheSettings(t *testing.T) {
+	oldActions := Actions
+	defer func() {
+		Actions = oldActions
+	}()
+
+	cfg, err := NewConfigProviderFromData(`[actions]`)
+	require.NoError(t, err)
+	require.NoError(t, loadActionsFrom(cfg))
+	assert.EqualValues(t, 7, Actions.CacheRetentionDays)
+	assert.EqualValues(t, 10*1024*1024*1024, Actions.CacheMaxSizePerRepo)
+	assert.EqualValues(t, "local", Actions.CacheStorage.Type)
+	assert.Equal(t, "actions_cache", filepath.Base(Actions.CacheStorage.Path))
+
+	cfg, err = NewConfigProviderFromData(`
+[actions]
+CACHE_RETENTION_DAYS = 30
+CACHE_MAX_SIZE_PER_REPO = -1
+
+[storage.actions_cache]
+STORAGE_TYPE = minio
+`)
+	require.NoError(t, err)
+	require.NoError(t, loadActionsFrom(cfg))
+	assert.EqualValues(t, 30, Actions.CacheRetentionDays)
+	assert.EqualValues(t, -1, Actions.CacheMaxSizePerRepo)
+	assert.EqualValues(t, "minio", Actions.CacheStorage.Type)
+	assert.Equal(t, "actions_cache/", Actions.CacheStorage.MinioConfig.BasePath)
+}
+
+func Test_IDTokenSettings(t *testing.T) {
+	oldActions := Actions
+	defer func() {
+		Actions = oldActions
+	}()
+	appDataPath := t.TempDir()
+	defer test.MockVariableValue(&AppDataPath, appDataPath)()
+
+	cfg, err := NewConfigProviderFromData(`[actions]`)
+	require.NoError(t, err)
+	require.NoError(t, loadActionsFrom(cfg))
+	assert.Equal(t, "RS256", Actions.IDTokenSigningAlgorithm)
+	assert.Equal(t, filepath.Join(appDataPath, "actions_id_token/private.pem"), Actions.IDTokenSigningPrivateKeyFile)
+	assert.Equal(t, 10*time.Minute, Actions.IDTokenExpirationTime)
+
+	cfg, err = NewConfigProviderFromData(`
+[actions]
+ID_TOKEN_SIGNING_ALGORITHM = HS256
+`)
+	require.NoError(t, err)
+	assert.Error(t, loadActionsFrom(cfg))
+}
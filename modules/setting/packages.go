@@ -6,6 +6,7 @@ package setting
 import (
 	"fmt"
 	"math"
+	"time"
 
 	"github.com/dustin/go-humanize"
 )
@@ -19,6 +20,7 @@ var (
 		LimitTotalOwnerCount    int64
 		LimitTotalOwnerSize     int64
 		LimitSizeAlpine         int64
+		LimitSizeAnsible        int64
 		LimitSizeArch           int64
 		LimitSizeCargo          int64
 		LimitSizeChef           int64
@@ -31,7 +33,9 @@ var (
 		LimitSizeGeneric        int64
 		LimitSizeGo             int64
 		LimitSizeHelm           int64
+		LimitSizeHex            int64
 		LimitSizeMaven          int64
+		LimitSizeNix            int64
 		LimitSizeNpm            int64
 		LimitSizeNuGet          int64
 		LimitSizePub            int64
@@ -41,10 +45,16 @@ var (
 		LimitSizeSwift          int64
 		LimitSizeTerraformState int64
 		LimitSizeVagrant        int64
+		LimitSizeVsix           int64
 
 		DefaultRPMSignEnabled     bool
 		RetainMavenSnapshotBuilds int
 		DebugMavenCleanup         bool
+
+		RemoteAllowedHostList string
+		RemoteFetchTimeout    time.Duration
+		RemoteLimitSize       int64
+		RemoteCacheOnRead     bool
 	}{
 		Enabled:                   true,
 		LimitTotalOwnerCount:      -1,
@@ -70,6 +80,7 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 
 	Packages.LimitTotalOwnerSize = mustBytes(sec, "LIMIT_TOTAL_OWNER_SIZE")
 	Packages.LimitSizeAlpine = mustBytes(sec, "LIMIT_SIZE_ALPINE")
+	Packages.LimitSizeAnsible = mustBytes(sec, "LIMIT_SIZE_ANSIBLE")
 	Packages.LimitSizeArch = mustBytes(sec, "LIMIT_SIZE_ARCH")
 	Packages.LimitSizeCargo = mustBytes(sec, "LIMIT_SIZE_CARGO")
 	Packages.LimitSizeChef = mustBytes(sec, "LIMIT_SIZE_CHEF")
@@ -82,7 +93,9 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeGeneric = mustBytes(sec, "LIMIT_SIZE_GENERIC")
 	Packages.LimitSizeGo = mustBytes(sec, "LIMIT_SIZE_GO")
 	Packages.LimitSizeHelm = mustBytes(sec, "LIMIT_SIZE_HELM")
+	Packages.LimitSizeHex = mustBytes(sec, "LIMIT_SIZE_HEX")
 	Packages.LimitSizeMaven = mustBytes(sec, "LIMIT_SIZE_MAVEN")
+	Packages.LimitSizeNix = mustBytes(sec, "LIMIT_SIZE_NIX")
 	Packages.LimitSizeNpm = mustBytes(sec, "LIMIT_SIZE_NPM")
 	Packages.LimitSizeNuGet = mustBytes(sec, "LIMIT_SIZE_NUGET")
 	Packages.LimitSizePub = mustBytes(sec, "LIMIT_SIZE_PUB")
@@ -92,9 +105,15 @@ func loadPackagesFrom(rootCfg ConfigProvider) (err error) {
 	Packages.LimitSizeSwift = mustBytes(sec, "LIMIT_SIZE_SWIFT")
 	Packages.LimitSizeTerraformState = mustBytes(sec, "LIMIT_SIZE_TERRAFORM_STATE")
 	Packages.LimitSizeVagrant = mustBytes(sec, "LIMIT_SIZE_VAGRANT")
+	Packages.LimitSizeVsix = mustBytes(sec, "LIMIT_SIZE_VSIX")
 	Packages.DefaultRPMSignEnabled = sec.Key("DEFAULT_RPM_SIGN_ENABLED").MustBool(false)
 	Packages.RetainMavenSnapshotBuilds = sec.Key("RETAIN_MAVEN_SNAPSHOT_BUILDS").MustInt(Packages.RetainMavenSnapshotBuilds)
 	Packages.DebugMavenCleanup = sec.Key("DEBUG_MAVEN_CLEANUP").MustBool(true)
+	Packages.RemoteAllowedHostList = sec.Key("REMOTE_ALLOWED_HOST_LIST").MustString("")
+	Packages.RemoteFetchTimeout = sec.Key("REMOTE_FETCH_TIMEOUT").MustDuration(5 * time.Minute)
+	sec.Key("REMOTE_LIMIT_SIZE").MustString("1 GiB")
+	Packages.RemoteLimitSize = mustBytes(sec, "REMOTE_LIMIT_SIZE")
+	Packages.RemoteCacheOnRead = sec.Key("REMOTE_CACHE_ON_READ").MustBool(false)
 	return nil
 }
 
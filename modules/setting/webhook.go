@@ -5,6 +5,7 @@ package setting
 
 import (
 	"net/url"
+	"time"
 
 	"code.gitea.io/gitea/modules/log"
 )
@@ -20,6 +21,11 @@ var Webhook = struct {
 	ProxyURL        string
 	ProxyURLFixed   *url.URL
 	ProxyHosts      []string
+
+	MaxRetries           int
+	RetryBackoff         time.Duration
+	MaxRetryBackoff      time.Duration
+	DisableAfterFailures int
 }{
 	QueueLength:    1000,
 	DeliverTimeout: 5,
@@ -27,6 +33,10 @@ var Webhook = struct {
 	PagingNum:      10,
 	ProxyURL:       "",
 	ProxyHosts:     []string{},
+
+	MaxRetries:      5,
+	RetryBackoff:    time.Minute,
+	MaxRetryBackoff: time.Hour,
 }
 
 func loadWebhookFrom(rootCfg ConfigProvider) {
@@ -35,7 +45,7 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 	Webhook.DeliverTimeout = sec.Key("DELIVER_TIMEOUT").MustInt(5)
 	Webhook.SkipTLSVerify = sec.Key("SKIP_TLS_VERIFY").MustBool()
 	Webhook.AllowedHostList = sec.Key("ALLOWED_HOST_LIST").MustString("")
-	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist"}
+	Webhook.Types = []string{"gitea", "gogs", "slack", "discord", "dingtalk", "telegram", "msteams", "feishu", "matrix", "wechatwork", "packagist", "custom", "cloudevents", "mattermost", "rocketchat", "zulip"}
 	Webhook.PagingNum = sec.Key("PAGING_NUM").MustInt(10)
 	Webhook.ProxyURL = sec.Key("PROXY_URL").MustString("")
 	if Webhook.ProxyURL != "" {
@@ -47,4 +57,8 @@ func loadWebhookFrom(rootCfg ConfigProvider) {
 		}
 	}
 	Webhook.ProxyHosts = sec.Key("PROXY_HOSTS").Strings(",")
+	Webhook.MaxRetries = max(sec.Key("MAX_RETRIES").MustInt(5), 0)
+	Webhook.RetryBackoff = sec.Key("RETRY_BACKOFF").MustDuration(time.Minute)
+	Webhook.MaxRetryBackoff = max(sec.Key("MAX_RETRY_BACKOFF").MustDuration(time.Hour), Webhook.RetryBackoff)
+	Webhook.DisableAfterFailures = max(sec.Key("DISABLE_AFTER_FAILURES").MustInt(0), 0)
 }
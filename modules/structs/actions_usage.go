@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+// ActionUsage represents the runner time used by the finished Actions tasks, aggregated by owner, repository or runs-on labels
+// swagger:model
+type ActionUsage struct {
+	OwnerID int64  `json:"owner_id"`
+	Owner   string `json:"owner"`
+	// only set when the usage is aggregated by repository, empty if the repository has been deleted
+	RepoID     int64  `json:"repo_id,omitempty"`
+	Repository string `json:"repository,omitempty"`
+	// only set when the usage is aggregated by labels, the "runs-on" labels of the jobs
+	Labels    []string `json:"labels,omitempty"`
+	Seconds   int64    `json:"seconds"`
+	Minutes   int64    `json:"minutes"` // the seconds rounded up to minutes
+	TaskCount int64    `json:"task_count"`
+}
+
+// ActionUsageQuota represents the monthly minutes quota of the Actions tasks of an owner
+// swagger:model
+type ActionUsageQuota struct {
+	// 0 means unlimited
+	MonthlyMinutes int64 `json:"monthly_minutes"`
+	// the minutes used by the finished tasks in the current month
+	UsedMinutes int64 `json:"used_minutes"`
+	// no new jobs are queued once the quota is exceeded
+	Exceeded bool `json:"exceeded"`
+}
+
+// SetActionUsageQuotaOption options when setting the monthly minutes quota of an owner
+// swagger:model
+type SetActionUsageQuotaOption struct {
+	// required: true
+	MonthlyMinutes int64 `json:"monthly_minutes" binding:"Required"`
+}
@@ -25,6 +25,8 @@ type Hook struct {
 	Type string `json:"type"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter"`
+	// Filter expression the events must match to trigger the webhook
+	FilterExpression string `json:"filter_expression"`
 	// The URL of the webhook endpoint (hidden in JSON)
 	URL string `json:"-"`
 	// Configuration settings for the webhook
@@ -53,7 +55,7 @@ type CreateHookOptionConfig map[string]string
 // CreateHookOption options when create a hook
 type CreateHookOption struct {
 	// required: true
-	// enum: ["dingtalk","discord","gitea","gogs","msteams","slack","telegram","feishu","wechatwork","packagist"]
+	// enum: ["dingtalk","discord","gitea","gogs","msteams","slack","telegram","feishu","wechatwork","packagist","custom","cloudevents","mattermost","rocketchat","zulip"]
 	// The type of the webhook to create
 	Type string `json:"type" binding:"Required"`
 	// required: true
@@ -63,6 +65,9 @@ type CreateHookOption struct {
 	Events []string `json:"events"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter" binding:"GlobPattern"`
+	// Filter expression the events must match to trigger the webhook, e.g. `action == "opened" && labels contains "deploy"`.
+	// The fields are event, action, sender, base_branch, labels and paths.
+	FilterExpression string `json:"filter_expression" binding:"WebhookFilter"`
 	// Authorization header to include in webhook requests
 	AuthorizationHeader string `json:"authorization_header"`
 	// default: false
@@ -80,6 +85,8 @@ type EditHookOption struct {
 	Events []string `json:"events"`
 	// Branch filter pattern to determine which branches trigger the webhook
 	BranchFilter string `json:"branch_filter" binding:"GlobPattern"`
+	// Filter expression the events must match to trigger the webhook, an empty string removes the filter
+	FilterExpression *string `json:"filter_expression" binding:"WebhookFilter"`
 	// Authorization header to include in webhook requests
 	AuthorizationHeader string `json:"authorization_header"`
 	// Whether the webhook is active and will be triggered
@@ -88,6 +95,60 @@ type EditHookOption struct {
 	Name *string `json:"name,omitzero" binding:"MaxSize(255)"`
 }
 
+// HookPreviewOption options to preview the request of a hook
+type HookPreviewOption struct {
+	// required: true
+	// The event type to render the request for, e.g. "push" or "pull_request_sync"
+	Event string `json:"event" binding:"Required"`
+	// The event payload, a sample payload of the repository is used if it is omitted
+	Payload map[string]any `json:"payload"`
+}
+
+// HookPreview represents the request a hook would send
+type HookPreview struct {
+	// The URL the request would be sent to
+	URL string `json:"url"`
+	// The HTTP method of the request
+	HTTPMethod string `json:"http_method"`
+	// The headers of the request, signatures and the authorization header are masked
+	Headers map[string]string `json:"headers"`
+	// The body of the request
+	Body string `json:"body"`
+}
+
+// HookDelivery represents a delivery of a hook
+type HookDelivery struct {
+	// The unique identifier of the delivery
+	ID int64 `json:"id"`
+	// The ID of the hook
+	HookID int64 `json:"hook_id"`
+	// The UUID sent in the X-Gitea-Delivery header, retries of the delivery keep it
+	UUID string `json:"uuid"`
+	// The event type of the delivery
+	Event string `json:"event"`
+	// Whether the last attempt succeeded
+	Succeeded bool `json:"succeeded"`
+	// The number of delivery attempts
+	Attempts int `json:"attempts"`
+	// Whether all attempts failed and the delivery is not retried anymore
+	IsDeadLettered bool `json:"is_dead_lettered"`
+	// The HTTP status of the response to the last attempt, 0 if there was no response
+	ResponseStatus int `json:"response_status"`
+	// The body of the response to the last attempt, or the error if there was no response
+	ResponseBody string `json:"response_body"`
+	// swagger:strfmt date-time
+	// The time of the last attempt
+	Delivered time.Time `json:"delivered"`
+}
+
+// RedeliverHookDeliveriesOption options to redeliver dead-lettered hook deliveries
+type RedeliverHookDeliveriesOption struct {
+	// The IDs of the dead-lettered deliveries to redeliver, all matching dead-lettered deliveries are redelivered if it is empty
+	IDs []int64 `json:"ids"`
+	// Only redeliver the deliveries of this hook
+	HookID int64 `json:"hook_id"`
+}
+
 // Payloader payload is some part of one hook
 type Payloader interface {
 	JSONPayload() ([]byte, error)
@@ -0,0 +1,62 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// ActionRequiredWorkflow represents a workflow of a central repository which is enforced on the repositories of an organization
+// swagger:model
+type ActionRequiredWorkflow struct {
+	ID int64 `json:"id"`
+	// the name of the required workflow, the commit status contexts of its jobs are "{name} / {job} ({event})"
+	Name string `json:"name"`
+	// the name of the central repository where the workflow file is read from
+	Repository string `json:"repository"`
+	// the path of the workflow file in the central repository
+	Path string `json:"path"`
+	// the branch or tag of the central repository, empty means the default branch
+	Ref string `json:"ref"`
+	// glob patterns of the names of the repositories which run the workflow, empty means all of them
+	RepoPatterns []string `json:"repo_patterns"`
+	// glob patterns of the pushed branches and the base branches of the pull requests, empty means all of them
+	BranchPatterns []string `json:"branch_patterns"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateActionRequiredWorkflowOption options when creating a required workflow
+// swagger:model
+type CreateActionRequiredWorkflowOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// the name of the central repository of the organization where the workflow file is read from
+	//
+	// required: true
+	Repository string `json:"repository" binding:"Required"`
+	// the path of the workflow file in the central repository, like ".gitea/workflows/compliance.yml"
+	//
+	// required: true
+	Path string `json:"path" binding:"Required"`
+	// the branch or tag of the central repository, empty means the default branch
+	Ref string `json:"ref"`
+	// glob patterns of the names of the repositories which run the workflow, empty means all of them
+	RepoPatterns []string `json:"repo_patterns"`
+	// glob patterns of the pushed branches and the base branches of the pull requests, empty means all of them
+	BranchPatterns []string `json:"branch_patterns"`
+}
+
+// EditActionRequiredWorkflowOption options when editing a required workflow, the omitted (null) fields are left unchanged
+// swagger:model
+type EditActionRequiredWorkflowOption struct {
+	Name       *string `json:"name" binding:"MaxSize(255)"`
+	Repository *string `json:"repository"`
+	Path       *string `json:"path"`
+	Ref        *string `json:"ref"`
+	// glob patterns of the names of the repositories which run the workflow, empty means all of them
+	RepoPatterns []string `json:"repo_patterns"`
+	// glob patterns of the pushed branches and the base branches of the pull requests, empty means all of them
+	BranchPatterns []string `json:"branch_patterns"`
+}
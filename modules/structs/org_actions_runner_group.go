@@ -0,0 +1,49 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// ActionRunnerGroup represents a group of runners which restricts the jobs the runners can pick up
+// swagger:model
+type ActionRunnerGroup struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// the names of the repositories which can use the runners, empty means all repositories of the owner
+	Repositories []string `json:"repositories"`
+	// glob patterns of the branches and tags which can use the runners, empty means all of them
+	BranchPatterns []string `json:"branch_patterns"`
+	// glob patterns of the workflow file names (like "deploy.yml") or reusable workflow refs
+	// (like "{owner}/{repo}/.gitea/workflows/deploy.yml@main") which can use the runners, empty means all of them
+	WorkflowPatterns []string `json:"workflow_patterns"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateActionRunnerGroupOption options when creating a runner group
+// swagger:model
+type CreateActionRunnerGroupOption struct {
+	// required: true
+	Name string `json:"name" binding:"Required;MaxSize(255)"`
+	// the names of the repositories which can use the runners, empty means all repositories of the owner
+	Repositories []string `json:"repositories"`
+	// glob patterns of the branches and tags which can use the runners, empty means all of them
+	BranchPatterns []string `json:"branch_patterns"`
+	// glob patterns of the workflow file names or reusable workflow refs which can use the runners, empty means all of them
+	WorkflowPatterns []string `json:"workflow_patterns"`
+}
+
+// EditActionRunnerGroupOption options when editing a runner group, the omitted (null) fields are left unchanged
+// swagger:model
+type EditActionRunnerGroupOption struct {
+	Name *string `json:"name" binding:"MaxSize(255)"`
+	// the names of the repositories which can use the runners, empty means all repositories of the owner
+	Repositories []string `json:"repositories"`
+	// glob patterns of the branches and tags which can use the runners, empty means all of them
+	BranchPatterns []string `json:"branch_patterns"`
+	// glob patterns of the workflow file names or reusable workflow refs which can use the runners, empty means all of them
+	WorkflowPatterns []string `json:"workflow_patterns"`
+}
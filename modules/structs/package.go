@@ -47,3 +47,96 @@ type PackageFile struct {
 	// The SHA512 hash of the package file
 	HashSHA512 string `json:"sha512"`
 }
+
+// PackageRemote represents an upstream registry which the missing packages of a package type are fetched from
+type PackageRemote struct {
+	// The package type of the remote
+	Type string `json:"type"`
+	// The URL of the upstream registry
+	URL string `json:"url"`
+	// The username to authenticate to the upstream registry
+	Username string `json:"username"`
+	// Whether a password is set to authenticate to the upstream registry
+	HasPassword bool `json:"has_password"`
+	// The seconds to cache the upstream metadata, like the version lists
+	MetadataTTL int64 `json:"metadata_ttl"`
+	// swagger:strfmt date-time
+	// The date and time when the remote was created
+	CreatedAt time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	// The date and time when the remote was last updated
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// SetPackageRemoteOption options for setting the upstream registry of a package type
+type SetPackageRemoteOption struct {
+	// The URL of the upstream registry
+	// required: true
+	URL string `json:"url" binding:"Required"`
+	// The username to authenticate to the upstream registry
+	Username string `json:"username"`
+	// The password or token to authenticate to the upstream registry
+	Password string `json:"password"`
+	// The seconds to cache the upstream metadata, 0 means the default of 600 seconds
+	MetadataTTL int64 `json:"metadata_ttl"`
+}
+
+// PackageVirtualRegistry represents the owners whose packages are resolved by the registry of a package type of another owner
+type PackageVirtualRegistry struct {
+	// The package type of the registry
+	Type string `json:"type"`
+	// The names of the member owners in the order they are resolved, after the owner of the registry
+	Members []string `json:"members"`
+}
+
+// SetPackageVirtualRegistryOption options for setting the members of a virtual package registry
+type SetPackageVirtualRegistryOption struct {
+	// The names of the member owners in the order they are resolved, after the owner of the registry
+	// required: true
+	Members []string `json:"members" binding:"Required"`
+}
+
+// PackageVersionChannel represents a channel a package version is published to
+type PackageVersionChannel struct {
+	// The name of the channel
+	Channel string `json:"channel"`
+	// The channel the version was promoted from, empty if the version was published to the channel directly
+	PromotedFrom string `json:"promoted_from"`
+	// swagger:strfmt date-time
+	// The date and time when the version was published to the channel
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// PromotePackageVersionOption options for promoting a package version to another channel
+type PromotePackageVersionOption struct {
+	// The channel the version is published to
+	// required: true
+	From string `json:"from" binding:"Required"`
+	// The channel to publish the version to
+	// required: true
+	To string `json:"to" binding:"Required"`
+}
+
+// PackageSettings represents the package settings of an owner
+type PackageSettings struct {
+	// Whether released generic, Maven and npm package versions can't be overwritten or deleted
+	ImmutableVersions bool `json:"immutable_versions"`
+}
+
+// EditPackageSettingsOption options for editing the package settings of an owner
+type EditPackageSettingsOption struct {
+	// Whether released generic, Maven and npm package versions can't be overwritten or deleted
+	ImmutableVersions *bool `json:"immutable_versions"`
+}
+
+// PackageCleanupCandidate represents a package version which would be removed by a cleanup rule
+type PackageCleanupCandidate struct {
+	Package *Package `json:"package"`
+	// The total size of the files of the version in bytes
+	Size int64 `json:"size"`
+	// The number of downloads of the version
+	DownloadCount int64 `json:"download_count"`
+	// swagger:strfmt date-time
+	// The date and time of the last download, empty if the version was never downloaded
+	LastDownloadAt *time.Time `json:"last_download_at,omitempty"`
+}
@@ -199,6 +199,8 @@ type ActionRunner struct {
 	Disabled  bool                 `json:"disabled"`
 	Ephemeral bool                 `json:"ephemeral"`
 	Labels    []*ActionRunnerLabel `json:"labels"`
+	// the id of the runner group which restricts the jobs the runner can pick up, 0 means no restriction
+	GroupID int64 `json:"runner_group_id"`
 }
 
 // EditActionRunnerOption represents the editable fields for a runner.
@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import "time"
+
+// ActionEnvironment represents a deployment environment of a repository
+// swagger:model
+type ActionEnvironment struct {
+	ID   int64  `json:"id"`
+	Name string `json:"name"`
+	// the users who can approve the deployments to the environment
+	ReviewerUsernames []string `json:"reviewer_usernames"`
+	// the teams whose members can approve the deployments to the environment
+	ReviewerTeams []string `json:"reviewer_teams"`
+	// minutes to wait before a job deploying to the environment can start
+	WaitTimer int64 `json:"wait_timer"`
+	// glob patterns of the branches and tags which can deploy to the environment, empty means all of them
+	BranchPatterns []string `json:"branch_patterns"`
+	// the latest deployment to the environment
+	LastDeployment *ActionDeployment `json:"last_deployment"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// ActionDeployment represents a job deploying to an environment
+// swagger:model
+type ActionDeployment struct {
+	ID          int64  `json:"id"`
+	Environment string `json:"environment"`
+	RunID       int64  `json:"run_id"`
+	JobID       int64  `json:"job_id"`
+	Ref         string `json:"ref"`
+	SHA         string `json:"sha"`
+	// the url of the environment declared by the job
+	URL string `json:"url"`
+	// the status of the job
+	Status string `json:"status"`
+	// the review status of the deployment, one of not_required, pending, approved and rejected
+	ReviewStatus  string `json:"review_status"`
+	Reviewer      *User  `json:"reviewer"`
+	ReviewComment string `json:"review_comment"`
+	// the time when the wait timer of the environment elapses
+	// swagger:strfmt date-time
+	WaitUntil *time.Time `json:"wait_until,omitempty"`
+	// swagger:strfmt date-time
+	Created time.Time `json:"created_at"`
+	// swagger:strfmt date-time
+	Updated time.Time `json:"updated_at"`
+}
+
+// CreateOrUpdateEnvironmentOption options when creating or updating an environment
+// swagger:model
+type CreateOrUpdateEnvironmentOption struct {
+	// the users who can approve the deployments to the environment
+	ReviewerUsernames []string `json:"reviewer_usernames"`
+	// the teams whose members can approve the deployments, only available for organization repositories
+	ReviewerTeams []string `json:"reviewer_teams"`
+	// minutes to wait before a job deploying to the environment can start, at most 43200 (30 days)
+	WaitTimer int64 `json:"wait_timer"`
+	// glob patterns of the branches and tags which can deploy to the environment, empty means all of them
+	BranchPatterns []string `json:"branch_patterns"`
+}
+
+// ReviewDeploymentsOption options when reviewing the pending deployments of a workflow run
+// swagger:model
+type ReviewDeploymentsOption struct {
+	// the ids of the environments whose pending deployments will be reviewed
+	//
+	// required: true
+	EnvironmentIDs []int64 `json:"environment_ids" binding:"Required"`
+	// required: true
+	// enum: approved,rejected
+	State   string `json:"state" binding:"Required;In(approved,rejected)"`
+	Comment string `json:"comment"`
+}
@@ -0,0 +1,53 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package structs
+
+import (
+	"time"
+)
+
+// SBOMDocument represents a software bill of materials attached to a package version, a commit or a release
+type SBOMDocument struct {
+	// The unique identifier of the document
+	ID int64 `json:"id"`
+	// The format of the document
+	Format string `json:"format"`
+	// The version of the specification the document follows
+	SpecVersion string `json:"spec_version"`
+	// The name of the described software
+	Name string `json:"name"`
+	// The number of components listed in the document
+	ComponentCount int `json:"component_count"`
+	// The components listed in the document
+	Components []*SBOMComponent `json:"components"`
+	// swagger:strfmt date-time
+	// The date and time when the document was uploaded
+	CreatedAt time.Time `json:"created_at"`
+}
+
+// SBOMComponent represents a software component listed in a software bill of materials
+type SBOMComponent struct {
+	// The name of the component
+	Name string `json:"name"`
+	// The version of the component
+	Version string `json:"version"`
+	// The package url of the component
+	PURL string `json:"purl"`
+	// The license expression of the component
+	License string `json:"license"`
+}
+
+// SBOMComponentSearchResult represents a component found in a software bill of materials of a package version or a repository
+type SBOMComponentSearchResult struct {
+	// The found component
+	Component *SBOMComponent `json:"component"`
+	// The package version the document is attached to
+	Package *Package `json:"package,omitempty"`
+	// The repository the document is attached to
+	Repository *Repository `json:"repository,omitempty"`
+	// The commit the document is attached to
+	CommitSHA string `json:"commit_sha,omitempty"`
+	// The release the document is attached to
+	ReleaseID int64 `json:"release_id,omitempty"`
+}
@@ -14,6 +14,7 @@ import (
 	"code.gitea.io/gitea/modules/glob"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/webhook"
 
 	"gitea.com/go-chi/binding"
 )
@@ -31,6 +32,8 @@ const (
 	ErrInvalidGroupTeamMap = "InvalidGroupTeamMap"
 	// ErrInvalidBadgeSlug is returned when a badge slug is invalid
 	ErrInvalidBadgeSlug = "InvalidBadgeSlug"
+	// ErrWebhookFilter is returned when a webhook filter expression is invalid
+	ErrWebhookFilter = "WebhookFilter"
 )
 
 type jsonProvider struct{}
@@ -60,6 +63,7 @@ func AddBindingRules() {
 	addUsernamePatternRule()
 	addValidGroupTeamMapRule()
 	addSlugPatternRule()
+	addWebhookFilterRule()
 }
 
 func addGitRefNameBindingRule() {
@@ -250,6 +254,31 @@ func addValidGroupTeamMapRule() {
 	})
 }
 
+func addWebhookFilterRule() {
+	binding.AddRule(&binding.Rule{
+		IsMatch: func(rule string) bool {
+			return rule == "WebhookFilter"
+		},
+		IsValid: func(errs binding.Errors, name string, val any) (bool, binding.Errors) {
+			var str string
+			switch v := val.(type) {
+			case string:
+				str = v
+			case *string:
+				str = *v
+			}
+			if strings.TrimSpace(str) == "" {
+				return true, errs
+			}
+			if _, err := webhook.ParseFilter(str); err != nil {
+				errs.Add([]string{name}, ErrWebhookFilter, err.Error())
+				return false, errs
+			}
+			return true, errs
+		},
+	})
+}
+
 func portOnly(hostport string) string {
 	_, after, ok := strings.Cut(hostport, ":")
 	if !ok {
@@ -140,6 +140,8 @@ func Validate(errs binding.Errors, data map[string]any, f Form, l translation.Lo
 				data["ErrorMsg"] = trName + l.TrString("form.invalid_group_team_map_error", errs[0].Message)
 			case validation.ErrInvalidBadgeSlug:
 				data["ErrorMsg"] = trName + l.TrString("form.invalid_slug_error")
+			case validation.ErrWebhookFilter:
+				data["ErrorMsg"] = trName + l.TrString("form.webhook_filter_error", errs[0].Message)
 			default:
 				msg := errs[0].Classification
 				if msg != "" && errs[0].Message != "" {
@@ -11,10 +11,11 @@ func (he HookEvents) Get(evt HookEventType) bool {
 
 // HookEvent represents events that will delivery hook.
 type HookEvent struct {
-	PushOnly       bool   `json:"push_only"`
-	SendEverything bool   `json:"send_everything"`
-	ChooseEvents   bool   `json:"choose_events"`
-	BranchFilter   string `json:"branch_filter"`
+	PushOnly         bool   `json:"push_only"`
+	SendEverything   bool   `json:"send_everything"`
+	ChooseEvents     bool   `json:"choose_events"`
+	BranchFilter     string `json:"branch_filter"`
+	FilterExpression string `json:"filter_expression"`
 
 	HookEvents `json:"events"`
 }
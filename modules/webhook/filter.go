@@ -0,0 +1,326 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/modules/glob"
+)
+
+// MaxFilterExpressionLength is the maximum length of a webhook filter expression
+const MaxFilterExpressionLength = 4096
+
+// FilterContext contains the fields of an event a filter expression is evaluated on
+type FilterContext struct {
+	Event      string   // the type of the event, e.g. "issues" or "pull_request_label"
+	Action     string   // the action of the event, e.g. "opened", empty for events without action like push
+	Sender     string   // the login of the user who triggered the event
+	BaseBranch string   // the base branch of a pull request
+	Labels     []string // the names of the labels of an issue or a pull request
+	Paths      []string // the paths of the files changed by the commits of a push
+}
+
+type filterField struct {
+	list   bool
+	values func(c *FilterContext) []string
+}
+
+var filterFields = map[string]filterField{
+	"event":       {values: func(c *FilterContext) []string { return []string{c.Event} }},
+	"action":      {values: func(c *FilterContext) []string { return []string{c.Action} }},
+	"sender":      {values: func(c *FilterContext) []string { return []string{c.Sender} }},
+	"base_branch": {values: func(c *FilterContext) []string { return []string{c.BaseBranch} }},
+	"labels":      {list: true, values: func(c *FilterContext) []string { return c.Labels }},
+	"paths":       {list: true, values: func(c *FilterContext) []string { return c.Paths }},
+}
+
+// Filter is a parsed webhook filter expression.
+//
+// An expression combines comparisons with "&&", "||", "!" and parentheses, for example
+//
+//	event == "issues" && action in ["opened", "reopened"] && !(labels contains "wontfix")
+//
+// A comparison tests a field of the [FilterContext]: "event", "action", "sender" and "base_branch"
+// hold a single value and support "==", "!=", "in" and "matches", "labels" and "paths" hold a list of values
+// and support "contains", "in" and "matches", which are true if any of the values satisfies them.
+// "in" takes a list of strings, "matches" a glob pattern where "*" doesn't match "/" and "**" does.
+type Filter struct {
+	root filterNode
+}
+
+// Match reports whether the event passes the filter
+func (f *Filter) Match(c *FilterContext) bool {
+	return f.root.match(c)
+}
+
+type filterNode interface {
+	match(c *FilterContext) bool
+}
+
+type filterAnd struct{ left, right filterNode }
+
+func (n *filterAnd) match(c *FilterContext) bool { return n.left.match(c) && n.right.match(c) }
+
+type filterOr struct{ left, right filterNode }
+
+func (n *filterOr) match(c *FilterContext) bool { return n.left.match(c) || n.right.match(c) }
+
+type filterNot struct{ node filterNode }
+
+func (n *filterNot) match(c *FilterContext) bool { return !n.node.match(c) }
+
+type filterComparison struct {
+	field    filterField
+	operator string
+	operands []string
+	glob     glob.Glob
+}
+
+func (n *filterComparison) match(c *FilterContext) bool {
+	values := n.field.values(c)
+	switch n.operator {
+	case "==", "contains":
+		return slices.Contains(values, n.operands[0])
+	case "!=":
+		return !slices.Contains(values, n.operands[0])
+	case "in":
+		return slices.ContainsFunc(values, func(v string) bool { return slices.Contains(n.operands, v) })
+	case "matches":
+		return slices.ContainsFunc(values, n.glob.Match)
+	}
+	return false
+}
+
+type filterTokenKind int
+
+const (
+	filterTokenEOF filterTokenKind = iota
+	filterTokenIdent
+	filterTokenString
+	filterTokenSymbol
+)
+
+type filterToken struct {
+	kind filterTokenKind
+	text string
+	pos  int
+}
+
+func (t filterToken) String() string {
+	switch t.kind {
+	case filterTokenEOF:
+		return "end of expression"
+	case filterTokenString:
+		return fmt.Sprintf("string %q", t.text)
+	}
+	return fmt.Sprintf("%q", t.text)
+}
+
+func tokenizeFilter(expr string) ([]filterToken, error) {
+	var tokens []filterToken
+	for i := 0; i < len(expr); {
+		ch := expr[i]
+		switch {
+		case ch == ' ' || ch == '\t' || ch == '\n' || ch == '\r':
+			i++
+		case ch == '_' || ch >= 'a' && ch <= 'z' || ch >= 'A' && ch <= 'Z':
+			start := i
+			for i < len(expr) && (expr[i] == '_' || expr[i] >= 'a' && expr[i] <= 'z' || expr[i] >= 'A' && expr[i] <= 'Z' || expr[i] >= '0' && expr[i] <= '9') {
+				i++
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenIdent, text: expr[start:i], pos: start})
+		case ch == '"' || ch == '\'':
+			start := i
+			var sb strings.Builder
+			for i++; ; i++ {
+				if i >= len(expr) {
+					return nil, fmt.Errorf("unterminated string at position %d", start+1)
+				}
+				if expr[i] == ch {
+					i++
+					break
+				}
+				if expr[i] == '\\' && i+1 < len(expr) && (expr[i+1] == ch || expr[i+1] == '\\') {
+					i++
+				}
+				sb.WriteByte(expr[i])
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenString, text: sb.String(), pos: start})
+		default:
+			var symbol string
+			for _, s := range []string{"==", "!=", "&&", "||", "!", "(", ")", "[", "]", ","} {
+				if strings.HasPrefix(expr[i:], s) {
+					symbol = s
+					break
+				}
+			}
+			if symbol == "" {
+				return nil, fmt.Errorf("unexpected character %q at position %d", ch, i+1)
+			}
+			tokens = append(tokens, filterToken{kind: filterTokenSymbol, text: symbol, pos: i})
+			i += len(symbol)
+		}
+	}
+	return append(tokens, filterToken{kind: filterTokenEOF, pos: len(expr)}), nil
+}
+
+type filterParser struct {
+	tokens []filterToken
+	next   int
+}
+
+func (p *filterParser) peek() filterToken {
+	return p.tokens[p.next]
+}
+
+func (p *filterParser) consume() filterToken {
+	t := p.tokens[p.next]
+	if t.kind != filterTokenEOF {
+		p.next++
+	}
+	return t
+}
+
+func (p *filterParser) consumeSymbol(symbol string) bool {
+	if t := p.peek(); t.kind == filterTokenSymbol && t.text == symbol {
+		p.next++
+		return true
+	}
+	return false
+}
+
+func unexpectedFilterToken(t filterToken, expected string) error {
+	return fmt.Errorf("expected %s at position %d, found %s", expected, t.pos+1, t)
+}
+
+func (p *filterParser) parseOr() (filterNode, error) {
+	left, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+	for p.consumeSymbol("||") {
+		right, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterOr{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseAnd() (filterNode, error) {
+	left, err := p.parseUnary()
+	if err != nil {
+		return nil, err
+	}
+	for p.consumeSymbol("&&") {
+		right, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		left = &filterAnd{left: left, right: right}
+	}
+	return left, nil
+}
+
+func (p *filterParser) parseUnary() (filterNode, error) {
+	if p.consumeSymbol("!") {
+		node, err := p.parseUnary()
+		if err != nil {
+			return nil, err
+		}
+		return &filterNot{node: node}, nil
+	}
+	if p.consumeSymbol("(") {
+		node, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if !p.consumeSymbol(")") {
+			return nil, unexpectedFilterToken(p.peek(), `")"`)
+		}
+		return node, nil
+	}
+	return p.parseComparison()
+}
+
+func (p *filterParser) parseComparison() (filterNode, error) {
+	t := p.consume()
+	if t.kind != filterTokenIdent {
+		return nil, unexpectedFilterToken(t, "a field")
+	}
+	field, ok := filterFields[t.text]
+	if !ok {
+		return nil, fmt.Errorf("unknown field %q at position %d", t.text, t.pos+1)
+	}
+
+	op := p.consume()
+	var operators []string
+	if field.list {
+		operators = []string{"contains", "in", "matches"}
+	} else {
+		operators = []string{"==", "!=", "in", "matches"}
+	}
+	if op.kind != filterTokenIdent && op.kind != filterTokenSymbol || !slices.Contains(operators, op.text) {
+		return nil, unexpectedFilterToken(op, fmt.Sprintf("one of %s for field %q", strings.Join(operators, ", "), t.text))
+	}
+
+	n := &filterComparison{field: field, operator: op.text}
+	if op.text == "in" {
+		if !p.consumeSymbol("[") {
+			return nil, unexpectedFilterToken(p.peek(), `"["`)
+		}
+		for {
+			s := p.consume()
+			if s.kind != filterTokenString {
+				return nil, unexpectedFilterToken(s, "a string")
+			}
+			n.operands = append(n.operands, s.text)
+			if p.consumeSymbol("]") {
+				break
+			}
+			if !p.consumeSymbol(",") {
+				return nil, unexpectedFilterToken(p.peek(), `"," or "]"`)
+			}
+		}
+		return n, nil
+	}
+
+	s := p.consume()
+	if s.kind != filterTokenString {
+		return nil, unexpectedFilterToken(s, "a string")
+	}
+	n.operands = []string{s.text}
+	if op.text == "matches" {
+		g, err := glob.Compile(s.text, '/')
+		if err != nil {
+			return nil, fmt.Errorf("invalid glob pattern %q at position %d: %w", s.text, s.pos+1, err)
+		}
+		n.glob = g
+	}
+	return n, nil
+}
+
+// ParseFilter parses a webhook filter expression, see [Filter] for the syntax
+func ParseFilter(expr string) (*Filter, error) {
+	if len(expr) > MaxFilterExpressionLength {
+		return nil, fmt.Errorf("the expression is longer than %d characters", MaxFilterExpressionLength)
+	}
+	tokens, err := tokenizeFilter(expr)
+	if err != nil {
+		return nil, err
+	}
+	p := &filterParser{tokens: tokens}
+	root, err := p.parseOr()
+	if err != nil {
+		return nil, err
+	}
+	if t := p.peek(); t.kind != filterTokenEOF {
+		return nil, unexpectedFilterToken(t, `"&&", "||" or end of expression`)
+	}
+	return &Filter{root: root}, nil
+}
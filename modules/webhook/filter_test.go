@@ -0,0 +1,90 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterMatch(t *testing.T) {
+	issue := &FilterContext{
+		Event:  "issues",
+		Action: "opened",
+		Sender: "user2",
+		Labels: []string{"kind/bug", "deploy"},
+	}
+	pull := &FilterContext{
+		Event:      "pull_request",
+		Action:     "synchronized",
+		Sender:     "user5",
+		BaseBranch: "release/v1.2",
+	}
+	push := &FilterContext{
+		Event:  "push",
+		Sender: "user2",
+		Paths:  []string{"README.md", "docs/content/usage.md"},
+	}
+
+	cases := []struct {
+		expr    string
+		matches []*FilterContext
+	}{
+		{`event == "issues"`, []*FilterContext{issue}},
+		{`event != "issues"`, []*FilterContext{pull, push}},
+		{`action in ["opened", 'synchronized']`, []*FilterContext{issue, pull}},
+		{`sender == "user2" && event == "push"`, []*FilterContext{push}},
+		{`sender == "user5" || event == "push"`, []*FilterContext{pull, push}},
+		{`labels contains "deploy"`, []*FilterContext{issue}},
+		{`!(labels contains "deploy")`, []*FilterContext{pull, push}},
+		{`labels matches "kind/*"`, []*FilterContext{issue}},
+		{`labels in ["wontfix", "kind/bug"]`, []*FilterContext{issue}},
+		{`base_branch matches "release/*"`, []*FilterContext{pull}},
+		{`paths matches "docs/**"`, []*FilterContext{push}},
+		{`paths matches "*.md"`, []*FilterContext{push}},
+		{`paths matches "*.go"`, nil},
+		{`event == "issues" || event == "push" && sender == "user5"`, []*FilterContext{issue}},
+		{`(event == "issues" || event == "push") && sender == "user2"`, []*FilterContext{issue, push}},
+		{`!!(event == "push")`, []*FilterContext{push}},
+		{`sender == "us\"er"`, nil},
+	}
+	for _, c := range cases {
+		f, err := ParseFilter(c.expr)
+		require.NoError(t, err, c.expr)
+
+		var matches []*FilterContext
+		for _, ctx := range []*FilterContext{issue, pull, push} {
+			if f.Match(ctx) {
+				matches = append(matches, ctx)
+			}
+		}
+		assert.Equal(t, c.matches, matches, c.expr)
+	}
+}
+
+func TestParseFilterErrors(t *testing.T) {
+	cases := map[string]string{
+		``:                        `expected a field at position 1, found end of expression`,
+		`author == "a"`:           `unknown field "author" at position 1`,
+		`labels == "a"`:           `expected one of contains, in, matches for field "labels" at position 8, found "=="`,
+		`event contains "a"`:      `expected one of ==, !=, in, matches for field "event" at position 7, found "contains"`,
+		`event == issues`:         `expected a string at position 10, found "issues"`,
+		`event == "a" "b"`:        `expected "&&", "||" or end of expression at position 14, found string "b"`,
+		`(event == "a"`:           `expected ")" at position 14, found end of expression`,
+		`action in "a"`:           `expected "[" at position 11, found string "a"`,
+		`action in ["a" "b"]`:     `expected "," or "]" at position 16, found string "b"`,
+		`action in []`:            `expected a string at position 12, found "]"`,
+		`event == "a`:             `unterminated string at position 10`,
+		`event = "a"`:             `unexpected character '=' at position 7`,
+		`paths matches "[a"`:      `invalid glob pattern "[a" at position 15: `,
+		`event == "a" && || true`: `expected a field at position 17, found "||"`,
+	}
+	for expr, expected := range cases {
+		_, err := ParseFilter(expr)
+		require.Error(t, err, expr)
+		assert.Contains(t, err.Error(), expected, expr)
+	}
+}
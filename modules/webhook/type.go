@@ -117,17 +117,22 @@ type HookType = string
 
 // Types of webhooks
 const (
-	GITEA      HookType = "gitea"
-	GOGS       HookType = "gogs"
-	SLACK      HookType = "slack"
-	DISCORD    HookType = "discord"
-	DINGTALK   HookType = "dingtalk"
-	TELEGRAM   HookType = "telegram"
-	MSTEAMS    HookType = "msteams"
-	FEISHU     HookType = "feishu"
-	MATRIX     HookType = "matrix"
-	WECHATWORK HookType = "wechatwork"
-	PACKAGIST  HookType = "packagist"
+	GITEA       HookType = "gitea"
+	GOGS        HookType = "gogs"
+	SLACK       HookType = "slack"
+	DISCORD     HookType = "discord"
+	DINGTALK    HookType = "dingtalk"
+	TELEGRAM    HookType = "telegram"
+	MSTEAMS     HookType = "msteams"
+	FEISHU      HookType = "feishu"
+	MATRIX      HookType = "matrix"
+	WECHATWORK  HookType = "wechatwork"
+	PACKAGIST   HookType = "packagist"
+	CUSTOM      HookType = "custom"
+	CLOUDEVENTS HookType = "cloudevents"
+	MATTERMOST  HookType = "mattermost"
+	ROCKETCHAT  HookType = "rocketchat"
+	ZULIP       HookType = "zulip"
 )
 
 // HookStatus is the status of a web hook
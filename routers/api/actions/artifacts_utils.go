@@ -13,6 +13,7 @@ import (
 	"code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/util"
+	actions_service "code.gitea.io/gitea/services/actions"
 )
 
 const (
@@ -54,6 +55,34 @@ func validateRunIDV4(ctx *ArtifactContext, rawRunID string) (*actions.ActionTask
 	return task, runID, true
 }
 
+// validateReadableRunIDV4 is like validateRunIDV4, but it also accepts the upstream run which triggered the task's run by a "workflow_run" event
+func validateReadableRunIDV4(ctx *ArtifactContext, rawRunID string) (int64, bool) {
+	task := ctx.ActionTask
+	runID, err := strconv.ParseInt(rawRunID, 10, 64)
+	if err != nil {
+		log.Error("Error runID not match")
+		ctx.HTTPError(http.StatusBadRequest, "run-id does not match")
+		return 0, false
+	}
+	if err := task.Job.LoadRun(ctx); err != nil {
+		log.Error("Error runner api getting run: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error runner api getting run")
+		return 0, false
+	}
+	canRead, err := actions_service.CanReadRunArtifacts(ctx, task.Job.Run, runID)
+	if err != nil {
+		log.Error("Error checking run artifacts permission: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error checking run artifacts permission")
+		return 0, false
+	}
+	if !canRead {
+		log.Error("Error runID not match")
+		ctx.HTTPError(http.StatusBadRequest, "run-id does not match")
+		return 0, false
+	}
+	return runID, true
+}
+
 func validateArtifactHash(ctx *ArtifactContext, artifactName string) bool {
 	paramHash := ctx.PathParam("artifact_hash")
 	// use artifact name to create upload url
@@ -0,0 +1,500 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+// GitHub Actions Cache V2 API Simple Description
+//
+// The cache API is used by "actions/cache" (@actions/cache >= 4.0.0), the caches are scoped by the repository and the git ref of the run.
+// A run can restore the caches created by the same ref, the base branch of the pull request, and the default branch of the repository.
+//
+// 1. Save cache
+// 1.1. CreateCacheEntry reserves a cache entry, it fails if the key and version has been used in the same scope
+// Post: /twirp/github.actions.results.api.v1.CacheService/CreateCacheEntry
+// Request:
+// {
+//     "key": "Linux-node-4f3c8a",
+//     "version": "b6325614d5649338b87215d9536b3c0477729b8638994c74cdefacb020a2cad4"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "signedUploadUrl": "http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=mO7y35r4GyjN7fwg0DTv3-Fv1NDXD84KLEgLpoPOtDI=&expires=2024-01-23+21%3A48%3A37.20833956+%2B0100+CET&taskID=75&cacheID=3"
+// }
+// 1.2. Upload the archive to Blobstorage (unauthenticated request)
+// PUT: http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=...&expires=...&taskID=75&cacheID=3
+// Large archives are uploaded in blocks, and committed with a BlockList xml payload in the same way as the artifacts
+// PUT: http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=...&expires=...&taskID=75&cacheID=3&comp=block&blockid=blockId1
+// PUT: http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/UploadCache?sig=...&expires=...&taskID=75&cacheID=3&comp=blocklist
+// 1.3. FinalizeCacheEntryUpload
+// Post: /twirp/github.actions.results.api.v1.CacheService/FinalizeCacheEntryUpload
+// Request:
+// {
+//     "key": "Linux-node-4f3c8a",
+//     "version": "b6325614d5649338b87215d9536b3c0477729b8638994c74cdefacb020a2cad4",
+//     "sizeBytes": "2097"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "entryId": "3"
+// }
+// 2. Restore cache
+// 2.1. GetCacheEntryDownloadURL matches the key exactly, then the restore keys by prefix
+// Post: /twirp/github.actions.results.api.v1.CacheService/GetCacheEntryDownloadURL
+// Request:
+// {
+//     "key": "Linux-node-4f3c8a",
+//     "restoreKeys": ["Linux-node-"],
+//     "version": "b6325614d5649338b87215d9536b3c0477729b8638994c74cdefacb020a2cad4"
+// }
+// Response:
+// {
+//     "ok": true,
+//     "signedDownloadUrl": "http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/DownloadCache?sig=...&expires=...&taskID=76&cacheID=3",
+//     "matchedKey": "Linux-node-4f3c8a"
+// }
+// 2.2. Download the archive from Blobstorage (unauthenticated request)
+// GET: http://localhost:3000/twirp/github.actions.results.api.v1.CacheService/DownloadCache?sig=...&expires=...&taskID=76&cacheID=3
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	actions_service "code.gitea.io/gitea/services/actions"
+)
+
+const CacheRouteBase = "/twirp/github.actions.results.api.v1.CacheService"
+
+// maxCacheKeyLength is the max length of a cache key, it's the same as GitHub
+const maxCacheKeyLength = 512
+
+type cacheRoutes struct {
+	prefix string
+	fs     storage.ObjectStorage
+}
+
+func CacheRoutes(prefix string) *web.Router {
+	m := web.NewRouter()
+
+	r := cacheRoutes{
+		prefix: prefix,
+		fs:     storage.ActionsCache,
+	}
+
+	m.Group("", func() {
+		m.Post("CreateCacheEntry", r.createCacheEntry)
+		m.Post("FinalizeCacheEntryUpload", r.finalizeCacheEntryUpload)
+		m.Post("GetCacheEntryDownloadURL", r.getCacheEntryDownloadURL)
+	}, ArtifactContexter())
+	m.Group("", func() {
+		m.Put("UploadCache", r.uploadCache)
+		m.Get("DownloadCache", r.downloadCache)
+	}, ArtifactV4Contexter())
+
+	return m
+}
+
+func (r *cacheRoutes) buildSignature(endpoint, expires string, taskID, cacheID int64) []byte {
+	mac := hmac.New(sha256.New, setting.GetGeneralTokenSigningSecret())
+	mac.Write([]byte(endpoint))
+	mac.Write([]byte(expires))
+	_, _ = fmt.Fprint(mac, taskID)
+	_, _ = fmt.Fprint(mac, cacheID)
+	return mac.Sum(nil)
+}
+
+func (r *cacheRoutes) buildCacheURL(ctx *ArtifactContext, endpoint string, taskID, cacheID int64) string {
+	expires := time.Now().Add(60 * time.Minute).Format("2006-01-02 15:04:05.999999999 -0700 MST")
+	return strings.TrimSuffix(httplib.GuessCurrentAppURL(ctx), "/") + strings.TrimSuffix(r.prefix, "/") +
+		"/" + endpoint +
+		"?sig=" + base64.RawURLEncoding.EncodeToString(r.buildSignature(endpoint, expires, taskID, cacheID)) +
+		"&expires=" + url.QueryEscape(expires) +
+		"&taskID=" + strconv.FormatInt(taskID, 10) +
+		"&cacheID=" + strconv.FormatInt(cacheID, 10)
+}
+
+func (r *cacheRoutes) verifySignature(ctx *ArtifactContext, endpoint string) (*actions_model.ActionCache, bool) {
+	query := ctx.Req.URL.Query()
+	expires := query.Get("expires")
+	dsig, errSig := base64.RawURLEncoding.DecodeString(query.Get("sig"))
+	taskID, errTask := strconv.ParseInt(query.Get("taskID"), 10, 64)
+	cacheID, errCache := strconv.ParseInt(query.Get("cacheID"), 10, 64)
+	if err := errors.Join(errSig, errTask, errCache); err != nil {
+		log.Error("Error decoding signature values: %v", err)
+		ctx.HTTPError(http.StatusBadRequest, "Error decoding signature values")
+		return nil, false
+	}
+	if !hmac.Equal(dsig, r.buildSignature(endpoint, expires, taskID, cacheID)) {
+		log.Error("Error unauthorized")
+		ctx.HTTPError(http.StatusUnauthorized, "Error unauthorized")
+		return nil, false
+	}
+	t, err := time.Parse("2006-01-02 15:04:05.999999999 -0700 MST", expires)
+	if err != nil || t.Before(time.Now()) {
+		log.Error("Error link expired")
+		ctx.HTTPError(http.StatusUnauthorized, "Error link expired")
+		return nil, false
+	}
+	task, err := actions_model.GetTaskByID(ctx, taskID)
+	if err != nil {
+		log.Error("Error runner api getting task by ID: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error runner api getting task by ID")
+		return nil, false
+	}
+	if task.Status != actions_model.StatusRunning {
+		log.Error("Error runner api getting task: task is not running")
+		ctx.HTTPError(http.StatusInternalServerError, "Error runner api getting task: task is not running")
+		return nil, false
+	}
+	cache, err := actions_model.GetCacheByID(ctx, task.RepoID, cacheID)
+	if err != nil {
+		log.Error("Error cache not found: %v", err)
+		ctx.HTTPError(http.StatusNotFound, "Error cache not found")
+		return nil, false
+	}
+	return cache, true
+}
+
+// getCacheRefs returns the git ref to save caches to, and the git refs to restore caches from for the current task
+func (r *cacheRoutes) getCacheRefs(ctx *ArtifactContext) (string, []string, bool) {
+	if err := ctx.ActionTask.Job.LoadRun(ctx); err != nil {
+		log.Error("Error runner api getting run: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error runner api getting run")
+		return "", nil, false
+	}
+	ref, restoreRefs, err := actions_service.GetCacheRefs(ctx, ctx.ActionTask.Job.Run)
+	if err != nil {
+		log.Error("Error getting cache refs: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error getting cache refs")
+		return "", nil, false
+	}
+	return ref, restoreRefs, true
+}
+
+func (r *cacheRoutes) createCacheEntry(ctx *ArtifactContext) {
+	var req CreateCacheEntryRequest
+
+	if ok := parseProtobufBody(ctx, &req); !ok {
+		return
+	}
+	if req.Key == "" || len(req.Key) > maxCacheKeyLength || req.Version == "" {
+		log.Error("Error invalid cache key or version")
+		ctx.HTTPError(http.StatusBadRequest, "Error invalid cache key or version")
+		return
+	}
+
+	ref, _, ok := r.getCacheRefs(ctx)
+	if !ok {
+		return
+	}
+
+	cache, err := actions_model.CreateCache(ctx, ctx.ActionTask, ref, req.Key, req.Version)
+	if errors.Is(err, util.ErrAlreadyExist) {
+		// it's not an error of the workflow, "actions/cache" only prints a warning
+		sendProtobufBody(ctx, &CreateCacheEntryResponse{
+			Ok:      false,
+			Message: fmt.Sprintf("Cache entry with key %q already exists in %s", req.Key, ref),
+		})
+		return
+	} else if err != nil {
+		log.Error("Error create cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error create cache")
+		return
+	}
+
+	respData := CreateCacheEntryResponse{
+		Ok:              true,
+		SignedUploadUrl: r.buildCacheURL(ctx, "UploadCache", ctx.ActionTask.ID, cache.ID),
+	}
+	sendProtobufBody(ctx, &respData)
+}
+
+func (r *cacheRoutes) uploadCache(ctx *ArtifactContext) {
+	cache, ok := r.verifySignature(ctx, "UploadCache")
+	if !ok {
+		return
+	}
+	if cache.Complete {
+		log.Error("Error cache %d has been finalized", cache.ID)
+		ctx.HTTPError(http.StatusConflict, "Error cache has been finalized")
+		return
+	}
+
+	switch ctx.Req.URL.Query().Get("comp") {
+	case "":
+		// small archives are uploaded in a single request
+		if err := r.saveUpload(ctx, cache.StoragePath(), setting.Actions.CacheMaxSizePerRepo); err != nil {
+			if errors.Is(err, util.ErrContentTooLarge) {
+				ctx.HTTPError(http.StatusRequestEntityTooLarge, "Error cache size exceeds the limit of the repository")
+				return
+			}
+			log.Error("Error uploading cache: %v", err)
+			ctx.HTTPError(http.StatusInternalServerError, "Error uploading cache")
+			return
+		}
+	case "block":
+		blockID := ctx.Req.URL.Query().Get("blockid")
+		if blockID == "" {
+			ctx.HTTPError(http.StatusBadRequest, "Error missing block id")
+			return
+		}
+		limit, err := r.remainingBlocksSize(cache, blockID)
+		if err != nil {
+			log.Error("Error getting cache blocks size: %v", err)
+			ctx.HTTPError(http.StatusInternalServerError, "Error uploading cache block")
+			return
+		}
+		if err := r.saveUpload(ctx, r.blockPath(cache, blockID), limit); err != nil {
+			if errors.Is(err, util.ErrContentTooLarge) {
+				ctx.HTTPError(http.StatusRequestEntityTooLarge, "Error cache size exceeds the limit of the repository")
+				return
+			}
+			log.Error("Error uploading cache block: %v", err)
+			ctx.HTTPError(http.StatusInternalServerError, "Error uploading cache block")
+			return
+		}
+	case "blocklist":
+		var blockList BlockList
+		if err := xml.NewDecoder(ctx.Req.Body).Decode(&blockList); err != nil {
+			log.Error("Error decoding block list: %v", err)
+			ctx.HTTPError(http.StatusBadRequest, "Error decoding block list")
+			return
+		}
+		if err := r.mergeBlocks(cache, blockList.Latest); err != nil {
+			if errors.Is(err, util.ErrContentTooLarge) {
+				ctx.HTTPError(http.StatusRequestEntityTooLarge, "Error cache size exceeds the limit of the repository")
+				return
+			}
+			log.Error("Error merging cache blocks: %v", err)
+			ctx.HTTPError(http.StatusInternalServerError, "Error merging cache blocks")
+			return
+		}
+	default:
+		ctx.HTTPError(http.StatusBadRequest, "Error unsupported comp")
+		return
+	}
+	ctx.Status(http.StatusCreated)
+}
+
+// saveUpload saves the request body, the uploaded data is not allowed to exceed the limit, -1 means no limit.
+// The caches are only checked against the size limit of the repository when they are finalized,
+// so the uploads are limited too, otherwise a task could fill the storage before.
+func (r *cacheRoutes) saveUpload(ctx *ArtifactContext, path string, limit int64) error {
+	body := ctx.Req.Body
+	if limit >= 0 {
+		if ctx.Req.ContentLength > limit {
+			return util.ErrorWrap(util.ErrContentTooLarge, "cache exceeds limit %d", limit)
+		}
+		body = http.MaxBytesReader(ctx.Resp, body, limit)
+	}
+	_, err := r.fs.Save(path, body, ctx.Req.ContentLength)
+	var maxBytesError *http.MaxBytesError
+	if errors.As(err, &maxBytesError) {
+		if err := r.fs.Delete(path); err != nil {
+			log.Warn("Error deleting cache upload: %s, %v", path, err)
+		}
+		return util.ErrorWrap(util.ErrContentTooLarge, "cache exceeds limit %d", limit)
+	}
+	return err
+}
+
+// remainingBlocksSize returns the size which can still be uploaded as the block of the cache, -1 means no limit.
+// Uploading a block again replaces it, so the old size of the block is not counted.
+func (r *cacheRoutes) remainingBlocksSize(cache *actions_model.ActionCache, blockID string) (int64, error) {
+	if setting.Actions.CacheMaxSizePerRepo < 0 {
+		return -1, nil
+	}
+	blockPath := r.blockPath(cache, blockID)
+	remaining := setting.Actions.CacheMaxSizePerRepo
+	err := r.fs.IterateObjects(cache.UploadBlocksPath(), func(path string, obj storage.Object) error {
+		if path == blockPath {
+			return nil
+		}
+		fi, err := obj.Stat()
+		if err != nil {
+			return err
+		}
+		remaining -= fi.Size()
+		return nil
+	})
+	return max(remaining, 0), err
+}
+
+func (r *cacheRoutes) blockPath(cache *actions_model.ActionCache, blockID string) string {
+	return cache.UploadBlocksPath() + "/" + base64.URLEncoding.EncodeToString([]byte(blockID))
+}
+
+func (r *cacheRoutes) mergeBlocks(cache *actions_model.ActionCache, blockIDs []string) error {
+	if len(blockIDs) == 0 {
+		return errors.New("empty block list")
+	}
+	readers := make([]io.Reader, 0, len(blockIDs))
+	closeReaders := func() {
+		for _, rd := range readers {
+			_ = rd.(io.Closer).Close()
+		}
+		readers = nil
+	}
+	defer closeReaders()
+	for _, blockID := range blockIDs {
+		f, err := r.fs.Open(r.blockPath(cache, blockID))
+		if err != nil {
+			return fmt.Errorf("open block %q: %w", blockID, err)
+		}
+		readers = append(readers, f)
+	}
+	// a block can be listed several times, so the merged archive can still exceed the limit
+	var merged io.Reader = io.MultiReader(readers...)
+	if setting.Actions.CacheMaxSizePerRepo >= 0 {
+		merged = io.LimitReader(merged, setting.Actions.CacheMaxSizePerRepo+1)
+	}
+	size, err := r.fs.Save(cache.StoragePath(), merged, -1)
+	if err != nil {
+		return fmt.Errorf("save merged blocks: %w", err)
+	}
+	closeReaders()
+	if setting.Actions.CacheMaxSizePerRepo >= 0 && size > setting.Actions.CacheMaxSizePerRepo {
+		if err := r.fs.Delete(cache.StoragePath()); err != nil {
+			log.Warn("Error deleting cache: %s, %v", cache.StoragePath(), err)
+		}
+		return util.ErrorWrap(util.ErrContentTooLarge, "cache exceeds limit %d", setting.Actions.CacheMaxSizePerRepo)
+	}
+
+	// the blocks which are not in the list are dropped too
+	return r.fs.IterateObjects(cache.UploadBlocksPath(), func(path string, _ storage.Object) error {
+		if err := r.fs.Delete(path); err != nil {
+			log.Warn("Error deleting cache block: %s, %v", path, err)
+		}
+		return nil
+	})
+}
+
+func (r *cacheRoutes) finalizeCacheEntryUpload(ctx *ArtifactContext) {
+	var req FinalizeCacheEntryUploadRequest
+
+	if ok := parseProtobufBody(ctx, &req); !ok {
+		return
+	}
+
+	cache, err := actions_model.GetReservedCache(ctx, ctx.ActionTask.ID, req.Key, req.Version)
+	if err != nil {
+		log.Error("Error cache not found: %v", err)
+		ctx.HTTPError(http.StatusNotFound, "Error cache not found")
+		return
+	}
+
+	fi, err := r.fs.Stat(cache.StoragePath())
+	if err != nil {
+		log.Error("Error stat cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error stat cache")
+		return
+	}
+	if fi.Size() != req.SizeBytes {
+		log.Error("Error cache size mismatch: %d != %d", fi.Size(), req.SizeBytes)
+		ctx.HTTPError(http.StatusBadRequest, "Error cache size mismatch")
+		return
+	}
+	if setting.Actions.CacheMaxSizePerRepo >= 0 && fi.Size() > setting.Actions.CacheMaxSizePerRepo {
+		if err := actions_service.DeleteCache(ctx, cache); err != nil {
+			log.Error("Error delete cache: %v", err)
+		}
+		ctx.HTTPError(http.StatusRequestEntityTooLarge, "Error cache size exceeds the limit of the repository")
+		return
+	}
+
+	if err := actions_model.FinalizeCache(ctx, cache, fi.Size()); err != nil {
+		log.Error("Error finalize cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error finalize cache")
+		return
+	}
+
+	respData := FinalizeCacheEntryUploadResponse{
+		Ok:      true,
+		EntryId: cache.ID,
+	}
+	sendProtobufBody(ctx, &respData)
+}
+
+func (r *cacheRoutes) getCacheEntryDownloadURL(ctx *ArtifactContext) {
+	var req GetCacheEntryDownloadURLRequest
+
+	if ok := parseProtobufBody(ctx, &req); !ok {
+		return
+	}
+
+	_, restoreRefs, ok := r.getCacheRefs(ctx)
+	if !ok {
+		return
+	}
+
+	cache, err := actions_model.FindCacheToRestore(ctx, ctx.ActionTask.RepoID, restoreRefs, req.Version, req.Key, req.RestoreKeys)
+	if errors.Is(err, util.ErrNotExist) {
+		// a cache miss is not an error for "actions/cache"
+		sendProtobufBody(ctx, &GetCacheEntryDownloadURLResponse{Ok: false})
+		return
+	} else if err != nil {
+		log.Error("Error find cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error find cache")
+		return
+	}
+
+	if err := actions_model.UpdateCacheLastUsed(ctx, cache); err != nil {
+		log.Error("Error update cache last used time: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error update cache last used time")
+		return
+	}
+
+	respData := GetCacheEntryDownloadURLResponse{
+		Ok:         true,
+		MatchedKey: cache.Key,
+	}
+	if setting.Actions.CacheStorage.ServeDirect() {
+		u, err := r.fs.ServeDirectURL(cache.StoragePath(), "cache", http.MethodGet, nil)
+		if err == nil {
+			respData.SignedDownloadUrl = u.String()
+		}
+	}
+	if respData.SignedDownloadUrl == "" {
+		respData.SignedDownloadUrl = r.buildCacheURL(ctx, "DownloadCache", ctx.ActionTask.ID, cache.ID)
+	}
+	sendProtobufBody(ctx, &respData)
+}
+
+func (r *cacheRoutes) downloadCache(ctx *ArtifactContext) {
+	cache, ok := r.verifySignature(ctx, "DownloadCache")
+	if !ok {
+		return
+	}
+	if !cache.Complete {
+		log.Error("Error cache %d has not been finalized", cache.ID)
+		ctx.HTTPError(http.StatusNotFound, "Error cache not found")
+		return
+	}
+
+	f, err := r.fs.Open(cache.StoragePath())
+	if err != nil {
+		log.Error("Error open cache: %v", err)
+		ctx.HTTPError(http.StatusInternalServerError, "Error open cache")
+		return
+	}
+	defer f.Close()
+	httplib.ServeUserContentByFile(ctx.Req, ctx.Resp, f, httplib.ServeHeaderOptions{Filename: "cache"})
+}
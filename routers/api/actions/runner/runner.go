@@ -276,6 +276,10 @@ func (s *Service) UpdateLog(
 	if err != nil {
 		return nil, status.Errorf(codes.Internal, "unable to append logs to dbfs file: %v", err)
 	}
+	if err := actions_service.CreateTaskAnnotationsFromLogs(ctx, task, rows); err != nil {
+		// annotations are auxiliary, don't fail the log uploading because of them
+		log.Error("CreateTaskAnnotationsFromLogs: %v", err)
+	}
 	task.LogLength += int64(len(rows))
 	for _, n := range ns {
 		task.LogIndexes = append(task.LogIndexes, task.LogSize)
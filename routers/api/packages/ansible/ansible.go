@@ -0,0 +1,355 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	ansible_module "code.gitea.io/gitea/modules/packages/ansible"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+const tarballExtension = ".tar.gz"
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	type Error struct {
+		Status string `json:"status"`
+		Detail string `json:"detail"`
+	}
+	ctx.JSON(status, map[string]any{
+		"errors": []Error{
+			{
+				Status: strconv.Itoa(status),
+				Detail: message,
+			},
+		},
+	})
+}
+
+func baseURL(ctx *context.Context) string {
+	return setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/ansible"
+}
+
+// splitPackageName splits the fully qualified collection name into the namespace and the name
+func splitPackageName(fullName string) (string, string) {
+	namespace, name, _ := strings.Cut(fullName, ".")
+	return namespace, name
+}
+
+// getCollectionDescriptors returns the versions of the collection ordered from the newest to the oldest
+func getCollectionDescriptors(ctx *context.Context, namespace, name string) ([]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, namespace+"."+name)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.GreaterThan(pds[j].SemVer)
+	})
+	return pds, nil
+}
+
+// APIRoot lists the supported API versions. The client requests it to discover the server.
+func APIRoot(ctx *context.Context) {
+	ctx.JSON(http.StatusOK, &apiRoot{
+		Description:       "GALAXY REST API",
+		CurrentVersion:    "v3",
+		AvailableVersions: map[string]string{"v3": "v3/"},
+	})
+}
+
+// CollectionInfo returns the collection and its highest version
+func CollectionInfo(ctx *context.Context) {
+	namespace, name := ctx.PathParam("namespace"), ctx.PathParam("name")
+
+	pds, err := getCollectionDescriptors(ctx, namespace, name)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	apiURL := baseURL(ctx) + "/api"
+	href := collectionURL(apiURL, namespace, name)
+
+	ctx.JSON(http.StatusOK, &collectionInfo{
+		Href:           href,
+		Namespace:      namespace,
+		Name:           name,
+		VersionsURL:    href + "versions/",
+		HighestVersion: createVersionRef(apiURL, pds[0]),
+		CreatedAt:      pds[len(pds)-1].Version.CreatedUnix.AsLocalTime(),
+		UpdatedAt:      pds[0].Version.CreatedUnix.AsLocalTime(),
+	})
+}
+
+// EnumeratePackageVersions lists all versions of the collection. The list is never paginated.
+func EnumeratePackageVersions(ctx *context.Context) {
+	namespace, name := ctx.PathParam("namespace"), ctx.PathParam("name")
+
+	pds, err := getCollectionDescriptors(ctx, namespace, name)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	apiURL := baseURL(ctx) + "/api"
+
+	resp := &versionList{
+		Data: make([]*versionRef, 0, len(pds)),
+	}
+	resp.Meta.Count = len(pds)
+	resp.Links.First = collectionURL(apiURL, namespace, name) + "versions/"
+	resp.Links.Last = resp.Links.First
+	for _, pd := range pds {
+		resp.Data = append(resp.Data, createVersionRef(apiURL, pd))
+	}
+
+	ctx.JSON(http.StatusOK, resp)
+}
+
+// PackageVersionMetadata returns the details of a collection version including the download url
+func PackageVersionMetadata(ctx *context.Context) {
+	namespace, name := ctx.PathParam("namespace"), ctx.PathParam("name")
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeAnsible, namespace+"."+name, ctx.PathParam("version"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createVersionDetails(baseURL(ctx), pd))
+}
+
+// DownloadPackageFile serves the collection tarball
+func DownloadPackageFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+
+	// namespace and name can't contain a hyphen but the version can
+	parts := strings.SplitN(strings.TrimSuffix(filename, tarballExtension), "-", 3)
+	if len(parts) != 3 || !strings.HasSuffix(filename, tarballExtension) {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeAnsible,
+			Name:        parts[0] + "." + parts[1],
+			Version:     parts[2],
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// readUpload reads the collection tarball and the optional checksum from the request.
+// ansible-galaxy sends the file part with a "file" content disposition which the standard form parser ignores.
+func readUpload(ctx *context.Context) (*packages_module.HashedBuffer, string, error) {
+	mr, err := ctx.Req.MultipartReader()
+	if err != nil {
+		if errors.Is(err, http.ErrNotMultipart) {
+			buf, err := packages_module.CreateHashedBufferFromReader(ctx.Req.Body)
+			return buf, "", err
+		}
+		return nil, "", util.NewInvalidArgumentErrorf("invalid multipart request: %v", err)
+	}
+
+	var buf *packages_module.HashedBuffer
+	var checksum string
+	for {
+		part, err := mr.NextPart()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			if buf != nil {
+				buf.Close()
+			}
+			return nil, "", util.NewInvalidArgumentErrorf("invalid multipart request: %v", err)
+		}
+
+		if part.FileName() != "" && buf == nil {
+			buf, err = packages_module.CreateHashedBufferFromReader(part)
+			if err != nil {
+				return nil, "", err
+			}
+		} else if part.FormName() == "sha256" {
+			data, err := util.ReadWithLimit(part, 128)
+			if err != nil {
+				if buf != nil {
+					buf.Close()
+				}
+				return nil, "", err
+			}
+			checksum = strings.TrimSpace(string(data))
+		}
+		part.Close()
+	}
+
+	if buf == nil {
+		return nil, "", util.NewInvalidArgumentErrorf("collection file is missing")
+	}
+	return buf, checksum, nil
+}
+
+// UploadPackage publishes a collection. The import is finished when the request completes.
+func UploadPackage(ctx *context.Context) {
+	buf, checksum, err := readUpload(ctx)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	defer buf.Close()
+
+	if checksum != "" {
+		_, _, sha256, _ := buf.Sums()
+		if !strings.EqualFold(checksum, hex.EncodeToString(sha256)) {
+			apiError(ctx, http.StatusBadRequest, "the checksum does not match the uploaded file")
+			return
+		}
+	}
+
+	pck, err := ansible_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, io.ErrUnexpectedEOF) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeAnsible,
+				Name:        pck.FullName(),
+				Version:     pck.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: fmt.Sprintf("%s-%s-%s%s", pck.Namespace, pck.Name, pck.Version, tarballExtension),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusAccepted, map[string]string{
+		"task": fmt.Sprintf("%s/api/v3/imports/collections/%d/", baseURL(ctx), pv.ID),
+	})
+}
+
+// ImportTask returns the state of an import. Imports are processed synchronously so they are always completed.
+func ImportTask(ctx *context.Context) {
+	pv, err := packages_model.GetVersionByID(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if p.OwnerID != ctx.Package.Owner.ID || p.Type != packages_model.TypeAnsible {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &importTask{
+		ID:         pv.ID,
+		State:      "completed",
+		CreatedAt:  pv.CreatedUnix.AsLocalTime(),
+		FinishedAt: pv.CreatedUnix.AsLocalTime(),
+		Messages:   []any{},
+	})
+}
@@ -0,0 +1,147 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package ansible
+
+import (
+	"fmt"
+	"net/url"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	ansible_module "code.gitea.io/gitea/modules/packages/ansible"
+)
+
+// https://github.com/ansible/ansible/blob/devel/lib/ansible/galaxy/api.py
+type apiRoot struct {
+	Description       string            `json:"description"`
+	CurrentVersion    string            `json:"current_version"`
+	AvailableVersions map[string]string `json:"available_versions"`
+}
+
+type importTask struct {
+	ID         int64     `json:"id"`
+	State      string    `json:"state"`
+	CreatedAt  time.Time `json:"created_at"`
+	FinishedAt time.Time `json:"finished_at"`
+	Messages   []any     `json:"messages"`
+}
+
+type collectionRef struct {
+	Name string `json:"name"`
+}
+
+type versionRef struct {
+	Version   string    `json:"version"`
+	Href      string    `json:"href"`
+	CreatedAt time.Time `json:"created_at"`
+}
+
+type collectionInfo struct {
+	Href           string      `json:"href"`
+	Namespace      string      `json:"namespace"`
+	Name           string      `json:"name"`
+	VersionsURL    string      `json:"versions_url"`
+	HighestVersion *versionRef `json:"highest_version"`
+	CreatedAt      time.Time   `json:"created_at"`
+	UpdatedAt      time.Time   `json:"updated_at"`
+}
+
+type versionList struct {
+	Meta struct {
+		Count int `json:"count"`
+	} `json:"meta"`
+	Links struct {
+		First    string  `json:"first"`
+		Previous *string `json:"previous"`
+		Next     *string `json:"next"`
+		Last     string  `json:"last"`
+	} `json:"links"`
+	Data []*versionRef `json:"data"`
+}
+
+type artifact struct {
+	Filename string `json:"filename"`
+	Sha256   string `json:"sha256"`
+	Size     int64  `json:"size"`
+}
+
+type versionMetadata struct {
+	Authors       []string          `json:"authors"`
+	Description   string            `json:"description"`
+	License       []string          `json:"license"`
+	Tags          []string          `json:"tags"`
+	Dependencies  map[string]string `json:"dependencies"`
+	Homepage      string            `json:"homepage"`
+	Repository    string            `json:"repository"`
+	Documentation string            `json:"documentation"`
+	Issues        string            `json:"issues"`
+}
+
+type versionDetails struct {
+	Href        string          `json:"href"`
+	Namespace   collectionRef   `json:"namespace"`
+	Collection  collectionRef   `json:"collection"`
+	Version     string          `json:"version"`
+	DownloadURL string          `json:"download_url"`
+	Artifact    artifact        `json:"artifact"`
+	Metadata    versionMetadata `json:"metadata"`
+	Signatures  []any           `json:"signatures"`
+	CreatedAt   time.Time       `json:"created_at"`
+}
+
+func collectionURL(apiURL, namespace, name string) string {
+	return fmt.Sprintf("%s/v3/collections/%s/%s/", apiURL, url.PathEscape(namespace), url.PathEscape(name))
+}
+
+func createVersionRef(apiURL string, pd *packages_model.PackageDescriptor) *versionRef {
+	namespace, name := splitPackageName(pd.Package.Name)
+	return &versionRef{
+		Version:   pd.Version.Version,
+		Href:      collectionURL(apiURL, namespace, name) + "versions/" + url.PathEscape(pd.Version.Version) + "/",
+		CreatedAt: pd.Version.CreatedUnix.AsLocalTime(),
+	}
+}
+
+func createVersionDetails(baseURL string, pd *packages_model.PackageDescriptor) *versionDetails {
+	metadata := pd.Metadata.(*ansible_module.Metadata)
+	namespace, name := splitPackageName(pd.Package.Name)
+
+	var a artifact
+	for _, pfd := range pd.Files {
+		if pfd.File.IsLead {
+			a = artifact{
+				Filename: pfd.File.Name,
+				Sha256:   pfd.Blob.HashSHA256,
+				Size:     pfd.Blob.Size,
+			}
+		}
+	}
+
+	dependencies := metadata.Dependencies
+	if dependencies == nil {
+		dependencies = map[string]string{}
+	}
+
+	return &versionDetails{
+		Href:        createVersionRef(baseURL+"/api", pd).Href,
+		Namespace:   collectionRef{Name: namespace},
+		Collection:  collectionRef{Name: name},
+		Version:     pd.Version.Version,
+		DownloadURL: baseURL + "/download/" + url.PathEscape(a.Filename),
+		Artifact:    a,
+		Metadata: versionMetadata{
+			Authors:       metadata.Authors,
+			Description:   metadata.Description,
+			License:       metadata.Licenses,
+			Tags:          metadata.Tags,
+			Dependencies:  dependencies,
+			Homepage:      metadata.ProjectURL,
+			Repository:    metadata.RepositoryURL,
+			Documentation: metadata.DocumentationURL,
+			Issues:        metadata.IssuesURL,
+		},
+		Signatures: []any{},
+		CreatedAt:  pd.Version.CreatedUnix.AsLocalTime(),
+	}
+}
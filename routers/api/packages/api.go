@@ -12,6 +12,7 @@ import (
 	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/web"
 	"code.gitea.io/gitea/routers/api/packages/alpine"
+	"code.gitea.io/gitea/routers/api/packages/ansible"
 	"code.gitea.io/gitea/routers/api/packages/arch"
 	"code.gitea.io/gitea/routers/api/packages/cargo"
 	"code.gitea.io/gitea/routers/api/packages/chef"
@@ -24,7 +25,9 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/generic"
 	"code.gitea.io/gitea/routers/api/packages/goproxy"
 	"code.gitea.io/gitea/routers/api/packages/helm"
+	"code.gitea.io/gitea/routers/api/packages/hex"
 	"code.gitea.io/gitea/routers/api/packages/maven"
+	"code.gitea.io/gitea/routers/api/packages/nix"
 	"code.gitea.io/gitea/routers/api/packages/npm"
 	"code.gitea.io/gitea/routers/api/packages/nuget"
 	"code.gitea.io/gitea/routers/api/packages/pub"
@@ -34,6 +37,7 @@ import (
 	"code.gitea.io/gitea/routers/api/packages/swift"
 	"code.gitea.io/gitea/routers/api/packages/terraform"
 	"code.gitea.io/gitea/routers/api/packages/vagrant"
+	"code.gitea.io/gitea/routers/api/packages/vsix"
 	"code.gitea.io/gitea/services/auth"
 	"code.gitea.io/gitea/services/context"
 )
@@ -141,6 +145,19 @@ func CommonRoutes() *web.Router {
 				})
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/ansible", func() {
+			r.Get("/api", ansible.APIRoot)
+			r.Group("/api/v3", func() {
+				r.Post("/artifacts/collections", reqPackageAccess(perm.AccessModeWrite), ansible.UploadPackage)
+				r.Get("/imports/collections/{id}", ansible.ImportTask)
+				r.Group("/collections/{namespace}/{name}", func() {
+					r.Get("", ansible.CollectionInfo)
+					r.Get("/versions", ansible.EnumeratePackageVersions)
+					r.Get("/versions/{version}", ansible.PackageVersionMetadata)
+				})
+			})
+			r.Get("/download/{filename}", ansible.DownloadPackageFile)
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/arch", func() {
 			r.Methods("HEAD,GET", "/repository.key", arch.GetRepositoryKey)
 			r.Methods("PUT", "" /* no repository */, reqPackageAccess(perm.AccessModeWrite), arch.UploadPackageFile)
@@ -358,6 +375,14 @@ func CommonRoutes() *web.Router {
 			r.Get("/{filename}", helm.DownloadPackageFile)
 			r.Post("/api/charts", reqPackageAccess(perm.AccessModeWrite), helm.UploadPackage)
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/hex", func() {
+			r.Get("/public_key", hex.GetPublicKey)
+			r.Get("/names", hex.EnumeratePackageNames)
+			r.Get("/versions", hex.EnumeratePackageVersions)
+			r.Get("/packages/{name}", hex.PackageMetadata)
+			r.Get("/tarballs/{filename}", hex.DownloadPackageFile)
+			r.Post("/api/publish", reqPackageAccess(perm.AccessModeWrite), hex.UploadPackage)
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/maven", func() {
 			r.Put("/*", reqPackageAccess(perm.AccessModeWrite), maven.UploadPackageFile)
 			r.Get("/*", maven.DownloadPackageFile)
@@ -400,6 +425,18 @@ func CommonRoutes() *web.Router {
 				})
 			}, reqPackageAccess(perm.AccessModeRead))
 		})
+		r.Group("/nix", func() {
+			r.Get("/nix-cache-info", nix.GetCacheInfo)
+			r.Get("/public_key", nix.GetPublicKey)
+			r.Group("/nar/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.DownloadNar)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNar)
+			})
+			r.Group("/{filename}", func() {
+				r.Methods("HEAD,GET", "", nix.GetNarInfo)
+				r.Put("", reqPackageAccess(perm.AccessModeWrite), nix.UploadNarInfo)
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 		r.Group("/npm", func() {
 			r.Group("/@{scope}/{id}", func() {
 				r.Get("", npm.PackageMetadata)
@@ -544,6 +581,27 @@ func CommonRoutes() *web.Router {
 				})
 			})
 		}, reqPackageAccess(perm.AccessModeRead))
+		r.Group("/vsix", func() {
+			r.Group("/api", func() {
+				r.Get("/-/query", vsix.Query)
+				r.Get("/-/search", vsix.Search)
+				r.Post("/-/publish", reqPackageAccess(perm.AccessModeWrite), vsix.UploadPackage)
+				r.Get("/{namespace}", vsix.GetNamespace)
+				r.Group("/{namespace}/{extension}", func() {
+					r.Get("", vsix.GetExtension)
+					r.Group("/{version}", func() {
+						r.Get("", vsix.GetExtensionVersion)
+						r.Get("/file/{filename}", vsix.DownloadFile)
+					})
+				})
+			})
+			r.Group("/vscode", func() {
+				r.Post("/gallery/extensionquery", vsix.GalleryQuery)
+				r.Get("/gallery/publishers/{namespace}/vsextensions/{extension}/{version}/vspackage", vsix.GalleryPackage)
+				r.Get("/asset/{namespace}/{extension}/{version}/{assettype}", vsix.GalleryAsset)
+				r.Get("/item", vsix.GalleryItem)
+			})
+		}, reqPackageAccess(perm.AccessModeRead))
 	}, context.UserAssignmentWeb(), context.PackageAssignment())
 
 	return r
@@ -570,8 +628,6 @@ func ContainerRoutes() *web.Router {
 		},
 	})
 
-	// TODO: Content Discovery / References (not implemented yet)
-
 	r.Get("", container.ReqContainerAccess, container.DetermineSupport)
 	r.Group("/token", func() {
 		r.Get("", container.Authenticate)
@@ -597,6 +653,8 @@ func ContainerRoutes() *web.Router {
 			g.MatchPath("GET", `/<image:*>/manifests/<reference>`, container.VerifyImageName, container.GetManifest)
 			g.MatchPath("PUT", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.PutManifest)
 			g.MatchPath("DELETE", `/<image:*>/manifests/<reference>`, container.VerifyImageName, reqPackageAccess(perm.AccessModeWrite), container.DeleteManifest)
+
+			g.MatchPath("GET", `/<image:*>/referrers/<digest>`, container.VerifyImageName, container.GetReferrers)
 		})
 	}, container.ReqContainerAccess, context.UserAssignmentWeb(), context.PackageAssignment(), reqPackageAccess(perm.AccessModeRead))
 
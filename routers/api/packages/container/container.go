@@ -35,6 +35,8 @@ import (
 	container_service "code.gitea.io/gitea/services/packages/container"
 
 	"github.com/opencontainers/go-digest"
+	specs "github.com/opencontainers/image-spec/specs-go"
+	oci "github.com/opencontainers/image-spec/specs-go/v1"
 )
 
 // maximum size of a container manifest
@@ -603,6 +605,11 @@ func PutManifest(ctx *context.Context) {
 		return
 	}
 
+	// the registry supports the referrers api, so the client doesn't need to maintain a referrers tag schema
+	if mci.Subject != "" {
+		ctx.Resp.Header().Set("OCI-Subject", mci.Subject)
+	}
+
 	setResponseHeaders(ctx.Resp, &containerHeaders{
 		Location:      fmt.Sprintf("/v2/%s/%s/manifests/%s", ctx.Package.Owner.LowerName, mci.Image, reference),
 		ContentDigest: digest,
@@ -782,6 +789,52 @@ func GetTagsList(ctx *context.Context) {
 	})
 }
 
+// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+func GetReferrers(ctx *context.Context) {
+	d := digest.Digest(ctx.PathParam("digest"))
+	if d.Validate() != nil {
+		apiErrorDefined(ctx, errDigestInvalid)
+		return
+	}
+
+	referrers, err := container_service.GetReferrers(ctx, ctx.Package.Owner.ID, ctx.PathParam("image"), string(d))
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	artifactType := ctx.FormTrim("artifactType")
+
+	index := oci.Index{
+		Versioned: specs.Versioned{SchemaVersion: 2},
+		MediaType: oci.MediaTypeImageIndex,
+		Manifests: make([]oci.Descriptor, 0, len(referrers)),
+	}
+	for _, referrer := range referrers {
+		if artifactType != "" && referrer.ArtifactType != artifactType {
+			continue
+		}
+
+		index.Manifests = append(index.Manifests, oci.Descriptor{
+			MediaType:    referrer.MediaType,
+			Digest:       digest.Digest(referrer.Digest),
+			Size:         referrer.Size,
+			ArtifactType: referrer.ArtifactType,
+			Annotations:  referrer.Annotations,
+		})
+	}
+
+	if artifactType != "" {
+		ctx.Resp.Header().Set("OCI-Filters-Applied", "artifactType")
+	}
+
+	setResponseHeaders(ctx.Resp, &containerHeaders{
+		Status:      http.StatusOK,
+		ContentType: oci.MediaTypeImageIndex,
+	})
+	_ = json.NewEncoder(ctx.Resp).Encode(index) // ignore network errors
+}
+
 // FIXME: Workaround to be removed in v1.20.
 // Update maybe we should never really remote it, as long as there is legacy data?
 // https://github.com/go-gitea/gitea/issues/19586
@@ -37,6 +37,7 @@ type manifestCreationInfo struct {
 	Image      string
 	Reference  string
 	IsTagged   bool
+	Subject    string
 	Properties map[string]string
 }
 
@@ -52,6 +53,14 @@ func processManifest(ctx context.Context, mci *manifestCreationInfo, buf *packag
 		return "", err
 	}
 
+	// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#pushing-manifests-with-subject
+	if index.Subject != nil {
+		if index.Subject.Digest.Validate() != nil {
+			return "", errManifestInvalid.WithMessage("Subject digest is invalid")
+		}
+		mci.Subject = string(index.Subject.Digest)
+	}
+
 	if !container_module.IsMediaTypeValid(mci.MediaType) {
 		mci.MediaType = index.MediaType
 		if !container_module.IsMediaTypeValid(mci.MediaType) {
@@ -108,6 +117,14 @@ func processOciImageManifest(ctx context.Context, mci *manifestCreationInfo, buf
 		return "", err
 	}
 
+	metadata.Subject = mci.Subject
+	metadata.Annotations = manifest.Annotations
+	metadata.ArtifactType = manifest.ArtifactType
+	if metadata.ArtifactType == "" && mci.Subject != "" {
+		// https://github.com/opencontainers/distribution-spec/blob/main/spec.md#listing-referrers
+		metadata.ArtifactType = manifest.Config.MediaType
+	}
+
 	contentStore := packages_module.NewContentStore()
 	var txRet processManifestTxRet
 	err = db.WithTx(ctx, func(ctx context.Context) (err error) {
@@ -173,8 +190,11 @@ func processOciImageIndex(ctx context.Context, mci *manifestCreationInfo, buf *p
 	var txRet processManifestTxRet
 	err := db.WithTx(ctx, func(ctx context.Context) (err error) {
 		metadata := &container_module.Metadata{
-			Type:      container_module.TypeOCI,
-			Manifests: make([]*container_module.Manifest, 0, len(index.Manifests)),
+			Type:         container_module.TypeOCI,
+			Manifests:    make([]*container_module.Manifest, 0, len(index.Manifests)),
+			Subject:      mci.Subject,
+			ArtifactType: index.ArtifactType,
+			Annotations:  index.Annotations,
 		}
 
 		for _, manifest := range index.Manifests {
@@ -318,6 +338,16 @@ func createPackageAndVersion(ctx context.Context, mci *manifestCreationInfo, met
 		}
 	}
 
+	if mci.Subject != "" {
+		if err = packages_model.InsertOrUpdateProperty(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject, mci.Subject); err != nil {
+			return nil, fmt.Errorf("InsertOrUpdateProperty(ManifestSubject): %w", err)
+		}
+	} else {
+		if err = packages_model.DeletePropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject); err != nil {
+			return nil, fmt.Errorf("DeletePropertiesByName(ManifestSubject): %w", err)
+		}
+	}
+
 	return pv, nil
 }
 
@@ -12,6 +12,8 @@ import (
 	"time"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	goproxy_module "code.gitea.io/gitea/modules/packages/goproxy"
@@ -32,7 +34,14 @@ func EnumeratePackageVersions(ctx *context.Context) {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
+
+	r, err := getRemote(ctx)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if len(pvs) == 0 && r == nil {
 		apiError(ctx, http.StatusNotFound, err)
 		return
 	}
@@ -41,10 +50,35 @@ func EnumeratePackageVersions(ctx *context.Context) {
 		return pvs[i].CreatedUnix < pvs[j].CreatedUnix
 	})
 
+	versions := make([]string, 0, len(pvs))
+	for _, pv := range pvs {
+		versions = append(versions, pv.Version)
+	}
+
+	if r != nil {
+		upstream, err := fetchRemoteVersions(ctx, r, ctx.PathParam("name"))
+		if err != nil {
+			if len(pvs) == 0 {
+				serveRemoteError(ctx, err)
+				return
+			}
+			if !errors.Is(err, util.ErrNotExist) {
+				log.Warn("Unable to fetch the upstream versions of Go module %s: %v", ctx.PathParam("name"), err)
+			}
+		}
+		// the cached versions are listed by the upstream proxy too
+		known := container.SetOf(versions...)
+		for _, v := range upstream {
+			if known.Add(v) {
+				versions = append(versions, v)
+			}
+		}
+	}
+
 	ctx.Resp.Header().Set("Content-Type", "text/plain;charset=utf-8")
 
-	for _, pv := range pvs {
-		fmt.Fprintln(ctx.Resp, pv.Version)
+	for _, v := range versions {
+		fmt.Fprintln(ctx.Resp, v)
 	}
 }
 
@@ -52,6 +86,17 @@ func PackageVersionMetadata(ctx *context.Context) {
 	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
+			if r, err := getRemote(ctx); err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			} else if r != nil {
+				p := ctx.PathParam("name") + "/@latest"
+				if v := ctx.PathParam("version"); v != "latest" {
+					p = ctx.PathParam("name") + "/@v/" + v + ".info"
+				}
+				serveRemoteMetadata(ctx, r, p, "application/json")
+				return
+			}
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -72,6 +117,13 @@ func PackageVersionGoModContent(ctx *context.Context) {
 	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
+			if r, err := getRemote(ctx); err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			} else if r != nil {
+				serveRemoteMetadata(ctx, r, ctx.PathParam("name")+"/@v/"+ctx.PathParam("version")+".mod", "text/plain;charset=utf-8")
+				return
+			}
 			apiError(ctx, http.StatusNotFound, err)
 		} else {
 			apiError(ctx, http.StatusInternalServerError, err)
@@ -89,23 +141,32 @@ func PackageVersionGoModContent(ctx *context.Context) {
 }
 
 func DownloadPackageFile(ctx *context.Context) {
+	var pf *packages_model.PackageFile
+
 	pv, err := resolvePackage(ctx, ctx.Package.Owner.ID, ctx.PathParam("name"), ctx.PathParam("version"))
-	if err != nil {
-		if errors.Is(err, util.ErrNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
+	if errors.Is(err, util.ErrNotExist) {
+		var r *packages_model.PackageRemote
+		if r, err = getRemote(ctx); err == nil && r != nil {
+			pf, err = cacheRemotePackageFile(ctx, r, ctx.PathParam("name"), ctx.PathParam("version"))
+		} else if err == nil {
+			err = packages_model.ErrPackageNotExist
+		}
+	} else if err == nil {
+		var pfs []*packages_model.PackageFile
+		pfs, err = packages_model.GetFilesByVersionID(ctx, pv.ID)
+		if err == nil && len(pfs) != 1 {
+			err = fmt.Errorf("package version %d has %d files", pv.ID, len(pfs))
+		}
+		if err == nil {
+			pf = pfs[0]
 		}
-		return
 	}
-
-	pfs, err := packages_model.GetFilesByVersionID(ctx, pv.ID)
-	if err != nil || len(pfs) != 1 {
-		apiError(ctx, http.StatusInternalServerError, err)
+	if err != nil {
+		serveRemoteError(ctx, err)
 		return
 	}
 
-	s, u, _, err := packages_service.OpenFileForDownload(ctx, pfs[0], ctx.Req.Method)
+	s, u, _, err := packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			apiError(ctx, http.StatusNotFound, err)
@@ -115,7 +176,7 @@ func DownloadPackageFile(ctx *context.Context) {
 		return
 	}
 
-	helper.ServePackageFile(ctx, s, u, pfs[0])
+	helper.ServePackageFile(ctx, s, u, pf)
 }
 
 func resolvePackage(ctx *context.Context, ownerID int64, name, version string) (*packages_model.PackageVersion, error) {
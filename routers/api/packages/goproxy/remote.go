@@ -0,0 +1,113 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package goproxy
+
+import (
+	"bufio"
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	goproxy_module "code.gitea.io/gitea/modules/packages/goproxy"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+// The module paths and versions of the requests are passed to the upstream proxy as they are,
+// they have already been escaped by the go command like the GOPROXY protocol requires.
+
+func getRemote(ctx *context.Context) (*packages_model.PackageRemote, error) {
+	return remote_service.GetRemote(ctx, ctx.Package.Owner.ID, packages_model.TypeGo)
+}
+
+// fetchRemoteVersions returns the versions listed by the upstream proxy
+func fetchRemoteVersions(ctx *context.Context, r *packages_model.PackageRemote, name string) ([]string, error) {
+	content, err := remote_service.FetchMetadata(ctx, r, name+"/@v/list", "")
+	if err != nil {
+		return nil, err
+	}
+	versions := make([]string, 0, 10)
+	scanner := bufio.NewScanner(bytes.NewReader(content))
+	for scanner.Scan() {
+		if v := strings.TrimSpace(scanner.Text()); v != "" {
+			versions = append(versions, v)
+		}
+	}
+	return versions, scanner.Err()
+}
+
+// serveRemoteMetadata serves the upstream metadata like the version info or the go.mod file of a version which hasn't been cached
+func serveRemoteMetadata(ctx *context.Context, r *packages_model.PackageRemote, p, contentType string) {
+	content, err := remote_service.FetchMetadata(ctx, r, p, "")
+	if err != nil {
+		serveRemoteError(ctx, err)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", contentType)
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(content)
+}
+
+// cacheRemotePackageFile fetches the module zip of the version from the upstream proxy and caches it as a normal package version.
+// The GOPROXY protocol publishes no digests, the go command verifies the module zips with the checksum database itself.
+func cacheRemotePackageFile(ctx *context.Context, r *packages_model.PackageRemote, name, version string) (*packages_model.PackageFile, error) {
+	if err := remote_service.CheckCachePermission(ctx.Package.AccessMode); err != nil {
+		return nil, err
+	}
+
+	buf, err := remote_service.FetchFile(ctx, r, fmt.Sprintf("%s/@v/%s.zip", name, version))
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	pck, err := goproxy_module.ParsePackage(buf, buf.Size())
+	if err != nil {
+		return nil, fmt.Errorf("invalid upstream module %s@%s: %w", name, version, err)
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	_, pf, err := remote_service.CachePackageFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeGo,
+				Name:        pck.Name,
+				Version:     pck.Version,
+			},
+			VersionProperties: map[string]string{
+				goproxy_module.PropertyGoMod: pck.GoMod,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: fmt.Sprintf("%v.zip", pck.Version),
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	return pf, err
+}
+
+func serveRemoteError(ctx *context.Context, err error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		apiError(ctx, http.StatusNotFound, err)
+	case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize),
+		errors.Is(err, remote_service.ErrCachePermissionDenied):
+		apiError(ctx, http.StatusForbidden, err)
+	default:
+		apiError(ctx, http.StatusInternalServerError, err)
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package helper
+
+import (
+	auth_model "code.gitea.io/gitea/models/auth"
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/services/context"
+)
+
+// ResolveOwners returns the owners whose packages are resolved by the registry of the package type of the context owner.
+// The context owner comes first, then the members of its virtual registry in the order of their priority.
+// The members whose packages the doer can't read are skipped, so the response doesn't depend on packages the doer can't access.
+func ResolveOwners(ctx *context.Context, packageType packages_model.Type) ([]*user_model.User, error) {
+	owners := []*user_model.User{ctx.Package.Owner}
+
+	members, err := packages_model.GetVirtualMembers(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil || len(members) == 0 {
+		return owners, err
+	}
+
+	memberIDs := make([]int64, 0, len(members))
+	for _, m := range members {
+		memberIDs = append(memberIDs, m.MemberID)
+	}
+	users, err := user_model.GetUsersMapByIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	publicOnly, err := isPublicOnlyToken(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	for _, m := range members {
+		u, ok := users[m.MemberID]
+		if !ok {
+			continue
+		}
+		if publicOnly && u.Visibility.IsPrivate() {
+			continue
+		}
+		if !ctx.IsUserSiteAdmin() {
+			accessMode, err := context.DeterminePackageAccessMode(ctx.Base, u, ctx.Doer)
+			if err != nil {
+				return nil, err
+			}
+			if accessMode < perm.AccessModeRead {
+				continue
+			}
+		}
+		owners = append(owners, u)
+	}
+	return owners, nil
+}
+
+// isPublicOnlyToken returns whether the request is authorized by a token which is limited to public resources
+func isPublicOnlyToken(ctx *context.Context) (bool, error) {
+	if ctx.Data["IsApiToken"] != true {
+		return false, nil
+	}
+	scope, ok := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope)
+	if !ok {
+		return false, nil
+	}
+	return scope.PublicOnly()
+}
@@ -0,0 +1,317 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package hex
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	hex_service "code.gitea.io/gitea/services/packages/hex"
+)
+
+const (
+	contentTypeErlang = "application/vnd.hex+erlang"
+	tarballExtension  = ".tar"
+)
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	writeResponse(ctx, status, map[string]string{
+		"message": message,
+	})
+}
+
+// writeResponse writes the API response in the format requested by the client
+func writeResponse(ctx *context.Context, status int, obj map[string]string) {
+	if !strings.Contains(ctx.Req.Header.Get("Accept"), contentTypeErlang) {
+		ctx.JSON(status, obj)
+		return
+	}
+	ctx.Resp.Header().Set("Content-Type", contentTypeErlang)
+	ctx.Resp.WriteHeader(status)
+	_, _ = ctx.Resp.Write(hex_module.EncodeTermMap(obj))
+}
+
+// repositoryName returns the name of the repository which the clients must use for the registry of the owner.
+// The clients verify that the registry resources contain this name.
+func repositoryName(ctx *context.Context) string {
+	return ctx.Package.Owner.Name
+}
+
+func serveResource(ctx *context.Context, payload []byte) {
+	content, err := hex_service.SignResource(ctx, ctx.Package.Owner.ID, payload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", "application/octet-stream")
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_, _ = ctx.Resp.Write(content)
+}
+
+// GetPublicKey returns the public key which verifies the signatures of the registry resources
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := hex_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.PlainText(http.StatusOK, pub)
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#names
+func EnumeratePackageNames(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, ctx.Package.Owner.ID, packages_model.TypeHex)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	updated := make(map[int64]int64)
+	for _, pv := range pvs {
+		updated[pv.PackageID] = max(updated[pv.PackageID], int64(pv.CreatedUnix))
+	}
+
+	ps, err := packages_model.GetPackagesByType(ctx, ctx.Package.Owner.ID, packages_model.TypeHex)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	entries := make([]*hex_module.NameEntry, 0, len(ps))
+	for _, p := range ps {
+		if _, ok := updated[p.ID]; !ok {
+			continue
+		}
+		entries = append(entries, &hex_module.NameEntry{
+			Name:             p.Name,
+			UpdatedAtSeconds: updated[p.ID],
+		})
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		return entries[i].Name < entries[j].Name
+	})
+
+	serveResource(ctx, hex_module.EncodeNames(repositoryName(ctx), entries))
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#versions
+func EnumeratePackageVersions(ctx *context.Context) {
+	pvs, err := packages_model.GetVersionsByPackageType(ctx, ctx.Package.Owner.ID, packages_model.TypeHex)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sortDescriptors(pds)
+
+	entries := make([]*hex_module.VersionsEntry, 0, len(pds))
+	for _, pd := range pds {
+		if len(entries) == 0 || entries[len(entries)-1].Name != pd.Package.Name {
+			entries = append(entries, &hex_module.VersionsEntry{Name: pd.Package.Name})
+		}
+		entry := entries[len(entries)-1]
+		entry.Versions = append(entry.Versions, pd.Version.Version)
+	}
+
+	serveResource(ctx, hex_module.EncodeVersions(repositoryName(ctx), entries))
+}
+
+// https://github.com/hexpm/specifications/blob/main/registry-v2.md#package
+func PackageMetadata(ctx *context.Context) {
+	packageName := ctx.PathParam("name")
+
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, packageName)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sortDescriptors(pds)
+
+	releases := make([]*hex_module.Release, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*hex_module.Metadata)
+
+		inner, err := hex.DecodeString(metadata.InnerChecksum)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		outer, err := hex.DecodeString(metadata.OuterChecksum)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		releases = append(releases, &hex_module.Release{
+			Version:       pd.Version.Version,
+			InnerChecksum: inner,
+			OuterChecksum: outer,
+			Dependencies:  metadata.Requirements,
+		})
+	}
+
+	serveResource(ctx, hex_module.EncodePackage(repositoryName(ctx), pds[0].Package.Name, releases))
+}
+
+func sortDescriptors(pds []*packages_model.PackageDescriptor) {
+	sort.Slice(pds, func(i, j int) bool {
+		if pds[i].Package.Name != pds[j].Package.Name {
+			return pds[i].Package.Name < pds[j].Package.Name
+		}
+		return pds[i].SemVer.LessThan(pds[j].SemVer)
+	})
+}
+
+// https://github.com/hexpm/specifications/blob/main/endpoints.md#repository
+func DownloadPackageFile(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+
+	// the package names can't contain a hyphen, so the first one separates the name from the version
+	name, version, ok := strings.Cut(strings.TrimSuffix(filename, tarballExtension), "-")
+	if !ok || !strings.HasSuffix(filename, tarballExtension) {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownloadByPackageNameAndVersion(
+		ctx,
+		&packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeHex,
+			Name:        name,
+			Version:     version,
+		},
+		&packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		ctx.Req.Method,
+	)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// https://github.com/hexpm/hex_core/blob/main/src/hex_api_release.erl
+func UploadPackage(ctx *context.Context) {
+	buf, err := packages_module.CreateHashedBufferFromReader(ctx.Req.Body)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	pck, err := hex_module.ParsePackage(buf)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) || errors.Is(err, io.ErrUnexpectedEOF) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	if ctx.FormBool("replace") {
+		pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeHex, pck.Name, pck.Version)
+		if err != nil && !errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if pv != nil {
+			if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+		}
+	}
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeHex,
+				Name:        pck.Name,
+				Version:     pck.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: fmt.Sprintf("%s-%s%s", pck.Name, pck.Version, tarballExtension),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	baseURL := setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/hex"
+
+	writeResponse(ctx, http.StatusCreated, map[string]string{
+		"name":     pck.Name,
+		"version":  pck.Version,
+		"checksum": pck.Metadata.OuterChecksum,
+		"url":      fmt.Sprintf("%s/packages/%s", baseURL, url.PathEscape(pck.Name)),
+		"html_url": fmt.Sprintf("%s%s/-/packages/hex/%s/%s", setting.AppURL, url.PathEscape(ctx.Package.Owner.Name), url.PathEscape(pck.Name), url.PathEscape(pck.Version)),
+	})
+}
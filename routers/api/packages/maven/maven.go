@@ -20,14 +20,17 @@ import (
 	"strings"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/globallock"
 	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	maven_module "code.gitea.io/gitea/modules/packages/maven"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 )
 
 const (
@@ -79,88 +82,148 @@ func handlePackageFile(ctx *context.Context, serveContent bool) {
 
 func serveMavenMetadata(ctx *context.Context, params parameters) {
 	// path pattern: /com/foo/project/maven-metadata.xml[.md5/.sha1/.sha256/.sha512]
-	// in case there are legacy package names ("GroupID-ArtifactID") we need to check both, new packages always use ":" as separator("GroupID:ArtifactID")
-	pvsLegacy, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy())
+	r, err := remote_service.GetRemote(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName())
+
+	owners, err := helper.ResolveOwners(ctx, packages_model.TypeMaven)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	pvs = append(pvsLegacy, pvs...)
 
-	if len(pvs) == 0 {
+	// if several owners have the same version, the version of the owner which is resolved first is used
+	versions := make(container.Set[string])
+	pvs := make([]*packages_model.PackageVersion, 0, 10)
+	for _, owner := range owners {
+		// in case there are legacy package names ("GroupID-ArtifactID") we need to check both, new packages always use ":" as separator("GroupID:ArtifactID")
+		pvsLegacy, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy())
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		ownerPvs, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypeMaven, params.toInternalPackageName())
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		for _, pv := range append(pvsLegacy, ownerPvs...) {
+			if versions.Add(pv.Version) {
+				pvs = append(pvs, pv)
+			}
+		}
+	}
+
+	if len(pvs) == 0 && r == nil {
 		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
 		return
 	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
-	if err != nil {
-		apiError(ctx, http.StatusInternalServerError, err)
-		return
+	var resp *MetadataResponse
+	if len(pvs) > 0 {
+		pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		sort.Slice(pds, func(i, j int) bool {
+			// Maven and Gradle order packages by their creation timestamp and not by their version string
+			return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
+		})
+
+		resp = createMetadataResponse(pds, params.GroupID, params.ArtifactID)
+
+		latest := pds[len(pds)-1]
+		// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
+		lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
+		ctx.Resp.Header().Set("Last-Modified", lastModified)
 	}
 
-	sort.Slice(pds, func(i, j int) bool {
-		// Maven and Gradle order packages by their creation timestamp and not by their version string
-		return pds[i].Version.CreatedUnix < pds[j].Version.CreatedUnix
-	})
+	if r != nil {
+		upstream, err := fetchRemoteMetadataResponse(ctx, r, params)
+		switch {
+		case err == nil:
+			resp = mergeRemoteMetadataResponse(resp, upstream, params.GroupID, params.ArtifactID)
+		case resp == nil && errors.Is(err, util.ErrNotExist):
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		case resp == nil:
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		case !errors.Is(err, util.ErrNotExist):
+			log.Warn("Unable to fetch the upstream metadata of Maven package %s: %v", params.toInternalPackageName(), err)
+		}
+	}
 
-	xmlMetadata, err := xml.Marshal(createMetadataResponse(pds, params.GroupID, params.ArtifactID))
+	xmlMetadata, err := xml.Marshal(resp)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	xmlMetadataWithHeader := append([]byte(xml.Header), xmlMetadata...)
-
-	latest := pds[len(pds)-1]
-	// http.TimeFormat required a UTC time, refer to https://pkg.go.dev/net/http#TimeFormat
-	lastModified := latest.Version.CreatedUnix.AsTime().UTC().Format(http.TimeFormat)
-	ctx.Resp.Header().Set("Last-Modified", lastModified)
+	writeMetadataFile(ctx, append([]byte(xml.Header), xmlMetadata...), strings.ToLower(path.Ext(params.Filename)))
+}
 
-	ext := strings.ToLower(path.Ext(params.Filename))
+// writeMetadataFile writes the content of a metadata file, or its checksum if the extension is a checksum extension
+func writeMetadataFile(ctx *context.Context, content []byte, ext string) {
 	if isChecksumExtension(ext) {
 		var hash []byte
 		switch ext {
 		case extensionMD5:
-			tmp := md5.Sum(xmlMetadataWithHeader)
+			tmp := md5.Sum(content)
 			hash = tmp[:]
 		case extensionSHA1:
-			tmp := sha1.Sum(xmlMetadataWithHeader)
+			tmp := sha1.Sum(content)
 			hash = tmp[:]
 		case extensionSHA256:
-			tmp := sha256.Sum256(xmlMetadataWithHeader)
+			tmp := sha256.Sum256(content)
 			hash = tmp[:]
 		case extensionSHA512:
-			tmp := sha512.Sum512(xmlMetadataWithHeader)
+			tmp := sha512.Sum512(content)
 			hash = tmp[:]
 		}
 		ctx.PlainText(http.StatusOK, hex.EncodeToString(hash))
 		return
 	}
 
-	ctx.Resp.Header().Set("Content-Length", strconv.Itoa(len(xmlMetadataWithHeader)))
+	ctx.Resp.Header().Set("Content-Length", strconv.Itoa(len(content)))
 	ctx.Resp.Header().Set("Content-Type", contentTypeXML)
 
-	_, _ = ctx.Resp.Write(xmlMetadataWithHeader)
+	_, _ = ctx.Resp.Write(content)
 }
 
-func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
-	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
+// getPackageFile returns the file of the first resolved owner which has it
+func getPackageFile(ctx *context.Context, params parameters, filename string) (*packages_model.PackageFile, error) {
+	owners, err := helper.ResolveOwners(ctx, packages_model.TypeMaven)
+	if err != nil {
+		return nil, err
+	}
+
+	err = packages_model.ErrPackageNotExist
+	for _, owner := range owners {
+		var pf *packages_model.PackageFile
+		pf, err = getOwnerPackageFile(ctx, owner.ID, params, filename)
+		if !errors.Is(err, util.ErrNotExist) {
+			return pf, err
+		}
+	}
+	return nil, err
+}
+
+func getOwnerPackageFile(ctx *context.Context, ownerID int64, params parameters, filename string) (*packages_model.PackageFile, error) {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, ownerID, packages_model.TypeMaven, params.toInternalPackageName(), params.Version)
 	if errors.Is(err, util.ErrNotExist) {
-		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
+		pv, err = packages_model.GetVersionByNameAndVersion(ctx, ownerID, packages_model.TypeMaven, params.toInternalPackageNameLegacy(), params.Version)
 	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
-		}
-		return
+		return nil, err
 	}
+	return packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
+}
 
+func servePackageFile(ctx *context.Context, params parameters, serveContent bool) {
 	filename := params.Filename
 
 	ext := strings.ToLower(path.Ext(filename))
@@ -168,13 +231,27 @@ func servePackageFile(ctx *context.Context, params parameters, serveContent bool
 		filename = filename[:len(filename)-len(ext)]
 	}
 
-	pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey)
-	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
-			apiError(ctx, http.StatusNotFound, err)
-		} else {
-			apiError(ctx, http.StatusInternalServerError, err)
+	pf, err := getPackageFile(ctx, params, filename)
+	if errors.Is(err, util.ErrNotExist) {
+		var r *packages_model.PackageRemote
+		if r, err = remote_service.GetRemote(ctx, ctx.Package.Owner.ID, packages_model.TypeMaven); err == nil && r != nil {
+			if params.IsMeta {
+				// the metadata of the snapshot versions changes with new builds, so it's not cached as a package file
+				content, err := remote_service.FetchMetadata(ctx, r, params.remotePath(filename), "")
+				if err != nil {
+					serveRemoteError(ctx, err)
+					return
+				}
+				writeMetadataFile(ctx, content, ext)
+				return
+			}
+			pf, err = cacheRemotePackageFile(ctx, r, params, filename)
+		} else if err == nil {
+			err = packages_model.ErrPackageFileNotExist
 		}
+	}
+	if err != nil {
+		serveRemoteError(ctx, err)
 		return
 	}
 
@@ -337,6 +414,15 @@ func UploadPackageFile(ctx *context.Context) {
 		return
 	}
 
+	if err := checkOverwriteAllowed(ctx, pvci, params.Filename); err != nil {
+		if errors.Is(err, packages_service.ErrImmutableVersion) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
 	pfci := &packages_service.PackageFileCreationInfo{
 		PackageFileInfo: packages_service.PackageFileInfo{
 			Filename: params.Filename,
@@ -404,6 +490,28 @@ func UploadPackageFile(ctx *context.Context) {
 	ctx.Status(http.StatusCreated)
 }
 
+// checkOverwriteAllowed returns an error if the file already exists and the version can't be modified anymore
+func checkOverwriteAllowed(ctx *context.Context, pvci *packages_service.PackageCreationInfo, filename string) error {
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, pvci.Owner.ID, pvci.PackageType, pvci.Name, pvci.Version)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			return nil
+		}
+		return err
+	}
+	if _, err := packages_model.GetFileForVersionByName(ctx, pv.ID, filename, packages_model.EmptyFileKey); err != nil {
+		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			return nil
+		}
+		return err
+	}
+	p, err := packages_model.GetPackageByID(ctx, pv.PackageID)
+	if err != nil {
+		return err
+	}
+	return packages_service.CheckVersionMutable(ctx, ctx.Doer, p, pv)
+}
+
 func isChecksumExtension(ext string) bool {
 	return ext == extensionMD5 || ext == extensionSHA1 || ext == extensionSHA256 || ext == extensionSHA512
 }
@@ -0,0 +1,186 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package maven
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"path"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/json"
+	maven_module "code.gitea.io/gitea/modules/packages/maven"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+// remotePath returns the path of the file relative to the upstream url, the path layout is the same as this registry
+func (p *parameters) remotePath(filename string) string {
+	parts := []string{strings.ReplaceAll(p.GroupID, ".", "/"), p.ArtifactID}
+	if p.Version != "" {
+		parts = append(parts, p.Version)
+	}
+	return path.Join(append(parts, filename)...)
+}
+
+func fetchRemoteMetadataResponse(ctx *context.Context, r *packages_model.PackageRemote, params parameters) (*MetadataResponse, error) {
+	content, err := remote_service.FetchMetadata(ctx, r, params.remotePath(mavenMetadataFile), "")
+	if err != nil {
+		return nil, err
+	}
+	var resp MetadataResponse
+	if err := xml.NewDecoder(bytes.NewReader(content)).Decode(&resp); err != nil {
+		return nil, fmt.Errorf("invalid upstream metadata of %s: %w", params.toInternalPackageName(), err)
+	}
+	return &resp, nil
+}
+
+// mergeRemoteMetadataResponse merges the upstream versions and the cached or uploaded ones, the upstream order is kept
+// and the versions which only exist in this registry are appended, so they are the latest ones
+func mergeRemoteMetadataResponse(resp, upstream *MetadataResponse, groupID, artifactID string) *MetadataResponse {
+	merged := &MetadataResponse{
+		GroupID:    groupID,
+		ArtifactID: artifactID,
+		Release:    upstream.Release,
+		Latest:     upstream.Latest,
+		Version:    make([]string, 0, len(upstream.Version)),
+	}
+
+	versions := make(container.Set[string])
+	for _, v := range upstream.Version {
+		if versions.Add(v) {
+			merged.Version = append(merged.Version, v)
+		}
+	}
+	if resp != nil {
+		for _, v := range resp.Version {
+			if versions.Add(v) {
+				merged.Version = append(merged.Version, v)
+				merged.Latest = v
+				if !strings.HasSuffix(v, "-SNAPSHOT") {
+					merged.Release = v
+				}
+			}
+		}
+	}
+	if merged.Latest == "" && len(merged.Version) > 0 {
+		merged.Latest = merged.Version[len(merged.Version)-1]
+	}
+	return merged
+}
+
+// fetchRemoteFileDigests returns the digest of the file from the checksum file published next to it, the stronger one is preferred.
+// The checksum files themselves have no digests, and no digest is returned if the upstream registry publishes none.
+func fetchRemoteFileDigests(ctx *context.Context, r *packages_model.PackageRemote, params parameters, filename string) ([]remote_service.FileDigest, error) {
+	switch strings.ToLower(path.Ext(filename)) {
+	case extensionMD5, extensionSHA1, extensionSHA256, extensionSHA512, ".asc":
+		return nil, nil
+	}
+	for _, algorithm := range []string{"sha256", "sha1"} {
+		content, err := remote_service.FetchMetadata(ctx, r, params.remotePath(filename+"."+algorithm), "")
+		if errors.Is(err, util.ErrNotExist) {
+			continue
+		} else if err != nil {
+			return nil, err
+		}
+		// some checksum files contain the filename after the checksum
+		fields := strings.Fields(string(content))
+		if len(fields) == 0 {
+			continue
+		}
+		sum, err := hex.DecodeString(fields[0])
+		if err != nil {
+			return nil, fmt.Errorf("invalid upstream checksum file of %s: %w", filename, err)
+		}
+		return []remote_service.FileDigest{{Algorithm: algorithm, Sum: sum}}, nil
+	}
+	return nil, nil
+}
+
+// cacheRemotePackageFile fetches the file of the version from the upstream registry and caches it as a normal package file
+func cacheRemotePackageFile(ctx *context.Context, r *packages_model.PackageRemote, params parameters, filename string) (*packages_model.PackageFile, error) {
+	if params.Version == "" {
+		return nil, packages_model.ErrPackageFileNotExist
+	}
+	if err := remote_service.CheckCachePermission(ctx.Package.AccessMode); err != nil {
+		return nil, err
+	}
+
+	digests, err := fetchRemoteFileDigests(ctx, r, params, filename)
+	if err != nil {
+		return nil, err
+	}
+	buf, err := remote_service.FetchFile(ctx, r, params.remotePath(filename), digests...)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	pvci := &packages_service.PackageCreationInfo{
+		PackageInfo: packages_service.PackageInfo{
+			Owner:       ctx.Package.Owner,
+			PackageType: packages_model.TypeMaven,
+			Name:        params.toInternalPackageName(),
+			Version:     params.Version,
+		},
+	}
+	pfci := &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Data: buf,
+	}
+
+	var metadata *maven_module.Metadata
+	if strings.ToLower(path.Ext(filename)) == extensionPom {
+		pfci.IsLead = true
+
+		// an invalid pom file is still cached like the upstream serves it, the version just has no metadata
+		if metadata, err = maven_module.ParsePackageMetaData(buf); err == nil {
+			pvci.Metadata = metadata
+		}
+		if _, err := buf.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+	}
+
+	pv, pf, err := remote_service.CachePackageFile(ctx, pvci, pfci)
+	if err != nil {
+		return nil, err
+	}
+
+	// the version is created without metadata if another file has been cached before the pom file
+	if metadata != nil && pv.MetadataJSON == "null" {
+		raw, err := json.Marshal(metadata)
+		if err != nil {
+			return nil, err
+		}
+		pv.MetadataJSON = string(raw)
+		if err := packages_model.UpdateVersion(ctx, pv); err != nil {
+			return nil, err
+		}
+	}
+	return pf, nil
+}
+
+func serveRemoteError(ctx *context.Context, err error) {
+	switch {
+	case errors.Is(err, util.ErrNotExist):
+		apiError(ctx, http.StatusNotFound, err)
+	case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize),
+		errors.Is(err, remote_service.ErrCachePermissionDenied):
+		apiError(ctx, http.StatusForbidden, err)
+	default:
+		apiError(ctx, http.StatusInternalServerError, err)
+	}
+}
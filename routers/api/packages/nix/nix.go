@@ -0,0 +1,324 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"net/http"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/optional"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	nix_module "code.gitea.io/gitea/modules/packages/nix"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
+)
+
+const (
+	narInfoExtension   = ".narinfo"
+	narDirectory       = "nar/"
+	contentTypeNarInfo = "text/x-nix-narinfo"
+
+	// lower values have a higher priority, the official cache uses 40
+	cachePriority = 50
+)
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	ctx.PlainText(status, message)
+}
+
+// https://github.com/NixOS/nix/blob/master/src/libstore/binary-cache-store.cc
+func GetCacheInfo(ctx *context.Context) {
+	ctx.PlainText(http.StatusOK, fmt.Sprintf("StoreDir: %s\nWantMassQuery: 1\nPriority: %d\n", nix_module.StoreDir, cachePriority))
+}
+
+// GetPublicKey returns the public key which must be added to the "trusted-public-keys" of the clients
+func GetPublicKey(ctx *context.Context) {
+	_, pub, err := nix_service.GetOrCreateKeyPair(ctx, ctx.Package.Owner)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.PlainText(http.StatusOK, pub)
+}
+
+// GetNarInfo serves the signed narinfo file of a store path
+func GetNarInfo(ctx *context.Context) {
+	hash, ok := strings.CutSuffix(ctx.PathParam("filename"), narInfoExtension)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID: ctx.Package.Owner.ID,
+		Type:    packages_model.TypeNix,
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      hash,
+		},
+		IsInternal: optional.Some(false),
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	if len(pvs) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	pd, err := packages_model.GetPackageDescriptor(ctx, pvs[0])
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	var url string
+	for _, pfd := range pd.Files {
+		if pfd.File.IsLead {
+			url = narDirectory + pfd.File.Name
+		}
+	}
+
+	metadata := pd.Metadata.(*nix_module.Metadata)
+
+	sig, err := nix_service.SignStorePath(ctx, ctx.Package.Owner, metadata)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Resp.Header().Set("Content-Type", contentTypeNarInfo)
+	ctx.Resp.WriteHeader(http.StatusOK)
+	_ = nix_module.WriteNarInfo(ctx.Resp, url, metadata, sig)
+}
+
+// UploadNarInfo creates the package version of a store path from the narinfo file and the previously uploaded NAR file
+func UploadNarInfo(ctx *context.Context) {
+	hash, ok := strings.CutSuffix(ctx.PathParam("filename"), narInfoExtension)
+	if !ok {
+		apiError(ctx, http.StatusNotFound, nil)
+		return
+	}
+
+	ni, err := nix_module.ParseNarInfo(ctx.Req.Body)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+	if ni.Hash != hash {
+		apiError(ctx, http.StatusBadRequest, "narinfo filename does not match the store path")
+		return
+	}
+
+	narFilename := strings.TrimPrefix(ni.URL, narDirectory)
+
+	uploadVersion, err := nix_service.GetOrCreateUploadVersion(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	uploaded, err := packages_model.GetFileForVersionByName(ctx, uploadVersion.ID, narFilename, packages_model.EmptyFileKey)
+	if err != nil && !errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pf := uploaded
+	if pf == nil {
+		// the same NAR file may be referenced by an existing store path already
+		pf, err = findNarFile(ctx, narFilename)
+		if err != nil {
+			if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+				apiError(ctx, http.StatusBadRequest, "the NAR file must be uploaded before the narinfo file")
+			} else {
+				apiError(ctx, http.StatusInternalServerError, err)
+			}
+			return
+		}
+	}
+
+	pb, err := packages_model.GetBlobByID(ctx, pf.BlobID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	sha256, err := hex.DecodeString(pb.HashSHA256)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	metadata := ni.Metadata
+	fileHash := "sha256:" + nix_module.EncodeBase32(sha256)
+	if (metadata.FileHash != "" && metadata.FileHash != fileHash) || (metadata.FileSize != 0 && metadata.FileSize != pb.Size) {
+		apiError(ctx, http.StatusBadRequest, "NAR file does not match the narinfo file")
+		return
+	}
+	metadata.FileHash = fileHash
+	metadata.FileSize = pb.Size
+
+	s, err := packages_service.OpenBlobStream(pb)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	buf, err := packages_module.CreateHashedBufferFromReader(s)
+	s.Close()
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	_, _, err = packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNix,
+				Name:        ni.Name,
+				Version:     ni.Hash,
+			},
+			Creator:  ctx.Doer,
+			Metadata: metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: narFilename,
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if uploaded != nil {
+		if err := packages_service.DeletePackageFile(ctx, uploaded); err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// findNarFile returns the NAR file with the given name which belongs to a store path of the owner
+func findNarFile(ctx *context.Context, filename string) (*packages_model.PackageFile, error) {
+	pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+		OwnerID:     ctx.Package.Owner.ID,
+		PackageType: packages_model.TypeNix,
+		Query:       filename,
+	})
+	if err != nil {
+		return nil, err
+	}
+	for _, pf := range pfs {
+		if pf.LowerName == strings.ToLower(filename) {
+			return pf, nil
+		}
+	}
+	return nil, packages_model.ErrPackageFileNotExist
+}
+
+// DownloadNar serves a (compressed) NAR file
+func DownloadNar(ctx *context.Context) {
+	pf, err := findNarFile(ctx, ctx.PathParam("filename"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	s, u, pf, err := packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	helper.ServePackageFile(ctx, s, u, pf)
+}
+
+// UploadNar stores a (compressed) NAR file until a narinfo file references it
+func UploadNar(ctx *context.Context) {
+	filename := ctx.PathParam("filename")
+	if !nix_module.IsValidNarFilename(filename) {
+		apiError(ctx, http.StatusBadRequest, "invalid NAR filename")
+		return
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(ctx.Req.Body)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	// the filename starts with the hash of the file content
+	_, _, sha256, _ := buf.Sums()
+	if !strings.HasPrefix(filename, nix_module.EncodeBase32(sha256)+".") {
+		apiError(ctx, http.StatusBadRequest, "NAR file does not match the hash of the filename")
+		return
+	}
+
+	if err := packages_service.CheckSizeQuotaExceeded(ctx, ctx.Doer, ctx.Package.Owner, packages_model.TypeNix, buf.Size()); err != nil {
+		switch err {
+		case packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pv, err := nix_service.GetOrCreateUploadVersion(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	_, err = packages_service.AddFileToPackageVersionInternal(ctx, pv, &packages_service.PackageFileCreationInfo{
+		PackageFileInfo: packages_service.PackageFileInfo{
+			Filename: filename,
+		},
+		Creator:           ctx.Doer,
+		Data:              buf,
+		OverwriteExisting: true,
+	})
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
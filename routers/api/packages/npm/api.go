@@ -11,10 +11,34 @@ import (
 	"sort"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
 	npm_module "code.gitea.io/gitea/modules/packages/npm"
 	"code.gitea.io/gitea/modules/setting"
 )
 
+// registryURL returns the url of the npm registry of the owner
+func registryURL(owner *user_model.User) string {
+	return setting.AppURL + "api/packages/" + owner.Name + "/npm"
+}
+
+// mergePackageMetadata adds the versions and the dist-tags of the other package metadata which don't exist in the first one
+func mergePackageMetadata(resp, other *npm_module.PackageMetadata) *npm_module.PackageMetadata {
+	if resp == nil {
+		return other
+	}
+	for v, meta := range other.Versions {
+		if _, has := resp.Versions[v]; !has {
+			resp.Versions[v] = meta
+		}
+	}
+	for tag, v := range other.DistTags {
+		if _, has := resp.DistTags[tag]; !has {
+			resp.DistTags[tag] = v
+		}
+	}
+	return resp
+}
+
 func createPackageMetadataResponse(registryURL string, pds []*packages_model.PackageDescriptor) *npm_module.PackageMetadata {
 	sort.Slice(pds, func(i, j int) bool {
 		return pds[i].SemVer.LessThan(pds[j].SemVer)
@@ -17,14 +17,15 @@ import (
 	access_model "code.gitea.io/gitea/models/perm/access"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/log"
 	"code.gitea.io/gitea/modules/optional"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	npm_module "code.gitea.io/gitea/modules/packages/npm"
-	"code.gitea.io/gitea/modules/setting"
 	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 
 	"github.com/hashicorp/go-version"
 )
@@ -54,26 +55,57 @@ func packageNameFromParams(ctx *context.Context) string {
 func PackageMetadata(ctx *context.Context) {
 	packageName := packageNameFromParams(ctx)
 
-	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm, packageName)
+	r, err := remote_service.GetRemote(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
-		return
-	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	owners, err := helper.ResolveOwners(ctx, packages_model.TypeNpm)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
-	resp := createPackageMetadataResponse(
-		setting.AppURL+"api/packages/"+ctx.Package.Owner.Name+"/npm",
-		pds,
-	)
+	// the versions of the owners which are resolved first take precedence, the tarballs are served by the registries of their owners
+	var resp *npm_module.PackageMetadata
+	for _, owner := range owners {
+		pvs, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypeNpm, packageName)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		if len(pvs) == 0 {
+			continue
+		}
+		pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		resp = mergePackageMetadata(resp, createPackageMetadataResponse(registryURL(owner), pds))
+	}
+	if resp == nil && r == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	if r != nil {
+		upstream, err := fetchRemotePackument(ctx, r, packageName)
+		switch {
+		case err == nil:
+			resp = mergeRemotePackageMetadata(registryURL(ctx.Package.Owner), packageName, resp, upstream)
+		case resp == nil && errors.Is(err, util.ErrNotExist):
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		case resp == nil:
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		case !errors.Is(err, util.ErrNotExist):
+			// the uploaded and cached versions can still be installed
+			log.Warn("Unable to fetch the upstream metadata of npm package %s: %v", packageName, err)
+		}
+	}
 
 	ctx.JSON(http.StatusOK, resp)
 }
@@ -97,12 +129,19 @@ func DownloadPackageFile(ctx *context.Context) {
 		},
 		ctx.Req.Method,
 	)
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		s, u, pf, err = openRemotePackageFile(ctx, packageName, packageVersion, filename, err)
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
 			apiError(ctx, http.StatusNotFound, err)
-			return
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize),
+			errors.Is(err, remote_service.ErrCachePermissionDenied):
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
 		}
-		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
@@ -272,6 +311,10 @@ func DeletePackageVersion(ctx *context.Context) {
 			apiError(ctx, http.StatusNotFound, err)
 			return
 		}
+		if errors.Is(err, packages_service.ErrImmutableVersion) {
+			apiError(ctx, http.StatusForbidden, err)
+			return
+		}
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
@@ -296,6 +339,10 @@ func DeletePackage(ctx *context.Context) {
 
 	for _, pv := range pvs {
 		if err := packages_service.RemovePackageVersion(ctx, ctx.Doer, pv); err != nil {
+			if errors.Is(err, packages_service.ErrImmutableVersion) {
+				apiError(ctx, http.StatusForbidden, err)
+				return
+			}
 			apiError(ctx, http.StatusInternalServerError, err)
 			return
 		}
@@ -0,0 +1,204 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package npm
+
+import (
+	"encoding/base64"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	npm_module "code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+
+	"github.com/hashicorp/go-version"
+)
+
+// remotePackument contains the fields of an upstream packument which are used by the remote registry,
+// the versions are decoded one by one, so a malformed old version doesn't break the whole package
+type remotePackument struct {
+	Name        string                `json:"name"`
+	Description string                `json:"description"`
+	Readme      string                `json:"readme"`
+	DistTags    map[string]string     `json:"dist-tags"`
+	Versions    map[string]json.Value `json:"versions"`
+}
+
+func fetchRemotePackument(ctx *context.Context, r *packages_model.PackageRemote, packageName string) (*remotePackument, error) {
+	content, err := remote_service.FetchMetadata(ctx, r, url.PathEscape(packageName), "application/json")
+	if err != nil {
+		return nil, err
+	}
+	var packument remotePackument
+	if err := json.Unmarshal(content, &packument); err != nil {
+		return nil, fmt.Errorf("invalid upstream metadata of %s: %w", packageName, err)
+	}
+	return &packument, nil
+}
+
+// findRemoteVersion returns the version metadata of the upstream packument, the version is normalized like the uploaded ones
+func (p *remotePackument) findRemoteVersion(packageVersion string) (*npm_module.PackageMetadataVersion, error) {
+	for v, raw := range p.Versions {
+		sv, err := version.NewSemver(v)
+		if err != nil || sv.String() != packageVersion {
+			continue
+		}
+		var meta npm_module.PackageMetadataVersion
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			return nil, err
+		}
+		return &meta, nil
+	}
+	return nil, util.NewNotExistErrorf("version %s doesn't exist in the upstream registry", packageVersion)
+}
+
+// mergeRemotePackageMetadata adds the upstream versions which haven't been cached to the package metadata,
+// their tarballs point to this registry, so they are fetched and cached when they are downloaded
+func mergeRemotePackageMetadata(registryURL, packageName string, resp *npm_module.PackageMetadata, upstream *remotePackument) *npm_module.PackageMetadata {
+	if resp == nil {
+		resp = &npm_module.PackageMetadata{
+			ID:          packageName,
+			Name:        packageName,
+			Description: upstream.Description,
+			Readme:      upstream.Readme,
+		}
+	}
+	if resp.Versions == nil {
+		resp.Versions = make(map[string]*npm_module.PackageMetadataVersion)
+	}
+	if resp.DistTags == nil {
+		resp.DistTags = make(map[string]string)
+	}
+
+	unscopedName := packageName
+	if parts := strings.SplitN(packageName, "/", 2); len(parts) == 2 {
+		unscopedName = parts[1]
+	}
+
+	for v, raw := range upstream.Versions {
+		sv, err := version.NewSemver(v)
+		if err != nil {
+			continue
+		}
+		if _, has := resp.Versions[sv.String()]; has {
+			continue
+		}
+		var meta npm_module.PackageMetadataVersion
+		if err := json.Unmarshal(raw, &meta); err != nil {
+			log.Debug("Ignore the invalid upstream version %s of %s: %v", v, packageName, err)
+			continue
+		}
+		filename := strings.ToLower(fmt.Sprintf("%s-%s.tgz", unscopedName, sv.String()))
+		meta.Version = sv.String()
+		meta.Dist.Tarball = fmt.Sprintf("%s/%s/-/%s/%s", registryURL, url.QueryEscape(packageName), url.PathEscape(sv.String()), url.PathEscape(filename))
+		resp.Versions[sv.String()] = &meta
+	}
+	for tag, v := range upstream.DistTags {
+		// the tags of the uploaded versions take precedence
+		if _, has := resp.DistTags[tag]; !has {
+			resp.DistTags[tag] = v
+		}
+	}
+	return resp
+}
+
+// remoteTarballDigests returns the digests of the tarball published in the upstream version metadata
+func remoteTarballDigests(meta *npm_module.PackageMetadataVersion) []remote_service.FileDigest {
+	digests := make([]remote_service.FileDigest, 0, 2)
+	// the integrity is a subresource integrity string, it can contain several hashes like "sha512-... sha1-..."
+	for integrity := range strings.FieldsSeq(meta.Dist.Integrity) {
+		algorithm, value, _ := strings.Cut(integrity, "-")
+		if sum, err := base64.StdEncoding.DecodeString(value); err == nil {
+			digests = append(digests, remote_service.FileDigest{Algorithm: algorithm, Sum: sum})
+		}
+	}
+	if sum, err := hex.DecodeString(meta.Dist.Shasum); err == nil && len(sum) > 0 {
+		digests = append(digests, remote_service.FileDigest{Algorithm: "sha1", Sum: sum})
+	}
+	return digests
+}
+
+// cacheRemotePackageFile fetches the tarball of the version from the upstream registry and caches it as a normal package version
+func cacheRemotePackageFile(ctx *context.Context, r *packages_model.PackageRemote, packageName, packageVersion, filename string) (*packages_model.PackageFile, error) {
+	if err := remote_service.CheckCachePermission(ctx.Package.AccessMode); err != nil {
+		return nil, err
+	}
+
+	packument, err := fetchRemotePackument(ctx, r, packageName)
+	if err != nil {
+		return nil, err
+	}
+	meta, err := packument.findRemoteVersion(packageVersion)
+	if err != nil {
+		return nil, err
+	}
+
+	buf, err := remote_service.FetchFile(ctx, r, meta.Dist.Tarball, remoteTarballDigests(meta)...)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	data, err := io.ReadAll(buf)
+	if err != nil {
+		return nil, err
+	}
+	npmPackage, err := npm_module.ParseRemotePackage(meta, data)
+	if err != nil {
+		return nil, err
+	}
+	if npmPackage.Name != packageName || !strings.EqualFold(npmPackage.Filename, filename) {
+		return nil, util.NewNotExistErrorf("file %s doesn't exist in the upstream registry", filename)
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		return nil, err
+	}
+
+	_, pf, err := remote_service.CachePackageFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeNpm,
+				Name:        npmPackage.Name,
+				Version:     npmPackage.Version,
+			},
+			SemverCompatible: true,
+			Metadata:         npmPackage.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: npmPackage.Filename,
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	return pf, err
+}
+
+// openRemotePackageFile caches the file from the upstream registry if the owner has a npm remote, notExistErr is returned if it hasn't
+func openRemotePackageFile(ctx *context.Context, packageName, packageVersion, filename string, notExistErr error) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+	r, err := remote_service.GetRemote(ctx, ctx.Package.Owner.ID, packages_model.TypeNpm)
+	if err != nil {
+		return nil, nil, nil, err
+	} else if r == nil {
+		return nil, nil, nil, notExistErr
+	}
+
+	pf, err := cacheRemotePackageFile(ctx, r, packageName, packageVersion, filename)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
+}
@@ -14,13 +14,17 @@ import (
 	"unicode"
 
 	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/log"
 	packages_module "code.gitea.io/gitea/modules/packages"
 	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
 	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/modules/validation"
 	"code.gitea.io/gitea/routers/api/packages/helper"
 	"code.gitea.io/gitea/services/context"
 	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
 )
 
 // https://peps.python.org/pep-0426/#name
@@ -48,30 +52,87 @@ func apiError(ctx *context.Context, status int, obj any) {
 func PackageMetadata(ctx *context.Context) {
 	packageName := normalizer.Replace(ctx.PathParam("id"))
 
-	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI, packageName)
+	r, err := remote_service.GetRemote(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
-	if len(pvs) == 0 {
-		apiError(ctx, http.StatusNotFound, err)
-		return
-	}
 
-	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	owners, err := helper.ResolveOwners(ctx, packages_model.TypePyPI)
 	if err != nil {
 		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
+	// the files are linked to the registries of their owners, if several owners have a file with the same name,
+	// the file of the owner which is resolved first is listed
+	listed := make(container.Set[string])
+	pds := make([]*packages_model.PackageDescriptor, 0, 10)
+	for _, owner := range owners {
+		pvs, err := packages_model.GetVersionsByPackageName(ctx, owner.ID, packages_model.TypePyPI, packageName)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		ownerPds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		for _, pd := range ownerPds {
+			files := make([]*packages_model.PackageFileDescriptor, 0, len(pd.Files))
+			for _, pfd := range pd.Files {
+				if listed.Add(pfd.File.LowerName) {
+					files = append(files, pfd)
+				}
+			}
+			if len(files) > 0 {
+				pd.Files = files
+				pds = append(pds, pd)
+			}
+		}
+	}
+	if len(pds) == 0 && r == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
 	// sort package descriptors by version to mimic PyPI format
-	sort.Slice(pds, func(i, j int) bool {
+	sort.SliceStable(pds, func(i, j int) bool {
 		return strings.Compare(pds[i].Version.Version, pds[j].Version.Version) < 0
 	})
 
+	var remoteFiles []*remoteFile
+	if r != nil {
+		files, err := fetchRemoteProject(ctx, r, packageName)
+		switch {
+		case err == nil:
+			// the upstream files which have been cached are listed with the local ones
+			for _, f := range files {
+				if !listed.Contains(strings.ToLower(f.Filename)) {
+					remoteFiles = append(remoteFiles, f)
+				}
+			}
+		case len(pds) == 0 && errors.Is(err, util.ErrNotExist):
+			apiError(ctx, http.StatusNotFound, err)
+			return
+		case len(pds) == 0:
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		case !errors.Is(err, util.ErrNotExist):
+			log.Warn("Unable to fetch the upstream project of PyPI package %s: %v", packageName, err)
+		}
+	}
+
+	ctx.Data["PackagesURL"] = setting.AppURL + "api/packages"
 	ctx.Data["RegistryURL"] = setting.AppURL + "api/packages/" + ctx.Package.Owner.Name + "/pypi"
-	ctx.Data["PackageDescriptor"] = pds[0]
+	ctx.Data["PackageName"] = packageName
+	if len(pds) > 0 {
+		ctx.Data["PackageName"] = pds[0].Package.Name
+	}
+	ctx.Data["LowerPackageName"] = strings.ToLower(packageName)
 	ctx.Data["PackageDescriptors"] = pds
+	ctx.Data["RemoteFiles"] = remoteFiles
 	ctx.HTML(http.StatusOK, "api/packages/pypi/simple")
 }
 
@@ -94,12 +155,19 @@ func DownloadPackageFile(ctx *context.Context) {
 		},
 		ctx.Req.Method,
 	)
+	if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		s, u, pf, err = openRemotePackageFile(ctx, packageName, packageVersion, filename, err)
+	}
 	if err != nil {
-		if errors.Is(err, packages_model.ErrPackageNotExist) || errors.Is(err, packages_model.ErrPackageFileNotExist) {
+		switch {
+		case errors.Is(err, util.ErrNotExist):
 			apiError(ctx, http.StatusNotFound, err)
-			return
+		case errors.Is(err, packages_service.ErrQuotaTotalCount), errors.Is(err, packages_service.ErrQuotaTypeSize), errors.Is(err, packages_service.ErrQuotaTotalSize),
+			errors.Is(err, remote_service.ErrCachePermissionDenied):
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
 		}
-		apiError(ctx, http.StatusInternalServerError, err)
 		return
 	}
 
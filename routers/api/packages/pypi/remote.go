@@ -0,0 +1,171 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package pypi
+
+import (
+	"encoding/hex"
+	"fmt"
+	"io"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	pypi_module "code.gitea.io/gitea/modules/packages/pypi"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+)
+
+// https://peps.python.org/pep-0691/
+const contentTypeSimpleJSON = "application/vnd.pypi.simple.v1+json"
+
+// remoteProject is the JSON project detail of the simple repository API of the upstream registry
+type remoteProject struct {
+	Files []*remoteFile `json:"files"`
+}
+
+type remoteFile struct {
+	Filename       string            `json:"filename"`
+	URL            string            `json:"url"`
+	Hashes         map[string]string `json:"hashes"`
+	RequiresPython string            `json:"requires-python"`
+	Version        string            `json:"-"`
+}
+
+// remoteProjectPath returns the path of the project page relative to the upstream url, like the "simple" path of this registry
+func remoteProjectPath(packageName string) string {
+	return "simple/" + url.PathEscape(strings.ToLower(packageName)) + "/"
+}
+
+// fetchRemoteProject fetches the files of the project from the upstream registry, the files whose versions can't be detected are ignored
+func fetchRemoteProject(ctx *context.Context, r *packages_model.PackageRemote, packageName string) ([]*remoteFile, error) {
+	p := remoteProjectPath(packageName)
+	content, err := remote_service.FetchMetadata(ctx, r, p, contentTypeSimpleJSON)
+	if err != nil {
+		return nil, err
+	}
+	var project remoteProject
+	if err := json.Unmarshal(content, &project); err != nil {
+		return nil, fmt.Errorf("invalid upstream project %s: %w", packageName, err)
+	}
+
+	base, err := url.Parse(r.URL + "/" + p)
+	if err != nil {
+		return nil, err
+	}
+	files := make([]*remoteFile, 0, len(project.Files))
+	for _, f := range project.Files {
+		f.Version = versionFromFilename(f.Filename)
+		if f.Version == "" || !isValidNameAndVersion(packageName, f.Version) {
+			continue
+		}
+		// the file urls can be relative to the project page
+		ref, err := url.Parse(f.URL)
+		if err != nil {
+			continue
+		}
+		f.URL = base.ResolveReference(ref).String()
+		files = append(files, f)
+	}
+	return files, nil
+}
+
+// versionFromFilename returns the version of a wheel or a source distribution
+// https://packaging.python.org/en/latest/specifications/binary-distribution-format/#file-name-convention
+// https://packaging.python.org/en/latest/specifications/source-distribution-format/#source-distribution-file-name
+func versionFromFilename(filename string) string {
+	if name, ok := strings.CutSuffix(filename, ".whl"); ok {
+		parts := strings.Split(name, "-")
+		if len(parts) < 5 {
+			return ""
+		}
+		return parts[1]
+	}
+	for _, ext := range []string{".tar.gz", ".tar.bz2", ".tgz", ".zip"} {
+		if name, ok := strings.CutSuffix(filename, ext); ok {
+			if pos := strings.LastIndex(name, "-"); pos > 0 {
+				return name[pos+1:]
+			}
+			return ""
+		}
+	}
+	return ""
+}
+
+// cacheRemotePackageFile fetches the file of the version from the upstream registry and caches it as a normal package file
+func cacheRemotePackageFile(ctx *context.Context, r *packages_model.PackageRemote, packageName, packageVersion, filename string) (*packages_model.PackageFile, error) {
+	if err := remote_service.CheckCachePermission(ctx.Package.AccessMode); err != nil {
+		return nil, err
+	}
+
+	files, err := fetchRemoteProject(ctx, r, packageName)
+	if err != nil {
+		return nil, err
+	}
+	var file *remoteFile
+	for _, f := range files {
+		if strings.EqualFold(f.Filename, filename) && f.Version == packageVersion {
+			file = f
+			break
+		}
+	}
+	if file == nil {
+		return nil, util.NewNotExistErrorf("file %s doesn't exist in the upstream registry", filename)
+	}
+
+	digests := make([]remote_service.FileDigest, 0, len(file.Hashes))
+	for algorithm, value := range file.Hashes {
+		sum, err := hex.DecodeString(value)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s hash of the upstream file %s", algorithm, filename)
+		}
+		digests = append(digests, remote_service.FileDigest{Algorithm: algorithm, Sum: sum})
+	}
+	buf, err := remote_service.FetchFile(ctx, r, file.URL, digests...)
+	if err != nil {
+		return nil, err
+	}
+	defer buf.Close()
+
+	_, pf, err := remote_service.CachePackageFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypePyPI,
+				Name:        packageName,
+				Version:     packageVersion,
+			},
+			Metadata: &pypi_module.Metadata{
+				RequiresPython: file.RequiresPython,
+			},
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: file.Filename,
+			},
+			Data:   buf,
+			IsLead: true,
+		},
+	)
+	return pf, err
+}
+
+// openRemotePackageFile caches the file from the upstream registry if the owner has a PyPI remote, notExistErr is returned if it hasn't
+func openRemotePackageFile(ctx *context.Context, packageName, packageVersion, filename string, notExistErr error) (io.ReadSeekCloser, *url.URL, *packages_model.PackageFile, error) {
+	r, err := remote_service.GetRemote(ctx, ctx.Package.Owner.ID, packages_model.TypePyPI)
+	if err != nil {
+		return nil, nil, nil, err
+	} else if r == nil {
+		return nil, nil, nil, notExistErr
+	}
+
+	pf, err := cacheRemotePackageFile(ctx, r, packageName, packageVersion, filename)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return packages_service.OpenFileForDownload(ctx, pf, ctx.Req.Method)
+}
@@ -0,0 +1,380 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vsix
+
+import (
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	vsix_module "code.gitea.io/gitea/modules/packages/vsix"
+)
+
+// https://github.com/eclipse/openvsx/blob/master/server/src/main/java/org/eclipse/openvsx/json/ExtensionJson.java
+type Extension struct {
+	NamespaceURL      string             `json:"namespaceUrl"`
+	Files             map[string]string  `json:"files"`
+	Name              string             `json:"name"`
+	Namespace         string             `json:"namespace"`
+	TargetPlatform    string             `json:"targetPlatform"`
+	Version           string             `json:"version"`
+	PreRelease        bool               `json:"preRelease"`
+	Preview           bool               `json:"preview"`
+	Timestamp         time.Time          `json:"timestamp"`
+	DisplayName       string             `json:"displayName,omitempty"`
+	Description       string             `json:"description,omitempty"`
+	Engines           map[string]string  `json:"engines,omitempty"`
+	Categories        []string           `json:"categories,omitempty"`
+	Tags              []string           `json:"tags,omitempty"`
+	License           string             `json:"license,omitempty"`
+	Homepage          string             `json:"homepage,omitempty"`
+	Repository        string             `json:"repository,omitempty"`
+	Bugs              string             `json:"bugs,omitempty"`
+	DownloadCount     int64              `json:"downloadCount"`
+	AllVersions       map[string]string  `json:"allVersions"`
+	Dependencies      []*ExtensionRef    `json:"dependencies,omitempty"`
+	BundledExtensions []*ExtensionRef    `json:"bundledExtensions,omitempty"`
+	Verified          bool               `json:"verified"`
+	NamespaceAccess   string             `json:"namespaceAccess"`
+	PublishedBy       *ExtensionUserJSON `json:"publishedBy,omitempty"`
+}
+
+// https://github.com/eclipse/openvsx/blob/master/server/src/main/java/org/eclipse/openvsx/json/ExtensionReferenceJson.java
+type ExtensionRef struct {
+	URL       string `json:"url"`
+	Namespace string `json:"namespace"`
+	Extension string `json:"extension"`
+}
+
+// https://github.com/eclipse/openvsx/blob/master/server/src/main/java/org/eclipse/openvsx/json/UserJson.java
+type ExtensionUserJSON struct {
+	LoginName string `json:"loginName"`
+}
+
+// https://github.com/eclipse/openvsx/blob/master/server/src/main/java/org/eclipse/openvsx/json/QueryResultJson.java
+type QueryResult struct {
+	Extensions []*Extension `json:"extensions"`
+}
+
+// https://github.com/eclipse/openvsx/blob/master/server/src/main/java/org/eclipse/openvsx/json/SearchResultJson.java
+type SearchResult struct {
+	Offset     int            `json:"offset"`
+	TotalSize  int64          `json:"totalSize"`
+	Extensions []*SearchEntry `json:"extensions"`
+}
+
+// https://github.com/eclipse/openvsx/blob/master/server/src/main/java/org/eclipse/openvsx/json/SearchEntryJson.java
+type SearchEntry struct {
+	URL           string            `json:"url"`
+	Files         map[string]string `json:"files"`
+	Name          string            `json:"name"`
+	Namespace     string            `json:"namespace"`
+	Version       string            `json:"version"`
+	Timestamp     time.Time         `json:"timestamp"`
+	DisplayName   string            `json:"displayName,omitempty"`
+	Description   string            `json:"description,omitempty"`
+	DownloadCount int64             `json:"downloadCount"`
+}
+
+// https://github.com/eclipse/openvsx/blob/master/server/src/main/java/org/eclipse/openvsx/json/NamespaceJson.java
+type Namespace struct {
+	Name       string            `json:"name"`
+	Extensions map[string]string `json:"extensions"`
+	Verified   bool              `json:"verified"`
+	Access     string            `json:"access"`
+}
+
+// splitPackageName returns the namespace and the extension name of the package name
+func splitPackageName(name string) (string, string) {
+	namespace, extension, _ := strings.Cut(name, ".")
+	return namespace, extension
+}
+
+func extensionURL(baseURL, namespace, extension string) string {
+	return baseURL + "/api/" + url.PathEscape(namespace) + "/" + url.PathEscape(extension)
+}
+
+func versionURL(baseURL string, pd *packages_model.PackageDescriptor) string {
+	namespace, extension := splitPackageName(pd.Package.Name)
+	return extensionURL(baseURL, namespace, extension) + "/" + url.PathEscape(pd.Version.Version)
+}
+
+// assetType returns the asset type of the package file, the extension package is the lead file and the assets are stored by their type
+func assetType(pf *packages_model.PackageFile) string {
+	if pf.IsLead {
+		return vsix_module.AssetTypePackage
+	}
+	return pf.Name
+}
+
+// fileURLs returns the download links of the extension package and the stored assets
+func fileURLs(baseURL string, pd *packages_model.PackageDescriptor) map[string]string {
+	versionURL := versionURL(baseURL, pd)
+
+	files := make(map[string]string)
+	for _, pf := range pd.Files {
+		fileURL := versionURL + "/file/" + url.PathEscape(pf.File.Name)
+		switch assetType(pf.File) {
+		case vsix_module.AssetTypePackage:
+			files["download"] = fileURL
+		case vsix_module.AssetTypeManifest:
+			files["manifest"] = fileURL
+		case vsix_module.AssetTypeDetails:
+			files["readme"] = fileURL
+		case vsix_module.AssetTypeChangelog:
+			files["changelog"] = fileURL
+		case vsix_module.AssetTypeLicense:
+			files["license"] = fileURL
+		case vsix_module.AssetTypeIcon:
+			files["icon"] = fileURL
+		}
+	}
+	return files
+}
+
+func createExtension(baseURL string, pd *packages_model.PackageDescriptor, all []*packages_model.PackageDescriptor) *Extension {
+	metadata := pd.Metadata.(*vsix_module.Metadata)
+	namespace, extension := splitPackageName(pd.Package.Name)
+
+	allVersions := make(map[string]string, len(all))
+	for _, other := range all {
+		allVersions[other.Version.Version] = versionURL(baseURL, other)
+	}
+
+	var engines map[string]string
+	if metadata.Engine != "" {
+		engines = map[string]string{"vscode": metadata.Engine}
+	}
+
+	return &Extension{
+		NamespaceURL:      baseURL + "/api/" + url.PathEscape(namespace),
+		Files:             fileURLs(baseURL, pd),
+		Name:              extension,
+		Namespace:         namespace,
+		TargetPlatform:    metadata.TargetPlatform,
+		Version:           pd.Version.Version,
+		PreRelease:        metadata.PreRelease,
+		Preview:           metadata.Preview,
+		Timestamp:         pd.Version.CreatedUnix.AsLocalTime(),
+		DisplayName:       metadata.DisplayName,
+		Description:       metadata.Description,
+		Engines:           engines,
+		Categories:        metadata.Categories,
+		Tags:              metadata.Tags,
+		License:           metadata.License,
+		Homepage:          metadata.ProjectURL,
+		Repository:        metadata.RepositoryURL,
+		Bugs:              metadata.BugsURL,
+		DownloadCount:     pd.Version.DownloadCount,
+		AllVersions:       allVersions,
+		Dependencies:      createExtensionRefs(baseURL, metadata.ExtensionDependencies),
+		BundledExtensions: createExtensionRefs(baseURL, metadata.ExtensionPack),
+		NamespaceAccess:   "restricted",
+		PublishedBy: &ExtensionUserJSON{
+			LoginName: pd.Creator.Name,
+		},
+	}
+}
+
+func createExtensionRefs(baseURL string, ids []string) []*ExtensionRef {
+	refs := make([]*ExtensionRef, 0, len(ids))
+	for _, id := range ids {
+		namespace, extension := splitPackageName(id)
+		refs = append(refs, &ExtensionRef{
+			URL:       extensionURL(baseURL, namespace, extension),
+			Namespace: namespace,
+			Extension: extension,
+		})
+	}
+	return refs
+}
+
+func createSearchEntry(baseURL string, pd *packages_model.PackageDescriptor) *SearchEntry {
+	metadata := pd.Metadata.(*vsix_module.Metadata)
+	namespace, extension := splitPackageName(pd.Package.Name)
+
+	return &SearchEntry{
+		URL:           extensionURL(baseURL, namespace, extension),
+		Files:         fileURLs(baseURL, pd),
+		Name:          extension,
+		Namespace:     namespace,
+		Version:       pd.Version.Version,
+		Timestamp:     pd.Version.CreatedUnix.AsLocalTime(),
+		DisplayName:   metadata.DisplayName,
+		Description:   metadata.Description,
+		DownloadCount: pd.Version.DownloadCount,
+	}
+}
+
+// https://github.com/microsoft/vscode/blob/main/src/vs/platform/extensionManagement/common/extensionGalleryService.ts
+type galleryQuery struct {
+	Filters []struct {
+		Criteria []struct {
+			FilterType int    `json:"filterType"`
+			Value      string `json:"value"`
+		} `json:"criteria"`
+		PageNumber int `json:"pageNumber"`
+		PageSize   int `json:"pageSize"`
+	} `json:"filters"`
+	Flags int `json:"flags"`
+}
+
+const (
+	galleryFilterTag           = 1
+	galleryFilterExtensionID   = 4
+	galleryFilterCategory      = 5
+	galleryFilterExtensionName = 7
+	galleryFilterTarget        = 8
+	galleryFilterSearchText    = 10
+
+	galleryFlagIncludeLatestVersionOnly = 0x200
+)
+
+type galleryQueryResult struct {
+	Results []*galleryResult `json:"results"`
+}
+
+type galleryResult struct {
+	Extensions     []*galleryExtension      `json:"extensions"`
+	ResultMetadata []*galleryResultMetadata `json:"resultMetadata"`
+}
+
+type galleryResultMetadata struct {
+	MetadataType  string                       `json:"metadataType"`
+	MetadataItems []*galleryResultMetadataItem `json:"metadataItems"`
+}
+
+type galleryResultMetadataItem struct {
+	Name  string `json:"name"`
+	Count int64  `json:"count"`
+}
+
+type galleryExtension struct {
+	ExtensionID      string              `json:"extensionId"`
+	ExtensionName    string              `json:"extensionName"`
+	DisplayName      string              `json:"displayName"`
+	ShortDescription string              `json:"shortDescription"`
+	Publisher        *galleryPublisher   `json:"publisher"`
+	Versions         []*galleryVersion   `json:"versions"`
+	Statistics       []*galleryStatistic `json:"statistics"`
+	Tags             []string            `json:"tags"`
+	Categories       []string            `json:"categories"`
+	Flags            string              `json:"flags"`
+	ReleaseDate      time.Time           `json:"releaseDate"`
+	PublishedDate    time.Time           `json:"publishedDate"`
+	LastUpdated      time.Time           `json:"lastUpdated"`
+}
+
+type galleryPublisher struct {
+	PublisherID   string `json:"publisherId"`
+	PublisherName string `json:"publisherName"`
+	DisplayName   string `json:"displayName"`
+}
+
+type galleryVersion struct {
+	Version          string             `json:"version"`
+	TargetPlatform   string             `json:"targetPlatform,omitempty"`
+	LastUpdated      time.Time          `json:"lastUpdated"`
+	AssetURI         string             `json:"assetUri"`
+	FallbackAssetURI string             `json:"fallbackAssetUri"`
+	Files            []*galleryFile     `json:"files"`
+	Properties       []*galleryProperty `json:"properties"`
+}
+
+type galleryFile struct {
+	AssetType string `json:"assetType"`
+	Source    string `json:"source"`
+}
+
+type galleryProperty struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+}
+
+type galleryStatistic struct {
+	StatisticName string `json:"statisticName"`
+	Value         int64  `json:"value"`
+}
+
+func createGalleryExtension(baseURL string, pds []*packages_model.PackageDescriptor) *galleryExtension {
+	latest := pds[0]
+	metadata := latest.Metadata.(*vsix_module.Metadata)
+	namespace, extension := splitPackageName(latest.Package.Name)
+
+	var downloads int64
+	versions := make([]*galleryVersion, 0, len(pds))
+	for _, pd := range pds {
+		downloads += pd.Version.DownloadCount
+		versions = append(versions, createGalleryVersion(baseURL, pd))
+	}
+
+	var flags []string
+	if metadata.Preview {
+		flags = append(flags, "preview")
+	}
+
+	return &galleryExtension{
+		ExtensionID:      strconv.FormatInt(latest.Package.ID, 10),
+		ExtensionName:    extension,
+		DisplayName:      metadata.DisplayName,
+		ShortDescription: metadata.Description,
+		Publisher: &galleryPublisher{
+			PublisherID:   namespace,
+			PublisherName: namespace,
+			DisplayName:   namespace,
+		},
+		Versions:      versions,
+		Statistics:    []*galleryStatistic{{StatisticName: "install", Value: downloads}},
+		Tags:          metadata.Tags,
+		Categories:    metadata.Categories,
+		Flags:         strings.Join(flags, ", "),
+		ReleaseDate:   pds[len(pds)-1].Version.CreatedUnix.AsLocalTime(),
+		PublishedDate: pds[len(pds)-1].Version.CreatedUnix.AsLocalTime(),
+		LastUpdated:   latest.Version.CreatedUnix.AsLocalTime(),
+	}
+}
+
+func createGalleryVersion(baseURL string, pd *packages_model.PackageDescriptor) *galleryVersion {
+	metadata := pd.Metadata.(*vsix_module.Metadata)
+	namespace, extension := splitPackageName(pd.Package.Name)
+
+	assetURI := baseURL + "/vscode/asset/" + url.PathEscape(namespace) + "/" + url.PathEscape(extension) + "/" + url.PathEscape(pd.Version.Version)
+
+	files := make([]*galleryFile, 0, len(pd.Files))
+	for _, pf := range pd.Files {
+		files = append(files, &galleryFile{
+			AssetType: assetType(pf.File),
+			Source:    assetURI + "/" + url.PathEscape(assetType(pf.File)),
+		})
+	}
+
+	properties := []*galleryProperty{
+		{Key: "Microsoft.VisualStudio.Code.Engine", Value: metadata.Engine},
+		{Key: "Microsoft.VisualStudio.Code.ExtensionDependencies", Value: strings.Join(metadata.ExtensionDependencies, ",")},
+		{Key: "Microsoft.VisualStudio.Code.ExtensionPack", Value: strings.Join(metadata.ExtensionPack, ",")},
+	}
+	if metadata.PreRelease {
+		properties = append(properties, &galleryProperty{Key: "Microsoft.VisualStudio.Code.PreRelease", Value: "true"})
+	}
+	if metadata.RepositoryURL != "" {
+		properties = append(properties, &galleryProperty{Key: "Microsoft.VisualStudio.Services.Links.Source", Value: metadata.RepositoryURL})
+	}
+
+	var targetPlatform string
+	if metadata.TargetPlatform != vsix_module.TargetPlatformUniversal {
+		targetPlatform = metadata.TargetPlatform
+	}
+
+	return &galleryVersion{
+		Version:          pd.Version.Version,
+		TargetPlatform:   targetPlatform,
+		LastUpdated:      pd.Version.CreatedUnix.AsLocalTime(),
+		AssetURI:         assetURI,
+		FallbackAssetURI: assetURI,
+		Files:            files,
+		Properties:       properties,
+	}
+}
@@ -0,0 +1,135 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vsix
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/json"
+	vsix_module "code.gitea.io/gitea/modules/packages/vsix"
+	"code.gitea.io/gitea/services/context"
+)
+
+const (
+	defaultGalleryPageSize = 50
+	maxGalleryPageSize     = 200
+)
+
+// GalleryQuery implements the extension query of the VS Code gallery which is used by VSCodium and code-server
+func GalleryQuery(ctx *context.Context) {
+	var query galleryQuery
+	if err := json.NewDecoder(ctx.Req.Body).Decode(&query); err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+
+	result := &galleryQueryResult{Results: make([]*galleryResult, 0, len(query.Filters))}
+
+	for _, filter := range query.Filters {
+		var text, category, tag string
+		var names []string
+		for _, criterion := range filter.Criteria {
+			switch criterion.FilterType {
+			case galleryFilterSearchText:
+				text = criterion.Value
+			case galleryFilterCategory:
+				category = criterion.Value
+			case galleryFilterTag:
+				tag = criterion.Value
+			case galleryFilterExtensionName, galleryFilterExtensionID:
+				names = append(names, criterion.Value)
+			}
+		}
+
+		pds, err := searchLatestDescriptors(ctx, text, category, tag)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		if len(names) > 0 {
+			matches := pds[:0]
+			for _, pd := range pds {
+				for _, name := range names {
+					if strings.EqualFold(pd.Package.Name, name) || strconv.FormatInt(pd.Package.ID, 10) == name {
+						matches = append(matches, pd)
+						break
+					}
+				}
+			}
+			pds = matches
+		}
+
+		pageSize := filter.PageSize
+		if pageSize <= 0 {
+			pageSize = defaultGalleryPageSize
+		}
+		pageSize = min(pageSize, maxGalleryPageSize)
+		offset := min(max(filter.PageNumber-1, 0)*pageSize, len(pds))
+
+		extensions := make([]*galleryExtension, 0, pageSize)
+		for _, pd := range pds[offset:min(offset+pageSize, len(pds))] {
+			namespace, extension := splitPackageName(pd.Package.Name)
+			all, err := getExtensionDescriptors(ctx, namespace, extension)
+			if err != nil {
+				apiError(ctx, http.StatusInternalServerError, err)
+				return
+			}
+			if query.Flags&galleryFlagIncludeLatestVersionOnly != 0 {
+				all = []*packages_model.PackageDescriptor{latestDescriptor(all)}
+			}
+			extensions = append(extensions, createGalleryExtension(baseURL(ctx), all))
+		}
+
+		result.Results = append(result.Results, &galleryResult{
+			Extensions: extensions,
+			ResultMetadata: []*galleryResultMetadata{
+				{
+					MetadataType: "ResultCount",
+					MetadataItems: []*galleryResultMetadataItem{
+						{Name: "TotalCount", Count: int64(len(pds))},
+					},
+				},
+			},
+		})
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// GalleryAsset serves an asset of an extension version by its asset type
+func GalleryAsset(ctx *context.Context) {
+	typ := ctx.PathParam("assettype")
+	serveFile(ctx, ctx.PathParam("namespace"), ctx.PathParam("extension"), ctx.PathParam("version"), func(pf *packages_model.PackageFile) bool {
+		return strings.EqualFold(assetType(pf), typ)
+	})
+}
+
+// GalleryPackage serves the extension package, VS Code uses this endpoint as fallback if the asset is not available
+func GalleryPackage(ctx *context.Context) {
+	serveFile(ctx, ctx.PathParam("namespace"), ctx.PathParam("extension"), ctx.PathParam("version"), func(pf *packages_model.PackageFile) bool {
+		return assetType(pf) == vsix_module.AssetTypePackage
+	})
+}
+
+// GalleryItem redirects to the web page of the extension
+func GalleryItem(ctx *context.Context) {
+	namespace, extension := splitPackageName(ctx.FormTrim("itemName"))
+
+	pds, err := getExtensionDescriptors(ctx, namespace, extension)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	ctx.Redirect(latestDescriptor(pds).VersionHTMLURL(ctx))
+}
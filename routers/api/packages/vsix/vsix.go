@@ -0,0 +1,420 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package vsix
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/modules/optional"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	vsix_module "code.gitea.io/gitea/modules/packages/vsix"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/packages/helper"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+const (
+	defaultSearchSize = 18
+	maxSearchSize     = 100
+)
+
+func apiError(ctx *context.Context, status int, obj any) {
+	message := helper.ProcessErrorForUser(ctx, status, obj)
+	type Error struct {
+		Error string `json:"error"`
+	}
+	ctx.JSON(status, Error{
+		Error: message,
+	})
+}
+
+func baseURL(ctx *context.Context) string {
+	return setting.AppURL + "api/packages/" + url.PathEscape(ctx.Package.Owner.Name) + "/vsix"
+}
+
+// getExtensionDescriptors returns the versions of the extension ordered from the newest to the oldest
+func getExtensionDescriptors(ctx *context.Context, namespace, extension string) ([]*packages_model.PackageDescriptor, error) {
+	pvs, err := packages_model.GetVersionsByPackageName(ctx, ctx.Package.Owner.ID, packages_model.TypeVsix, namespace+"."+extension)
+	if err != nil {
+		return nil, err
+	}
+	if len(pvs) == 0 {
+		return nil, packages_model.ErrPackageNotExist
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(pds, func(i, j int) bool {
+		return pds[i].SemVer.GreaterThan(pds[j].SemVer)
+	})
+	return pds, nil
+}
+
+// latestDescriptor returns the newest release or the newest pre-release if there are no releases
+func latestDescriptor(pds []*packages_model.PackageDescriptor) *packages_model.PackageDescriptor {
+	for _, pd := range pds {
+		if !pd.Metadata.(*vsix_module.Metadata).PreRelease && pd.SemVer.Prerelease() == "" {
+			return pd
+		}
+	}
+	return pds[0]
+}
+
+// findDescriptor returns the version of the extension, "latest" is an alias for the latest version
+func findDescriptor(pds []*packages_model.PackageDescriptor, version string) *packages_model.PackageDescriptor {
+	if version == "latest" {
+		return latestDescriptor(pds)
+	}
+	for _, pd := range pds {
+		if strings.EqualFold(pd.Version.Version, version) {
+			return pd
+		}
+	}
+	return nil
+}
+
+// https://open-vsx.org/swagger-ui/index.html#/registry-api/getNamespace
+func GetNamespace(ctx *context.Context) {
+	namespace := ctx.PathParam("namespace")
+
+	ps, err := packages_model.GetPackagesByType(ctx, ctx.Package.Owner.ID, packages_model.TypeVsix)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	extensions := make(map[string]string)
+	for _, p := range ps {
+		ns, extension := splitPackageName(p.Name)
+		if strings.EqualFold(ns, namespace) {
+			namespace = ns
+			extensions[extension] = extensionURL(baseURL(ctx), ns, extension)
+		}
+	}
+	if len(extensions) == 0 {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &Namespace{
+		Name:       namespace,
+		Extensions: extensions,
+		Access:     "restricted",
+	})
+}
+
+// https://open-vsx.org/swagger-ui/index.html#/registry-api/getExtension
+func GetExtension(ctx *context.Context) {
+	serveExtension(ctx, "latest")
+}
+
+// https://open-vsx.org/swagger-ui/index.html#/registry-api/getExtension_2
+func GetExtensionVersion(ctx *context.Context) {
+	serveExtension(ctx, ctx.PathParam("version"))
+}
+
+func serveExtension(ctx *context.Context, version string) {
+	pds, err := getExtensionDescriptors(ctx, ctx.PathParam("namespace"), ctx.PathParam("extension"))
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd := findDescriptor(pds, version)
+	if pd == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, createExtension(baseURL(ctx), pd, pds))
+}
+
+// https://open-vsx.org/swagger-ui/index.html#/registry-api/getQuery
+func Query(ctx *context.Context) {
+	namespace := ctx.FormTrim("namespaceName")
+	extension := ctx.FormTrim("extensionName")
+	if id := ctx.FormTrim("extensionId"); id != "" {
+		namespace, extension = splitPackageName(id)
+	}
+	version := ctx.FormTrim("extensionVersion")
+	includeAllVersions := ctx.FormString("includeAllVersions") == "true"
+
+	result := &QueryResult{Extensions: []*Extension{}}
+
+	var names []string
+	if namespace != "" && extension != "" {
+		names = []string{namespace + "." + extension}
+	} else {
+		ps, err := packages_model.GetPackagesByType(ctx, ctx.Package.Owner.ID, packages_model.TypeVsix)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+		for _, p := range ps {
+			ns, ext := splitPackageName(p.Name)
+			if (namespace == "" || strings.EqualFold(ns, namespace)) && (extension == "" || strings.EqualFold(ext, extension)) {
+				names = append(names, p.Name)
+			}
+		}
+		sort.Strings(names)
+	}
+
+	for _, name := range names {
+		ns, ext := splitPackageName(name)
+		pds, err := getExtensionDescriptors(ctx, ns, ext)
+		if err != nil {
+			if errors.Is(err, packages_model.ErrPackageNotExist) {
+				continue
+			}
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		switch {
+		case version != "":
+			if pd := findDescriptor(pds, version); pd != nil {
+				result.Extensions = append(result.Extensions, createExtension(baseURL(ctx), pd, pds))
+			}
+		case includeAllVersions:
+			for _, pd := range pds {
+				result.Extensions = append(result.Extensions, createExtension(baseURL(ctx), pd, pds))
+			}
+		default:
+			result.Extensions = append(result.Extensions, createExtension(baseURL(ctx), latestDescriptor(pds), pds))
+		}
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// searchLatestDescriptors returns the latest versions of the extensions which match the search text, the category and the tag
+func searchLatestDescriptors(ctx *context.Context, text, category, tag string) ([]*packages_model.PackageDescriptor, error) {
+	pvs, _, err := packages_model.SearchLatestVersions(ctx, &packages_model.PackageSearchOptions{
+		OwnerID:    ctx.Package.Owner.ID,
+		Type:       packages_model.TypeVsix,
+		IsInternal: optional.Some(false),
+		Sort:       packages_model.SortNameAsc,
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	text = strings.ToLower(text)
+
+	matches := make([]*packages_model.PackageDescriptor, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*vsix_module.Metadata)
+		if text != "" &&
+			!strings.Contains(pd.Package.LowerName, text) &&
+			!strings.Contains(strings.ToLower(metadata.DisplayName), text) &&
+			!strings.Contains(strings.ToLower(metadata.Description), text) {
+			continue
+		}
+		if category != "" && !containsFold(metadata.Categories, category) {
+			continue
+		}
+		if tag != "" && !containsFold(metadata.Tags, tag) {
+			continue
+		}
+		matches = append(matches, pd)
+	}
+	return matches, nil
+}
+
+func containsFold(values []string, value string) bool {
+	for _, v := range values {
+		if strings.EqualFold(v, value) {
+			return true
+		}
+	}
+	return false
+}
+
+// https://open-vsx.org/swagger-ui/index.html#/registry-api/search
+func Search(ctx *context.Context) {
+	size := ctx.FormInt("size")
+	if size <= 0 {
+		size = defaultSearchSize
+	}
+	size = min(size, maxSearchSize)
+	offset := max(ctx.FormInt("offset"), 0)
+
+	pds, err := searchLatestDescriptors(ctx, ctx.FormTrim("query"), ctx.FormTrim("category"), "")
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	result := &SearchResult{
+		Offset:     offset,
+		TotalSize:  int64(len(pds)),
+		Extensions: []*SearchEntry{},
+	}
+	for _, pd := range pds[min(offset, len(pds)):min(offset+size, len(pds))] {
+		result.Extensions = append(result.Extensions, createSearchEntry(baseURL(ctx), pd))
+	}
+
+	ctx.JSON(http.StatusOK, result)
+}
+
+// https://open-vsx.org/swagger-ui/index.html#/registry-api/getFile
+func DownloadFile(ctx *context.Context) {
+	serveFile(ctx, ctx.PathParam("namespace"), ctx.PathParam("extension"), ctx.PathParam("version"), func(pf *packages_model.PackageFile) bool {
+		return strings.EqualFold(pf.Name, ctx.PathParam("filename"))
+	})
+}
+
+func serveFile(ctx *context.Context, namespace, extension, version string, match func(*packages_model.PackageFile) bool) {
+	pds, err := getExtensionDescriptors(ctx, namespace, extension)
+	if err != nil {
+		if errors.Is(err, packages_model.ErrPackageNotExist) {
+			apiError(ctx, http.StatusNotFound, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	pd := findDescriptor(pds, version)
+	if pd == nil {
+		apiError(ctx, http.StatusNotFound, packages_model.ErrPackageNotExist)
+		return
+	}
+
+	for _, pfd := range pd.Files {
+		if !match(pfd.File) {
+			continue
+		}
+
+		s, u, pf, err := packages_service.OpenFileForDownload(ctx, pfd.File, ctx.Req.Method)
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		helper.ServePackageFile(ctx, s, u, pf)
+		return
+	}
+
+	apiError(ctx, http.StatusNotFound, packages_model.ErrPackageFileNotExist)
+}
+
+// https://open-vsx.org/swagger-ui/index.html#/registry-api/publish
+func UploadPackage(ctx *context.Context) {
+	upload, needToClose, err := ctx.UploadStream()
+	if err != nil {
+		apiError(ctx, http.StatusBadRequest, err)
+		return
+	}
+	if needToClose {
+		defer upload.Close()
+	}
+
+	buf, err := packages_module.CreateHashedBufferFromReader(upload)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+	defer buf.Close()
+
+	pck, err := vsix_module.ParsePackage(buf, buf.Size())
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			apiError(ctx, http.StatusBadRequest, err)
+		} else {
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	pv, _, err := packages_service.CreatePackageAndAddFile(
+		ctx,
+		&packages_service.PackageCreationInfo{
+			PackageInfo: packages_service.PackageInfo{
+				Owner:       ctx.Package.Owner,
+				PackageType: packages_model.TypeVsix,
+				Name:        pck.ID(),
+				Version:     pck.Version,
+			},
+			SemverCompatible: true,
+			Creator:          ctx.Doer,
+			Metadata:         pck.Metadata,
+		},
+		&packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: fmt.Sprintf("%s-%s.vsix", pck.ID(), pck.Version),
+			},
+			Creator: ctx.Doer,
+			Data:    buf,
+			IsLead:  true,
+		},
+	)
+	if err != nil {
+		switch err {
+		case packages_model.ErrDuplicatePackageVersion:
+			apiError(ctx, http.StatusConflict, err)
+		case packages_service.ErrQuotaTotalCount, packages_service.ErrQuotaTypeSize, packages_service.ErrQuotaTotalSize:
+			apiError(ctx, http.StatusForbidden, err)
+		default:
+			apiError(ctx, http.StatusInternalServerError, err)
+		}
+		return
+	}
+
+	for _, asset := range pck.Assets {
+		assetBuf, err := packages_module.CreateHashedBufferFromReader(bytes.NewReader(asset.Content))
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+
+		_, err = packages_service.AddFileToPackageVersionInternal(ctx, pv, &packages_service.PackageFileCreationInfo{
+			PackageFileInfo: packages_service.PackageFileInfo{
+				Filename: asset.Type,
+			},
+			Creator: ctx.Doer,
+			Data:    assetBuf,
+		})
+		assetBuf.Close()
+		if err != nil {
+			apiError(ctx, http.StatusInternalServerError, err)
+			return
+		}
+	}
+
+	pds, err := getExtensionDescriptors(ctx, pck.Publisher, pck.Name)
+	if err != nil {
+		apiError(ctx, http.StatusInternalServerError, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, createExtension(baseURL(ctx), findDescriptor(pds, pck.Version), pds))
+}
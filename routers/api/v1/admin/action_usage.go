@@ -0,0 +1,139 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package admin
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetActionsUsage gets the Actions usage of all owners
+func GetActionsUsage(ctx *context.APIContext) {
+	// swagger:operation GET /admin/actions/usage admin adminGetActionsUsage
+	// ---
+	// summary: Get the runner time used by the finished Actions tasks of all owners
+	// produces:
+	// - application/json
+	// - text/csv
+	// parameters:
+	// - name: group_by
+	//   in: query
+	//   description: aggregate the usage by owner, repository or runs-on labels, defaults to owner
+	//   type: string
+	//   enum: [owner, repo, labels]
+	// - name: from
+	//   in: query
+	//   description: the first month of the usage like 2026-01, defaults to the current month if both from and to are omitted
+	//   type: string
+	// - name: to
+	//   in: query
+	//   description: the last month of the usage like 2026-12
+	//   type: string
+	// - name: format
+	//   in: query
+	//   description: response format, csv exports the usage as a CSV file
+	//   type: string
+	//   enum: [json, csv]
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionUsageList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+
+	shared.ListUsage(ctx, 0, actions_model.UsageGroupByOwner)
+}
+
+// GetUserActionsUsageQuota gets the Actions minutes quota of a user or an organization
+func GetUserActionsUsageQuota(ctx *context.APIContext) {
+	// swagger:operation GET /admin/users/{username}/actions/quota admin adminGetUserActionsUsageQuota
+	// ---
+	// summary: Get the monthly Actions minutes quota of a user or an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user or the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionUsageQuota"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetUsageQuota(ctx, ctx.ContextUser.ID)
+}
+
+// SetUserActionsUsageQuota sets the Actions minutes quota of a user or an organization
+func SetUserActionsUsageQuota(ctx *context.APIContext) {
+	// swagger:operation PUT /admin/users/{username}/actions/quota admin adminSetUserActionsUsageQuota
+	// ---
+	// summary: Set the monthly Actions minutes quota of a user or an organization, no new jobs are queued once it is exceeded
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user or the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetActionUsageQuotaOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionUsageQuota"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetActionUsageQuotaOption)
+	if _, err := actions_model.SetUsageQuota(ctx, ctx.ContextUser.ID, form.MonthlyMinutes); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		ctx.APIErrorInternal(err)
+		return
+	}
+	shared.GetUsageQuota(ctx, ctx.ContextUser.ID)
+}
+
+// DeleteUserActionsUsageQuota deletes the Actions minutes quota of a user or an organization
+func DeleteUserActionsUsageQuota(ctx *context.APIContext) {
+	// swagger:operation DELETE /admin/users/{username}/actions/quota admin adminDeleteUserActionsUsageQuota
+	// ---
+	// summary: Delete the monthly Actions minutes quota of a user or an organization, its tasks can use unlimited minutes
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: username
+	//   in: path
+	//   description: username of the user or the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := actions_model.DeleteUsageQuota(ctx, ctx.ContextUser.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
@@ -202,3 +202,100 @@ func DeleteHook(ctx *context.APIContext) {
 	}
 	ctx.Status(http.StatusNoContent)
 }
+
+// PreviewHook renders the request a hook would send for an event without delivering it
+func PreviewHook(ctx *context.APIContext) {
+	// swagger:operation POST /admin/hooks/{id}/preview admin adminPreviewHook
+	// ---
+	// summary: Preview the request a hook would send for an event without delivering it
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the hook to preview
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/HookPreviewOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookPreview"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, ctx.PathParamInt64("id"))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	utils.PreviewHook(ctx, hook, web.GetForm(ctx).(*api.HookPreviewOption))
+}
+
+// ListDeadLetteredHookDeliveries lists the dead-lettered deliveries of all webhooks
+func ListDeadLetteredHookDeliveries(ctx *context.APIContext) {
+	// swagger:operation GET /admin/hooks/dead_letters admin adminListDeadLetteredHookDeliveries
+	// ---
+	// summary: List the dead-lettered deliveries of all webhooks
+	// description: A delivery is dead-lettered when all its automatic retries failed.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: hook_id
+	//   in: query
+	//   description: only list the deliveries of this hook
+	//   type: integer
+	//   format: int64
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	utils.ListDeadLetteredHookDeliveries(ctx, 0)
+}
+
+// RedeliverDeadLetteredHookDeliveries redelivers dead-lettered deliveries of all webhooks
+func RedeliverDeadLetteredHookDeliveries(ctx *context.APIContext) {
+	// swagger:operation POST /admin/hooks/dead_letters/redeliver admin adminRedeliverDeadLetteredHookDeliveries
+	// ---
+	// summary: Redeliver dead-lettered deliveries of all webhooks
+	// description: Each delivery is redelivered as a new delivery, which gets the automatic retries again.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/RedeliverHookDeliveriesOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+
+	utils.RedeliverDeadLetteredHookDeliveries(ctx, 0, web.GetForm(ctx).(*api.RedeliverHookDeliveriesOption))
+}
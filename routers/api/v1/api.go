@@ -1111,6 +1111,7 @@ func Routes() *web.Router {
 				m.Combo("/{id}").Get(user.GetHook).
 					Patch(bind(api.EditHookOption{}), user.EditHook).
 					Delete(user.DeleteHook)
+				m.Post("/{id}/preview", bind(api.HookPreviewOption{}), user.PreviewHook)
 			}, reqWebhooksEnabled())
 
 			m.Group("/avatar", func() {
@@ -1192,6 +1193,7 @@ func Routes() *web.Router {
 							Patch(bind(api.EditHookOption{}), repo.EditHook).
 							Delete(repo.DeleteHook)
 						m.Post("/tests", context.ReferencesGitRepo(), context.RepoRefForAPI, repo.TestHook)
+						m.Post("/preview", bind(api.HookPreviewOption{}), repo.PreviewHook)
 					})
 				}, reqToken(), reqAdmin(), reqWebhooksEnabled())
 				m.Group("/collaborators", func() {
@@ -1261,6 +1263,28 @@ func Routes() *web.Router {
 							m.Get("/jobs", repo.ListWorkflowRunJobs)
 							m.Post("/jobs/{job_id}/rerun", reqToken(), reqRepoWriter(unit.TypeActions), repo.RerunWorkflowJob)
 							m.Get("/artifacts", repo.GetArtifactsOfRun)
+							m.Combo("/pending_deployments").Get(repo.GetPendingDeployments).
+								Post(reqToken(), bind(api.ReviewDeploymentsOption{}), repo.ReviewPendingDeployments)
+						})
+					})
+					m.Group("/environments", func() {
+						m.Get("", repo.ListActionEnvironments)
+						m.Group("/{environment_name}", func() {
+							m.Combo("").Get(repo.GetActionEnvironment).
+								Put(reqToken(), reqAdmin(), bind(api.CreateOrUpdateEnvironmentOption{}), repo.CreateOrUpdateActionEnvironment).
+								Delete(reqToken(), reqAdmin(), repo.DeleteActionEnvironment)
+							m.Get("/deployments", repo.ListActionEnvironmentDeployments)
+							m.Group("/secrets/{secretname}", func() {
+								m.Put("", bind(api.CreateOrUpdateSecretOption{}), repo.CreateOrUpdateEnvironmentSecret)
+								m.Delete("", repo.DeleteEnvironmentSecret)
+							}, reqToken(), reqAdmin())
+							m.Group("/variables", func() {
+								m.Get("", repo.ListEnvironmentVariables)
+								m.Combo("/{variablename}").
+									Post(bind(api.CreateVariableOption{}), repo.CreateEnvironmentVariable).
+									Put(bind(api.UpdateVariableOption{}), repo.UpdateEnvironmentVariable).
+									Delete(repo.DeleteEnvironmentVariable)
+							}, reqToken(), reqAdmin())
 						})
 					})
 					m.Get("/artifacts", repo.GetArtifacts)
@@ -1314,6 +1338,8 @@ func Routes() *web.Router {
 								Patch(reqToken(), reqRepoWriter(unit.TypeReleases), bind(api.EditAttachmentOptions{}), repo.EditReleaseAttachment).
 								Delete(reqToken(), reqRepoWriter(unit.TypeReleases), repo.DeleteReleaseAttachment)
 						})
+						m.Combo("/sbom").Get(repo.GetReleaseSBOM).
+							Put(reqToken(), reqRepoWriter(unit.TypeReleases), mustNotBeArchived, repo.UploadReleaseSBOM)
 					})
 					m.Group("/tags", func() {
 						m.Combo("/{tag}").
@@ -1387,6 +1413,8 @@ func Routes() *web.Router {
 					m.Group("/commits", func() {
 						m.Get("/{sha}", repo.GetSingleCommit)
 						m.Get("/{sha}.{diffType:diff|patch}", repo.DownloadCommitDiffOrPatch)
+						m.Combo("/{sha}/sbom").Get(repo.GetCommitSBOM).
+							Put(reqToken(), reqRepoWriter(unit.TypeCode), mustNotBeArchived, repo.UploadCommitSBOM)
 					})
 					m.Get("/refs", repo.GetGitAllRefs)
 					m.Get("/refs/*", repo.GetGitRefs)
@@ -1576,6 +1604,27 @@ func Routes() *web.Router {
 
 		// NOTE: these are Gitea package management API - see packages.CommonRoutes and packages.DockerContainerRoutes for endpoints that implement package manager APIs
 		m.Group("/packages/{username}", func() {
+			m.Group("/-/remotes", func() {
+				m.Get("", packages.ListPackageRemotes)
+				m.Combo("/{type}").Get(packages.GetPackageRemote).
+					Put(bind(api.SetPackageRemoteOption{}), packages.SetPackageRemote).
+					Delete(packages.DeletePackageRemote)
+			}, reqPackageAccess(perm.AccessModeAdmin))
+
+			m.Group("/-/virtuals", func() {
+				m.Get("", packages.ListPackageVirtualRegistries)
+				m.Combo("/{type}").Get(packages.GetPackageVirtualRegistry).
+					Put(bind(api.SetPackageVirtualRegistryOption{}), packages.SetPackageVirtualRegistry).
+					Delete(packages.DeletePackageVirtualRegistry)
+			}, reqPackageAccess(perm.AccessModeAdmin))
+
+			m.Get("/-/cleanup_rules/{type}/preview", reqPackageAccess(perm.AccessModeAdmin), packages.PreviewPackageCleanupRule)
+
+			m.Combo("/-/settings", reqPackageAccess(perm.AccessModeAdmin)).Get(packages.GetPackageSettings).
+				Patch(bind(api.EditPackageSettingsOption{}), packages.EditPackageSettings)
+
+			m.Get("/-/sbom/components", packages.SearchSBOMComponents)
+
 			m.Group("/{type}/{name}", func() {
 				m.Get("/", packages.ListPackageVersions)
 				m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackage)
@@ -1584,10 +1633,20 @@ func Routes() *web.Router {
 					m.Get("", packages.GetPackage)
 					m.Delete("", reqPackageAccess(perm.AccessModeWrite), packages.DeletePackageVersion)
 					m.Get("/files", packages.ListPackageFiles)
+					m.Combo("/sbom").Get(packages.GetPackageSBOM).
+						Put(reqPackageAccess(perm.AccessModeWrite), packages.UploadPackageSBOM)
+					m.Group("/channels", func() {
+						m.Get("", packages.ListPackageVersionChannels)
+						m.Combo("/{channel}", reqPackageAccess(perm.AccessModeWrite)).
+							Put(packages.AddPackageVersionToChannel).
+							Delete(packages.RemovePackageVersionFromChannel)
+					})
+					m.Post("/promote", reqPackageAccess(perm.AccessModeWrite), bind(api.PromotePackageVersionOption{}), packages.PromotePackageVersion)
 				})
 
 				m.Group("/-", func() {
 					m.Get("/latest", packages.GetLatestPackageVersion)
+					m.Get("/channels/{channel}", packages.ListPackageChannelVersions)
 					m.Post("/link/{repo_name}", reqPackageAccess(perm.AccessModeWrite), packages.LinkPackage)
 					m.Post("/unlink", reqPackageAccess(perm.AccessModeWrite), packages.UnlinkPackage)
 				})
@@ -1623,6 +1682,29 @@ func Routes() *web.Router {
 				reqOrgOwnership(),
 				org.NewAction(),
 			)
+			m.Group("/actions/runner-groups", func() {
+				m.Combo("").Get(org.ListRunnerGroups).
+					Post(bind(api.CreateActionRunnerGroupOption{}), org.CreateRunnerGroup)
+				m.Group("/{group_id}", func() {
+					m.Combo("").Get(org.GetRunnerGroup).
+						Patch(bind(api.EditActionRunnerGroupOption{}), org.EditRunnerGroup).
+						Delete(org.DeleteRunnerGroup)
+					m.Get("/runners", org.ListRunnerGroupRunners)
+					m.Combo("/runners/{runner_id}").Put(org.AddRunnerGroupRunner).
+						Delete(org.RemoveRunnerGroupRunner)
+				})
+			}, reqToken(), reqOrgOwnership())
+			m.Group("/actions/required-workflows", func() {
+				m.Combo("").Get(org.ListRequiredWorkflows).
+					Post(bind(api.CreateActionRequiredWorkflowOption{}), org.CreateRequiredWorkflow)
+				m.Combo("/{workflow_id}").Get(org.GetRequiredWorkflow).
+					Patch(bind(api.EditActionRequiredWorkflowOption{}), org.EditRequiredWorkflow).
+					Delete(org.DeleteRequiredWorkflow)
+			}, reqToken(), reqOrgOwnership())
+			m.Group("/actions/usage", func() {
+				m.Get("", org.GetActionsUsage)
+				m.Get("/quota", org.GetActionsUsageQuota)
+			}, reqToken(), reqOrgOwnership())
 			m.Group("/public_members", func() {
 				m.Get("", org.ListPublicMembers)
 				m.Combo("/{username}").Get(org.IsPublicMember).
@@ -1644,9 +1726,12 @@ func Routes() *web.Router {
 			m.Group("/hooks", func() {
 				m.Combo("").Get(org.ListHooks).
 					Post(bind(api.CreateHookOption{}), org.CreateHook)
+				m.Get("/dead_letters", org.ListDeadLetteredHookDeliveries)
+				m.Post("/dead_letters/redeliver", bind(api.RedeliverHookDeliveriesOption{}), org.RedeliverDeadLetteredHookDeliveries)
 				m.Combo("/{id}").Get(org.GetHook).
 					Patch(bind(api.EditHookOption{}), org.EditHook).
 					Delete(org.DeleteHook)
+				m.Post("/{id}/preview", bind(api.HookPreviewOption{}), org.PreviewHook)
 			}, reqToken(), reqOrgOwnership(), reqWebhooksEnabled())
 			m.Group("/avatar", func() {
 				m.Post("", bind(api.UpdateUserAvatarOption{}), org.UpdateAvatar)
@@ -1707,6 +1792,9 @@ func Routes() *web.Router {
 					m.Get("/badges", admin.ListUserBadges)
 					m.Post("/badges", bind(api.UserBadgeOption{}), admin.AddUserBadges)
 					m.Delete("/badges", bind(api.UserBadgeOption{}), admin.DeleteUserBadges)
+					m.Combo("/actions/quota").Get(admin.GetUserActionsUsageQuota).
+						Put(bind(api.SetActionUsageQuotaOption{}), admin.SetUserActionsUsageQuota).
+						Delete(admin.DeleteUserActionsUsageQuota)
 				}, context.UserAssignmentAPI())
 			})
 			m.Group("/emails", func() {
@@ -1721,9 +1809,12 @@ func Routes() *web.Router {
 			m.Group("/hooks", func() {
 				m.Combo("").Get(admin.ListHooks).
 					Post(bind(api.CreateHookOption{}), admin.CreateHook)
+				m.Get("/dead_letters", admin.ListDeadLetteredHookDeliveries)
+				m.Post("/dead_letters/redeliver", bind(api.RedeliverHookDeliveriesOption{}), admin.RedeliverDeadLetteredHookDeliveries)
 				m.Combo("/{id}").Get(admin.GetHook).
 					Patch(bind(api.EditHookOption{}), admin.EditHook).
 					Delete(admin.DeleteHook)
+				m.Post("/{id}/preview", bind(api.HookPreviewOption{}), admin.PreviewHook)
 			})
 			m.Group("/actions", func() {
 				m.Group("/runners", func() {
@@ -1735,6 +1826,7 @@ func Routes() *web.Router {
 				})
 				m.Get("/runs", admin.ListWorkflowRuns)
 				m.Get("/jobs", admin.ListWorkflowJobs)
+				m.Get("/usage", admin.GetActionsUsage)
 			})
 		}, tokenRequiresScopes(auth_model.AccessTokenScopeCategoryAdmin), reqToken(), reqSiteAdmin())
 
@@ -0,0 +1,346 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func getCurrentOrgRequiredWorkflow(ctx *context.APIContext) *actions_model.ActionRequiredWorkflow {
+	w, err := actions_model.GetRequiredWorkflowByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("workflow_id"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err)
+		return nil
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	return w
+}
+
+// getOrgRepoIDByName returns the id of the repository of the organization, it responds 422 if the repository doesn't exist
+func getOrgRepoIDByName(ctx *context.APIContext, name string) int64 {
+	repo, err := repo_model.GetRepositoryByName(ctx, ctx.Org.Organization.ID, name)
+	if err != nil {
+		if repo_model.IsErrRepoNotExist(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("repository %q doesn't exist", name))
+			return 0
+		}
+		ctx.APIErrorInternal(err)
+		return 0
+	}
+	return repo.ID
+}
+
+// saveRequiredWorkflow checks the workflow file can be read from the central repository, then creates or updates the required workflow
+func saveRequiredWorkflow(ctx *context.APIContext, w *actions_model.ActionRequiredWorkflow, cols ...string) bool {
+	if err := actions_model.ValidateRequiredWorkflow(w); err != nil {
+		ctx.APIError(http.StatusBadRequest, err)
+		return false
+	}
+	content, err := actions_service.ReadRequiredWorkflow(ctx, w)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return false
+		}
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	if _, err := actions_module.GetEventsFromContent(content); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("invalid workflow %q: %v", w.WorkflowPath, err))
+		return false
+	}
+
+	if w.ID == 0 {
+		err = actions_model.CreateRequiredWorkflow(ctx, w)
+	} else {
+		err = actions_model.UpdateRequiredWorkflow(ctx, w, cols...)
+	}
+	if err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusBadRequest, err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return false
+	}
+	return true
+}
+
+func writeRequiredWorkflowResponse(ctx *context.APIContext, status int, w *actions_model.ActionRequiredWorkflow) {
+	apiWorkflow, err := convert.ToActionRequiredWorkflow(ctx, w)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, apiWorkflow)
+}
+
+// ListRequiredWorkflows lists the required workflows of an organization
+func ListRequiredWorkflows(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/required-workflows organization orgListRequiredWorkflows
+	// ---
+	// summary: List the required workflows of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRequiredWorkflowList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	workflows, count, err := db.FindAndCount[actions_model.ActionRequiredWorkflow](ctx, actions_model.FindRequiredWorkflowsOptions{
+		ListOptions: listOptions,
+		OwnerID:     ctx.Org.Organization.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiWorkflows := make([]*api.ActionRequiredWorkflow, 0, len(workflows))
+	for _, w := range workflows {
+		apiWorkflow, err := convert.ToActionRequiredWorkflow(ctx, w)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiWorkflows = append(apiWorkflows, apiWorkflow)
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiWorkflows)
+}
+
+// CreateRequiredWorkflow creates a required workflow for an organization
+func CreateRequiredWorkflow(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/actions/required-workflows organization orgCreateRequiredWorkflow
+	// ---
+	// summary: Create a required workflow which runs on the pushes and the pull requests of the matched repositories of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateActionRequiredWorkflowOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRequiredWorkflow"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: a required workflow with the same name already exists
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateActionRequiredWorkflowOption)
+	repoID := getOrgRepoIDByName(ctx, form.Repository)
+	if ctx.Written() {
+		return
+	}
+
+	w := &actions_model.ActionRequiredWorkflow{
+		OwnerID:        ctx.Org.Organization.ID,
+		Name:           form.Name,
+		RepoID:         repoID,
+		WorkflowPath:   form.Path,
+		Ref:            form.Ref,
+		RepoPatterns:   form.RepoPatterns,
+		BranchPatterns: form.BranchPatterns,
+	}
+	if !saveRequiredWorkflow(ctx, w) {
+		return
+	}
+	writeRequiredWorkflowResponse(ctx, http.StatusCreated, w)
+}
+
+// GetRequiredWorkflow gets a required workflow of an organization
+func GetRequiredWorkflow(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/required-workflows/{workflow_id} organization orgGetRequiredWorkflow
+	// ---
+	// summary: Get a required workflow of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: workflow_id
+	//   in: path
+	//   description: id of the required workflow
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRequiredWorkflow"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	w := getCurrentOrgRequiredWorkflow(ctx)
+	if ctx.Written() {
+		return
+	}
+	writeRequiredWorkflowResponse(ctx, http.StatusOK, w)
+}
+
+// EditRequiredWorkflow edits a required workflow of an organization
+func EditRequiredWorkflow(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/actions/required-workflows/{workflow_id} organization orgEditRequiredWorkflow
+	// ---
+	// summary: Edit a required workflow of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: workflow_id
+	//   in: path
+	//   description: id of the required workflow
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionRequiredWorkflowOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRequiredWorkflow"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: a required workflow with the same name already exists
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditActionRequiredWorkflowOption)
+	w := getCurrentOrgRequiredWorkflow(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var cols []string
+	if form.Name != nil {
+		w.Name = *form.Name
+		cols = append(cols, "name")
+	}
+	if form.Repository != nil {
+		w.RepoID = getOrgRepoIDByName(ctx, *form.Repository)
+		if ctx.Written() {
+			return
+		}
+		cols = append(cols, "repo_id")
+	}
+	if form.Path != nil {
+		w.WorkflowPath = *form.Path
+		cols = append(cols, "workflow_path")
+	}
+	if form.Ref != nil {
+		w.Ref = *form.Ref
+		cols = append(cols, "ref")
+	}
+	if form.RepoPatterns != nil {
+		w.RepoPatterns = form.RepoPatterns
+		cols = append(cols, "repo_patterns")
+	}
+	if form.BranchPatterns != nil {
+		w.BranchPatterns = form.BranchPatterns
+		cols = append(cols, "branch_patterns")
+	}
+
+	if len(cols) > 0 && !saveRequiredWorkflow(ctx, w, cols...) {
+		return
+	}
+	writeRequiredWorkflowResponse(ctx, http.StatusOK, w)
+}
+
+// DeleteRequiredWorkflow deletes a required workflow of an organization
+func DeleteRequiredWorkflow(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/required-workflows/{workflow_id} organization orgDeleteRequiredWorkflow
+	// ---
+	// summary: Delete a required workflow of an organization, its status checks are not required anymore
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: workflow_id
+	//   in: path
+	//   description: id of the required workflow
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	w := getCurrentOrgRequiredWorkflow(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_model.DeleteRequiredWorkflow(ctx, w); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
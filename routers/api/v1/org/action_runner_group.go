@@ -0,0 +1,484 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+func getCurrentOrgRunnerGroup(ctx *context.APIContext) *actions_model.ActionRunnerGroup {
+	g, err := actions_model.GetRunnerGroupByID(ctx, ctx.Org.Organization.ID, ctx.PathParamInt64("group_id"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err)
+		return nil
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	return g
+}
+
+func getCurrentOrgRunner(ctx *context.APIContext) *actions_model.ActionRunner {
+	runner, err := actions_model.GetRunnerByID(ctx, ctx.PathParamInt64("runner_id"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound("Runner not found")
+		return nil
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	if !runner.EditableInContext(ctx.Org.Organization.ID, 0) {
+		ctx.APIErrorNotFound("No permission to access this runner")
+		return nil
+	}
+	return runner
+}
+
+// getOrgRepoIDsByNames returns the ids of the repositories of the organization, it responds 422 if a repository doesn't exist
+func getOrgRepoIDsByNames(ctx *context.APIContext, names []string) []int64 {
+	ids := make([]int64, 0, len(names))
+	for _, name := range names {
+		repo, err := repo_model.GetRepositoryByName(ctx, ctx.Org.Organization.ID, name)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, fmt.Sprintf("repository %q doesn't exist", name))
+				return nil
+			}
+			ctx.APIErrorInternal(err)
+			return nil
+		}
+		ids = append(ids, repo.ID)
+	}
+	return ids
+}
+
+func writeRunnerGroupResponse(ctx *context.APIContext, status int, g *actions_model.ActionRunnerGroup) {
+	apiGroup, err := convert.ToActionRunnerGroup(ctx, g)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, apiGroup)
+}
+
+// ListRunnerGroups lists the runner groups of an organization
+func ListRunnerGroups(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups organization orgListRunnerGroups
+	// ---
+	// summary: List the runner groups of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroupList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	groups, count, err := db.FindAndCount[actions_model.ActionRunnerGroup](ctx, actions_model.FindRunnerGroupsOptions{
+		ListOptions: listOptions,
+		OwnerID:     ctx.Org.Organization.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiGroups := make([]*api.ActionRunnerGroup, 0, len(groups))
+	for _, g := range groups {
+		apiGroup, err := convert.ToActionRunnerGroup(ctx, g)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiGroups = append(apiGroups, apiGroup)
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiGroups)
+}
+
+// CreateRunnerGroup creates a runner group for an organization
+func CreateRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/actions/runner-groups organization orgCreateRunnerGroup
+	// ---
+	// summary: Create a runner group for an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateActionRunnerGroupOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: a runner group with the same name already exists
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateActionRunnerGroupOption)
+	repoIDs := getOrgRepoIDsByNames(ctx, form.Repositories)
+	if ctx.Written() {
+		return
+	}
+
+	g := &actions_model.ActionRunnerGroup{
+		OwnerID:          ctx.Org.Organization.ID,
+		Name:             form.Name,
+		RepoIDs:          repoIDs,
+		BranchPatterns:   form.BranchPatterns,
+		WorkflowPatterns: form.WorkflowPatterns,
+	}
+	if err := actions_model.CreateRunnerGroup(ctx, g); err != nil {
+		switch {
+		case errors.Is(err, util.ErrInvalidArgument):
+			ctx.APIError(http.StatusBadRequest, err)
+		case errors.Is(err, util.ErrAlreadyExist):
+			ctx.APIError(http.StatusConflict, err)
+		default:
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	writeRunnerGroupResponse(ctx, http.StatusCreated, g)
+}
+
+// GetRunnerGroup gets a runner group of an organization
+func GetRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups/{group_id} organization orgGetRunnerGroup
+	// ---
+	// summary: Get a runner group of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getCurrentOrgRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	writeRunnerGroupResponse(ctx, http.StatusOK, g)
+}
+
+// EditRunnerGroup edits a runner group of an organization
+func EditRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation PATCH /orgs/{org}/actions/runner-groups/{group_id} organization orgEditRunnerGroup
+	// ---
+	// summary: Edit a runner group of an organization
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditActionRunnerGroupOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionRunnerGroup"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: a runner group with the same name already exists
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.EditActionRunnerGroupOption)
+	g := getCurrentOrgRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	var cols []string
+	if form.Name != nil {
+		g.Name = *form.Name
+		cols = append(cols, "name")
+	}
+	if form.Repositories != nil {
+		g.RepoIDs = getOrgRepoIDsByNames(ctx, form.Repositories)
+		if ctx.Written() {
+			return
+		}
+		cols = append(cols, "repo_ids")
+	}
+	if form.BranchPatterns != nil {
+		g.BranchPatterns = form.BranchPatterns
+		cols = append(cols, "branch_patterns")
+	}
+	if form.WorkflowPatterns != nil {
+		g.WorkflowPatterns = form.WorkflowPatterns
+		cols = append(cols, "workflow_patterns")
+	}
+
+	if len(cols) > 0 {
+		if err := actions_model.UpdateRunnerGroup(ctx, g, cols...); err != nil {
+			switch {
+			case errors.Is(err, util.ErrInvalidArgument):
+				ctx.APIError(http.StatusBadRequest, err)
+			case errors.Is(err, util.ErrAlreadyExist):
+				ctx.APIError(http.StatusConflict, err)
+			default:
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+	}
+	writeRunnerGroupResponse(ctx, http.StatusOK, g)
+}
+
+// DeleteRunnerGroup deletes a runner group of an organization
+func DeleteRunnerGroup(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/runner-groups/{group_id} organization orgDeleteRunnerGroup
+	// ---
+	// summary: Delete a runner group of an organization, its runners are moved out of it and become unrestricted
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getCurrentOrgRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_model.DeleteRunnerGroup(ctx, g); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListRunnerGroupRunners lists the runners in a runner group of an organization
+func ListRunnerGroupRunners(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/runner-groups/{group_id}/runners organization orgListRunnerGroupRunners
+	// ---
+	// summary: List the runners in a runner group of an organization
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/RunnerList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getCurrentOrgRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	runners, total, err := db.FindAndCount[actions_model.ActionRunner](ctx, &actions_model.FindRunnerOptions{
+		ListOptions: utils.GetListOptions(ctx),
+		OwnerID:     ctx.Org.Organization.ID,
+		GroupID:     g.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	res := &api.ActionRunnersResponse{
+		TotalCount: total,
+		Entries:    make([]*api.ActionRunner, len(runners)),
+	}
+	for i, runner := range runners {
+		res.Entries[i] = convert.ToActionRunner(ctx, runner)
+	}
+	ctx.JSON(http.StatusOK, res)
+}
+
+// AddRunnerGroupRunner moves an org-level runner into a runner group
+func AddRunnerGroupRunner(ctx *context.APIContext) {
+	// swagger:operation PUT /orgs/{org}/actions/runner-groups/{group_id}/runners/{runner_id} organization orgAddRunnerGroupRunner
+	// ---
+	// summary: Move an org-level runner into a runner group, the runner is removed from its previous group
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getCurrentOrgRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	runner := getCurrentOrgRunner(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_model.SetRunnerGroup(ctx, runner, g.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// RemoveRunnerGroupRunner moves an org-level runner out of a runner group
+func RemoveRunnerGroupRunner(ctx *context.APIContext) {
+	// swagger:operation DELETE /orgs/{org}/actions/runner-groups/{group_id}/runners/{runner_id} organization orgRemoveRunnerGroupRunner
+	// ---
+	// summary: Move an org-level runner out of a runner group, the runner becomes unrestricted
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_id
+	//   in: path
+	//   description: id of the runner group
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: runner_id
+	//   in: path
+	//   description: id of the runner
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	g := getCurrentOrgRunnerGroup(ctx)
+	if ctx.Written() {
+		return
+	}
+	runner := getCurrentOrgRunner(ctx)
+	if ctx.Written() {
+		return
+	}
+	if runner.GroupID != g.ID {
+		ctx.APIErrorNotFound("The runner isn't in the runner group")
+		return
+	}
+	if err := actions_model.SetRunnerGroup(ctx, runner, 0); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
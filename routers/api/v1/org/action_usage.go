@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package org
+
+import (
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/routers/api/v1/shared"
+	"code.gitea.io/gitea/services/context"
+)
+
+// GetActionsUsage gets the Actions usage of an organization
+func GetActionsUsage(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/usage organization orgGetActionsUsage
+	// ---
+	// summary: Get the runner time used by the finished Actions tasks of an organization
+	// produces:
+	// - application/json
+	// - text/csv
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: group_by
+	//   in: query
+	//   description: aggregate the usage by owner, repository or runs-on labels, defaults to repo
+	//   type: string
+	//   enum: [owner, repo, labels]
+	// - name: from
+	//   in: query
+	//   description: the first month of the usage like 2026-01, defaults to the current month if both from and to are omitted
+	//   type: string
+	// - name: to
+	//   in: query
+	//   description: the last month of the usage like 2026-12
+	//   type: string
+	// - name: format
+	//   in: query
+	//   description: response format, csv exports the usage as a CSV file
+	//   type: string
+	//   enum: [json, csv]
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionUsageList"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.ListUsage(ctx, ctx.Org.Organization.ID, actions_model.UsageGroupByRepo)
+}
+
+// GetActionsUsageQuota gets the Actions minutes quota of an organization
+func GetActionsUsageQuota(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/actions/usage/quota organization orgGetActionsUsageQuota
+	// ---
+	// summary: Get the monthly Actions minutes quota of an organization and the minutes used in the current month
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionUsageQuota"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	shared.GetUsageQuota(ctx, ctx.Org.Organization.ID)
+}
@@ -187,3 +187,110 @@ func DeleteHook(ctx *context.APIContext) {
 		ctx.PathParamInt64("id"),
 	)
 }
+
+// PreviewHook renders the request a hook would send for an event without delivering it
+func PreviewHook(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/hooks/{id}/preview organization orgPreviewHook
+	// ---
+	// summary: Preview the request a hook would send for an event without delivering it
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the hook to preview
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/HookPreviewOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookPreview"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	hook, err := utils.GetOwnerHook(ctx, ctx.ContextUser.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		return
+	}
+	utils.PreviewHook(ctx, hook, web.GetForm(ctx).(*api.HookPreviewOption))
+}
+
+// ListDeadLetteredHookDeliveries lists the dead-lettered deliveries of an organization's webhooks
+func ListDeadLetteredHookDeliveries(ctx *context.APIContext) {
+	// swagger:operation GET /orgs/{org}/hooks/dead_letters organization orgListDeadLetteredHookDeliveries
+	// ---
+	// summary: List the dead-lettered deliveries of the webhooks of an organization and of its repositories
+	// description: A delivery is dead-lettered when all its automatic retries failed.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: hook_id
+	//   in: query
+	//   description: only list the deliveries of this hook
+	//   type: integer
+	//   format: int64
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	utils.ListDeadLetteredHookDeliveries(ctx, ctx.ContextUser.ID)
+}
+
+// RedeliverDeadLetteredHookDeliveries redelivers dead-lettered deliveries of an organization's webhooks
+func RedeliverDeadLetteredHookDeliveries(ctx *context.APIContext) {
+	// swagger:operation POST /orgs/{org}/hooks/dead_letters/redeliver organization orgRedeliverDeadLetteredHookDeliveries
+	// ---
+	// summary: Redeliver dead-lettered deliveries of the webhooks of an organization and of its repositories
+	// description: Each delivery is redelivered as a new delivery, which gets the automatic retries again.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: org
+	//   in: path
+	//   description: name of the organization
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/RedeliverHookDeliveriesOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/HookDeliveryList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	utils.RedeliverDeadLetteredHookDeliveries(ctx, ctx.ContextUser.ID, web.GetForm(ctx).(*api.RedeliverHookDeliveriesOption))
+}
@@ -0,0 +1,300 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+func writeChannelError(ctx *context.APIContext, err error) {
+	switch {
+	case errors.Is(err, util.ErrInvalidArgument):
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+	case errors.Is(err, util.ErrAlreadyExist):
+		ctx.APIError(http.StatusConflict, err)
+	case errors.Is(err, util.ErrNotExist):
+		ctx.APIErrorNotFound(err)
+	default:
+		ctx.APIErrorInternal(err)
+	}
+}
+
+// ListPackageVersionChannels gets the channels a package version is published to
+func ListPackageVersionChannels(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/channels package listPackageVersionChannels
+	// ---
+	// summary: Gets the channels a package version is published to
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVersionChannelList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	channels, err := packages.GetChannelsByVersionID(ctx, ctx.Package.Descriptor.Version.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiChannels := make([]*api.PackageVersionChannel, 0, len(channels))
+	for _, pvc := range channels {
+		apiChannels = append(apiChannels, convert.ToPackageVersionChannel(pvc))
+	}
+
+	ctx.JSON(http.StatusOK, apiChannels)
+}
+
+// AddPackageVersionToChannel publishes a package version to a channel
+func AddPackageVersionToChannel(ctx *context.APIContext) {
+	// swagger:operation PUT /packages/{owner}/{type}/{name}/{version}/channels/{channel} package addPackageVersionToChannel
+	// ---
+	// summary: Publishes a package version to a channel
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: channel
+	//   in: path
+	//   description: name of the channel
+	//   type: string
+	//   required: true
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageVersionChannel"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	pvc, err := packages_service.PublishToChannel(ctx, ctx.Doer, ctx.Package.Descriptor.Version, ctx.PathParam("channel"))
+	if err != nil {
+		writeChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToPackageVersionChannel(pvc))
+}
+
+// RemovePackageVersionFromChannel removes a package version from a channel
+func RemovePackageVersionFromChannel(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/{type}/{name}/{version}/channels/{channel} package removePackageVersionFromChannel
+	// ---
+	// summary: Removes a package version from a channel
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: channel
+	//   in: path
+	//   description: name of the channel
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	if err := packages.RemoveVersionFromChannel(ctx, ctx.Package.Descriptor.Version.ID, ctx.PathParam("channel")); err != nil {
+		writeChannelError(ctx, err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// PromotePackageVersion promotes a package version from one channel to another
+func PromotePackageVersion(ctx *context.APIContext) {
+	// swagger:operation POST /packages/{owner}/{type}/{name}/{version}/promote package promotePackageVersion
+	// ---
+	// summary: Promotes a package version from one channel to another
+	// description: The version must be published to the source channel. The files of the version are not copied, the version is published to the target channel too.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/PromotePackageVersionOption"
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/PackageVersionChannel"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     "$ref": "#/responses/conflict"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.PromotePackageVersionOption)
+
+	pvc, err := packages_service.PromoteVersion(ctx, ctx.Doer, ctx.Package.Descriptor.Version, form.From, form.To)
+	if err != nil {
+		writeChannelError(ctx, err)
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToPackageVersionChannel(pvc))
+}
+
+// ListPackageChannelVersions gets the versions of a package published to a channel
+func ListPackageChannelVersions(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/-/channels/{channel} package listPackageChannelVersions
+	// ---
+	// summary: Gets the versions of a package published to a channel, the newest version first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: channel
+	//   in: path
+	//   description: name of the channel
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	p, err := packages.GetPackageByName(ctx, ctx.Package.Owner.ID, packages.Type(ctx.PathParam("type")), ctx.PathParam("name"))
+	if err != nil {
+		if errors.Is(err, packages.ErrPackageNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	pvs, err := packages.GetVersionsByChannel(ctx, p.ID, ctx.PathParam("channel"))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	pds, err := packages.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiPackages := make([]*api.Package, 0, len(pds))
+	for _, pd := range pds {
+		apiPackage, err := convert.ToPackage(ctx, pd, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiPackages = append(apiPackages, apiPackage)
+	}
+
+	ctx.JSON(http.StatusOK, apiPackages)
+}
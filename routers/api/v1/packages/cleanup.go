@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
+)
+
+// PreviewPackageCleanupRule lists the package versions the cleanup rule of a package type would remove
+func PreviewPackageCleanupRule(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/cleanup_rules/{type}/preview package previewPackageCleanupRule
+	// ---
+	// summary: Lists the package versions the cleanup rule of a package type would remove if it was executed now
+	// description: This is a dry run, no package version is removed. The rule is evaluated even if it is disabled.
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the cleanup rule
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the cleanup rule
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageCleanupCandidateList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	pcr, err := packages.GetCleanupRuleByOwnerAndType(ctx, ctx.Package.Owner.ID, packages.Type(ctx.PathParam("type")))
+	if err != nil {
+		if errors.Is(err, packages.ErrPackageCleanupRuleNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	pds, err := packages_cleanup_service.PreviewCleanupRule(ctx, pcr)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	candidates := make([]*api.PackageCleanupCandidate, 0, len(pds))
+	for _, pd := range pds {
+		candidate, err := convert.ToPackageCleanupCandidate(ctx, pd, ctx.Doer)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		candidates = append(candidates, candidate)
+	}
+
+	ctx.JSON(http.StatusOK, candidates)
+}
@@ -0,0 +1,202 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/packages"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListPackageRemotes gets all remotes of an owner
+func ListPackageRemotes(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/remotes package listPackageRemotes
+	// ---
+	// summary: Gets all upstream registries of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the remotes
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageRemoteList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	rs, err := packages.GetRemotesByOwner(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiRemotes := make([]*api.PackageRemote, 0, len(rs))
+	for _, r := range rs {
+		apiRemotes = append(apiRemotes, convert.ToPackageRemote(r))
+	}
+
+	ctx.JSON(http.StatusOK, apiRemotes)
+}
+
+// GetPackageRemote gets the remote of a package type
+func GetPackageRemote(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/remotes/{type} package getPackageRemote
+	// ---
+	// summary: Gets the upstream registry of a package type
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the remote
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the remote
+	//   type: string
+	//   enum: [go, maven, npm, pypi]
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageRemote"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r, err := packages.GetRemoteByOwnerAndType(ctx, ctx.Package.Owner.ID, packages.Type(ctx.PathParam("type")))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageRemote(r))
+}
+
+// SetPackageRemote creates or replaces the remote of a package type
+func SetPackageRemote(ctx *context.APIContext) {
+	// swagger:operation PUT /packages/{owner}/-/remotes/{type} package setPackageRemote
+	// ---
+	// summary: Creates or replaces the upstream registry of a package type
+	// description: The packages which are requested but don't exist are fetched from the upstream registry and cached as normal package versions.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the remote
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the remote
+	//   type: string
+	//   enum: [go, maven, npm, pypi]
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetPackageRemoteOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageRemote"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetPackageRemoteOption)
+
+	r := &packages.PackageRemote{
+		OwnerID:     ctx.Package.Owner.ID,
+		Type:        packages.Type(ctx.PathParam("type")),
+		URL:         form.URL,
+		Username:    form.Username,
+		MetadataTTL: form.MetadataTTL,
+	}
+	if err := r.SetPassword(form.Password); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if err := packages.SetRemote(ctx, r); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	r, err := packages.GetRemoteByOwnerAndType(ctx, r.OwnerID, r.Type)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToPackageRemote(r))
+}
+
+// DeletePackageRemote deletes the remote of a package type
+func DeletePackageRemote(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/-/remotes/{type} package deletePackageRemote
+	// ---
+	// summary: Deletes the upstream registry of a package type, the cached packages are kept
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the remote
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the remote
+	//   type: string
+	//   enum: [go, maven, npm, pypi]
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	r, err := packages.GetRemoteByOwnerAndType(ctx, ctx.Package.Owner.ID, packages.Type(ctx.PathParam("type")))
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if err := packages.DeleteRemote(ctx, r); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
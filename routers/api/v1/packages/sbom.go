@@ -0,0 +1,231 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	sbom_model "code.gitea.io/gitea/models/sbom"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	sbom_service "code.gitea.io/gitea/services/sbom"
+)
+
+// GetPackageSBOM gets the software bill of materials attached to a package version
+func GetPackageSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/{type}/{name}/{version}/sbom package getPackageSBOM
+	// ---
+	// summary: Gets the software bill of materials attached to a package version
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SBOMDocument"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	doc, err := sbom_model.GetDocumentByPackageVersionID(ctx, ctx.Package.Descriptor.Version.ID)
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	inventory, err := sbom_service.GetInventory(ctx, doc)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, convert.ToSBOMDocument(inventory.Document, inventory.Components))
+}
+
+// UploadPackageSBOM attaches a software bill of materials to a package version
+func UploadPackageSBOM(ctx *context.APIContext) {
+	// swagger:operation PUT /packages/{owner}/{type}/{name}/{version}/sbom package uploadPackageSBOM
+	// ---
+	// summary: Attach a CycloneDX or SPDX JSON software bill of materials to a package version, replacing the existing one
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the package
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: type of the package
+	//   type: string
+	//   required: true
+	// - name: name
+	//   in: path
+	//   description: name of the package
+	//   type: string
+	//   required: true
+	// - name: version
+	//   in: path
+	//   description: version of the package
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the CycloneDX or SPDX JSON document
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SBOMDocument"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	inventory, err := sbom_service.IngestForPackageVersion(ctx, ctx.Doer, ctx.Package.Descriptor.Version, ctx.Req.Body)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusCreated, convert.ToSBOMDocument(inventory.Document, inventory.Components))
+}
+
+// SearchSBOMComponents searches the components of the software bills of materials of an owner
+func SearchSBOMComponents(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/sbom/components package searchSBOMComponents
+	// ---
+	// summary: Search the components listed in the software bills of materials attached to the packages and repositories of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages and repositories
+	//   type: string
+	//   required: true
+	// - name: purl
+	//   in: query
+	//   description: package url of the component, a package url with a version only matches this version
+	//   type: string
+	// - name: version
+	//   in: query
+	//   description: version of the component
+	//   type: string
+	// - name: q
+	//   in: query
+	//   description: name filter
+	//   type: string
+	// - name: license
+	//   in: query
+	//   description: license filter
+	//   type: string
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SBOMComponentSearchResultList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	// the repositories are only searched if the token grants access to them
+	includeRepos := true
+	if scope, ok := ctx.Data["ApiTokenScope"].(auth_model.AccessTokenScope); ctx.Data["IsApiToken"] == true && ok {
+		hasScope, err := scope.HasScope(auth_model.AccessTokenScopeReadRepository)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		includeRepos = hasScope
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+
+	matches, count, err := sbom_service.SearchComponents(ctx, &sbom_model.ComponentSearchOptions{
+		ListOptions:  listOptions,
+		OwnerID:      ctx.Package.Owner.ID,
+		Actor:        ctx.Doer,
+		IncludeRepos: includeRepos,
+		PublicOnly:   ctx.PublicOnly,
+		Keyword:      ctx.FormTrim("q"),
+		PURL:         ctx.FormTrim("purl"),
+		Version:      ctx.FormTrim("version"),
+		License:      ctx.FormTrim("license"),
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	results := make([]*api.SBOMComponentSearchResult, 0, len(matches))
+	for _, m := range matches {
+		result := &api.SBOMComponentSearchResult{
+			Component: convert.ToSBOMComponent(m.Component),
+			CommitSHA: m.Document.CommitSHA,
+			ReleaseID: m.Document.ReleaseID,
+		}
+		if m.PackageDescriptor != nil {
+			result.Package, err = convert.ToPackage(ctx, m.PackageDescriptor, ctx.Doer)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+		}
+		if m.Repository != nil {
+			permission, err := access_model.GetDoerRepoPermission(ctx, m.Repository, ctx.Doer)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+			result.Repository = convert.ToRepo(ctx, m.Repository, permission)
+		}
+		results = append(results, result)
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, results)
+}
@@ -0,0 +1,88 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"net/http"
+
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+func writePackageSettings(ctx *context.APIContext) {
+	immutable, err := packages_service.IsImmutableVersionsEnabled(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &api.PackageSettings{
+		ImmutableVersions: immutable,
+	})
+}
+
+// GetPackageSettings gets the package settings of an owner
+func GetPackageSettings(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/settings package getPackageSettings
+	// ---
+	// summary: Gets the package settings of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageSettings"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	writePackageSettings(ctx)
+}
+
+// EditPackageSettings changes the package settings of an owner
+func EditPackageSettings(ctx *context.APIContext) {
+	// swagger:operation PATCH /packages/{owner}/-/settings package editPackageSettings
+	// ---
+	// summary: Changes the package settings of an owner
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the packages
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/EditPackageSettingsOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageSettings"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	form := web.GetForm(ctx).(*api.EditPackageSettingsOption)
+
+	if form.ImmutableVersions != nil {
+		if err := packages_service.SetImmutableVersionsEnabled(ctx, ctx.Package.Owner.ID, *form.ImmutableVersions); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	writePackageSettings(ctx)
+}
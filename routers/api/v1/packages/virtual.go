@@ -0,0 +1,240 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"errors"
+	"net/http"
+
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListPackageVirtualRegistries gets all virtual registries of an owner
+func ListPackageVirtualRegistries(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/virtuals package listPackageVirtualRegistries
+	// ---
+	// summary: Gets the members of all virtual registries of an owner
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the registries
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVirtualRegistryList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	members, err := packages.GetVirtualMembersByOwner(ctx, ctx.Package.Owner.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	registries, err := convert.ToPackageVirtualRegistries(ctx, members)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registries)
+}
+
+// GetPackageVirtualRegistry gets the virtual registry of a package type
+func GetPackageVirtualRegistry(ctx *context.APIContext) {
+	// swagger:operation GET /packages/{owner}/-/virtuals/{type} package getPackageVirtualRegistry
+	// ---
+	// summary: Gets the members of the virtual registry of a package type
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the registry
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the registry
+	//   type: string
+	//   enum: [maven, npm, pypi]
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVirtualRegistry"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	packageType := packages.Type(ctx.PathParam("type"))
+
+	members, err := packages.GetVirtualMembers(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if len(members) == 0 {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	registries, err := convert.ToPackageVirtualRegistries(ctx, members)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if len(registries) == 0 {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registries[0])
+}
+
+// SetPackageVirtualRegistry replaces the members of the virtual registry of a package type
+func SetPackageVirtualRegistry(ctx *context.APIContext) {
+	// swagger:operation PUT /packages/{owner}/-/virtuals/{type} package setPackageVirtualRegistry
+	// ---
+	// summary: Replaces the members of the virtual registry of a package type
+	// description: The registry of the package type of the owner resolves the packages of the owner first, then the ones of the members in the given order.
+	//   The packages of the members which the requesting user can't read are ignored.
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the registry
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the registry
+	//   type: string
+	//   enum: [maven, npm, pypi]
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/SetPackageVirtualRegistryOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/PackageVirtualRegistry"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.SetPackageVirtualRegistryOption)
+	packageType := packages.Type(ctx.PathParam("type"))
+
+	memberIDs := make([]int64, 0, len(form.Members))
+	for _, name := range form.Members {
+		u, err := user_model.GetUserByName(ctx, name)
+		if err != nil {
+			if user_model.IsErrUserNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		// the members which the doer can't read are handled like unknown owners, so their existence isn't disclosed
+		if !ctx.IsUserSiteAdmin() {
+			accessMode, err := context.DeterminePackageAccessMode(ctx.Base, u, ctx.Doer)
+			if err != nil {
+				ctx.APIErrorInternal(err)
+				return
+			}
+			if accessMode < perm.AccessModeRead {
+				ctx.APIError(http.StatusUnprocessableEntity, user_model.ErrUserNotExist{Name: name})
+				return
+			}
+		}
+		memberIDs = append(memberIDs, u.ID)
+	}
+
+	if err := packages.SetVirtualMembers(ctx, ctx.Package.Owner.ID, packageType, memberIDs); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	members, err := packages.GetVirtualMembers(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	registries, err := convert.ToPackageVirtualRegistries(ctx, members)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.JSON(http.StatusOK, registries[0])
+}
+
+// DeletePackageVirtualRegistry removes all members of the virtual registry of a package type
+func DeletePackageVirtualRegistry(ctx *context.APIContext) {
+	// swagger:operation DELETE /packages/{owner}/-/virtuals/{type} package deletePackageVirtualRegistry
+	// ---
+	// summary: Removes all members of the virtual registry of a package type
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the registry
+	//   type: string
+	//   required: true
+	// - name: type
+	//   in: path
+	//   description: package type of the registry
+	//   type: string
+	//   enum: [maven, npm, pypi]
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	packageType := packages.Type(ctx.PathParam("type"))
+
+	members, err := packages.GetVirtualMembers(ctx, ctx.Package.Owner.ID, packageType)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if len(members) == 0 {
+		ctx.APIErrorNotFound()
+		return
+	}
+
+	if err := packages.DeleteVirtualMembers(ctx, ctx.Package.Owner.ID, packageType); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
@@ -0,0 +1,934 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/web"
+	"code.gitea.io/gitea/routers/api/v1/utils"
+	actions_service "code.gitea.io/gitea/services/actions"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	secret_service "code.gitea.io/gitea/services/secrets"
+)
+
+func getCurrentRepoActionEnvironment(ctx *context.APIContext) *actions_model.ActionEnvironment {
+	env, err := actions_model.GetEnvironmentByName(ctx, ctx.Repo.Repository.ID, ctx.PathParam("environment_name"))
+	if errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorNotFound(err)
+		return nil
+	} else if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+	return env
+}
+
+// ListActionEnvironments lists the deployment environments of a repository
+func ListActionEnvironments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments repository repoListActionEnvironments
+	// ---
+	// summary: List the deployment environments of a repository with their latest deployments
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironmentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	listOptions := utils.GetListOptions(ctx)
+	envs, count, err := db.FindAndCount[actions_model.ActionEnvironment](ctx, actions_model.FindEnvironmentsOptions{
+		ListOptions: listOptions,
+		RepoID:      ctx.Repo.Repository.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	latestDeployments, err := actions_model.GetLatestDeployments(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiEnvs := make([]*api.ActionEnvironment, 0, len(envs))
+	for _, env := range envs {
+		apiEnv, err := convert.ToActionEnvironment(ctx, env, latestDeployments[env.ID])
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiEnvs = append(apiEnvs, apiEnv)
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiEnvs)
+}
+
+// GetActionEnvironment gets a deployment environment of a repository
+func GetActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoGetActionEnvironment
+	// ---
+	// summary: Get a deployment environment of a repository with its latest deployment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	latestDeployments, err := actions_model.GetLatestDeployments(ctx, ctx.Repo.Repository.ID)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiEnv, err := convert.ToActionEnvironment(ctx, env, latestDeployments[env.ID])
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, apiEnv)
+}
+
+// CreateOrUpdateActionEnvironment creates or updates a deployment environment of a repository
+func CreateOrUpdateActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoCreateOrUpdateActionEnvironment
+	// ---
+	// summary: Create or update a deployment environment of a repository
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateEnvironmentOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "201":
+	//     "$ref": "#/responses/ActionEnvironment"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.CreateOrUpdateEnvironmentOption)
+	repo := ctx.Repo.Repository
+
+	reviewerUserIDs, err := user_model.GetUserIDsByNames(ctx, form.ReviewerUsernames, false)
+	if err != nil {
+		if user_model.IsErrUserNotExist(err) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+			return
+		}
+		ctx.APIErrorInternal(err)
+		return
+	}
+	var reviewerTeamIDs []int64
+	if len(form.ReviewerTeams) > 0 {
+		if !repo.Owner.IsOrganization() {
+			ctx.APIError(http.StatusUnprocessableEntity, "reviewer teams are only available for organization repositories")
+			return
+		}
+		reviewerTeamIDs, err = organization.GetTeamIDsByNames(ctx, repo.OwnerID, form.ReviewerTeams, false)
+		if err != nil {
+			if organization.IsErrTeamNotExist(err) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+				return
+			}
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	env, err := actions_model.GetEnvironmentByName(ctx, repo.ID, ctx.PathParam("environment_name"))
+	created := errors.Is(err, util.ErrNotExist)
+	if err != nil && !created {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if created {
+		env = &actions_model.ActionEnvironment{RepoID: repo.ID, Name: ctx.PathParam("environment_name")}
+	}
+	env.ReviewerUserIDs = reviewerUserIDs
+	env.ReviewerTeamIDs = reviewerTeamIDs
+	env.WaitTimer = form.WaitTimer
+	env.BranchPatterns = form.BranchPatterns
+
+	if created {
+		err = actions_model.CreateEnvironment(ctx, env)
+	} else {
+		err = actions_model.UpdateEnvironment(ctx, env, "reviewer_user_ids", "reviewer_team_ids", "wait_timer", "branch_patterns")
+	}
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	apiEnv, err := convert.ToActionEnvironment(ctx, env, nil)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(util.Iif(created, http.StatusCreated, http.StatusOK), apiEnv)
+}
+
+// DeleteActionEnvironment deletes a deployment environment of a repository
+func DeleteActionEnvironment(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name} repository repoDeleteActionEnvironment
+	// ---
+	// summary: Delete a deployment environment of a repository with its secrets, variables and deployment history
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     "$ref": "#/responses/empty"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	if err := actions_service.DeleteEnvironment(ctx, env); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListActionEnvironmentDeployments lists the deployment history of an environment
+func ListActionEnvironmentDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name}/deployments repository repoListActionEnvironmentDeployments
+	// ---
+	// summary: List the deployment history of an environment, the latest deployment first
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionDeploymentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	deployments, count, err := db.FindAndCount[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+		ListOptions:   listOptions,
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	apiDeployments := make([]*api.ActionDeployment, 0, len(deployments))
+	for _, d := range deployments {
+		d.Environment = env
+		apiDeployment, err := convert.ToActionDeployment(ctx, d)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiDeployments = append(apiDeployments, apiDeployment)
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, apiDeployments)
+}
+
+// CreateOrUpdateEnvironmentSecret creates or updates a secret of an environment
+func CreateOrUpdateEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name}/secrets/{secretname} repository updateRepoEnvironmentSecret
+	// ---
+	// summary: Create or Update a secret value in an environment
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateOrUpdateSecretOption"
+	// responses:
+	//   "201":
+	//     description: response when creating a secret
+	//   "204":
+	//     description: response when updating a secret
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm(ctx).(*api.CreateOrUpdateSecretOption)
+
+	_, created, err := secret_service.CreateOrUpdateEnvironmentSecret(ctx, env, ctx.PathParam("secretname"), opt.Data, opt.Description)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if created {
+		ctx.Status(http.StatusCreated)
+	} else {
+		ctx.Status(http.StatusNoContent)
+	}
+}
+
+// DeleteEnvironmentSecret deletes a secret of an environment
+func DeleteEnvironmentSecret(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name}/secrets/{secretname} repository deleteRepoEnvironmentSecret
+	// ---
+	// summary: Delete a secret in an environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repository
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: secretname
+	//   in: path
+	//   description: name of the secret
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: delete one secret of the environment
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	if err := secret_service.DeleteEnvironmentSecretByName(ctx, env, ctx.PathParam("secretname")); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// ListEnvironmentVariables lists the variables of an environment
+func ListEnvironmentVariables(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/environments/{environment_name}/variables repository getRepoEnvironmentVariablesList
+	// ---
+	// summary: Get the variables list of an environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: page
+	//   in: query
+	//   description: page number of results to return (1-based)
+	//   type: integer
+	// - name: limit
+	//   in: query
+	//   description: page size of results
+	//   type: integer
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/VariableList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	listOptions := utils.GetListOptions(ctx)
+	vars, count, err := db.FindAndCount[actions_model.ActionVariable](ctx, &actions_model.FindVariablesOpts{
+		ListOptions:   listOptions,
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	variables := make([]*api.ActionVariable, len(vars))
+	for i, v := range vars {
+		variables[i] = &api.ActionVariable{
+			OwnerID:     v.OwnerID,
+			RepoID:      v.RepoID,
+			Name:        v.Name,
+			Data:        v.Data,
+			Description: v.Description,
+		}
+	}
+
+	ctx.SetLinkHeader(count, listOptions.PageSize)
+	ctx.SetTotalCountHeader(count)
+	ctx.JSON(http.StatusOK, variables)
+}
+
+// CreateEnvironmentVariable creates a variable of an environment
+func CreateEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/environments/{environment_name}/variables/{variablename} repository createRepoEnvironmentVariable
+	// ---
+	// summary: Create a variable in an environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/CreateVariableOption"
+	// responses:
+	//   "201":
+	//     description: response when creating a variable of the environment
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "409":
+	//     description: variable name already exists.
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm(ctx).(*api.CreateVariableOption)
+	variableName := ctx.PathParam("variablename")
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          variableName,
+	})
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	if v != nil && v.ID > 0 {
+		ctx.APIError(http.StatusConflict, util.NewAlreadyExistErrorf("variable name %s already exists", variableName))
+		return
+	}
+
+	if _, err := actions_service.CreateEnvironmentVariable(ctx, env, variableName, opt.Value, opt.Description); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusCreated)
+}
+
+// UpdateEnvironmentVariable updates a variable of an environment
+func UpdateEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/actions/environments/{environment_name}/variables/{variablename} repository updateRepoEnvironmentVariable
+	// ---
+	// summary: Update a variable in an environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/UpdateVariableOption"
+	// responses:
+	//   "204":
+	//     description: response when updating a variable of the environment
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+	opt := web.GetForm(ctx).(*api.UpdateVariableOption)
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          ctx.PathParam("variablename"),
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if opt.Name == "" {
+		opt.Name = ctx.PathParam("variablename")
+	}
+	v.Name = opt.Name
+	v.Data = opt.Value
+	v.Description = opt.Description
+
+	if _, err := actions_service.UpdateVariableNameData(ctx, v); err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+// DeleteEnvironmentVariable deletes a variable of an environment
+func DeleteEnvironmentVariable(ctx *context.APIContext) {
+	// swagger:operation DELETE /repos/{owner}/{repo}/actions/environments/{environment_name}/variables/{variablename} repository deleteRepoEnvironmentVariable
+	// ---
+	// summary: Delete a variable in an environment
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: environment_name
+	//   in: path
+	//   description: name of the environment
+	//   type: string
+	//   required: true
+	// - name: variablename
+	//   in: path
+	//   description: name of the variable
+	//   type: string
+	//   required: true
+	// responses:
+	//   "204":
+	//     description: response when deleting a variable of the environment
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	env := getCurrentRepoActionEnvironment(ctx)
+	if ctx.Written() {
+		return
+	}
+
+	v, err := actions_service.GetVariable(ctx, actions_model.FindVariablesOpts{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          ctx.PathParam("variablename"),
+	})
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIError(http.StatusNotFound, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	if err := actions_service.DeleteVariableByID(ctx, v.ID); err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	ctx.Status(http.StatusNoContent)
+}
+
+func findPendingDeploymentsOfRun(ctx *context.APIContext, run *actions_model.ActionRun) []*actions_model.ActionDeployment {
+	deployments, err := db.Find[actions_model.ActionDeployment](ctx, actions_model.FindDeploymentsOptions{
+		RepoID:       run.RepoID,
+		RunID:        run.ID,
+		ReviewStatus: []actions_model.DeploymentReviewStatus{actions_model.DeploymentReviewPending},
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return nil
+	}
+
+	// the deployments of the previous attempts of the jobs are no longer pending
+	pending := make([]*actions_model.ActionDeployment, 0, len(deployments))
+	for _, d := range deployments {
+		if err := d.LoadJob(ctx); err != nil {
+			ctx.APIErrorInternal(err)
+			return nil
+		}
+		if !d.Job.Status.IsWaitingApproval() {
+			continue
+		}
+		latest, err := actions_model.GetLatestDeploymentOfJob(ctx, d.Job)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return nil
+		}
+		if latest.ID == d.ID {
+			pending = append(pending, d)
+		}
+	}
+	return pending
+}
+
+// GetPendingDeployments lists the deployments of a workflow run which are waiting for review
+func GetPendingDeployments(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/actions/runs/{run}/pending_deployments repository getWorkflowRunPendingDeployments
+	// ---
+	// summary: List the deployments of a workflow run which are waiting for review
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionDeploymentList"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	run := getCurrentRepoActionRunByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	deployments := findPendingDeploymentsOfRun(ctx, run)
+	if ctx.Written() {
+		return
+	}
+
+	apiDeployments := make([]*api.ActionDeployment, 0, len(deployments))
+	for _, d := range deployments {
+		apiDeployment, err := convert.ToActionDeployment(ctx, d)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		apiDeployments = append(apiDeployments, apiDeployment)
+	}
+	ctx.JSON(http.StatusOK, apiDeployments)
+}
+
+// ReviewPendingDeployments approves or rejects the pending deployments of a workflow run
+func ReviewPendingDeployments(ctx *context.APIContext) {
+	// swagger:operation POST /repos/{owner}/{repo}/actions/runs/{run}/pending_deployments repository reviewWorkflowRunPendingDeployments
+	// ---
+	// summary: Approve or reject the pending deployments of a workflow run, only the reviewers of the environments can review them
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repository
+	//   type: string
+	//   required: true
+	// - name: run
+	//   in: path
+	//   description: id of the run
+	//   type: integer
+	//   required: true
+	// - name: body
+	//   in: body
+	//   schema:
+	//     "$ref": "#/definitions/ReviewDeploymentsOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/ActionDeploymentList"
+	//   "403":
+	//     "$ref": "#/responses/forbidden"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	form := web.GetForm(ctx).(*api.ReviewDeploymentsOption)
+	run := getCurrentRepoActionRunByID(ctx)
+	if ctx.Written() {
+		return
+	}
+	deployments := findPendingDeploymentsOfRun(ctx, run)
+	if ctx.Written() {
+		return
+	}
+
+	envIDs := make(map[int64]bool, len(form.EnvironmentIDs))
+	for _, id := range form.EnvironmentIDs {
+		envIDs[id] = true
+	}
+	reviewed := make([]*api.ActionDeployment, 0, len(deployments))
+	for _, d := range deployments {
+		if !envIDs[d.EnvironmentID] {
+			continue
+		}
+		if err := actions_service.ReviewDeployment(ctx, ctx.Doer, d, form.State == "approved", form.Comment); err != nil {
+			if errors.Is(err, util.ErrPermissionDenied) {
+				ctx.APIError(http.StatusForbidden, err)
+			} else if errors.Is(err, util.ErrInvalidArgument) {
+				ctx.APIError(http.StatusUnprocessableEntity, err)
+			} else {
+				ctx.APIErrorInternal(err)
+			}
+			return
+		}
+		d.Job = nil
+		apiDeployment, err := convert.ToActionDeployment(ctx, d)
+		if err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+		reviewed = append(reviewed, apiDeployment)
+	}
+	if len(reviewed) == 0 {
+		ctx.APIError(http.StatusUnprocessableEntity, "no pending deployment of the environments")
+		return
+	}
+	ctx.JSON(http.StatusOK, reviewed)
+}
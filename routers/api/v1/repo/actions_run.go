@@ -34,6 +34,10 @@ func DownloadActionsRunJobLogs(ctx *context.APIContext) {
 	//   description: id of the job
 	//   type: integer
 	//   required: true
+	// - name: attempt
+	//   in: query
+	//   description: the attempt of the job whose logs are downloaded, defaults to the latest attempt
+	//   type: integer
 	// responses:
 	//   "200":
 	//     description: output blob content
@@ -57,7 +61,7 @@ func DownloadActionsRunJobLogs(ctx *context.APIContext) {
 		return
 	}
 
-	err = common.DownloadActionsRunJobLogs(ctx.Base, ctx.Repo.Repository, curJob)
+	err = common.DownloadActionsRunJobLogs(ctx.Base, ctx.Repo.Repository, curJob, ctx.FormInt64("attempt"))
 	if err != nil {
 		if errors.Is(err, util.ErrNotExist) {
 			ctx.APIErrorNotFound(err)
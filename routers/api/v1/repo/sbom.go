@@ -0,0 +1,263 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package repo
+
+import (
+	"errors"
+	"net/http"
+
+	repo_model "code.gitea.io/gitea/models/repo"
+	sbom_model "code.gitea.io/gitea/models/sbom"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+	sbom_service "code.gitea.io/gitea/services/sbom"
+)
+
+// resolveSBOMCommit returns the full id of the commit in the path
+func resolveSBOMCommit(ctx *context.APIContext) (string, bool) {
+	sha := ctx.PathParam("sha")
+	if !git.IsValidRefPattern(sha) {
+		ctx.APIError(http.StatusUnprocessableEntity, "no valid ref or sha: "+sha)
+		return "", false
+	}
+
+	commit, err := ctx.Repo.GitRepo.GetCommit(sha)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			ctx.APIErrorNotFound("commit doesn't exist: " + sha)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return "", false
+	}
+	return commit.ID.String(), true
+}
+
+// resolveSBOMRelease returns the release in the path, draft releases are only visible to the users with write access
+func resolveSBOMRelease(ctx *context.APIContext) (*repo_model.Release, bool) {
+	release, err := repo_model.GetReleaseForRepoByID(ctx, ctx.Repo.Repository.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		if repo_model.IsErrReleaseNotExist(err) {
+			ctx.APIErrorNotFound()
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return nil, false
+	}
+	if release.IsTag || (release.IsDraft && !canAccessReleaseDraft(ctx)) {
+		ctx.APIErrorNotFound()
+		return nil, false
+	}
+	return release, true
+}
+
+func writeSBOMDocument(ctx *context.APIContext, status int, doc *sbom_model.Document, err error) {
+	if err != nil {
+		if errors.Is(err, util.ErrNotExist) {
+			ctx.APIErrorNotFound(err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	inventory, err := sbom_service.GetInventory(ctx, doc)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(status, convert.ToSBOMDocument(inventory.Document, inventory.Components))
+}
+
+func writeIngestedSBOMDocument(ctx *context.APIContext, inventory *sbom_service.Inventory, err error) {
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusBadRequest, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+	ctx.JSON(http.StatusCreated, convert.ToSBOMDocument(inventory.Document, inventory.Components))
+}
+
+// GetCommitSBOM gets the software bill of materials attached to a commit
+func GetCommitSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/git/commits/{sha}/sbom repository repoGetCommitSBOM
+	// ---
+	// summary: Get the software bill of materials attached to a commit
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: a git ref or commit sha
+	//   type: string
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SBOMDocument"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	sha, ok := resolveSBOMCommit(ctx)
+	if !ok {
+		return
+	}
+
+	doc, err := sbom_model.GetDocumentByCommit(ctx, ctx.Repo.Repository.ID, sha)
+	writeSBOMDocument(ctx, http.StatusOK, doc, err)
+}
+
+// UploadCommitSBOM attaches a software bill of materials to a commit
+func UploadCommitSBOM(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/git/commits/{sha}/sbom repository repoUploadCommitSBOM
+	// ---
+	// summary: Attach a CycloneDX or SPDX JSON software bill of materials to a commit, replacing the existing one
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: sha
+	//   in: path
+	//   description: a git ref or commit sha
+	//   type: string
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the CycloneDX or SPDX JSON document
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SBOMDocument"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	sha, ok := resolveSBOMCommit(ctx)
+	if !ok {
+		return
+	}
+
+	inventory, err := sbom_service.IngestForCommit(ctx, ctx.Doer, ctx.Repo.Repository, sha, ctx.Req.Body)
+	writeIngestedSBOMDocument(ctx, inventory, err)
+}
+
+// GetReleaseSBOM gets the software bill of materials attached to a release
+func GetReleaseSBOM(ctx *context.APIContext) {
+	// swagger:operation GET /repos/{owner}/{repo}/releases/{id}/sbom repository repoGetReleaseSBOM
+	// ---
+	// summary: Get the software bill of materials attached to a release
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the release
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/SBOMDocument"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	release, ok := resolveSBOMRelease(ctx)
+	if !ok {
+		return
+	}
+
+	doc, err := sbom_model.GetDocumentByReleaseID(ctx, ctx.Repo.Repository.ID, release.ID)
+	writeSBOMDocument(ctx, http.StatusOK, doc, err)
+}
+
+// UploadReleaseSBOM attaches a software bill of materials to a release
+func UploadReleaseSBOM(ctx *context.APIContext) {
+	// swagger:operation PUT /repos/{owner}/{repo}/releases/{id}/sbom repository repoUploadReleaseSBOM
+	// ---
+	// summary: Attach a CycloneDX or SPDX JSON software bill of materials to a release, replacing the existing one
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: owner
+	//   in: path
+	//   description: owner of the repo
+	//   type: string
+	//   required: true
+	// - name: repo
+	//   in: path
+	//   description: name of the repo
+	//   type: string
+	//   required: true
+	// - name: id
+	//   in: path
+	//   description: id of the release
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   description: the CycloneDX or SPDX JSON document
+	//   required: true
+	//   schema:
+	//     type: object
+	// responses:
+	//   "201":
+	//     "$ref": "#/responses/SBOMDocument"
+	//   "400":
+	//     "$ref": "#/responses/error"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+
+	release, ok := resolveSBOMRelease(ctx)
+	if !ok {
+		return
+	}
+
+	inventory, err := sbom_service.IngestForRelease(ctx, ctx.Doer, release, ctx.Req.Body)
+	writeIngestedSBOMDocument(ctx, inventory, err)
+}
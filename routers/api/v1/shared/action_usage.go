@@ -0,0 +1,103 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package shared
+
+import (
+	"encoding/csv"
+	"errors"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/httplib"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/context"
+	"code.gitea.io/gitea/services/convert"
+)
+
+// ListUsage lists the Actions usage for api route validated ownerID, ownerID == 0 means all owners.
+// The usage is aggregated by the "group_by" query (defaults to defaultGroupBy) in the months from the "from" query to the "to" query,
+// which default to the current month, and it is responded as CSV if the "format" query is "csv".
+// Access rights are checked at the API route level
+func ListUsage(ctx *context.APIContext, ownerID int64, defaultGroupBy actions_model.UsageGroupBy) {
+	opts := actions_model.FindUsageStatsOptions{
+		OwnerID: ownerID,
+		GroupBy: actions_model.UsageGroupBy(ctx.FormString("group_by", string(defaultGroupBy))),
+	}
+	if !opts.GroupBy.IsValid() {
+		ctx.APIError(http.StatusBadRequest, "group_by should be one of owner, repo and labels")
+		return
+	}
+
+	from, to := ctx.FormString("from"), ctx.FormString("to")
+	if from == "" && to == "" {
+		opts.FromMonth = actions_model.UsageMonth(time.Now())
+		opts.ToMonth = opts.FromMonth
+	}
+	var err error
+	if from != "" {
+		if opts.FromMonth, err = actions_model.ParseUsageMonth(from); err != nil {
+			ctx.APIError(http.StatusBadRequest, err)
+			return
+		}
+	}
+	if to != "" {
+		if opts.ToMonth, err = actions_model.ParseUsageMonth(to); err != nil {
+			ctx.APIError(http.StatusBadRequest, err)
+			return
+		}
+	}
+
+	stats, err := actions_model.GetUsageStats(ctx, opts)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	usages, err := convert.ToActionUsages(ctx, stats)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	if ctx.FormString("format") != "csv" {
+		ctx.JSON(http.StatusOK, usages)
+		return
+	}
+
+	ctx.SetServeHeaders(context.ServeHeaderOptions{
+		ContentType:        "text/csv; charset=utf-8",
+		Filename:           "actions-usage.csv",
+		ContentDisposition: httplib.ContentDispositionAttachment,
+	})
+	w := csv.NewWriter(ctx.Resp)
+	_ = w.Write([]string{"owner", "repository", "labels", "seconds", "minutes", "task_count"})
+	for _, u := range usages {
+		_ = w.Write([]string{
+			u.Owner,
+			u.Repository,
+			strings.Join(u.Labels, " "),
+			strconv.FormatInt(u.Seconds, 10),
+			strconv.FormatInt(u.Minutes, 10),
+			strconv.FormatInt(u.TaskCount, 10),
+		})
+	}
+	w.Flush()
+}
+
+// GetUsageQuota responds the monthly minutes quota of the owner and the minutes used in the current month
+func GetUsageQuota(ctx *context.APIContext, ownerID int64) {
+	quota, err := actions_model.GetUsageQuota(ctx, ownerID)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	used, err := actions_model.GetOwnerMonthUsageSeconds(ctx, ownerID, actions_model.UsageMonth(time.Now()))
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+	ctx.JSON(http.StatusOK, convert.ToActionUsageQuota(quota, used))
+}
@@ -53,3 +53,66 @@ type swaggerResponseRunDetails struct {
 	// in:body
 	Body api.RunDetails `json:"body"`
 }
+
+// ActionEnvironment
+// swagger:response ActionEnvironment
+type swaggerResponseActionEnvironment struct {
+	// in:body
+	Body api.ActionEnvironment `json:"body"`
+}
+
+// ActionEnvironmentList
+// swagger:response ActionEnvironmentList
+type swaggerResponseActionEnvironmentList struct {
+	// in:body
+	Body []api.ActionEnvironment `json:"body"`
+}
+
+// ActionDeploymentList
+// swagger:response ActionDeploymentList
+type swaggerResponseActionDeploymentList struct {
+	// in:body
+	Body []api.ActionDeployment `json:"body"`
+}
+
+// ActionRunnerGroup
+// swagger:response ActionRunnerGroup
+type swaggerResponseActionRunnerGroup struct {
+	// in:body
+	Body api.ActionRunnerGroup `json:"body"`
+}
+
+// ActionRunnerGroupList
+// swagger:response ActionRunnerGroupList
+type swaggerResponseActionRunnerGroupList struct {
+	// in:body
+	Body []api.ActionRunnerGroup `json:"body"`
+}
+
+// ActionRequiredWorkflow
+// swagger:response ActionRequiredWorkflow
+type swaggerResponseActionRequiredWorkflow struct {
+	// in:body
+	Body api.ActionRequiredWorkflow `json:"body"`
+}
+
+// ActionRequiredWorkflowList
+// swagger:response ActionRequiredWorkflowList
+type swaggerResponseActionRequiredWorkflowList struct {
+	// in:body
+	Body []api.ActionRequiredWorkflow `json:"body"`
+}
+
+// ActionUsageList
+// swagger:response ActionUsageList
+type swaggerResponseActionUsageList struct {
+	// in:body
+	Body []api.ActionUsage `json:"body"`
+}
+
+// ActionUsageQuota
+// swagger:response ActionUsageQuota
+type swaggerResponseActionUsageQuota struct {
+	// in:body
+	Body api.ActionUsageQuota `json:"body"`
+}
@@ -26,6 +26,10 @@ type swaggerParameterBodies struct {
 	CreateHookOption api.CreateHookOption
 	// in:body
 	EditHookOption api.EditHookOption
+	// in:body
+	HookPreviewOption api.HookPreviewOption
+	// in:body
+	RedeliverHookDeliveriesOption api.RedeliverHookDeliveriesOption
 
 	// in:body
 	EditGitHookOption api.EditGitHookOption
@@ -216,6 +220,27 @@ type swaggerParameterBodies struct {
 	// in:body
 	CreateVariableOption api.CreateVariableOption
 
+	// in:body
+	CreateOrUpdateEnvironmentOption api.CreateOrUpdateEnvironmentOption
+
+	// in:body
+	ReviewDeploymentsOption api.ReviewDeploymentsOption
+
+	// in:body
+	CreateActionRunnerGroupOption api.CreateActionRunnerGroupOption
+
+	// in:body
+	EditActionRunnerGroupOption api.EditActionRunnerGroupOption
+
+	// in:body
+	CreateActionRequiredWorkflowOption api.CreateActionRequiredWorkflowOption
+
+	// in:body
+	EditActionRequiredWorkflowOption api.EditActionRequiredWorkflowOption
+
+	// in:body
+	SetActionUsageQuotaOption api.SetActionUsageQuotaOption
+
 	// in:body
 	RenameOrgOption api.RenameOrgOption
 
@@ -230,4 +255,16 @@ type swaggerParameterBodies struct {
 
 	// in:body
 	LockIssueOption api.LockIssueOption
+
+	// in:body
+	SetPackageRemoteOption api.SetPackageRemoteOption
+
+	// in:body
+	SetPackageVirtualRegistryOption api.SetPackageVirtualRegistryOption
+
+	// in:body
+	PromotePackageVersionOption api.PromotePackageVersionOption
+
+	// in:body
+	EditPackageSettingsOption api.EditPackageSettingsOption
 }
@@ -27,3 +27,59 @@ type swaggerResponsePackageFileList struct {
 	// in:body
 	Body []api.PackageFile `json:"body"`
 }
+
+// PackageRemote
+// swagger:response PackageRemote
+type swaggerResponsePackageRemote struct {
+	// in:body
+	Body api.PackageRemote `json:"body"`
+}
+
+// PackageRemoteList
+// swagger:response PackageRemoteList
+type swaggerResponsePackageRemoteList struct {
+	// in:body
+	Body []api.PackageRemote `json:"body"`
+}
+
+// PackageVirtualRegistry
+// swagger:response PackageVirtualRegistry
+type swaggerResponsePackageVirtualRegistry struct {
+	// in:body
+	Body api.PackageVirtualRegistry `json:"body"`
+}
+
+// PackageVirtualRegistryList
+// swagger:response PackageVirtualRegistryList
+type swaggerResponsePackageVirtualRegistryList struct {
+	// in:body
+	Body []api.PackageVirtualRegistry `json:"body"`
+}
+
+// PackageVersionChannel
+// swagger:response PackageVersionChannel
+type swaggerResponsePackageVersionChannel struct {
+	// in:body
+	Body api.PackageVersionChannel `json:"body"`
+}
+
+// PackageVersionChannelList
+// swagger:response PackageVersionChannelList
+type swaggerResponsePackageVersionChannelList struct {
+	// in:body
+	Body []api.PackageVersionChannel `json:"body"`
+}
+
+// PackageSettings
+// swagger:response PackageSettings
+type swaggerResponsePackageSettings struct {
+	// in:body
+	Body api.PackageSettings `json:"body"`
+}
+
+// PackageCleanupCandidateList
+// swagger:response PackageCleanupCandidateList
+type swaggerResponsePackageCleanupCandidateList struct {
+	// in:body
+	Body []api.PackageCleanupCandidate `json:"body"`
+}
@@ -0,0 +1,22 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package swagger
+
+import (
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// SBOMDocument
+// swagger:response SBOMDocument
+type swaggerResponseSBOMDocument struct {
+	// in:body
+	Body api.SBOMDocument `json:"body"`
+}
+
+// SBOMComponentSearchResultList
+// swagger:response SBOMComponentSearchResultList
+type swaggerResponseSBOMComponentSearchResultList struct {
+	// in:body
+	Body []api.SBOMComponentSearchResult `json:"body"`
+}
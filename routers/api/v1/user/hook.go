@@ -157,3 +157,39 @@ func DeleteHook(ctx *context.APIContext) {
 		ctx.PathParamInt64("id"),
 	)
 }
+
+// PreviewHook renders the request a hook would send for an event without delivering it
+func PreviewHook(ctx *context.APIContext) {
+	// swagger:operation POST /user/hooks/{id}/preview user userPreviewHook
+	// ---
+	// summary: Preview the request a hook would send for an event without delivering it
+	// consumes:
+	// - application/json
+	// produces:
+	// - application/json
+	// parameters:
+	// - name: id
+	//   in: path
+	//   description: id of the hook to preview
+	//   type: integer
+	//   format: int64
+	//   required: true
+	// - name: body
+	//   in: body
+	//   required: true
+	//   schema:
+	//     "$ref": "#/definitions/HookPreviewOption"
+	// responses:
+	//   "200":
+	//     "$ref": "#/responses/HookPreview"
+	//   "404":
+	//     "$ref": "#/responses/notFound"
+	//   "422":
+	//     "$ref": "#/responses/validationError"
+
+	hook, err := utils.GetOwnerHook(ctx, ctx.Doer.ID, ctx.PathParamInt64("id"))
+	if err != nil {
+		return
+	}
+	utils.PreviewHook(ctx, hook, web.GetForm(ctx).(*api.HookPreviewOption))
+}
@@ -4,6 +4,7 @@
 package utils
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 	"strings"
@@ -222,9 +223,10 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		HTTPMethod:      "POST",
 		IsSystemWebhook: isSystemWebhook,
 		HookEvent: &webhook_module.HookEvent{
-			ChooseEvents: true,
-			HookEvents:   updateHookEvents(form.Events),
-			BranchFilter: form.BranchFilter,
+			ChooseEvents:     true,
+			HookEvents:       updateHookEvents(form.Events),
+			BranchFilter:     form.BranchFilter,
+			FilterExpression: strings.TrimSpace(form.FilterExpression),
 		},
 		IsActive: form.Active,
 		Type:     form.Type,
@@ -259,6 +261,39 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 		}
 		w.Meta = string(meta)
 	}
+	if w.Type == webhook_module.CUSTOM {
+		meta := &webhook_service.CustomMeta{}
+		meta.ApplyConfig(form.Config)
+		if !setHookMeta(ctx, w, meta) {
+			return nil, false
+		}
+	}
+	if w.Type == webhook_module.CLOUDEVENTS {
+		if !setCloudEventsHookMeta(ctx, w, &webhook_service.CloudEventsMeta{}, form.Config) {
+			return nil, false
+		}
+	}
+	if w.Type == webhook_module.MATTERMOST {
+		meta := &webhook_service.MattermostMeta{}
+		meta.ApplyConfig(form.Config)
+		if !setHookMeta(ctx, w, meta) {
+			return nil, false
+		}
+	}
+	if w.Type == webhook_module.ROCKETCHAT {
+		meta := &webhook_service.RocketChatMeta{}
+		meta.ApplyConfig(form.Config)
+		if !setHookMeta(ctx, w, meta) {
+			return nil, false
+		}
+	}
+	if w.Type == webhook_module.ZULIP {
+		meta := &webhook_service.ZulipMeta{}
+		meta.ApplyConfig(form.Config)
+		if !setHookMeta(ctx, w, meta) {
+			return nil, false
+		}
+	}
 
 	if err := w.UpdateEvent(); err != nil {
 		ctx.APIErrorInternal(err)
@@ -270,6 +305,43 @@ func addHook(ctx *context.APIContext, form *api.CreateHookOption, ownerID, repoI
 	return w, true
 }
 
+// validatableHookMeta is the metadata of a webhook type which is validated before it is stored
+type validatableHookMeta interface {
+	Validate() error
+}
+
+// setHookMeta validates the metadata of a webhook and stores it in its meta. If there is an error,
+// write to `ctx` accordingly. Return whether successful
+func setHookMeta(ctx *context.APIContext, w *webhook.Webhook, meta validatableHookMeta) bool {
+	if err := meta.Validate(); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return false
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	w.Meta = string(data)
+	return true
+}
+
+// setCloudEventsHookMeta applies the config to the CloudEvents metadata and stores it in the webhook. If there is an error,
+// write to `ctx` accordingly. Return whether successful
+func setCloudEventsHookMeta(ctx *context.APIContext, w *webhook.Webhook, meta *webhook_service.CloudEventsMeta, config map[string]string) bool {
+	if err := meta.ApplyConfig(config); err != nil {
+		ctx.APIError(http.StatusUnprocessableEntity, err)
+		return false
+	}
+	data, err := json.Marshal(meta)
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return false
+	}
+	w.Meta = string(data)
+	return true
+}
+
 // EditSystemHook edit system webhook `w` according to `form`. Writes to `ctx` accordingly
 func EditSystemHook(ctx *context.APIContext, form *api.EditHookOption, hookID int64) {
 	hook, err := webhook.GetSystemOrDefaultWebhook(ctx, hookID)
@@ -369,6 +441,44 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 				w.Meta = string(meta)
 			}
 		}
+
+		if w.Type == webhook_module.CUSTOM {
+			meta := webhook_service.GetCustomHook(w)
+			meta.ApplyConfig(form.Config)
+			if !setHookMeta(ctx, w, meta) {
+				return false
+			}
+		}
+
+		if w.Type == webhook_module.CLOUDEVENTS {
+			if !setCloudEventsHookMeta(ctx, w, webhook_service.GetCloudEventsHook(w), form.Config) {
+				return false
+			}
+		}
+
+		if w.Type == webhook_module.MATTERMOST {
+			meta := webhook_service.GetMattermostHook(w)
+			meta.ApplyConfig(form.Config)
+			if !setHookMeta(ctx, w, meta) {
+				return false
+			}
+		}
+
+		if w.Type == webhook_module.ROCKETCHAT {
+			meta := webhook_service.GetRocketChatHook(w)
+			meta.ApplyConfig(form.Config)
+			if !setHookMeta(ctx, w, meta) {
+				return false
+			}
+		}
+
+		if w.Type == webhook_module.ZULIP {
+			meta := webhook_service.GetZulipHook(w)
+			meta.ApplyConfig(form.Config)
+			if !setHookMeta(ctx, w, meta) {
+				return false
+			}
+		}
 	}
 
 	// Update events
@@ -377,6 +487,9 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	w.SendEverything = false
 	w.ChooseEvents = true
 	w.BranchFilter = form.BranchFilter
+	if form.FilterExpression != nil {
+		w.FilterExpression = strings.TrimSpace(*form.FilterExpression)
+	}
 
 	err := w.SetHeaderAuthorization(form.AuthorizationHeader)
 	if err != nil {
@@ -404,6 +517,79 @@ func editHook(ctx *context.APIContext, form *api.EditHookOption, w *webhook.Webh
 	return true
 }
 
+// PreviewHook renders the request of the webhook for the event of the form without delivering it. Writes to `ctx` accordingly
+func PreviewHook(ctx *context.APIContext, w *webhook.Webhook, form *api.HookPreviewOption) {
+	var payload []byte
+	if form.Payload != nil {
+		var err error
+		if payload, err = json.Marshal(form.Payload); err != nil {
+			ctx.APIErrorInternal(err)
+			return
+		}
+	}
+
+	preview, err := webhook_service.PreviewRequest(ctx, ctx.Doer, w, webhook_module.HookEventType(form.Event), payload)
+	if err != nil {
+		if errors.Is(err, util.ErrInvalidArgument) {
+			ctx.APIError(http.StatusUnprocessableEntity, err)
+		} else {
+			ctx.APIErrorInternal(err)
+		}
+		return
+	}
+
+	ctx.JSON(http.StatusOK, &api.HookPreview{
+		URL:        preview.URL,
+		HTTPMethod: preview.HTTPMethod,
+		Headers:    preview.Headers,
+		Body:       preview.Body,
+	})
+}
+
+// ListDeadLetteredHookDeliveries lists the dead-lettered deliveries of the webhooks of the owner and of its repositories,
+// or of all webhooks if the owner ID is 0
+func ListDeadLetteredHookDeliveries(ctx *context.APIContext, ownerID int64) {
+	listOptions := GetListOptions(ctx)
+	tasks, total, err := db.FindAndCount[webhook.HookTask](ctx, webhook.FindDeadLetteredHookTasksOptions{
+		ListOptions: listOptions,
+		HookID:      ctx.FormInt64("hook_id"),
+		OwnerID:     ownerID,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	deliveries := make([]*api.HookDelivery, 0, len(tasks))
+	for _, t := range tasks {
+		deliveries = append(deliveries, webhook_service.ToHookDelivery(t))
+	}
+
+	ctx.SetLinkHeader(total, listOptions.PageSize)
+	ctx.SetTotalCountHeader(total)
+	ctx.JSON(http.StatusOK, deliveries)
+}
+
+// RedeliverDeadLetteredHookDeliveries redelivers the dead-lettered deliveries of the webhooks of the owner and of its repositories,
+// or of all webhooks if the owner ID is 0
+func RedeliverDeadLetteredHookDeliveries(ctx *context.APIContext, ownerID int64, form *api.RedeliverHookDeliveriesOption) {
+	tasks, err := webhook_service.RedeliverDeadLetteredHookTasks(ctx, webhook.FindDeadLetteredHookTasksOptions{
+		HookID:  form.HookID,
+		OwnerID: ownerID,
+		IDs:     form.IDs,
+	})
+	if err != nil {
+		ctx.APIErrorInternal(err)
+		return
+	}
+
+	deliveries := make([]*api.HookDelivery, 0, len(tasks))
+	for _, t := range tasks {
+		deliveries = append(deliveries, webhook_service.ToHookDelivery(t))
+	}
+	ctx.JSON(http.StatusCreated, deliveries)
+}
+
 // DeleteOwnerHook deletes the hook owned by the owner.
 func DeleteOwnerHook(ctx *context.APIContext, owner *user_model.User, hookID int64) {
 	if err := webhook.DeleteWebhookByOwnerID(ctx, owner.ID, hookID); err != nil {
@@ -211,6 +211,11 @@ func NormalRoutes() *web.Router {
 		r.Mount(prefix, actions_router.ArtifactsRoutes(prefix))
 		prefix = actions_router.ArtifactV4RouteBase
 		r.Mount(prefix, actions_router.ArtifactsV4Routes(prefix))
+		if setting.Actions.CacheEnabled {
+			prefix = actions_router.CacheRouteBase
+			r.Mount(prefix, actions_router.CacheRoutes(prefix))
+		}
+		r.Mount(actions_service.IDTokenIssuerPath, actions_router.OIDCRoutes())
 	}
 
 	r.NotFound(func(w http.ResponseWriter, req *http.Request) {
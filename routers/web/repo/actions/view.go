@@ -476,6 +476,12 @@ func fillViewRunResponseCurrentJob(ctx *context_module.Context, resp *ViewRespon
 	if run.NeedApproval {
 		resp.State.CurrentJob.Detail = ctx.Locale.TrString("actions.need_approval_desc")
 	}
+	if current.QuotaExceeded {
+		resp.State.CurrentJob.Detail = ctx.Locale.TrString("actions.usage.quota_exceeded_desc")
+	}
+	if current.Status.IsWaiting() && current.RetryCount > 0 {
+		resp.State.CurrentJob.Detail = ctx.Locale.TrString("actions.retrying_desc", current.RetryCount)
+	}
 	resp.State.CurrentJob.Steps = make([]*ViewJobStep, 0) // marshal to '[]' instead fo 'null' in json
 	resp.Logs.StepsLog = make([]*ViewStepLog, 0)          // marshal to '[]' instead fo 'null' in json
 	if task != nil {
@@ -644,7 +650,7 @@ func Logs(ctx *context_module.Context) {
 	}
 	jobID := ctx.PathParamInt64("job")
 
-	if err := common.DownloadActionsRunJobLogsWithID(ctx.Base, ctx.Repo.Repository, run.ID, jobID); err != nil {
+	if err := common.DownloadActionsRunJobLogsWithID(ctx.Base, ctx.Repo.Repository, run.ID, jobID, ctx.FormInt64("attempt")); err != nil {
 		ctx.NotFoundOrServerError("DownloadActionsRunJobLogsWithID", func(err error) bool {
 			return errors.Is(err, util.ErrNotExist)
 		}, err)
@@ -730,7 +736,19 @@ func approveRuns(ctx *context_module.Context, runIDs []int64) {
 					return err
 				}
 				if job.Status == actions_model.StatusWaiting {
-					n, err := actions_model.UpdateRunJob(ctx, job, nil, "status")
+					job.Status, err = actions_service.PrepareJobWithUsageQuota(ctx, job)
+					if err != nil {
+						return err
+					}
+				}
+				if job.Status == actions_model.StatusWaiting {
+					job.Status, err = actions_service.PrepareJobDeployment(ctx, job)
+					if err != nil {
+						return err
+					}
+				}
+				if job.Status.In(actions_model.StatusWaiting, actions_model.StatusWaitingApproval, actions_model.StatusFailure) {
+					n, err := actions_model.UpdateRunJob(ctx, job, nil, "status", "quota_exceeded")
 					if err != nil {
 						return err
 					}
@@ -762,6 +780,8 @@ func approveRuns(ctx *context_module.Context, runIDs []int64) {
 		_ = job.LoadAttributes(ctx)
 		notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
 	}
+	// the jobs can be failed by the protection rules of the deployment environments
+	actions_service.EmitJobsIfReadyByJobs(updatedJobs)
 }
 
 func Delete(ctx *context_module.Context) {
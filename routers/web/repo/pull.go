@@ -14,6 +14,7 @@ import (
 	"strings"
 	"time"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	activities_model "code.gitea.io/gitea/models/activities"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
@@ -397,7 +398,13 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 		ctx.ServerError("LoadProtectedBranch", err)
 		return nil
 	}
-	ctx.Data["EnableStatusCheck"] = pb != nil && pb.EnableStatusCheck
+	requiredWorkflowContexts, err := actions_model.GetRequiredWorkflowStatusCheckContexts(ctx, repo, pull.BaseBranch)
+	if err != nil {
+		ctx.ServerError("GetRequiredWorkflowStatusCheckContexts", err)
+		return nil
+	}
+	// the status checks of the required workflows of the org are enforced even if the branch isn't protected
+	ctx.Data["EnableStatusCheck"] = (pb != nil && pb.EnableStatusCheck) || len(requiredWorkflowContexts) > 0
 
 	var baseGitRepo *git.Repository
 	if pull.BaseRepoID == ctx.Repo.Repository.ID && ctx.Repo.GitRepo != nil {
@@ -529,9 +536,15 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 		ctx.Data["LatestCommitStatus"] = statusCheckData.LatestCommitStatus
 	}
 
-	if pb != nil && pb.EnableStatusCheck {
+	if (pb != nil && pb.EnableStatusCheck) || len(requiredWorkflowContexts) > 0 {
+		var requiredContexts []string
+		if pb != nil && pb.EnableStatusCheck {
+			requiredContexts = append(requiredContexts, pb.StatusCheckContexts...)
+		}
+		requiredContexts = append(requiredContexts, requiredWorkflowContexts...)
+
 		var missingRequiredChecks []string
-		for _, requiredContext := range pb.StatusCheckContexts {
+		for _, requiredContext := range requiredContexts {
 			contextFound := false
 			matchesRequiredContext := createRequiredContextMatcher(requiredContext)
 			for _, presentStatus := range commitStatuses {
@@ -548,7 +561,7 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 		statusCheckData.MissingRequiredChecks = missingRequiredChecks
 
 		statusCheckData.IsContextRequired = func(context string) bool {
-			for _, c := range pb.StatusCheckContexts {
+			for _, c := range requiredContexts {
 				if c == context {
 					return true
 				}
@@ -563,7 +576,7 @@ func prepareViewPullInfo(ctx *context.Context, issue *issues_model.Issue) *git_s
 			}
 			return false
 		}
-		statusCheckData.RequiredChecksState = pull_service.MergeRequiredContextsCommitStatus(commitStatuses, pb.StatusCheckContexts)
+		statusCheckData.RequiredChecksState = pull_service.MergeBranchRequiredCommitStatus(commitStatuses, pb, requiredWorkflowContexts)
 	}
 
 	ctx.Data["HeadBranchMovedOn"] = headBranchSha != sha
@@ -843,6 +856,19 @@ func viewPullFiles(ctx *context.Context, beforeCommitID, afterCommitID string) {
 		return
 	}
 
+	if ctx.Repo.CanRead(unit.TypeActions) {
+		annotations, err := db.Find[actions_model.ActionTaskAnnotation](ctx, actions_model.FindTaskAnnotationsOptions{
+			RepoID:    ctx.Repo.Repository.ID,
+			CommitSHA: afterCommitID,
+			HasPath:   true,
+		})
+		if err != nil {
+			ctx.ServerError("FindTaskAnnotations", err)
+			return
+		}
+		diff.LoadActionsAnnotations(annotations)
+	}
+
 	allComments := issues_model.CommentList{}
 	for _, file := range diff.Files {
 		for _, section := range file.Sections {
@@ -188,7 +188,8 @@ func ParseHookEvent(form forms.WebhookForm) *webhook_module.HookEvent {
 			webhook_module.HookEventWorkflowRun:              form.WorkflowRun,
 			webhook_module.HookEventWorkflowJob:              form.WorkflowJob,
 		},
-		BranchFilter: form.BranchFilter,
+		BranchFilter:     form.BranchFilter,
+		FilterExpression: strings.TrimSpace(form.FilterExpression),
 	}
 }
 
@@ -278,6 +279,11 @@ func editWebhook(ctx *context.Context, params webhookParams) {
 		return
 	}
 
+	// the per-event templates of a custom webhook can only be managed through the API
+	if meta, ok := params.Meta.(*webhook_service.CustomMeta); ok && meta != nil && w.Type == webhook_module.CUSTOM {
+		meta.EventTemplates = webhook_service.GetCustomHook(w).EventTemplates
+	}
+
 	var meta []byte
 	var err error
 	if params.Meta != nil {
@@ -592,6 +598,132 @@ func packagistHookParams(ctx *context.Context) webhookParams {
 	}
 }
 
+// CustomHooksNewPost response for creating custom webhook
+func CustomHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, customHookParams(ctx))
+}
+
+// CustomHooksEditPost response for editing custom webhook
+func CustomHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, customHookParams(ctx))
+}
+
+func customHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewCustomHookForm)
+
+	// the form has been validated, so the headers can be parsed
+	meta, _ := form.CustomMeta()
+
+	return webhookParams{
+		Type:        webhook_module.CUSTOM,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		HTTPMethod:  form.HTTPMethod,
+		WebhookForm: form.WebhookForm,
+		Meta:        meta,
+	}
+}
+
+// CloudEventsHooksNewPost response for creating CloudEvents webhook
+func CloudEventsHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, cloudEventsHookParams(ctx))
+}
+
+// CloudEventsHooksEditPost response for editing CloudEvents webhook
+func CloudEventsHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, cloudEventsHookParams(ctx))
+}
+
+func cloudEventsHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewCloudEventsHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.CLOUDEVENTS,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		HTTPMethod:  form.HTTPMethod,
+		WebhookForm: form.WebhookForm,
+		Meta: &webhook_service.CloudEventsMeta{
+			ContentMode: form.ContentMode,
+			BatchSize:   form.BatchSize,
+		},
+	}
+}
+
+// MattermostHooksNewPost response for creating Mattermost webhook
+func MattermostHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, mattermostHookParams(ctx))
+}
+
+// MattermostHooksEditPost response for editing Mattermost webhook
+func MattermostHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, mattermostHookParams(ctx))
+}
+
+func mattermostHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewMattermostHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.MATTERMOST,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		WebhookForm: form.WebhookForm,
+		Meta: &webhook_service.MattermostMeta{
+			Channel:  strings.TrimSpace(form.Channel),
+			Username: strings.TrimSpace(form.Username),
+			IconURL:  form.IconURL,
+		},
+	}
+}
+
+// RocketChatHooksNewPost response for creating Rocket.Chat webhook
+func RocketChatHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, rocketChatHookParams(ctx))
+}
+
+// RocketChatHooksEditPost response for editing Rocket.Chat webhook
+func RocketChatHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, rocketChatHookParams(ctx))
+}
+
+func rocketChatHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewRocketChatHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.ROCKETCHAT,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeJSON,
+		WebhookForm: form.WebhookForm,
+		Meta: &webhook_service.RocketChatMeta{
+			Channel:  strings.TrimSpace(form.Channel),
+			Username: strings.TrimSpace(form.Username),
+			IconURL:  form.IconURL,
+		},
+	}
+}
+
+// ZulipHooksNewPost response for creating Zulip webhook
+func ZulipHooksNewPost(ctx *context.Context) {
+	createWebhook(ctx, zulipHookParams(ctx))
+}
+
+// ZulipHooksEditPost response for editing Zulip webhook
+func ZulipHooksEditPost(ctx *context.Context) {
+	editWebhook(ctx, zulipHookParams(ctx))
+}
+
+func zulipHookParams(ctx *context.Context) webhookParams {
+	form := web.GetForm(ctx).(*forms.NewZulipHookForm)
+
+	return webhookParams{
+		Type:        webhook_module.ZULIP,
+		URL:         form.PayloadURL,
+		ContentType: webhook.ContentTypeForm,
+		WebhookForm: form.WebhookForm,
+		Meta:        form.ZulipMeta(),
+	}
+}
+
 func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 	orCtx, err := getOwnerRepoCtx(ctx)
 	if err != nil {
@@ -630,6 +762,16 @@ func checkWebhook(ctx *context.Context) (*ownerRepoCtx, *webhook.Webhook) {
 		ctx.Data["MatrixHook"] = webhook_service.GetMatrixHook(w)
 	case webhook_module.PACKAGIST:
 		ctx.Data["PackagistHook"] = webhook_service.GetPackagistHook(w)
+	case webhook_module.CUSTOM:
+		ctx.Data["CustomHook"] = webhook_service.GetCustomHook(w)
+	case webhook_module.CLOUDEVENTS:
+		ctx.Data["CloudEventsHook"] = webhook_service.GetCloudEventsHook(w)
+	case webhook_module.MATTERMOST:
+		ctx.Data["MattermostHook"] = webhook_service.GetMattermostHook(w)
+	case webhook_module.ROCKETCHAT:
+		ctx.Data["RocketChatHook"] = webhook_service.GetRocketChatHook(w)
+	case webhook_module.ZULIP:
+		ctx.Data["ZulipHook"] = webhook_service.GetZulipHook(w)
 	}
 
 	ctx.Data["History"], err = w.History(ctx, 1)
@@ -708,8 +850,8 @@ func TestWebhook(ctx *context.Context) {
 		Pusher:       apiUser,
 		Sender:       apiUser,
 	}
-	if err := webhook_service.PrepareWebhook(ctx, w, webhook_module.HookEventPush, p); err != nil {
-		ctx.Flash.Error("PrepareWebhook: " + err.Error())
+	if err := webhook_service.PrepareTestWebhook(ctx, w, webhook_module.HookEventPush, p); err != nil {
+		ctx.Flash.Error("PrepareTestWebhook: " + err.Error())
 		ctx.Status(http.StatusInternalServerError)
 	} else {
 		ctx.Flash.Info(ctx.Tr("repo.settings.webhook.delivery.success"))
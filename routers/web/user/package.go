@@ -187,6 +187,33 @@ func viewPackageContainerImage(ctx gocontext.Context, pd *packages_model.Package
 	return metadata, err
 }
 
+// viewPackageContainerReferrers gets the signatures, SBOMs and attestations attached to the displayed manifests
+func viewPackageContainerReferrers(ctx gocontext.Context, pd *packages_model.PackageDescriptor, digest string) ([]*container_service.Referrer, error) {
+	var subjects []string
+	if digest != "" {
+		subjects = append(subjects, digest)
+	} else {
+		for _, pfd := range pd.Files {
+			if pfd.File.IsLead {
+				subjects = append(subjects, pfd.Properties.GetByName(container_module.PropertyDigest))
+			}
+		}
+		for _, manifest := range pd.Metadata.(*container_module.Metadata).Manifests {
+			subjects = append(subjects, manifest.Digest)
+		}
+	}
+
+	var referrers []*container_service.Referrer
+	for _, subject := range subjects {
+		rs, err := container_service.GetReferrers(ctx, pd.Owner.ID, pd.Package.LowerName, subject)
+		if err != nil {
+			return nil, err
+		}
+		referrers = append(referrers, rs...)
+	}
+	return referrers, nil
+}
+
 // ViewPackageVersion displays a single package version
 func ViewPackageVersion(ctx *context.Context) {
 	if _, err := shared_user.RenderUserOrgHeader(ctx); err != nil {
@@ -296,6 +323,13 @@ func ViewPackageVersion(ctx *context.Context) {
 			}
 		}
 		ctx.Data["ContainerImageMetadata"] = imageMetadata
+
+		referrers, err := viewPackageContainerReferrers(ctx, pd, versionSub)
+		if err != nil {
+			ctx.ServerError("viewPackageContainerReferrers", err)
+			return
+		}
+		ctx.Data["ContainerReferrers"] = referrers
 	}
 	var pvs []*packages_model.PackageVersion
 	var pvsTotal int64
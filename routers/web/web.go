@@ -454,6 +454,11 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		m.Post("/feishu/new", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksNewPost)
 		m.Post("/wechatwork/new", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksNewPost)
 		m.Post("/packagist/new", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksNewPost)
+		m.Post("/custom/new", web.Bind(forms.NewCustomHookForm{}), repo_setting.CustomHooksNewPost)
+		m.Post("/cloudevents/new", web.Bind(forms.NewCloudEventsHookForm{}), repo_setting.CloudEventsHooksNewPost)
+		m.Post("/mattermost/new", web.Bind(forms.NewMattermostHookForm{}), repo_setting.MattermostHooksNewPost)
+		m.Post("/rocketchat/new", web.Bind(forms.NewRocketChatHookForm{}), repo_setting.RocketChatHooksNewPost)
+		m.Post("/zulip/new", web.Bind(forms.NewZulipHookForm{}), repo_setting.ZulipHooksNewPost)
 	}
 
 	addWebhookEditRoutes := func() {
@@ -468,6 +473,11 @@ func registerWebRoutes(m *web.Router, webAuth *AuthMiddleware) {
 		m.Post("/feishu/{id}", web.Bind(forms.NewFeishuHookForm{}), repo_setting.FeishuHooksEditPost)
 		m.Post("/wechatwork/{id}", web.Bind(forms.NewWechatWorkHookForm{}), repo_setting.WechatworkHooksEditPost)
 		m.Post("/packagist/{id}", web.Bind(forms.NewPackagistHookForm{}), repo_setting.PackagistHooksEditPost)
+		m.Post("/custom/{id}", web.Bind(forms.NewCustomHookForm{}), repo_setting.CustomHooksEditPost)
+		m.Post("/cloudevents/{id}", web.Bind(forms.NewCloudEventsHookForm{}), repo_setting.CloudEventsHooksEditPost)
+		m.Post("/mattermost/{id}", web.Bind(forms.NewMattermostHookForm{}), repo_setting.MattermostHooksEditPost)
+		m.Post("/rocketchat/{id}", web.Bind(forms.NewRocketChatHookForm{}), repo_setting.RocketChatHooksEditPost)
+		m.Post("/zulip/{id}", web.Bind(forms.NewZulipHookForm{}), repo_setting.ZulipHooksEditPost)
 	}
 
 	addSettingsVariablesRoutes := func() {
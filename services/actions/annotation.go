@@ -0,0 +1,79 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"regexp"
+	"strconv"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/util"
+
+	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+)
+
+// annotationCommandPattern matches the "::error", "::warning" and "::notice" workflow commands, like
+// "::error file=app.js,line=1,col=5,endColumn=7,title=Syntax error::Missing semicolon"
+// https://docs.github.com/en/actions/reference/workflows-and-actions/workflow-commands#setting-an-error-message
+var annotationCommandPattern = regexp.MustCompile(`^::(error|warning|notice)(?:\s+([^:]*))?::(.*)$`)
+
+// unescapeWorkflowCommandValue reverts the escaping of "@actions/core", the properties also escape ':' and ','
+var unescapeWorkflowCommandValue = strings.NewReplacer("%0D", "\r", "%0A", "\n", "%3A", ":", "%2C", ",", "%25", "%").Replace
+
+// ParseAnnotationCommand parses a log line of the workflow commands which create annotations, it returns nil if the line isn't one of them
+func ParseAnnotationCommand(line string) *actions_model.ActionTaskAnnotation {
+	m := annotationCommandPattern.FindStringSubmatch(strings.TrimRight(line, "\r\n"))
+	if m == nil {
+		return nil
+	}
+
+	annotation := &actions_model.ActionTaskAnnotation{
+		Level:   actions_model.AnnotationLevel(m[1]),
+		Message: unescapeWorkflowCommandValue(m[3]),
+	}
+	for prop := range strings.SplitSeq(m[2], ",") {
+		key, value, ok := strings.Cut(strings.TrimSpace(prop), "=")
+		if !ok {
+			continue
+		}
+		value = unescapeWorkflowCommandValue(value)
+		switch key {
+		case "file":
+			annotation.Path = strings.TrimPrefix(util.PathJoinRelX(value), "./")
+		case "title":
+			annotation.Title = util.EllipsisDisplayString(value, 255)
+		case "line":
+			annotation.StartLine, _ = strconv.Atoi(value)
+		case "endLine":
+			annotation.EndLine, _ = strconv.Atoi(value)
+		case "col":
+			annotation.StartColumn, _ = strconv.Atoi(value)
+		case "endColumn":
+			annotation.EndColumn, _ = strconv.Atoi(value)
+		}
+	}
+	if annotation.EndLine < annotation.StartLine {
+		annotation.EndLine = annotation.StartLine
+	}
+	return annotation
+}
+
+// CreateTaskAnnotationsFromLogs creates the annotations of the workflow commands in the log rows of the task
+func CreateTaskAnnotationsFromLogs(ctx context.Context, task *actions_model.ActionTask, rows []*runnerv1.LogRow) error {
+	var annotations []*actions_model.ActionTaskAnnotation
+	for _, row := range rows {
+		if annotation := ParseAnnotationCommand(row.Content); annotation != nil {
+			annotations = append(annotations, annotation)
+		}
+	}
+	if len(annotations) == 0 {
+		return nil
+	}
+	if err := task.LoadJob(ctx); err != nil {
+		return err
+	}
+	return actions_model.InsertTaskAnnotations(ctx, task, annotations)
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseAnnotationCommand(t *testing.T) {
+	a := ParseAnnotationCommand("::error file=./src/app.js,line=10,endLine=12,col=5,endColumn=7,title=Lint %3A failed::Missing semicolon%0Aat line 10\n")
+	assert.Equal(t, &actions_model.ActionTaskAnnotation{
+		Level:       actions_model.AnnotationLevelError,
+		Path:        "src/app.js",
+		StartLine:   10,
+		EndLine:     12,
+		StartColumn: 5,
+		EndColumn:   7,
+		Title:       "Lint : failed",
+		Message:     "Missing semicolon\nat line 10",
+	}, a)
+
+	a = ParseAnnotationCommand("::warning::Deprecated API")
+	assert.Equal(t, &actions_model.ActionTaskAnnotation{Level: actions_model.AnnotationLevelWarning, Message: "Deprecated API"}, a)
+
+	a = ParseAnnotationCommand("::notice file=README.md,line=3::Consider a link")
+	assert.Equal(t, "README.md", a.Path)
+	assert.Equal(t, 3, a.EndLine)
+
+	assert.Nil(t, ParseAnnotationCommand("::debug::not an annotation"))
+	assert.Nil(t, ParseAnnotationCommand("error: plain log line"))
+	assert.Nil(t, ParseAnnotationCommand("::group::Run tests"))
+}
@@ -0,0 +1,156 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io/fs"
+	"slices"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/optional"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/storage"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// GetCacheRefs returns the git ref which the caches created by the run are scoped to,
+// and the git refs whose caches can be restored by the run in priority order:
+// the ref of the run, the base branch of the pull request and the default branch of the repository.
+func GetCacheRefs(ctx context.Context, run *actions_model.ActionRun) (string, []string, error) {
+	if err := run.LoadRepo(ctx); err != nil {
+		return "", nil, err
+	}
+
+	ref := run.Ref
+	var baseRef string
+	if pullPayload, err := run.GetPullRequestEventPayload(); err == nil && pullPayload.PullRequest != nil && pullPayload.PullRequest.Base != nil {
+		baseRef = git.RefNameFromBranch(pullPayload.PullRequest.Base.Ref).String()
+		// pull_request_target runs in the context of the base branch, so do the caches it creates
+		if run.TriggerEvent == actions_module.GithubEventPullRequestTarget {
+			ref = baseRef
+		}
+	}
+
+	restoreRefs := []string{ref}
+	for _, r := range []string{baseRef, git.RefNameFromBranch(run.Repo.DefaultBranch).String()} {
+		if r != "" && !slices.Contains(restoreRefs, r) {
+			restoreRefs = append(restoreRefs, r)
+		}
+	}
+	return ref, restoreRefs, nil
+}
+
+// abandonedCacheUploadTimeout is the time after which a reserved but not finalized cache entry is considered abandoned
+const abandonedCacheUploadTimeout = 24 * time.Hour
+
+// CleanupCaches removes the caches which haven't been used for a long time or abandoned during uploading,
+// then evicts the least recently used caches of the repositories which exceed the size limit
+func CleanupCaches(ctx context.Context) error {
+	if err := deleteCaches(ctx, actions_model.FindCachesOptions{
+		Complete:       optional.Some(true),
+		LastUsedBefore: timeutil.TimeStampNow().AddDuration(-time.Duration(setting.Actions.CacheRetentionDays) * 24 * time.Hour),
+	}, "expiration"); err != nil {
+		return fmt.Errorf("delete expired caches: %w", err)
+	}
+
+	if err := deleteCaches(ctx, actions_model.FindCachesOptions{
+		Complete:       optional.Some(false),
+		LastUsedBefore: timeutil.TimeStampNow().AddDuration(-abandonedCacheUploadTimeout),
+	}, "abandoned upload"); err != nil {
+		return fmt.Errorf("delete abandoned caches: %w", err)
+	}
+
+	if setting.Actions.CacheMaxSizePerRepo < 0 {
+		return nil
+	}
+	repos, err := actions_model.FindReposExceedingCacheSize(ctx, setting.Actions.CacheMaxSizePerRepo)
+	if err != nil {
+		return fmt.Errorf("find repositories exceeding cache size: %w", err)
+	}
+	for _, repo := range repos {
+		if err := evictRepoCaches(ctx, repo.RepoID, repo.Size-setting.Actions.CacheMaxSizePerRepo); err != nil {
+			return fmt.Errorf("evict caches of repository %d: %w", repo.RepoID, err)
+		}
+	}
+	return nil
+}
+
+// deleteCacheBatchSize is the batch size of deleting caches
+const deleteCacheBatchSize = 100
+
+func deleteCaches(ctx context.Context, opts actions_model.FindCachesOptions, reason string) error {
+	opts.ListOptions = db.ListOptions{PageSize: deleteCacheBatchSize}
+	for {
+		caches, err := db.Find[actions_model.ActionCache](ctx, opts)
+		if err != nil {
+			return err
+		}
+		for _, cache := range caches {
+			if err := DeleteCache(ctx, cache); err != nil {
+				return err
+			}
+			log.Info("Cache %d of repository %d is deleted (due to %s)", cache.ID, cache.RepoID, reason)
+		}
+		if len(caches) < deleteCacheBatchSize {
+			return nil
+		}
+	}
+}
+
+// evictRepoCaches deletes the least recently used caches of the repository until the freed size reaches the given size
+func evictRepoCaches(ctx context.Context, repoID, size int64) error {
+	for size > 0 {
+		caches, err := db.Find[actions_model.ActionCache](ctx, actions_model.FindCachesOptions{
+			ListOptions: db.ListOptions{PageSize: deleteCacheBatchSize},
+			RepoID:      repoID,
+			Complete:    optional.Some(true),
+		})
+		if err != nil {
+			return err
+		}
+		if len(caches) == 0 {
+			return nil
+		}
+		for _, cache := range caches {
+			if size <= 0 {
+				break
+			}
+			if err := DeleteCache(ctx, cache); err != nil {
+				return err
+			}
+			size -= cache.Size
+			log.Info("Cache %d of repository %d is deleted (due to size limit)", cache.ID, cache.RepoID)
+		}
+	}
+	return nil
+}
+
+// DeleteCache deletes the record and the content of the cache entry
+func DeleteCache(ctx context.Context, cache *actions_model.ActionCache) error {
+	if err := actions_model.DeleteCacheByID(ctx, cache.ID); err != nil {
+		return err
+	}
+	if err := storage.ActionsCache.Delete(cache.StoragePath()); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		log.Error("Cannot delete cache file %q: %v", cache.StoragePath(), err)
+		// go on
+	}
+	if !cache.Complete {
+		// the blocks are left in the storage if the upload is abandoned
+		if err := storage.ActionsCache.IterateObjects(cache.UploadBlocksPath(), func(path string, _ storage.Object) error {
+			return storage.ActionsCache.Delete(path)
+		}); err != nil {
+			log.Error("Cannot delete cache blocks %q: %v", cache.UploadBlocksPath(), err)
+			// go on
+		}
+	}
+	return nil
+}
@@ -224,6 +224,10 @@ func DeleteRun(ctx context.Context, run *actions_model.ActionRun) error {
 			TaskID: tas.ID,
 		})
 	}
+	recordsToDelete = append(recordsToDelete, &actions_model.ActionTaskAnnotation{
+		RepoID: repoID,
+		RunID:  run.ID,
+	})
 	recordsToDelete = append(recordsToDelete, &actions_model.ActionArtifact{
 		RepoID: repoID,
 		RunID:  run.ID,
@@ -18,6 +18,8 @@ import (
 	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 	notify_service "code.gitea.io/gitea/services/notify"
+
+	"xorm.io/builder"
 )
 
 // StopZombieTasks stops the task which have running status, but haven't been updated for a long time
@@ -25,7 +27,7 @@ func StopZombieTasks(ctx context.Context) error {
 	return stopTasks(ctx, actions_model.FindTaskOptions{
 		Status:        actions_model.StatusRunning,
 		UpdatedBefore: timeutil.TimeStamp(time.Now().Add(-setting.Actions.ZombieTaskTimeout).Unix()),
-	})
+	}, actions_model.RetryConclusionRunnerLost)
 }
 
 // StopEndlessTasks stops the tasks which have running status and continuous updates, but don't end for a long time
@@ -33,7 +35,7 @@ func StopEndlessTasks(ctx context.Context) error {
 	return stopTasks(ctx, actions_model.FindTaskOptions{
 		Status:        actions_model.StatusRunning,
 		StartedBefore: timeutil.TimeStamp(time.Now().Add(-setting.Actions.EndlessTaskTimeout).Unix()),
-	})
+	}, actions_model.RetryConclusionTimeout)
 }
 
 func notifyWorkflowJobStatusUpdate(ctx context.Context, jobs []*actions_model.ActionRunJob) {
@@ -140,7 +142,8 @@ func PrepareToStartRunWithConcurrency(ctx context.Context, run *actions_model.Ac
 	return util.Iif(shouldBlock, actions_model.StatusBlocked, actions_model.StatusWaiting), nil
 }
 
-func stopTasks(ctx context.Context, opts actions_model.FindTaskOptions) error {
+// stopTasks stops the tasks as failures caused by the infrastructure, their jobs may be re-queued by the retry policy
+func stopTasks(ctx context.Context, opts actions_model.FindTaskOptions, conclusion actions_model.RetryConclusion) error {
 	tasks, err := db.Find[actions_model.ActionTask](ctx, opts)
 	if err != nil {
 		return fmt.Errorf("find tasks: %w", err)
@@ -149,7 +152,7 @@ func stopTasks(ctx context.Context, opts actions_model.FindTaskOptions) error {
 	jobs := make([]*actions_model.ActionRunJob, 0, len(tasks))
 	for _, task := range tasks {
 		if err := db.WithTx(ctx, func(ctx context.Context) error {
-			if err := actions_model.StopTask(ctx, task.ID, actions_model.StatusFailure); err != nil {
+			if err := actions_model.StopTaskByInfraFailure(ctx, task.ID, conclusion); err != nil {
 				return err
 			}
 			if err := task.LoadJob(ctx); err != nil {
@@ -236,3 +239,20 @@ func CancelAbandonedJobs(ctx context.Context) error {
 
 	return nil
 }
+
+// QueueRetryingJobs makes the re-queued jobs whose retry backoff has elapsed available to the runners
+func QueueRetryingJobs(ctx context.Context) error {
+	jobs, err := actions_model.FindRetryingJobsToQueue(ctx, 100)
+	if err != nil {
+		return fmt.Errorf("find retrying jobs: %w", err)
+	}
+	for _, job := range jobs {
+		retryAfter := job.RetryAfter
+		job.RetryAfter = 0
+		// updating the status increases the tasks version, so the runners will fetch the job
+		if _, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusWaiting, "retry_after": retryAfter}, "status", "retry_after"); err != nil {
+			log.Error("Queue retrying job %d: %v", job.ID, err)
+		}
+	}
+	return nil
+}
@@ -143,8 +143,16 @@ func createCommitStatus(ctx context.Context, repo *repo_model.Repository, event,
 	if wfs, err := jobparser.Parse(job.WorkflowPayload); err == nil && len(wfs) > 0 {
 		runName = wfs[0].Name
 	}
+	name, isRequiredWorkflow := getRequiredWorkflowStatusName(ctx, run)
+	if isRequiredWorkflow {
+		runName = name
+	}
 	ctxName := fmt.Sprintf("%s / %s (%s)", runName, job.Name, event)
 	ctxName = strings.TrimSpace(ctxName) // git_model.NewCommitStatus also trims spaces
+	if !isRequiredWorkflow && actions_model.IsRequiredWorkflowStatusContext(ctxName) {
+		// the workflow of the repository can't pretend to be a required workflow, use its file name instead
+		ctxName = fmt.Sprintf("%s / %s (%s)", path.Base(run.WorkflowID), job.Name, event)
+	}
 	state := toCommitStatus(job.Status)
 	if statuses, err := git_model.GetLatestCommitStatus(ctx, repo.ID, commitID, db.ListOptionsAll); err == nil {
 		for _, v := range statuses {
@@ -167,6 +175,9 @@ func createCommitStatus(ctx context.Context, repo *repo_model.Repository, event,
 		description = fmt.Sprintf("Successful in %s", job.Duration())
 	case actions_model.StatusFailure:
 		description = fmt.Sprintf("Failing after %s", job.Duration())
+		if job.QuotaExceeded {
+			description = "Monthly minutes quota of the owner has been used up"
+		}
 	case actions_model.StatusCancelled:
 		description = "Has been cancelled"
 	case actions_model.StatusSkipped:
@@ -175,8 +186,13 @@ func createCommitStatus(ctx context.Context, repo *repo_model.Repository, event,
 		description = "Has started running"
 	case actions_model.StatusWaiting:
 		description = "Waiting to run"
+		if job.RetryCount > 0 {
+			description = fmt.Sprintf("Waiting to retry after an infrastructure failure (retry %d)", job.RetryCount)
+		}
 	case actions_model.StatusBlocked:
 		description = "Blocked by required conditions"
+	case actions_model.StatusWaitingApproval:
+		description = "Waiting for approval to deploy"
 	default:
 		description = "Unknown status: " + strconv.Itoa(int(job.Status))
 	}
@@ -191,6 +207,9 @@ func createCommitStatus(ctx context.Context, repo *repo_model.Repository, event,
 		State:       state,
 	}
 
+	if isRequiredWorkflow {
+		return commitstatus_service.CreateRequiredWorkflowCommitStatus(ctx, repo, creator, commitID, &status)
+	}
 	return commitstatus_service.CreateCommitStatus(ctx, repo, creator, commitID, &status)
 }
 
@@ -200,7 +219,7 @@ func toCommitStatus(status actions_model.Status) commitstatus.CommitStatusState
 		return commitstatus.CommitStatusSuccess
 	case actions_model.StatusFailure, actions_model.StatusCancelled:
 		return commitstatus.CommitStatusFailure
-	case actions_model.StatusWaiting, actions_model.StatusBlocked, actions_model.StatusRunning:
+	case actions_model.StatusWaiting, actions_model.StatusBlocked, actions_model.StatusWaitingApproval, actions_model.StatusRunning:
 		return commitstatus.CommitStatusPending
 	case actions_model.StatusSkipped:
 		return commitstatus.CommitStatusSkipped
@@ -7,10 +7,12 @@ import (
 	"context"
 	"fmt"
 	"strconv"
+	"strings"
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/actions/jobparser"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/json"
@@ -18,6 +20,7 @@ import (
 	"code.gitea.io/gitea/modules/util"
 
 	"github.com/nektos/act/pkg/model"
+	"go.yaml.in/yaml/v4"
 )
 
 type GiteaContext map[string]any
@@ -116,6 +119,9 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 	jobIDJobs := make(map[string][]*actions_model.ActionRunJob)
 	for _, job := range jobs {
 		jobIDJobs[job.JobID] = append(jobIDJobs[job.JobID], job)
+		for _, callerID := range jobparser.ReusableWorkflowCallerIDs(job.JobID) {
+			jobIDJobs[callerID] = append(jobIDJobs[callerID], job)
+		}
 	}
 
 	ret := make(map[string]*TaskNeed, len(needs))
@@ -124,24 +130,14 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 			continue
 		}
 		var jobOutputs map[string]string
-		for _, job := range jobsWithSameID {
-			if job.TaskID == 0 || !job.Status.IsDone() {
-				// it shouldn't happen, or the job has been rerun
-				continue
-			}
-			got, err := actions_model.FindTaskOutputByTaskID(ctx, job.TaskID)
-			if err != nil {
-				return nil, fmt.Errorf("FindTaskOutputByTaskID: %w", err)
-			}
-			outputs := make(map[string]string, len(got))
-			for _, v := range got {
-				outputs[v.OutputKey] = v.OutputValue
-			}
-			if len(jobOutputs) == 0 {
-				jobOutputs = outputs
-			} else {
-				jobOutputs = mergeTwoOutputs(outputs, jobOutputs)
-			}
+		if jobsWithSameID[0].JobID != jobID {
+			// the needed job is a caller of a reusable workflow, and these jobs are expanded from the called workflow
+			jobOutputs, err = findReusableWorkflowOutputs(ctx, jobID, jobsWithSameID)
+		} else {
+			jobOutputs, err = findJobsOutputs(ctx, jobsWithSameID)
+		}
+		if err != nil {
+			return nil, err
 		}
 		ret[jobID] = &TaskNeed{
 			Outputs: jobOutputs,
@@ -151,6 +147,77 @@ func FindTaskNeeds(ctx context.Context, job *actions_model.ActionRunJob) (map[st
 	return ret, nil
 }
 
+// findJobsOutputs returns the merged outputs of the jobs with the same job id
+func findJobsOutputs(ctx context.Context, jobs []*actions_model.ActionRunJob) (map[string]string, error) {
+	var jobOutputs map[string]string
+	for _, job := range jobs {
+		if job.TaskID == 0 || !job.Status.IsDone() {
+			// it shouldn't happen, or the job has been rerun
+			continue
+		}
+		got, err := actions_model.FindTaskOutputByTaskID(ctx, job.TaskID)
+		if err != nil {
+			return nil, fmt.Errorf("FindTaskOutputByTaskID: %w", err)
+		}
+		outputs := make(map[string]string, len(got))
+		for _, v := range got {
+			outputs[v.OutputKey] = v.OutputValue
+		}
+		if len(jobOutputs) == 0 {
+			jobOutputs = outputs
+		} else {
+			jobOutputs = mergeTwoOutputs(outputs, jobOutputs)
+		}
+	}
+	return jobOutputs, nil
+}
+
+// findReusableWorkflowOutputs returns the outputs of a caller job by evaluating the "on.workflow_call.outputs" of the called workflow,
+// the jobs are all the jobs expanded from the called workflow.
+func findReusableWorkflowOutputs(ctx context.Context, callerID string, jobs []*actions_model.ActionRunJob) (map[string]string, error) {
+	prefix := callerID + jobparser.ReusableWorkflowJobIDSeparator
+	calledJobs := make(map[string][]*actions_model.ActionRunJob)
+	var callConfig *jobparser.WorkflowCallConfig
+	for _, job := range jobs {
+		calledID, _, nested := strings.Cut(strings.TrimPrefix(job.JobID, prefix), jobparser.ReusableWorkflowJobIDSeparator)
+		calledJobs[calledID] = append(calledJobs[calledID], job)
+		if callConfig == nil && !nested {
+			// the payload of a job directly expanded from the called workflow keeps the "on" of the called workflow
+			swf := &jobparser.SingleWorkflow{}
+			if err := yaml.Unmarshal(job.WorkflowPayload, swf); err != nil {
+				return nil, fmt.Errorf("unmarshal workflow payload of job %d: %w", job.ID, err)
+			}
+			cfg, err := jobparser.GetWorkflowCallConfig(&swf.RawOn)
+			if err != nil {
+				return nil, fmt.Errorf("job %d: %w", job.ID, err)
+			}
+			callConfig = cfg
+		}
+	}
+	if callConfig == nil || len(callConfig.Outputs) == 0 {
+		return nil, nil //nolint:nilnil // the called workflow has no outputs
+	}
+
+	results := make(map[string]*jobparser.JobResult, len(calledJobs))
+	for calledID, js := range calledJobs {
+		var outputs map[string]string
+		var err error
+		if js[0].JobID == prefix+calledID {
+			outputs, err = findJobsOutputs(ctx, js)
+		} else {
+			outputs, err = findReusableWorkflowOutputs(ctx, prefix+calledID, js)
+		}
+		if err != nil {
+			return nil, err
+		}
+		results[calledID] = &jobparser.JobResult{
+			Result:  actions_model.AggregateJobStatus(js).String(),
+			Outputs: outputs,
+		}
+	}
+	return jobparser.EvaluateWorkflowCallOutputs(callConfig.Outputs, results), nil
+}
+
 // mergeTwoOutputs merges two outputs from two different ActionRunJobs
 // Values with the same output name may be overridden. The user should ensure the output names are unique.
 // See https://docs.github.com/en/actions/writing-workflows/workflow-syntax-for-github-actions#using-job-outputs-in-a-matrix-job
@@ -0,0 +1,190 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"slices"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/organization"
+	secret_model "code.gitea.io/gitea/models/secret"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	notify_service "code.gitea.io/gitea/services/notify"
+
+	"xorm.io/builder"
+)
+
+// PrepareJobDeployment checks the protection rules of the environment which the job deploys to, and records the deployment.
+// It returns the new status of the job which is ready to start:
+// StatusFailure if the ref of the run isn't allowed to deploy to the environment,
+// StatusWaitingApproval if the deployment has to be reviewed or wait for the wait timer, otherwise StatusWaiting.
+// An environment is created without protection rules when a job deploys to it for the first time.
+func PrepareJobDeployment(ctx context.Context, job *actions_model.ActionRunJob) (actions_model.Status, error) {
+	if job.Environment == "" {
+		return actions_model.StatusWaiting, nil
+	}
+	if err := job.LoadRun(ctx); err != nil {
+		return actions_model.StatusBlocked, err
+	}
+
+	env, err := actions_model.GetEnvironmentByName(ctx, job.RepoID, job.Environment)
+	if errors.Is(err, util.ErrNotExist) {
+		env = &actions_model.ActionEnvironment{RepoID: job.RepoID, Name: job.Environment}
+		err = actions_model.CreateEnvironment(ctx, env)
+	}
+	if err != nil {
+		return actions_model.StatusBlocked, fmt.Errorf("get environment %q: %w", job.Environment, err)
+	}
+
+	if !env.CanDeployRef(job.Run.Ref) {
+		log.Info("Job %d fails because %q is not allowed to deploy to environment %q", job.ID, job.Run.Ref, env.Name)
+		return actions_model.StatusFailure, nil
+	}
+
+	deployment := &actions_model.ActionDeployment{
+		RepoID:        job.RepoID,
+		EnvironmentID: env.ID,
+		RunID:         job.RunID,
+		JobID:         job.ID,
+		Ref:           job.Run.Ref,
+		CommitSHA:     job.CommitSHA,
+		TriggerUserID: job.Run.TriggerUserID,
+	}
+	if parsedJob, err := job.ParseJob(); err == nil {
+		_, deployment.URL = parsedJob.Environment()
+	}
+	if env.NeedReview() {
+		deployment.ReviewStatus = actions_model.DeploymentReviewPending
+	}
+	if env.WaitTimer > 0 {
+		deployment.WaitUntil = timeutil.TimeStampNow().AddDuration(time.Duration(env.WaitTimer) * time.Minute)
+	}
+	if err := db.Insert(ctx, deployment); err != nil {
+		return actions_model.StatusBlocked, err
+	}
+
+	return util.Iif(deployment.IsWaiting(), actions_model.StatusWaitingApproval, actions_model.StatusWaiting), nil
+}
+
+// IsEnvironmentReviewer returns whether the user is one of the required reviewers of the environment
+func IsEnvironmentReviewer(ctx context.Context, env *actions_model.ActionEnvironment, user *user_model.User) (bool, error) {
+	if slices.Contains(env.ReviewerUserIDs, user.ID) {
+		return true, nil
+	}
+	if len(env.ReviewerTeamIDs) == 0 {
+		return false, nil
+	}
+	return organization.IsUserInTeams(ctx, user.ID, env.ReviewerTeamIDs)
+}
+
+// ReviewDeployment approves or rejects a pending deployment by a reviewer of its environment.
+// The job is failed if the deployment is rejected, or it starts when the deployment is approved and the wait timer has elapsed.
+func ReviewDeployment(ctx context.Context, doer *user_model.User, deployment *actions_model.ActionDeployment, approve bool, comment string) error {
+	if err := deployment.LoadEnvironment(ctx); err != nil {
+		return err
+	}
+	if ok, err := IsEnvironmentReviewer(ctx, deployment.Environment, doer); err != nil {
+		return err
+	} else if !ok {
+		return util.NewPermissionDeniedErrorf("user %s is not a reviewer of environment %q", doer.Name, deployment.Environment.Name)
+	}
+
+	var job *actions_model.ActionRunJob
+	if err := db.WithTx(ctx, func(ctx context.Context) error {
+		if err := deployment.LoadJob(ctx); err != nil {
+			return err
+		}
+		job = deployment.Job
+		latest, err := actions_model.GetLatestDeploymentOfJob(ctx, job)
+		if err != nil {
+			return err
+		}
+		if latest.ID != deployment.ID || deployment.ReviewStatus != actions_model.DeploymentReviewPending || !job.Status.IsWaitingApproval() {
+			return util.NewInvalidArgumentErrorf("deployment %d is not waiting for review", deployment.ID)
+		}
+
+		deployment.ReviewStatus = util.Iif(approve, actions_model.DeploymentReviewApproved, actions_model.DeploymentReviewRejected)
+		deployment.ReviewerID = doer.ID
+		deployment.Reviewer = doer
+		deployment.ReviewComment = comment
+		if err := actions_model.UpdateDeployment(ctx, deployment, "review_status", "reviewer_id", "review_comment"); err != nil {
+			return err
+		}
+
+		switch {
+		case !approve:
+			job.Status = actions_model.StatusFailure
+			job.Stopped = timeutil.TimeStampNow()
+		case !deployment.IsWaiting():
+			job.Status = actions_model.StatusWaiting
+		default:
+			// the wait timer hasn't elapsed, the job will be started by the cron task
+			return nil
+		}
+		_, err = actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusWaitingApproval}, "status", "stopped")
+		return err
+	}); err != nil {
+		return err
+	}
+
+	if job.Status.IsWaitingApproval() {
+		return nil
+	}
+	notifyDeploymentJobStatusUpdate(ctx, job)
+	return nil
+}
+
+// StartReadyDeployments starts the jobs waiting for approval whose deployments have been approved and wait timers have elapsed
+func StartReadyDeployments(ctx context.Context) error {
+	deployments, err := actions_model.FindReadyDeployments(ctx, 100)
+	if err != nil {
+		return fmt.Errorf("find ready deployments: %w", err)
+	}
+
+	for _, deployment := range deployments {
+		if err := deployment.LoadJob(ctx); err != nil {
+			log.Error("LoadJob of deployment %d: %v", deployment.ID, err)
+			continue
+		}
+		job := deployment.Job
+		job.Status = actions_model.StatusWaiting
+		if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusWaitingApproval}, "status"); err != nil {
+			log.Error("Start job %d of deployment %d: %v", job.ID, deployment.ID, err)
+			continue
+		} else if n == 0 {
+			continue
+		}
+		notifyDeploymentJobStatusUpdate(ctx, job)
+	}
+	return nil
+}
+
+func notifyDeploymentJobStatusUpdate(ctx context.Context, job *actions_model.ActionRunJob) {
+	job.Run = nil
+	if err := job.LoadAttributes(ctx); err != nil {
+		log.Error("LoadAttributes: %v", err)
+		return
+	}
+	CreateCommitStatusForRunJobs(ctx, job.Run, job)
+	notify_service.WorkflowJobStatusUpdate(ctx, job.Run.Repo, job.Run.TriggerUser, job, nil)
+	EmitJobsIfReadyByJobs([]*actions_model.ActionRunJob{job})
+}
+
+// DeleteEnvironment deletes the environment with its secrets, variables and deployment history
+func DeleteEnvironment(ctx context.Context, env *actions_model.ActionEnvironment) error {
+	return db.WithTx(ctx, func(ctx context.Context) error {
+		if _, err := db.GetEngine(ctx).Where(builder.Eq{"repo_id": env.RepoID, "environment_id": env.ID}).Delete(new(secret_model.Secret)); err != nil {
+			return err
+		}
+		return actions_model.DeleteEnvironment(ctx, env)
+	})
+}
@@ -0,0 +1,207 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/services/oauth2_provider"
+
+	"github.com/golang-jwt/jwt/v5"
+	"github.com/google/uuid"
+)
+
+// IDTokenIssuerPath is the path of the OIDC issuer of the ID tokens of Actions jobs, it's relative to the AppURL
+const IDTokenIssuerPath = "/api/actions_oidc"
+
+var idTokenSigningKey oauth2_provider.JWTSigningKey
+
+func initIDTokenSigningKey() error {
+	key, err := oauth2_provider.LoadOrCreateAsymmetricSigningKey(setting.Actions.IDTokenSigningAlgorithm, setting.Actions.IDTokenSigningPrivateKeyFile)
+	if err != nil {
+		return fmt.Errorf("unable to load the signing key of actions ID tokens: %w", err)
+	}
+	idTokenSigningKey = key
+	return nil
+}
+
+// IDTokenSigningKey returns the key which signs the ID tokens, it's nil if Actions is disabled
+func IDTokenSigningKey() oauth2_provider.JWTSigningKey {
+	return idTokenSigningKey
+}
+
+// IDTokenIssuer returns the "iss" claim of the ID tokens
+func IDTokenIssuer() string {
+	return setting.AppURL + IDTokenIssuerPath[1:]
+}
+
+// IDTokenClaims are the claims of the ID token of an Actions job.
+// The custom claims follow GitHub's, so cloud providers' trust policies written for GitHub can be reused.
+// https://docs.github.com/en/actions/reference/security/oidc#oidc-token-claims
+type IDTokenClaims struct {
+	jwt.RegisteredClaims
+
+	Ref                  string `json:"ref"`
+	RefType              string `json:"ref_type"`
+	SHA                  string `json:"sha"`
+	Repository           string `json:"repository"`
+	RepositoryID         string `json:"repository_id"`
+	RepositoryOwner      string `json:"repository_owner"`
+	RepositoryOwnerID    string `json:"repository_owner_id"`
+	RepositoryVisibility string `json:"repository_visibility"`
+	Actor                string `json:"actor"`
+	ActorID              string `json:"actor_id"`
+	EventName            string `json:"event_name"`
+	HeadRef              string `json:"head_ref"`
+	BaseRef              string `json:"base_ref"`
+	Environment          string `json:"environment,omitempty"`
+	Workflow             string `json:"workflow"`
+	WorkflowRef          string `json:"workflow_ref"`
+	WorkflowSHA          string `json:"workflow_sha"`
+	JobWorkflowRef       string `json:"job_workflow_ref"`
+	RunID                string `json:"run_id"`
+	RunNumber            string `json:"run_number"`
+	RunAttempt           string `json:"run_attempt"`
+	RunnerEnvironment    string `json:"runner_environment"`
+}
+
+// IDTokenClaimNames are the names of the claims which can be found in the ID tokens, they're advertised by the discovery document
+var IDTokenClaimNames = []string{
+	"sub", "aud", "exp", "iat", "iss", "jti", "nbf",
+	"ref", "ref_type", "sha", "repository", "repository_id", "repository_owner", "repository_owner_id", "repository_visibility",
+	"actor", "actor_id", "event_name", "head_ref", "base_ref", "environment",
+	"workflow", "workflow_ref", "workflow_sha", "job_workflow_ref", "run_id", "run_number", "run_attempt", "runner_environment",
+}
+
+// CanRequestIDToken returns whether the job can request ID tokens.
+// The job must be granted "id-token: write" explicitly, and jobs triggered by pull requests from forks never can.
+func CanRequestIDToken(job *actions_model.ActionRunJob) bool {
+	return job.TokenPermissions != nil && job.TokenPermissions.IDToken && !job.IsForkPullRequest
+}
+
+// idTokenSubject returns the "sub" claim, it's the same as GitHub's default subject
+func idTokenSubject(repoFullName, eventName, ref, environment string) string {
+	switch {
+	case environment != "":
+		return fmt.Sprintf("repo:%s:environment:%s", repoFullName, environment)
+	case eventName == actions.GithubEventPullRequest || eventName == actions.GithubEventPullRequestTarget:
+		return fmt.Sprintf("repo:%s:pull_request", repoFullName)
+	default:
+		return fmt.Sprintf("repo:%s:ref:%s", repoFullName, ref)
+	}
+}
+
+// getWorkflowPath returns the path of the run's workflow file in the repository
+func getWorkflowPath(ctx context.Context, run *actions_model.ActionRun) (string, error) {
+	gitRepo, err := gitrepo.OpenRepository(ctx, run.Repo)
+	if err != nil {
+		return "", fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	commit, err := gitRepo.GetCommit(run.CommitSHA)
+	if err != nil {
+		return "", fmt.Errorf("GetCommit: %w", err)
+	}
+	dir, entries, err := actions.ListWorkflows(commit)
+	if err != nil {
+		return "", fmt.Errorf("ListWorkflows: %w", err)
+	}
+	for _, entry := range entries {
+		if entry.Name() == run.WorkflowID {
+			return dir + "/" + run.WorkflowID, nil
+		}
+	}
+	// the workflow file should exist in the commit of the run, fall back to the first directory just in case
+	return setting.Actions.WorkflowDirs[0] + "/" + run.WorkflowID, nil
+}
+
+// CreateIDToken creates a signed ID token for the running task.
+// The audience defaults to the URL of the repository owner like GitHub, when the job doesn't specify one.
+func CreateIDToken(ctx context.Context, task *actions_model.ActionTask, audience string) (string, error) {
+	if idTokenSigningKey == nil {
+		return "", util.NewInvalidArgumentErrorf("actions ID tokens are not enabled")
+	}
+	if err := task.LoadAttributes(ctx); err != nil {
+		return "", err
+	}
+	job := task.Job
+	if !CanRequestIDToken(job) {
+		return "", util.NewPermissionDeniedErrorf("the job is not allowed to request ID tokens")
+	}
+	run := job.Run
+
+	workflowPath, err := getWorkflowPath(ctx, run)
+	if err != nil {
+		return "", err
+	}
+
+	gitCtx := GenerateGiteaContext(run, job)
+	ref := gitCtx["ref"].(string)
+	eventName := gitCtx["event_name"].(string)
+	repoFullName := run.Repo.FullName()
+
+	visibility := "public"
+	if run.Repo.IsPrivate {
+		visibility = "private"
+	} else if !run.Repo.Owner.Visibility.IsPublic() {
+		visibility = "internal"
+	}
+
+	workflowRef := fmt.Sprintf("%s/%s@%s", repoFullName, workflowPath, run.Ref)
+	jobWorkflowRef := job.CalledWorkflowRef
+	if jobWorkflowRef == "" {
+		jobWorkflowRef = workflowRef
+	}
+	if audience == "" {
+		audience = run.Repo.Owner.HTMLURL(ctx)
+	}
+
+	now := time.Now()
+	claims := IDTokenClaims{
+		RegisteredClaims: jwt.RegisteredClaims{
+			Issuer:    IDTokenIssuer(),
+			Subject:   idTokenSubject(repoFullName, eventName, ref, job.Environment),
+			Audience:  jwt.ClaimStrings{audience},
+			ExpiresAt: jwt.NewNumericDate(now.Add(setting.Actions.IDTokenExpirationTime)),
+			NotBefore: jwt.NewNumericDate(now),
+			IssuedAt:  jwt.NewNumericDate(now),
+			ID:        uuid.NewString(),
+		},
+		Ref:                  ref,
+		RefType:              gitCtx["ref_type"].(string),
+		SHA:                  gitCtx["sha"].(string),
+		Repository:           repoFullName,
+		RepositoryID:         strconv.FormatInt(run.RepoID, 10),
+		RepositoryOwner:      run.Repo.OwnerName,
+		RepositoryOwnerID:    strconv.FormatInt(run.Repo.OwnerID, 10),
+		RepositoryVisibility: visibility,
+		Actor:                run.TriggerUser.Name,
+		ActorID:              strconv.FormatInt(run.TriggerUserID, 10),
+		EventName:            eventName,
+		HeadRef:              gitCtx["head_ref"].(string),
+		BaseRef:              gitCtx["base_ref"].(string),
+		Environment:          job.Environment,
+		Workflow:             run.WorkflowID,
+		WorkflowRef:          workflowRef,
+		WorkflowSHA:          run.CommitSHA,
+		JobWorkflowRef:       jobWorkflowRef,
+		RunID:                strconv.FormatInt(run.ID, 10),
+		RunNumber:            strconv.FormatInt(run.Index, 10),
+		RunAttempt:           strconv.FormatInt(job.Attempt, 10),
+		RunnerEnvironment:    "self-hosted",
+	}
+
+	token := jwt.NewWithClaims(idTokenSigningKey.SigningMethod(), claims)
+	idTokenSigningKey.PreProcessToken(token)
+	return token.SignedString(idTokenSigningKey.SignKey())
+}
@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestIDTokenSubject(t *testing.T) {
+	assert.Equal(t, "repo:user2/repo1:environment:production", idTokenSubject("user2/repo1", "push", "refs/heads/main", "production"))
+	assert.Equal(t, "repo:user2/repo1:pull_request", idTokenSubject("user2/repo1", "pull_request", "refs/pull/1/merge", ""))
+	assert.Equal(t, "repo:user2/repo1:pull_request", idTokenSubject("user2/repo1", "pull_request_target", "refs/heads/main", ""))
+	assert.Equal(t, "repo:user2/repo1:ref:refs/tags/v1.0", idTokenSubject("user2/repo1", "push", "refs/tags/v1.0", ""))
+}
+
+func TestCanRequestIDToken(t *testing.T) {
+	assert.False(t, CanRequestIDToken(&actions_model.ActionRunJob{}))
+	assert.False(t, CanRequestIDToken(&actions_model.ActionRunJob{TokenPermissions: &repo_model.ActionsTokenPermissions{}}))
+	assert.True(t, CanRequestIDToken(&actions_model.ActionRunJob{TokenPermissions: &repo_model.ActionsTokenPermissions{IDToken: true}}))
+	assert.False(t, CanRequestIDToken(&actions_model.ActionRunJob{IsForkPullRequest: true, TokenPermissions: &repo_model.ActionsTokenPermissions{IDToken: true}}))
+}
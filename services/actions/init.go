@@ -66,6 +66,10 @@ func Init(ctx context.Context) error {
 	}
 	go graceful.GetManager().RunWithCancel(jobEmitterQueue)
 
+	if err := initIDTokenSigningKey(); err != nil {
+		return err
+	}
+
 	notify_service.RegisterNotifier(NewNotifier())
 	return initGlobalRunnerToken(ctx)
 }
@@ -10,6 +10,7 @@ import (
 
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/modules/actions/jobparser"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/graceful"
 	"code.gitea.io/gitea/modules/log"
@@ -213,7 +214,7 @@ func checkJobsOfRun(ctx context.Context, run *actions_model.ActionRun) (jobs, up
 		for _, job := range jobs {
 			if status, ok := updates[job.ID]; ok {
 				job.Status = status
-				if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "status"); err != nil {
+				if n, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": actions_model.StatusBlocked}, "status", "quota_exceeded"); err != nil {
 					return err
 				} else if n != 1 {
 					return fmt.Errorf("no affected for updating blocked job %v", job.ID)
@@ -250,6 +251,10 @@ func newJobStatusResolver(jobs actions_model.ActionJobList, vars map[string]stri
 	jobMap := make(map[int64]*actions_model.ActionRunJob)
 	for _, job := range jobs {
 		idToJobs[job.JobID] = append(idToJobs[job.JobID], job)
+		// a job needing the caller job of a reusable workflow needs all jobs of the called workflow
+		for _, callerID := range jobparser.ReusableWorkflowCallerIDs(job.JobID) {
+			idToJobs[callerID] = append(idToJobs[callerID], job)
+		}
 		jobMap[job.ID] = job
 	}
 
@@ -346,6 +351,18 @@ func (r *jobStatusResolver) resolve(ctx context.Context) map[int64]actions_model
 				log.Error("ShouldBlockJobByConcurrency failed, this job will stay blocked: job: %d, err: %v", id, err)
 			}
 		}
+		if newStatus == actions_model.StatusWaiting {
+			newStatus, err = PrepareJobWithUsageQuota(ctx, actionRunJob)
+			if err != nil {
+				log.Error("PrepareJobWithUsageQuota failed, this job will stay blocked: job: %d, err: %v", id, err)
+			}
+		}
+		if newStatus == actions_model.StatusWaiting {
+			newStatus, err = PrepareJobDeployment(ctx, actionRunJob)
+			if err != nil {
+				log.Error("PrepareJobDeployment failed, this job will stay blocked: job: %d, err: %v", id, err)
+			}
+		}
 
 		if newStatus != actions_model.StatusBlocked {
 			ret[id] = newStatus
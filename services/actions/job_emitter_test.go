@@ -72,6 +72,24 @@ func Test_jobStatusResolver_Resolve(t *testing.T) {
 			},
 			want: map[int64]actions_model.Status{},
 		},
+		{
+			name: "need reusable workflow caller",
+			jobs: actions_model.ActionJobList{
+				{ID: 1, JobID: "call/build", Status: actions_model.StatusSuccess, Needs: []string{}},
+				{ID: 2, JobID: "call/test", Status: actions_model.StatusRunning, Needs: []string{"call/build"}},
+				{ID: 3, JobID: "deploy", Status: actions_model.StatusBlocked, Needs: []string{"call"}},
+			},
+			want: map[int64]actions_model.Status{},
+		},
+		{
+			name: "need completed reusable workflow caller",
+			jobs: actions_model.ActionJobList{
+				{ID: 1, JobID: "call/build", Status: actions_model.StatusSuccess, Needs: []string{}},
+				{ID: 2, JobID: "call/test", Status: actions_model.StatusSuccess, Needs: []string{"call/build"}},
+				{ID: 3, JobID: "deploy", Status: actions_model.StatusBlocked, Needs: []string{"call"}},
+			},
+			want: map[int64]actions_model.Status{3: actions_model.StatusWaiting},
+		},
 		{
 			name: "`if` is not empty and all jobs in `needs` completed successfully",
 			jobs: actions_model.ActionJobList{
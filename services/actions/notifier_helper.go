@@ -233,6 +233,13 @@ func notify(ctx context.Context, input *notifyInput) error {
 		}
 	}
 
+	// the required workflows of the org can't be disabled by the repository
+	requiredWorkflows, err := detectRequiredWorkflows(ctx, gitRepo, commit, input, ref)
+	if err != nil {
+		return fmt.Errorf("detectRequiredWorkflows: %w", err)
+	}
+	detectedWorkflows = append(detectedWorkflows, requiredWorkflows...)
+
 	if shouldDetectSchedules {
 		if err := handleSchedules(ctx, schedules, commit, input, ref); err != nil {
 			return err
@@ -264,24 +271,10 @@ func skipWorkflows(ctx context.Context, input *notifyInput, commit *git.Commit)
 	}
 	if input.Event == webhook_module.HookEventWorkflowRun {
 		wrun, ok := input.Payload.(*api.WorkflowRunPayload)
-		for i := 0; i < 5 && ok && wrun.WorkflowRun != nil; i++ {
-			if wrun.WorkflowRun.Event != "workflow_run" {
-				return false
-			}
-			r, err := actions_model.GetRunByRepoAndID(ctx, input.Repo.ID, wrun.WorkflowRun.ID)
-			if err != nil {
-				log.Error("GetRunByRepoAndID: %v", err)
-				return true
-			}
-			wrun, err = r.GetWorkflowRunEventPayload()
-			if err != nil {
-				log.Error("GetWorkflowRunEventPayload: %v", err)
-				return true
-			}
+		if !ok || isWorkflowRunChainTooDeep(ctx, input.Repo.ID, wrun) {
+			log.Debug("repo %s: skipped workflow_run because of recursive event of %d", input.Repo.RelativePath(), maxWorkflowRunChainDepth)
+			return true
 		}
-		// skip workflow runs events exceeding the maximum of 5 recursive events
-		log.Debug("repo %s: skipped workflow_run because of recursive event of 5", input.Repo.RelativePath())
-		return true
 	}
 	return false
 }
@@ -320,20 +313,21 @@ func handleWorkflows(
 
 	for _, dwf := range detectedWorkflows {
 		run := &actions_model.ActionRun{
-			Title:             strings.SplitN(commit.CommitMessage, "\n", 2)[0],
-			RepoID:            input.Repo.ID,
-			Repo:              input.Repo,
-			OwnerID:           input.Repo.OwnerID,
-			WorkflowID:        dwf.EntryName,
-			TriggerUserID:     input.Doer.ID,
-			TriggerUser:       input.Doer,
-			Ref:               ref.String(),
-			CommitSHA:         commit.ID.String(),
-			IsForkPullRequest: isForkPullRequest,
-			Event:             input.Event,
-			EventPayload:      string(p),
-			TriggerEvent:      dwf.TriggerEvent.Name,
-			Status:            actions_model.StatusWaiting,
+			Title:              strings.SplitN(commit.CommitMessage, "\n", 2)[0],
+			RepoID:             input.Repo.ID,
+			Repo:               input.Repo,
+			OwnerID:            input.Repo.OwnerID,
+			WorkflowID:         dwf.EntryName,
+			TriggerUserID:      input.Doer.ID,
+			TriggerUser:        input.Doer,
+			Ref:                ref.String(),
+			CommitSHA:          commit.ID.String(),
+			IsForkPullRequest:  isForkPullRequest,
+			Event:              input.Event,
+			EventPayload:       string(p),
+			TriggerEvent:       dwf.TriggerEvent.Name,
+			Status:             actions_model.StatusWaiting,
+			RequiredWorkflowID: dwf.RequiredWorkflowID,
 		}
 
 		need, err := ifNeedApproval(ctx, run, input.Repo, input.Doer)
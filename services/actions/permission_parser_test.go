@@ -106,6 +106,26 @@ projects: none
 	assert.Equal(t, perm.AccessModeNone, result.UnitAccessModes[unit.TypeProjects])
 }
 
+func TestParseRawPermissions_IDToken(t *testing.T) {
+	cases := map[string]bool{
+		"id-token: write":                   true,
+		"id-token: read":                    false,
+		"id-token: none":                    false,
+		"contents: write":                   false,
+		"write-all":                         true,
+		"read-all":                          false,
+		"{contents: read, id-token: write}": true,
+	}
+	for yamlContent, expected := range cases {
+		var rawPerms yaml.Node
+		require.NoError(t, yaml.Unmarshal([]byte(yamlContent), &rawPerms))
+
+		result := parseRawPermissionsExplicit(&rawPerms)
+		require.NotNil(t, result)
+		assert.Equal(t, expected, result.IDToken, yamlContent)
+	}
+}
+
 func TestParseRawPermissions_Priority(t *testing.T) {
 	t.Run("granular-wins-over-contents", func(t *testing.T) {
 		yamlContent := `
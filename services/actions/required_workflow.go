@@ -0,0 +1,121 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"slices"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// requiredWorkflowEvents are the events which the required workflows run with, other events of their "on" are ignored
+var requiredWorkflowEvents = []string{actions_module.GithubEventPush, actions_module.GithubEventPullRequest}
+
+// detectRequiredWorkflows returns the required workflows of the org which should run with the event of the repository.
+// A required workflow which can't be read is logged and skipped, its status checks will be missing and block the pull requests.
+func detectRequiredWorkflows(ctx context.Context, gitRepo *git.Repository, commit *git.Commit, input *notifyInput, ref git.RefName) ([]*actions_module.DetectedWorkflow, error) {
+	var branch string
+	switch input.Event {
+	case webhook_module.HookEventPush:
+		if !ref.IsBranch() {
+			return nil, nil
+		}
+		branch = ref.BranchName()
+	case webhook_module.HookEventPullRequest, webhook_module.HookEventPullRequestSync:
+		if input.PullRequest == nil {
+			return nil, nil
+		}
+		branch = input.PullRequest.BaseBranch
+	default:
+		return nil, nil
+	}
+
+	requiredWorkflows, err := actions_model.FindRequiredWorkflowsForBranch(ctx, input.Repo, branch)
+	if err != nil {
+		return nil, fmt.Errorf("FindRequiredWorkflowsForBranch: %w", err)
+	}
+
+	var detected []*actions_module.DetectedWorkflow
+	for _, w := range requiredWorkflows {
+		content, err := ReadRequiredWorkflow(ctx, w)
+		if err != nil {
+			log.Error("Read required workflow %d of owner %d: %v", w.ID, w.OwnerID, err)
+			continue
+		}
+		workflows, err := actions_module.DetectWorkflowContent(gitRepo, commit, path.Base(w.WorkflowPath), content, input.Event, input.Payload)
+		if err != nil {
+			log.Warn("ignore invalid required workflow %d of owner %d: %v", w.ID, w.OwnerID, err)
+			continue
+		}
+		for _, dwf := range workflows {
+			if slices.Contains(requiredWorkflowEvents, dwf.TriggerEvent.Name) {
+				dwf.RequiredWorkflowID = w.ID
+				detected = append(detected, dwf)
+			}
+		}
+	}
+	return detected, nil
+}
+
+// ReadRequiredWorkflow reads the content of the workflow file from the central repository of the required workflow
+func ReadRequiredWorkflow(ctx context.Context, w *actions_model.ActionRequiredWorkflow) ([]byte, error) {
+	repo, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+	if err != nil {
+		return nil, err
+	}
+	if repo.OwnerID != w.OwnerID {
+		// the central repository has been transferred to another owner
+		return nil, util.NewPermissionDeniedErrorf("repository %s doesn't belong to the owner of the required workflow", repo.FullName())
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, repo)
+	if err != nil {
+		return nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	ref := w.Ref
+	if ref == "" {
+		ref = repo.DefaultBranch
+	}
+	commit, err := getReusableWorkflowCommit(gitRepo, ref)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil, util.NewNotExistErrorf("ref %q doesn't exist in %s", ref, repo.FullName())
+		}
+		return nil, err
+	}
+	entry, err := commit.GetTreeEntryByPath(w.WorkflowPath)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil, util.NewNotExistErrorf("workflow %q doesn't exist in %s", w.WorkflowPath, repo.FullName())
+		}
+		return nil, err
+	}
+	return actions_module.GetContentFromEntry(entry)
+}
+
+// getRequiredWorkflowStatusName returns the namespaced name of the required workflow which the run is created from,
+// it's used as the prefix of the commit status contexts, so they can match the status checks required by the workflow
+func getRequiredWorkflowStatusName(ctx context.Context, run *actions_model.ActionRun) (string, bool) {
+	if run.RequiredWorkflowID == 0 {
+		return "", false
+	}
+	w, err := actions_model.GetRequiredWorkflowByID(ctx, run.OwnerID, run.RequiredWorkflowID)
+	if err != nil {
+		// the required workflow has been deleted, its status checks are not required anymore
+		return "", false
+	}
+	return w.StatusContextName(), true
+}
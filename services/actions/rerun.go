@@ -11,7 +11,9 @@ import (
 	"code.gitea.io/gitea/models/db"
 	repo_model "code.gitea.io/gitea/models/repo"
 	"code.gitea.io/gitea/models/unit"
+	"code.gitea.io/gitea/modules/actions/jobparser"
 	"code.gitea.io/gitea/modules/container"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 
@@ -44,7 +46,7 @@ func GetFailedRerunJobs(allJobs []*actions_model.ActionRunJob) []*actions_model.
 func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.ActionRunJob) []*actions_model.ActionRunJob {
 	rerunJobs := []*actions_model.ActionRunJob{job}
 	rerunJobsIDSet := make(container.Set[string])
-	rerunJobsIDSet.Add(job.JobID)
+	rerunJobsIDSet.AddMultiple(rerunNeedIDs(job)...)
 
 	for {
 		found := false
@@ -56,7 +58,7 @@ func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.A
 				if rerunJobsIDSet.Contains(need) {
 					found = true
 					rerunJobs = append(rerunJobs, j)
-					rerunJobsIDSet.Add(j.JobID)
+					rerunJobsIDSet.AddMultiple(rerunNeedIDs(j)...)
 					break
 				}
 			}
@@ -69,6 +71,12 @@ func GetAllRerunJobs(job *actions_model.ActionRunJob, allJobs []*actions_model.A
 	return rerunJobs
 }
 
+// rerunNeedIDs returns the ids which the dependent jobs of the rerun job may need,
+// including the caller jobs if the job is expanded from reusable workflows.
+func rerunNeedIDs(job *actions_model.ActionRunJob) []string {
+	return append(jobparser.ReusableWorkflowCallerIDs(job.JobID), job.JobID)
+}
+
 // prepareRunRerun validates the run, resets its state, handles concurrency, persists the
 // updated run, and fires a status-update notification.
 // It returns isRunBlocked (true when the run itself is held by a concurrency group).
@@ -145,7 +153,7 @@ func RerunWorkflowRunJobs(ctx context.Context, repo *repo_model.Repository, run
 
 	rerunJobIDs := make(container.Set[string])
 	for _, j := range jobsToRerun {
-		rerunJobIDs.Add(j.JobID)
+		rerunJobIDs.AddMultiple(rerunNeedIDs(j)...)
 	}
 
 	for _, job := range jobsToRerun {
@@ -179,6 +187,9 @@ func rerunWorkflowJob(ctx context.Context, job *actions_model.ActionRunJob, shou
 	job.ConcurrencyGroup = ""
 	job.ConcurrencyCancel = false
 	job.IsConcurrencyEvaluated = false
+	job.QuotaExceeded = false
+	job.RetryCount = 0
+	job.RetryAfter = 0
 
 	if err := job.LoadRun(ctx); err != nil {
 		return err
@@ -204,7 +215,20 @@ func rerunWorkflowJob(ctx context.Context, job *actions_model.ActionRunJob, shou
 	}
 
 	if err := db.WithTx(ctx, func(ctx context.Context) error {
-		updateCols := []string{"task_id", "status", "started", "stopped", "concurrency_group", "concurrency_cancel", "is_concurrency_evaluated"}
+		if job.Status == actions_model.StatusWaiting {
+			if job.Status, err = PrepareJobWithUsageQuota(ctx, job); err != nil {
+				return err
+			}
+		}
+		if job.Status == actions_model.StatusWaiting {
+			if job.Status, err = PrepareJobDeployment(ctx, job); err != nil {
+				return err
+			}
+		}
+		if job.Status.IsDone() {
+			job.Stopped = timeutil.TimeStampNow()
+		}
+		updateCols := []string{"task_id", "status", "started", "stopped", "concurrency_group", "concurrency_cancel", "is_concurrency_evaluated", "quota_exceeded", "retry_count", "retry_after"}
 		_, err := actions_model.UpdateRunJob(ctx, job, builder.Eq{"status": status}, updateCols...)
 		return err
 	}); err != nil {
@@ -50,6 +50,19 @@ func TestGetAllRerunJobs(t *testing.T) {
 	}
 }
 
+func TestGetAllRerunJobsWithReusableWorkflow(t *testing.T) {
+	job1 := &actions_model.ActionRunJob{JobID: "call/build"}
+	job2 := &actions_model.ActionRunJob{JobID: "call/test", Needs: []string{"call/build"}}
+	job3 := &actions_model.ActionRunJob{JobID: "deploy", Needs: []string{"call"}}
+	job4 := &actions_model.ActionRunJob{JobID: "lint"}
+
+	jobs := []*actions_model.ActionRunJob{job1, job2, job3, job4}
+
+	assert.ElementsMatch(t, []*actions_model.ActionRunJob{job1, job2, job3}, GetAllRerunJobs(job1, jobs))
+	assert.ElementsMatch(t, []*actions_model.ActionRunJob{job2, job3}, GetAllRerunJobs(job2, jobs))
+	assert.ElementsMatch(t, []*actions_model.ActionRunJob{job3}, GetAllRerunJobs(job3, jobs))
+}
+
 func TestGetFailedRerunJobs(t *testing.T) {
 	// IDs must be non-zero to distinguish jobs in the dedup set.
 	makeJob := func(id int64, jobID string, status actions_model.Status, needs ...string) *actions_model.ActionRunJob {
@@ -0,0 +1,168 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	actions_module "code.gitea.io/gitea/modules/actions"
+	"code.gitea.io/gitea/modules/actions/jobparser"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/gitrepo"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// maxReusableWorkflowDepth is the maximum nesting level of reusable workflows, the top-level caller workflow is not counted.
+// https://docs.github.com/en/actions/sharing-automations/reusing-workflows#nesting-reusable-workflows
+const maxReusableWorkflowDepth = 9
+
+// reusableWorkflowSource is the repository and commit where a workflow file is read from,
+// local reusable workflows ("./{path}") are read from the same source as their caller.
+type reusableWorkflowSource struct {
+	repo      *repo_model.Repository
+	commitSHA string
+	ref       string // the git ref which the commit is resolved from, it's used by the references of the called workflows
+}
+
+// expandReusableWorkflows replaces the jobs which use reusable workflows with the jobs of the called workflows
+func expandReusableWorkflows(ctx context.Context, run *actions_model.ActionRun, jobs []*jobparser.SingleWorkflow, vars map[string]string, inputs map[string]any) ([]*jobparser.SingleWorkflow, error) {
+	giteaCtx := GenerateGiteaContext(run, nil)
+	source := &reusableWorkflowSource{repo: run.Repo, commitSHA: run.CommitSHA, ref: run.Ref}
+	ret, err := expandReusableWorkflowJobs(ctx, run, source, jobs, 0, jobparser.WithVars(vars), jobparser.WithGitContext(giteaCtx.ToGitHubContext()), jobparser.WithInputs(inputs))
+	if err != nil {
+		return nil, err
+	}
+	if len(ret) > actions_model.MaxJobNumPerRun {
+		return nil, util.NewInvalidArgumentErrorf("the run has %d jobs, exceeds the limit %d", len(ret), actions_model.MaxJobNumPerRun)
+	}
+	return ret, nil
+}
+
+func expandReusableWorkflowJobs(ctx context.Context, run *actions_model.ActionRun, source *reusableWorkflowSource, jobs []*jobparser.SingleWorkflow, depth int, options ...jobparser.ParseOption) ([]*jobparser.SingleWorkflow, error) {
+	ret := make([]*jobparser.SingleWorkflow, 0, len(jobs))
+	for _, swf := range jobs {
+		id, job := swf.Job()
+		if job == nil || job.Uses == "" {
+			ret = append(ret, swf)
+			continue
+		}
+		if depth >= maxReusableWorkflowDepth {
+			return nil, util.NewInvalidArgumentErrorf("job %q: reusable workflows are nested more than %d levels", id, maxReusableWorkflowDepth)
+		}
+
+		ref, err := jobparser.ParseReusableWorkflowRef(job.Uses)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("job %q: %v", id, err)
+		}
+		content, calledSource, err := readReusableWorkflow(ctx, run, source, ref)
+		if err != nil {
+			return nil, fmt.Errorf("job %q: %w", id, err)
+		}
+
+		children, err := jobparser.ExpandReusableWorkflow(swf, content, options...)
+		if err != nil {
+			return nil, util.NewInvalidArgumentErrorf("job %q: expand reusable workflow %q: %v", id, job.Uses, err)
+		}
+		for _, child := range children {
+			child.CalledWorkflowRef = fmt.Sprintf("%s/%s@%s", calledSource.repo.FullName(), ref.Path, calledSource.ref)
+		}
+		// the inputs of a nested reusable workflow call are evaluated in the context of the called workflow,
+		// which have been rewritten into literals by ExpandReusableWorkflow, so the options can be passed through
+		children, err = expandReusableWorkflowJobs(ctx, run, calledSource, children, depth+1, options...)
+		if err != nil {
+			return nil, err
+		}
+		ret = append(ret, children...)
+	}
+	return ret, nil
+}
+
+// readReusableWorkflow reads the content of the called workflow, and checks whether the run can access it
+func readReusableWorkflow(ctx context.Context, run *actions_model.ActionRun, source *reusableWorkflowSource, ref *jobparser.ReusableWorkflowRef) ([]byte, *reusableWorkflowSource, error) {
+	if !actions_module.IsWorkflow(ref.Path) {
+		return nil, nil, util.NewInvalidArgumentErrorf("%q is not in a workflow directory", ref.Path)
+	}
+
+	if ref.IsLocal() && run.RequiredWorkflowID != 0 && source.repo.ID == run.RepoID {
+		// the workflow files of the repository are not trusted by the required workflows of the org
+		return nil, nil, util.NewInvalidArgumentErrorf("required workflows can't call the local reusable workflow %q, please use {owner}/{repo}/{path}@{ref}", ref.Path)
+	}
+
+	calledSource := source
+	if !ref.IsLocal() {
+		repo, err := repo_model.GetRepositoryByOwnerAndName(ctx, ref.Owner, ref.Repo)
+		if err != nil {
+			if repo_model.IsErrRepoNotExist(err) {
+				return nil, nil, util.NewNotExistErrorf("repository %s/%s doesn't exist", ref.Owner, ref.Repo)
+			}
+			return nil, nil, err
+		}
+		// the called workflow runs with the permissions of the caller's repository, so it's the caller's repository to be checked
+		canRead, err := access_model.CanActionsReadRepo(ctx, run.Repo, repo, run.IsForkPullRequest)
+		if err != nil {
+			return nil, nil, err
+		}
+		if !canRead {
+			// don't leak the existence of the private repository
+			return nil, nil, util.NewNotExistErrorf("repository %s/%s doesn't exist", ref.Owner, ref.Repo)
+		}
+		calledSource = &reusableWorkflowSource{repo: repo, ref: ref.Ref}
+	}
+
+	gitRepo, err := gitrepo.OpenRepository(ctx, calledSource.repo)
+	if err != nil {
+		return nil, nil, fmt.Errorf("OpenRepository: %w", err)
+	}
+	defer gitRepo.Close()
+
+	var commit *git.Commit
+	if ref.IsLocal() {
+		commit, err = gitRepo.GetCommit(calledSource.commitSHA)
+	} else {
+		commit, err = getReusableWorkflowCommit(gitRepo, ref.Ref)
+	}
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil, nil, util.NewNotExistErrorf("ref %q doesn't exist in %s", ref.Ref, calledSource.repo.FullName())
+		}
+		return nil, nil, err
+	}
+	if !ref.IsLocal() {
+		calledSource.commitSHA = commit.ID.String()
+	}
+
+	entry, err := commit.GetTreeEntryByPath(ref.Path)
+	if err != nil {
+		if git.IsErrNotExist(err) {
+			return nil, nil, util.NewNotExistErrorf("workflow %q doesn't exist in %s", ref.Path, calledSource.repo.FullName())
+		}
+		return nil, nil, err
+	}
+	content, err := actions_module.GetContentFromEntry(entry)
+	if err != nil {
+		return nil, nil, err
+	}
+	return content, calledSource, nil
+}
+
+func getReusableWorkflowCommit(gitRepo *git.Repository, ref string) (*git.Commit, error) {
+	refName := git.RefName(ref)
+	switch {
+	case refName.IsBranch():
+		return gitRepo.GetBranchCommit(refName.BranchName())
+	case refName.IsTag():
+		return gitRepo.GetTagCommit(refName.TagName())
+	}
+	if gitRepo.IsBranchExist(ref) {
+		return gitRepo.GetBranchCommit(ref)
+	}
+	if gitRepo.IsTagExist(ref) {
+		return gitRepo.GetTagCommit(ref)
+	}
+	return gitRepo.GetCommit(ref)
+}
@@ -10,6 +10,7 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	"code.gitea.io/gitea/modules/actions/jobparser"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	notify_service "code.gitea.io/gitea/services/notify"
 
@@ -48,6 +49,11 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 		return fmt.Errorf("parse workflow: %w", err)
 	}
 
+	jobs, err = expandReusableWorkflows(ctx, run, jobs, vars, inputsWithDefaults)
+	if err != nil {
+		return fmt.Errorf("expandReusableWorkflows: %w", err)
+	}
+
 	if len(jobs) > 0 && jobs[0].RunName != "" {
 		run.Title = jobs[0].RunName
 	}
@@ -63,6 +69,8 @@ func PrepareRunAndInsert(ctx context.Context, content []byte, run *actions_model
 	}
 
 	CreateCommitStatusForRunJobs(ctx, run, allJobs...)
+	// the jobs can be failed by the protection rules of the deployment environments, the jobs needing them should be resolved
+	EmitJobsIfReadyByJobs(allJobs)
 
 	notify_service.WorkflowRunStatusUpdate(ctx, run.Repo, run.TriggerUser, run)
 	for _, job := range allJobs {
@@ -115,6 +123,7 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 			shouldBlockJob := len(needs) > 0 || run.NeedApproval || run.Status == actions_model.StatusBlocked
 
 			job.Name = util.EllipsisDisplayString(job.Name, 255)
+			envName, _ := job.Environment()
 			runJob := &actions_model.ActionRunJob{
 				RunID:             run.ID,
 				RepoID:            run.RepoID,
@@ -126,6 +135,8 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 				JobID:             id,
 				Needs:             needs,
 				RunsOn:            job.RunsOn(),
+				Environment:       util.EllipsisDisplayString(envName, 255),
+				CalledWorkflowRef: v.CalledWorkflowRef,
 				Status:            util.Iif(shouldBlockJob, actions_model.StatusBlocked, actions_model.StatusWaiting),
 			}
 			// Parse workflow/job permissions (no clamping here)
@@ -159,11 +170,37 @@ func InsertRun(ctx context.Context, run *actions_model.ActionRun, jobs []*jobpar
 				}
 			}
 
-			hasWaitingJobs = hasWaitingJobs || runJob.Status == actions_model.StatusWaiting
+			// stop queuing the jobs once the owner has used up the monthly minutes quota
+			if runJob.Status == actions_model.StatusWaiting {
+				runJob.Status, err = PrepareJobWithUsageQuota(ctx, runJob)
+				if err != nil {
+					return fmt.Errorf("prepare job with usage quota: %w", err)
+				}
+				if runJob.Status.IsDone() {
+					runJob.Stopped = timeutil.TimeStampNow()
+				}
+			}
+
 			if err := db.Insert(ctx, runJob); err != nil {
 				return err
 			}
 
+			// check the protection rules of the deployment environment, it needs the id of the inserted job to record the deployment
+			if runJob.Status == actions_model.StatusWaiting && runJob.Environment != "" {
+				runJob.Run = run
+				runJob.Status, err = PrepareJobDeployment(ctx, runJob)
+				if err != nil {
+					return fmt.Errorf("prepare job deployment: %w", err)
+				}
+				if runJob.Status.IsDone() {
+					runJob.Stopped = timeutil.TimeStampNow()
+				}
+				if _, err := db.GetEngine(ctx).ID(runJob.ID).Cols("status", "stopped").Update(runJob); err != nil {
+					return err
+				}
+			}
+			hasWaitingJobs = hasWaitingJobs || runJob.Status == actions_model.StatusWaiting
+
 			runJobs = append(runJobs, runJob)
 		}
 
@@ -11,9 +11,12 @@ import (
 	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	secret_model "code.gitea.io/gitea/models/secret"
+	"code.gitea.io/gitea/modules/actions/jobparser"
+	"code.gitea.io/gitea/modules/setting"
 	notify_service "code.gitea.io/gitea/services/notify"
 
 	runnerv1 "code.gitea.io/actions-proto-go/runner/v1"
+	"go.yaml.in/yaml/v4"
 	"google.golang.org/protobuf/types/known/structpb"
 )
 
@@ -68,9 +71,9 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 			return fmt.Errorf("GetSecretsOfTask: %w", err)
 		}
 
-		vars, err := actions_model.GetVariablesOfRun(ctx, t.Job.Run)
+		vars, err := actions_model.GetVariablesOfJob(ctx, t.Job)
 		if err != nil {
-			return fmt.Errorf("GetVariablesOfRun: %w", err)
+			return fmt.Errorf("GetVariablesOfJob: %w", err)
 		}
 
 		needs, err := findTaskNeeds(ctx, job)
@@ -78,14 +81,24 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 			return fmt.Errorf("findTaskNeeds: %w", err)
 		}
 
-		taskContext, err := generateTaskContext(t)
+		giteaRuntimeToken, err := CreateAuthorizationToken(t.ID, t.Job.RunID, t.JobID)
+		if err != nil {
+			return fmt.Errorf("CreateAuthorizationToken: %w", err)
+		}
+
+		taskContext, err := generateTaskContext(t, giteaRuntimeToken)
 		if err != nil {
 			return fmt.Errorf("generateTaskContext: %w", err)
 		}
 
+		payload, err := injectTaskEnv(t.Job.WorkflowPayload, generateTaskEnv(t, giteaRuntimeToken))
+		if err != nil {
+			return fmt.Errorf("injectTaskEnv: %w", err)
+		}
+
 		task = &runnerv1.Task{
 			Id:              t.ID,
-			WorkflowPayload: t.Job.WorkflowPayload,
+			WorkflowPayload: payload,
 			Context:         taskContext,
 			Secrets:         secrets,
 			Vars:            vars,
@@ -112,12 +125,7 @@ func PickTask(ctx context.Context, runner *actions_model.ActionRunner) (*runnerv
 	return task, true, nil
 }
 
-func generateTaskContext(t *actions_model.ActionTask) (*structpb.Struct, error) {
-	giteaRuntimeToken, err := CreateAuthorizationToken(t.ID, t.Job.RunID, t.JobID)
-	if err != nil {
-		return nil, err
-	}
-
+func generateTaskContext(t *actions_model.ActionTask, giteaRuntimeToken string) (*structpb.Struct, error) {
 	gitCtx := GenerateGiteaContext(t.Job.Run, t.Job)
 	gitCtx["token"] = t.Token
 	gitCtx["gitea_runtime_token"] = giteaRuntimeToken
@@ -125,6 +133,44 @@ func generateTaskContext(t *actions_model.ActionTask) (*structpb.Struct, error)
 	return structpb.NewStruct(gitCtx)
 }
 
+// generateTaskEnv returns the environment variables which Gitea provides to all steps of the task.
+// The runners don't map the task context to environment variables, so they are passed by the workflow payload.
+func generateTaskEnv(t *actions_model.ActionTask, giteaRuntimeToken string) map[string]string {
+	env := map[string]string{}
+	if CanRequestIDToken(t.Job) {
+		// "actions/core" appends the audience to the url and requests the ID token with the runtime token
+		env["ACTIONS_ID_TOKEN_REQUEST_URL"] = IDTokenIssuer() + "/token?api-version=2.0"
+		env["ACTIONS_ID_TOKEN_REQUEST_TOKEN"] = giteaRuntimeToken
+	}
+	if setting.Actions.CacheEnabled {
+		// "actions/cache" uses the cache service of Gitea instead of the cache server of the runner
+		env["ACTIONS_CACHE_SERVICE_V2"] = "true"
+		env["ACTIONS_RESULTS_URL"] = setting.AppURL
+	}
+	return env
+}
+
+// injectTaskEnv adds the environment variables to the workflow level "env" of the payload, which runners export to all steps.
+// The variables defined by the workflow itself are kept, so a workflow could override them.
+func injectTaskEnv(payload []byte, env map[string]string) ([]byte, error) {
+	if len(env) == 0 {
+		return payload, nil
+	}
+	swf := &jobparser.SingleWorkflow{}
+	if err := yaml.Unmarshal(payload, swf); err != nil {
+		return nil, err
+	}
+	if swf.Env == nil {
+		swf.Env = make(map[string]string, len(env))
+	}
+	for k, v := range env {
+		if _, ok := swf.Env[k]; !ok {
+			swf.Env[k] = v
+		}
+	}
+	return swf.Marshal()
+}
+
 func findTaskNeeds(ctx context.Context, taskJob *actions_model.ActionRunJob) (map[string]*runnerv1.TaskNeed, error) {
 	taskNeeds, err := FindTaskNeeds(ctx, taskJob)
 	if err != nil {
@@ -0,0 +1,82 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	"code.gitea.io/gitea/modules/actions/jobparser"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.yaml.in/yaml/v4"
+)
+
+func TestGenerateTaskEnv(t *testing.T) {
+	defer test.MockVariableValue(&setting.AppURL, "https://gitea.example.com/")()
+	payload := []byte(`
+name: test
+on: push
+env:
+  ACTIONS_RESULTS_URL: https://cache.example.com/
+  FOO: bar
+jobs:
+  build:
+    runs-on: ubuntu-latest
+    steps:
+      - run: echo
+`)
+	task := &actions_model.ActionTask{Job: &actions_model.ActionRunJob{}}
+	parse := func(t *testing.T, payload []byte) *jobparser.SingleWorkflow {
+		swf := &jobparser.SingleWorkflow{}
+		require.NoError(t, yaml.Unmarshal(payload, swf))
+		return swf
+	}
+
+	t.Run("CacheEnabled", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Actions.CacheEnabled, true)()
+		injected, err := injectTaskEnv(payload, generateTaskEnv(task, "runtime-token"))
+		require.NoError(t, err)
+
+		swf := parse(t, injected)
+		assert.Equal(t, "true", swf.Env["ACTIONS_CACHE_SERVICE_V2"])
+		// the variables defined by the workflow are kept
+		assert.Equal(t, "https://cache.example.com/", swf.Env["ACTIONS_RESULTS_URL"])
+		assert.Equal(t, "bar", swf.Env["FOO"])
+		id, job := swf.Job()
+		assert.Equal(t, "build", id)
+		assert.Len(t, job.Steps, 1)
+
+		injected, err = injectTaskEnv([]byte("on: push\njobs:\n  build:\n    runs-on: ubuntu-latest\n"), generateTaskEnv(task, "runtime-token"))
+		require.NoError(t, err)
+		assert.Equal(t, "https://gitea.example.com/", parse(t, injected).Env["ACTIONS_RESULTS_URL"])
+	})
+
+	t.Run("CacheDisabled", func(t *testing.T) {
+		defer test.MockVariableValue(&setting.Actions.CacheEnabled, false)()
+		injected, err := injectTaskEnv(payload, generateTaskEnv(task, "runtime-token"))
+		require.NoError(t, err)
+		assert.NotContains(t, parse(t, injected).Env, "ACTIONS_CACHE_SERVICE_V2")
+	})
+
+	t.Run("IDToken", func(t *testing.T) {
+		assert.NotContains(t, generateTaskEnv(task, "runtime-token"), "ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+
+		task := &actions_model.ActionTask{Job: &actions_model.ActionRunJob{
+			TokenPermissions: &repo_model.ActionsTokenPermissions{IDToken: true},
+		}}
+		injected, err := injectTaskEnv(payload, generateTaskEnv(task, "runtime-token"))
+		require.NoError(t, err)
+		env := parse(t, injected).Env
+		assert.Equal(t, "https://gitea.example.com/api/actions_oidc/token?api-version=2.0", env["ACTIONS_ID_TOKEN_REQUEST_URL"])
+		assert.Equal(t, "runtime-token", env["ACTIONS_ID_TOKEN_REQUEST_TOKEN"])
+
+		task.Job.IsForkPullRequest = true
+		assert.NotContains(t, generateTaskEnv(task, "runtime-token"), "ACTIONS_ID_TOKEN_REQUEST_TOKEN")
+	})
+}
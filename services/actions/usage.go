@@ -0,0 +1,27 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+	"fmt"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/log"
+)
+
+// PrepareJobWithUsageQuota checks the monthly minutes quota of the owner of the job which is ready to start.
+// It returns StatusFailure and marks the job as QuotaExceeded if the quota has been used up, otherwise StatusWaiting.
+func PrepareJobWithUsageQuota(ctx context.Context, job *actions_model.ActionRunJob) (actions_model.Status, error) {
+	exceeded, err := actions_model.IsUsageQuotaExceeded(ctx, job.OwnerID)
+	if err != nil {
+		return actions_model.StatusBlocked, fmt.Errorf("check usage quota of owner %d: %w", job.OwnerID, err)
+	}
+	job.QuotaExceeded = exceeded
+	if exceeded {
+		log.Info("Job %d fails because owner %d has used up the monthly minutes quota", job.ID, job.OwnerID)
+		return actions_model.StatusFailure, nil
+	}
+	return actions_model.StatusWaiting, nil
+}
@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// maxWorkflowRunChainDepth is the max number of runs chained by "workflow_run" events,
+// the events of a run at the end of the chain don't trigger any more workflows, to break infinite chains
+const maxWorkflowRunChainDepth = 5
+
+// isWorkflowRunChainTooDeep returns whether the run triggering the "workflow_run" event is already at the end of a chain
+func isWorkflowRunChainTooDeep(ctx context.Context, repoID int64, payload *api.WorkflowRunPayload) bool {
+	for i := 0; i < maxWorkflowRunChainDepth && payload.WorkflowRun != nil; i++ {
+		if payload.WorkflowRun.Event != string(webhook_module.HookEventWorkflowRun) {
+			return false
+		}
+		r, err := actions_model.GetRunByRepoAndID(ctx, repoID, payload.WorkflowRun.ID)
+		if err != nil {
+			log.Error("GetRunByRepoAndID: %v", err)
+			return true
+		}
+		payload, err = r.GetWorkflowRunEventPayload()
+		if err != nil {
+			log.Error("GetWorkflowRunEventPayload: %v", err)
+			return true
+		}
+	}
+	return true
+}
+
+// GetUpstreamRun returns the run whose "workflow_run" event triggered the run, it returns nil if the run isn't triggered by a "workflow_run" event
+func GetUpstreamRun(ctx context.Context, run *actions_model.ActionRun) (*actions_model.ActionRun, error) {
+	if run.Event != webhook_module.HookEventWorkflowRun {
+		return nil, nil //nolint:nilnil // return nil when the run has no upstream run
+	}
+	payload, err := run.GetWorkflowRunEventPayload()
+	if err != nil {
+		return nil, err
+	}
+	if payload.WorkflowRun == nil {
+		return nil, nil //nolint:nilnil // return nil when the run has no upstream run
+	}
+	return actions_model.GetRunByRepoAndID(ctx, run.RepoID, payload.WorkflowRun.ID)
+}
+
+// CanReadRunArtifacts returns whether the jobs of the run can read the artifacts of the run with runID,
+// they can read the artifacts of their own run and the upstream run which triggered their run by a "workflow_run" event.
+func CanReadRunArtifacts(ctx context.Context, run *actions_model.ActionRun, runID int64) (bool, error) {
+	if run.ID == runID {
+		return true, nil
+	}
+	upstream, err := GetUpstreamRun(ctx, run)
+	if err != nil {
+		return false, err
+	}
+	return upstream != nil && upstream.ID == runID, nil
+}
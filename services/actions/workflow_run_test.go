@@ -0,0 +1,43 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package actions
+
+import (
+	"testing"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/unittest"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanReadRunArtifacts(t *testing.T) {
+	require.NoError(t, unittest.PrepareTestDatabase())
+
+	pushRun := &actions_model.ActionRun{ID: 792, RepoID: 4, Event: webhook_module.HookEventPush}
+	canRead, err := CanReadRunArtifacts(t.Context(), pushRun, 792)
+	require.NoError(t, err)
+	assert.True(t, canRead)
+	canRead, err = CanReadRunArtifacts(t.Context(), pushRun, 791)
+	require.NoError(t, err)
+	assert.False(t, canRead)
+
+	downstreamRun := &actions_model.ActionRun{
+		ID:           10000,
+		RepoID:       4,
+		Event:        webhook_module.HookEventWorkflowRun,
+		EventPayload: `{"action":"completed","workflow_run":{"id":791,"event":"push"}}`,
+	}
+	upstream, err := GetUpstreamRun(t.Context(), downstreamRun)
+	require.NoError(t, err)
+	assert.EqualValues(t, 791, upstream.ID)
+	canRead, err = CanReadRunArtifacts(t.Context(), downstreamRun, 791)
+	require.NoError(t, err)
+	assert.True(t, canRead)
+	canRead, err = CanReadRunArtifacts(t.Context(), downstreamRun, 792)
+	require.NoError(t, err)
+	assert.False(t, canRead)
+}
@@ -60,9 +60,9 @@ func PackageAssignmentAPI() func(ctx *APIContext) {
 
 func packageAssignment(ctx *packageAssignmentCtx, errCb func(int, any)) *Package {
 	pkgOwner := ctx.ContextUser
-	accessMode, err := determineAccessMode(ctx.Base, pkgOwner, ctx.Doer)
+	accessMode, err := DeterminePackageAccessMode(ctx.Base, pkgOwner, ctx.Doer)
 	if err != nil {
-		errCb(http.StatusInternalServerError, fmt.Errorf("determineAccessMode: %w", err))
+		errCb(http.StatusInternalServerError, fmt.Errorf("DeterminePackageAccessMode: %w", err))
 		return nil
 	}
 
@@ -113,7 +113,8 @@ func packageAssignment(ctx *packageAssignmentCtx, errCb func(int, any)) *Package
 	return pkg
 }
 
-func determineAccessMode(ctx *Base, pkgOwner, doer *user_model.User) (perm.AccessMode, error) {
+// DeterminePackageAccessMode returns the access mode of the doer to the packages of the owner
+func DeterminePackageAccessMode(ctx *Base, pkgOwner, doer *user_model.User) (perm.AccessMode, error) {
 	if setting.Service.RequireSignInViewStrict && (doer == nil || doer.IsGhost()) {
 		return perm.AccessModeNone, nil
 	}
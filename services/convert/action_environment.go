@@ -0,0 +1,87 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+	"time"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToActionEnvironment converts an actions_model.ActionEnvironment to an api.ActionEnvironment
+// lastDeployment is optional and can be nil
+func ToActionEnvironment(ctx context.Context, env *actions_model.ActionEnvironment, lastDeployment *actions_model.ActionDeployment) (*api.ActionEnvironment, error) {
+	users, err := user_model.GetUsersByIDs(ctx, env.ReviewerUserIDs)
+	if err != nil {
+		return nil, err
+	}
+	teams, err := organization.GetTeamsByIDs(ctx, env.ReviewerTeamIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.ActionEnvironment{
+		ID:                env.ID,
+		Name:              env.Name,
+		ReviewerUsernames: make([]string, 0, len(users)),
+		ReviewerTeams:     make([]string, 0, len(teams)),
+		WaitTimer:         env.WaitTimer,
+		BranchPatterns:    env.BranchPatterns,
+		Created:           env.CreatedUnix.AsTime(),
+		Updated:           env.UpdatedUnix.AsTime(),
+	}
+	if result.BranchPatterns == nil {
+		result.BranchPatterns = []string{}
+	}
+	for _, u := range users {
+		result.ReviewerUsernames = append(result.ReviewerUsernames, u.Name)
+	}
+	for _, id := range env.ReviewerTeamIDs {
+		if team, ok := teams[id]; ok {
+			result.ReviewerTeams = append(result.ReviewerTeams, team.Name)
+		}
+	}
+
+	if lastDeployment != nil {
+		lastDeployment.Environment = env
+		if result.LastDeployment, err = ToActionDeployment(ctx, lastDeployment); err != nil {
+			return nil, err
+		}
+	}
+	return result, nil
+}
+
+// ToActionDeployment converts an actions_model.ActionDeployment to an api.ActionDeployment
+func ToActionDeployment(ctx context.Context, d *actions_model.ActionDeployment) (*api.ActionDeployment, error) {
+	if err := d.LoadAttributes(ctx); err != nil {
+		return nil, err
+	}
+
+	result := &api.ActionDeployment{
+		ID:            d.ID,
+		Environment:   d.Environment.Name,
+		RunID:         d.RunID,
+		JobID:         d.JobID,
+		Ref:           d.Ref,
+		SHA:           d.CommitSHA,
+		URL:           d.URL,
+		Status:        d.Job.Status.String(),
+		ReviewStatus:  d.ReviewStatus.String(),
+		ReviewComment: d.ReviewComment,
+		Created:       d.CreatedUnix.AsTime(),
+		Updated:       d.UpdatedUnix.AsTime(),
+	}
+	if d.Reviewer != nil {
+		result.Reviewer = ToUser(ctx, d.Reviewer, nil)
+	}
+	if d.WaitUntil > 0 {
+		result.WaitUntil = new(time.Time)
+		*result.WaitUntil = d.WaitUntil.AsTime()
+	}
+	return result, nil
+}
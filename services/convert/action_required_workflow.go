@@ -0,0 +1,39 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToActionRequiredWorkflow converts an actions_model.ActionRequiredWorkflow to an api.ActionRequiredWorkflow
+func ToActionRequiredWorkflow(ctx context.Context, w *actions_model.ActionRequiredWorkflow) (*api.ActionRequiredWorkflow, error) {
+	repo, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.ActionRequiredWorkflow{
+		ID:             w.ID,
+		Name:           w.Name,
+		Repository:     repo.Name,
+		Path:           w.WorkflowPath,
+		Ref:            w.Ref,
+		RepoPatterns:   w.RepoPatterns,
+		BranchPatterns: w.BranchPatterns,
+		Created:        w.CreatedUnix.AsTime(),
+		Updated:        w.UpdatedUnix.AsTime(),
+	}
+	if result.RepoPatterns == nil {
+		result.RepoPatterns = []string{}
+	}
+	if result.BranchPatterns == nil {
+		result.BranchPatterns = []string{}
+	}
+	return result, nil
+}
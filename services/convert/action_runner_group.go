@@ -0,0 +1,42 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToActionRunnerGroup converts an actions_model.ActionRunnerGroup to an api.ActionRunnerGroup
+func ToActionRunnerGroup(ctx context.Context, g *actions_model.ActionRunnerGroup) (*api.ActionRunnerGroup, error) {
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, g.RepoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	result := &api.ActionRunnerGroup{
+		ID:               g.ID,
+		Name:             g.Name,
+		Repositories:     make([]string, 0, len(repos)),
+		BranchPatterns:   g.BranchPatterns,
+		WorkflowPatterns: g.WorkflowPatterns,
+		Created:          g.CreatedUnix.AsTime(),
+		Updated:          g.UpdatedUnix.AsTime(),
+	}
+	for _, id := range g.RepoIDs {
+		if repo, ok := repos[id]; ok {
+			result.Repositories = append(result.Repositories, repo.Name)
+		}
+	}
+	if result.BranchPatterns == nil {
+		result.BranchPatterns = []string{}
+	}
+	if result.WorkflowPatterns == nil {
+		result.WorkflowPatterns = []string{}
+	}
+	return result, nil
+}
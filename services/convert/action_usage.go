@@ -0,0 +1,65 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	"context"
+	"strings"
+
+	actions_model "code.gitea.io/gitea/models/actions"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/container"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+func usageMinutes(seconds int64) int64 {
+	return (seconds + 59) / 60
+}
+
+// ToActionUsages converts the aggregated usage to API format, the owners and repositories which have been deleted are left empty
+func ToActionUsages(ctx context.Context, stats []*actions_model.ActionUsageStat) ([]*api.ActionUsage, error) {
+	ownerIDs := container.FilterSlice(stats, func(s *actions_model.ActionUsageStat) (int64, bool) { return s.OwnerID, s.OwnerID > 0 })
+	owners, err := user_model.GetUsersMapByIDs(ctx, ownerIDs)
+	if err != nil {
+		return nil, err
+	}
+	repoIDs := container.FilterSlice(stats, func(s *actions_model.ActionUsageStat) (int64, bool) { return s.RepoID, s.RepoID > 0 })
+	repos, err := repo_model.GetRepositoriesMapByIDs(ctx, repoIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	usages := make([]*api.ActionUsage, 0, len(stats))
+	for _, s := range stats {
+		usage := &api.ActionUsage{
+			OwnerID:   s.OwnerID,
+			RepoID:    s.RepoID,
+			Seconds:   s.Seconds,
+			Minutes:   usageMinutes(s.Seconds),
+			TaskCount: s.TaskCount,
+		}
+		if owner, ok := owners[s.OwnerID]; ok {
+			usage.Owner = owner.Name
+		}
+		if repo, ok := repos[s.RepoID]; ok {
+			usage.Repository = repo.Name
+		}
+		if s.Labels != "" {
+			usage.Labels = strings.Split(s.Labels, ",")
+		}
+		usages = append(usages, usage)
+	}
+	return usages, nil
+}
+
+// ToActionUsageQuota converts the quota of an owner to API format, a nil quota means unlimited
+func ToActionUsageQuota(quota *actions_model.ActionUsageQuota, usedSeconds int64) *api.ActionUsageQuota {
+	ret := &api.ActionUsageQuota{UsedMinutes: usageMinutes(usedSeconds)}
+	if quota != nil {
+		ret.MonthlyMinutes = quota.MonthlyMinutes
+		ret.Exceeded = usedSeconds >= quota.MonthlyMinutes*60
+	}
+	return ret
+}
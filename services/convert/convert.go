@@ -277,7 +277,7 @@ func ToActionWorkflowRun(ctx context.Context, repo *repo_model.Repository, run *
 func ToWorkflowRunAction(status actions_model.Status) string {
 	var action string
 	switch status {
-	case actions_model.StatusWaiting, actions_model.StatusBlocked:
+	case actions_model.StatusWaiting, actions_model.StatusBlocked, actions_model.StatusWaitingApproval:
 		action = "requested"
 	case actions_model.StatusRunning:
 		action = "in_progress"
@@ -295,7 +295,7 @@ func ToActionsStatus(status actions_model.Status) (string, string) {
 	// This is a naming conflict of the webhook between Gitea and GitHub Actions
 	case actions_model.StatusWaiting:
 		action = "queued"
-	case actions_model.StatusBlocked:
+	case actions_model.StatusBlocked, actions_model.StatusWaitingApproval:
 		action = "waiting"
 	case actions_model.StatusRunning:
 		action = "in_progress"
@@ -527,6 +527,7 @@ func ToActionRunner(ctx context.Context, runner *actions_model.ActionRunner) *ap
 		Disabled:  runner.IsDisabled,
 		Ephemeral: runner.Ephemeral,
 		Labels:    labels,
+		GroupID:   runner.GroupID,
 	}
 }
 
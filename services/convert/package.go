@@ -51,3 +51,70 @@ func ToPackageFile(pfd *packages.PackageFileDescriptor) *api.PackageFile {
 		HashSHA512: pfd.Blob.HashSHA512,
 	}
 }
+
+// ToPackageRemote convert a packages.PackageRemote to api.PackageRemote
+func ToPackageRemote(r *packages.PackageRemote) *api.PackageRemote {
+	return &api.PackageRemote{
+		Type:        string(r.Type),
+		URL:         r.URL,
+		Username:    r.Username,
+		HasPassword: r.PasswordEncrypted != "",
+		MetadataTTL: r.GetMetadataTTL(),
+		CreatedAt:   r.CreatedUnix.AsTime(),
+		UpdatedAt:   r.UpdatedUnix.AsTime(),
+	}
+}
+
+// ToPackageVersionChannel converts a packages.PackageVersionChannel to api.PackageVersionChannel
+func ToPackageVersionChannel(pvc *packages.PackageVersionChannel) *api.PackageVersionChannel {
+	return &api.PackageVersionChannel{
+		Channel:      pvc.Channel,
+		PromotedFrom: pvc.PromotedFrom,
+		CreatedAt:    pvc.CreatedUnix.AsTime(),
+	}
+}
+
+// ToPackageVirtualRegistries converts the members of the virtual registries of an owner to api.PackageVirtualRegistry grouped by the package type,
+// the members are expected to be ordered by the type and the priority
+func ToPackageVirtualRegistries(ctx context.Context, members []*packages.PackageVirtualMember) ([]*api.PackageVirtualRegistry, error) {
+	memberIDs := make([]int64, 0, len(members))
+	for _, m := range members {
+		memberIDs = append(memberIDs, m.MemberID)
+	}
+	users, err := user_model.GetUsersMapByIDs(ctx, memberIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	registries := make([]*api.PackageVirtualRegistry, 0, len(packages.VirtualTypeList))
+	for _, m := range members {
+		u, ok := users[m.MemberID]
+		if !ok {
+			continue
+		}
+		if len(registries) == 0 || registries[len(registries)-1].Type != string(m.Type) {
+			registries = append(registries, &api.PackageVirtualRegistry{Type: string(m.Type), Members: []string{}})
+		}
+		registry := registries[len(registries)-1]
+		registry.Members = append(registry.Members, u.Name)
+	}
+	return registries, nil
+}
+
+// ToPackageCleanupCandidate converts a packages.PackageDescriptor to api.PackageCleanupCandidate
+func ToPackageCleanupCandidate(ctx context.Context, pd *packages.PackageDescriptor, doer *user_model.User) (*api.PackageCleanupCandidate, error) {
+	apiPackage, err := ToPackage(ctx, pd, doer)
+	if err != nil {
+		return nil, err
+	}
+
+	candidate := &api.PackageCleanupCandidate{
+		Package:       apiPackage,
+		Size:          pd.CalculateBlobSize(),
+		DownloadCount: pd.Version.DownloadCount,
+	}
+	if pd.Version.LastDownloadUnix != 0 {
+		candidate.LastDownloadAt = pd.Version.LastDownloadUnix.AsTimePtr()
+	}
+	return candidate, nil
+}
@@ -0,0 +1,37 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package convert
+
+import (
+	sbom_model "code.gitea.io/gitea/models/sbom"
+	api "code.gitea.io/gitea/modules/structs"
+)
+
+// ToSBOMDocument converts a sbom_model.Document and its components to api.SBOMDocument
+func ToSBOMDocument(doc *sbom_model.Document, components []*sbom_model.Component) *api.SBOMDocument {
+	apiComponents := make([]*api.SBOMComponent, 0, len(components))
+	for _, c := range components {
+		apiComponents = append(apiComponents, ToSBOMComponent(c))
+	}
+
+	return &api.SBOMDocument{
+		ID:             doc.ID,
+		Format:         string(doc.Format),
+		SpecVersion:    doc.SpecVersion,
+		Name:           doc.Name,
+		ComponentCount: doc.ComponentCount,
+		Components:     apiComponents,
+		CreatedAt:      doc.CreatedUnix.AsTime(),
+	}
+}
+
+// ToSBOMComponent converts a sbom_model.Component to api.SBOMComponent
+func ToSBOMComponent(c *sbom_model.Component) *api.SBOMComponent {
+	return &api.SBOMComponent{
+		Name:    c.Name,
+		Version: c.Version,
+		PURL:    c.PURL,
+		License: c.License,
+	}
+}
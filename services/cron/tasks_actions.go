@@ -20,6 +20,9 @@ func initActionsTasks() {
 	registerCancelAbandonedJobs()
 	registerScheduleTasks()
 	registerActionsCleanup()
+	registerActionsCacheCleanup()
+	registerStartReadyDeployments()
+	registerQueueRetryingJobs()
 }
 
 func registerStopZombieTasks() {
@@ -74,3 +77,33 @@ func registerActionsCleanup() {
 		return actions_service.Cleanup(ctx)
 	})
 }
+
+func registerActionsCacheCleanup() {
+	RegisterTaskFatal("cleanup_actions_cache", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1h",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.CleanupCaches(ctx)
+	})
+}
+
+func registerStartReadyDeployments() {
+	RegisterTaskFatal("start_ready_deployments", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.StartReadyDeployments(ctx)
+	})
+}
+
+func registerQueueRetryingJobs() {
+	RegisterTaskFatal("queue_retrying_jobs", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: false,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return actions_service.QueueRetryingJobs(ctx)
+	})
+}
@@ -19,6 +19,7 @@ import (
 	packages_cleanup_service "code.gitea.io/gitea/services/packages/cleanup"
 	repo_service "code.gitea.io/gitea/services/repository"
 	archiver_service "code.gitea.io/gitea/services/repository/archiver"
+	webhook_service "code.gitea.io/gitea/services/webhook"
 )
 
 func registerUpdateMirrorTask() {
@@ -142,6 +143,16 @@ func registerCleanupHookTaskTable() {
 	})
 }
 
+func registerRetryFailedHookTasks() {
+	RegisterTaskFatal("retry_failed_hook_tasks", &BaseConfig{
+		Enabled:    true,
+		RunAtStart: true,
+		Schedule:   "@every 1m",
+	}, func(ctx context.Context, _ *user_model.User, _ Config) error {
+		return webhook_service.RetryDueHookTasks(ctx)
+	})
+}
+
 func registerCleanupPackages() {
 	RegisterTaskFatal("cleanup_packages", &OlderThanConfig{
 		BaseConfig: BaseConfig{
@@ -179,6 +190,7 @@ func initBasicTasks() {
 		registerUpdateMigrationPosterID()
 	}
 	registerCleanupHookTaskTable()
+	registerRetryFailedHookTasks()
 	if setting.Packages.Enabled {
 		registerCleanupPackages()
 	}
@@ -13,15 +13,17 @@ import (
 )
 
 type PackageCleanupRuleForm struct {
-	ID            int64
-	Enabled       bool
-	Type          string `binding:"Required;In(alpine,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,maven,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant)"`
-	KeepCount     int    `binding:"In(0,1,5,10,25,50,100)"`
-	KeepPattern   string `binding:"RegexPattern"`
-	RemoveDays    int    `binding:"In(0,7,14,30,60,90,180)"`
-	RemovePattern string `binding:"RegexPattern"`
-	MatchFullName bool
-	Action        string `binding:"Required;In(save,remove)"`
+	ID                 int64
+	Enabled            bool
+	Type               string `binding:"Required;In(alpine,ansible,arch,cargo,chef,composer,conan,conda,container,cran,debian,generic,go,helm,hex,maven,nix,npm,nuget,pub,pypi,rpm,rubygems,swift,terraform,vagrant,vsix)"`
+	KeepCount          int    `binding:"In(0,1,5,10,25,50,100)"`
+	KeepPattern        string `binding:"RegexPattern"`
+	KeepDownloadedDays int    `binding:"In(0,7,14,30,60,90,180)"`
+	RemoveDays         int    `binding:"In(0,7,14,30,60,90,180)"`
+	RemoveUnusedDays   int    `binding:"In(0,7,14,30,60,90,180,365)"`
+	RemovePattern      string `binding:"RegexPattern"`
+	MatchFullName      bool
+	Action             string `binding:"Required;In(save,remove)"`
 }
 
 func (f *PackageCleanupRuleForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
@@ -236,6 +236,7 @@ type WebhookForm struct {
 	WorkflowJob              bool
 	Active                   bool
 	BranchFilter             string `binding:"GlobPattern"`
+	FilterExpression         string `binding:"WebhookFilter"`
 	AuthorizationHeader      string
 	Secret                   string
 }
@@ -409,6 +410,124 @@ func (f *NewPackagistHookForm) Validate(req *http.Request, errs binding.Errors)
 	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
 }
 
+// NewCustomHookForm form for creating custom hook
+type NewCustomHookForm struct {
+	PayloadURL      string `binding:"Required;ValidUrl"`
+	HTTPMethod      string `binding:"Required;In(POST,PUT,PATCH)"`
+	BodyTemplate    string `binding:"Required"`
+	BodyContentType string
+	Headers         string
+	WebhookForm
+}
+
+// CustomMeta returns the templates of the form
+func (f *NewCustomHookForm) CustomMeta() (*webhook.CustomMeta, error) {
+	headers, err := webhook.ParseCustomHeaderLines(f.Headers)
+	if err != nil {
+		return nil, err
+	}
+	return &webhook.CustomMeta{
+		BodyTemplate:    f.BodyTemplate,
+		ContentType:     strings.TrimSpace(f.BodyContentType),
+		HeaderTemplates: headers,
+	}, nil
+}
+
+// Validate validates the fields
+func (f *NewCustomHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	if len(errs) == 0 {
+		meta, err := f.CustomMeta()
+		if err == nil {
+			err = meta.Validate()
+		}
+		if err != nil {
+			errs = append(errs, binding.Error{
+				FieldNames:     []string{"BodyTemplate"},
+				Classification: "",
+				Message:        ctx.Locale.TrString("repo.settings.custom_invalid_template", err.Error()),
+			})
+		}
+	}
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// NewCloudEventsHookForm form for creating CloudEvents hook
+type NewCloudEventsHookForm struct {
+	PayloadURL  string `binding:"Required;ValidUrl"`
+	HTTPMethod  string `binding:"Required;In(POST,PUT)"`
+	ContentMode string `binding:"Required;In(structured,binary,batched)"`
+	BatchSize   int    `binding:"Range(0,100)"`
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewCloudEventsHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// NewMattermostHookForm form for creating Mattermost hook
+type NewMattermostHookForm struct {
+	PayloadURL string `binding:"Required;ValidUrl"`
+	Channel    string
+	Username   string
+	IconURL    string `binding:"ValidUrl"`
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewMattermostHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// NewRocketChatHookForm form for creating Rocket.Chat hook
+type NewRocketChatHookForm struct {
+	PayloadURL string `binding:"Required;ValidUrl"`
+	Channel    string
+	Username   string
+	IconURL    string `binding:"ValidUrl"`
+	WebhookForm
+}
+
+// Validate validates the fields
+func (f *NewRocketChatHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
+// NewZulipHookForm form for creating Zulip hook
+type NewZulipHookForm struct {
+	PayloadURL string `binding:"Required;ValidUrl"`
+	Stream     string `binding:"Required"`
+	Topic      string
+	WebhookForm
+}
+
+// ZulipMeta returns the stream and topic templates of the form
+func (f *NewZulipHookForm) ZulipMeta() *webhook.ZulipMeta {
+	return &webhook.ZulipMeta{
+		Stream: strings.TrimSpace(f.Stream),
+		Topic:  strings.TrimSpace(f.Topic),
+	}
+}
+
+// Validate validates the fields
+func (f *NewZulipHookForm) Validate(req *http.Request, errs binding.Errors) binding.Errors {
+	ctx := context.GetValidateContext(req)
+	if len(errs) == 0 {
+		if err := f.ZulipMeta().Validate(); err != nil {
+			errs = append(errs, binding.Error{
+				FieldNames:     []string{"Topic"},
+				Classification: "",
+				Message:        ctx.Locale.TrString("repo.settings.custom_invalid_template", err.Error()),
+			})
+		}
+	}
+	return middleware.Validate(errs, ctx.Data, f, ctx.Locale)
+}
+
 // CreateIssueForm form for creating issue
 type CreateIssueForm struct {
 	Title               string `binding:"Required;MaxSize(255)"`
@@ -18,6 +18,7 @@ import (
 	"strings"
 	"time"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -26,6 +27,7 @@ import (
 	"code.gitea.io/gitea/modules/analyze"
 	"code.gitea.io/gitea/modules/base"
 	"code.gitea.io/gitea/modules/charset"
+	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
 	"code.gitea.io/gitea/modules/git/attribute"
 	"code.gitea.io/gitea/modules/git/gitcmd"
@@ -77,7 +79,8 @@ type DiffLine struct {
 	Match       int // the diff matched index. -1: no match. 0: plain and no need to match. >0: for add/del, "Lines" slice index of the other side
 	Type        DiffLineType
 	Content     string
-	Comments    issues_model.CommentList // related PR code comments
+	Comments    issues_model.CommentList              // related PR code comments
+	Annotations []*actions_model.ActionTaskAnnotation // related Actions annotations of the new side
 	SectionInfo *DiffLineSectionInfo
 }
 
@@ -625,6 +628,44 @@ func (diff *Diff) LoadComments(ctx context.Context, issue *issues_model.Issue, c
 	return nil
 }
 
+// LoadActionsAnnotations attaches the Actions annotations to the lines of the new side at their start lines,
+// the same annotations created by the reruns of a job are only shown once
+func (diff *Diff) LoadActionsAnnotations(annotations []*actions_model.ActionTaskAnnotation) {
+	type annotationKey struct {
+		path, title, message string
+		line                 int
+		level                actions_model.AnnotationLevel
+	}
+	seen := make(container.Set[annotationKey])
+	fileLineAnnotations := map[string]map[int][]*actions_model.ActionTaskAnnotation{}
+	for _, a := range annotations {
+		if a.Path == "" || a.StartLine <= 0 {
+			continue
+		}
+		if !seen.Add(annotationKey{path: a.Path, title: a.Title, message: a.Message, line: a.StartLine, level: a.Level}) {
+			continue
+		}
+		if fileLineAnnotations[a.Path] == nil {
+			fileLineAnnotations[a.Path] = map[int][]*actions_model.ActionTaskAnnotation{}
+		}
+		fileLineAnnotations[a.Path][a.StartLine] = append(fileLineAnnotations[a.Path][a.StartLine], a)
+	}
+
+	for _, file := range diff.Files {
+		lineAnnotations, ok := fileLineAnnotations[file.Name]
+		if !ok {
+			continue
+		}
+		for _, section := range file.Sections {
+			for _, line := range section.Lines {
+				if line.RightIdx > 0 && line.Type != DiffLineSection {
+					line.Annotations = lineAnnotations[line.RightIdx]
+				}
+			}
+		}
+	}
+}
+
 const cmdDiffHead = "diff --git "
 
 // ParsePatch builds a Diff object from a io.Reader and some parameters.
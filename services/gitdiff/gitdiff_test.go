@@ -10,6 +10,7 @@ import (
 	"strings"
 	"testing"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	issues_model "code.gitea.io/gitea/models/issues"
 	pull_model "code.gitea.io/gitea/models/pull"
 	"code.gitea.io/gitea/models/unittest"
@@ -587,6 +588,21 @@ func TestDiff_LoadCommentsWithOutdated(t *testing.T) {
 	assert.Len(t, diff.Files[0].Sections[0].Lines[0].Comments, 3)
 }
 
+func TestDiff_LoadActionsAnnotations(t *testing.T) {
+	diff := setupDefaultDiff()
+	diff.LoadActionsAnnotations([]*actions_model.ActionTaskAnnotation{
+		{ID: 1, TaskID: 1, Level: actions_model.AnnotationLevelError, Path: "README.md", StartLine: 4, Message: "typo"},
+		{ID: 2, TaskID: 2, Level: actions_model.AnnotationLevelError, Path: "README.md", StartLine: 4, Message: "typo"}, // rerun
+		{ID: 3, TaskID: 2, Level: actions_model.AnnotationLevelNotice, Path: "README.md", StartLine: 4, Message: "long line"},
+		{ID: 4, TaskID: 2, Level: actions_model.AnnotationLevelError, Path: "README.md", StartLine: 5, Message: "other line"},
+		{ID: 5, TaskID: 2, Level: actions_model.AnnotationLevelError, Path: "main.go", StartLine: 4, Message: "other file"},
+	})
+	annotations := diff.Files[0].Sections[0].Lines[0].Annotations
+	require.Len(t, annotations, 2)
+	assert.EqualValues(t, 1, annotations[0].ID)
+	assert.EqualValues(t, 3, annotations[1].ID)
+}
+
 func TestDiffLine_CanComment(t *testing.T) {
 	assert.False(t, (&DiffLine{Type: DiffLineSection}).CanComment())
 	assert.False(t, (&DiffLine{Type: DiffLineAdd, Comments: []*issues_model.Comment{{Content: "bla"}}}).CanComment())
@@ -0,0 +1,75 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package mailer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+
+	"code.gitea.io/gitea/models/organization"
+	user_model "code.gitea.io/gitea/models/user"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/templates"
+	"code.gitea.io/gitea/modules/translation"
+	sender_service "code.gitea.io/gitea/services/mailer/sender"
+)
+
+const mailWebhookDisabled templates.TplName = "webhook/disabled"
+
+// SendWebhookDisabledMail notifies the owner of a webhook, or the owners of the organization owning it,
+// that the webhook has been deactivated after the given number of consecutive failed deliveries
+func SendWebhookDisabledMail(ctx context.Context, owner *user_model.User, w *webhook_model.Webhook, failures int, link string) error {
+	if setting.MailService == nil {
+		// No mail service configured
+		return nil
+	}
+
+	recipients := []*user_model.User{owner}
+	if owner.IsOrganization() {
+		team, err := organization.GetOwnerTeam(ctx, owner.ID)
+		if err != nil {
+			return err
+		}
+		if err := team.LoadMembers(ctx); err != nil {
+			return err
+		}
+		recipients = team.Members
+	}
+
+	hookName := w.Name
+	if hookName == "" {
+		hookName = w.HostName()
+	}
+
+	for _, u := range recipients {
+		if !u.IsActive || u.ProhibitLogin {
+			continue
+		}
+
+		locale := translation.NewLocale(u.Language)
+		subject := locale.TrString("mail.webhook.disabled.subject", hookName)
+		data := map[string]any{
+			"locale":   locale,
+			"Subject":  subject,
+			"HookName": hookName,
+			"Failures": failures,
+			"Link":     link,
+			"Language": locale.Language(),
+		}
+
+		var content bytes.Buffer
+		if err := LoadedTemplates().BodyTemplates.ExecuteTemplate(&content, string(mailWebhookDisabled), data); err != nil {
+			return err
+		}
+
+		msg := sender_service.NewMessage(u.EmailTo(), subject, content.String())
+		msg.Info = fmt.Sprintf("UID: %d, webhook %d disabled", u.ID, w.ID)
+
+		SendAsync(msg)
+	}
+
+	return nil
+}
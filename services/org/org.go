@@ -39,10 +39,21 @@ func deleteOrganization(ctx context.Context, org *org_model.Organization) error
 		&user_model.Blocking{BlockerID: org.ID},
 		&actions_model.ActionRunner{OwnerID: org.ID},
 		&actions_model.ActionRunnerToken{OwnerID: org.ID},
+		&actions_model.ActionRunnerGroup{OwnerID: org.ID},
+		&actions_model.ActionRequiredWorkflow{OwnerID: org.ID},
+		&actions_model.ActionUsageQuota{OwnerID: org.ID},
 	); err != nil {
 		return fmt.Errorf("DeleteBeans: %w", err)
 	}
 
+	if err := packages_model.DeleteRemotesByOwner(ctx, org.ID); err != nil {
+		return err
+	}
+
+	if err := packages_model.DeleteVirtualMembersByOwner(ctx, org.ID); err != nil {
+		return err
+	}
+
 	if _, err := db.GetEngine(ctx).ID(org.ID).Delete(new(user_model.User)); err != nil {
 		return fmt.Errorf("Delete: %w", err)
 	}
@@ -0,0 +1,47 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// PublishToChannel publishes the version to the channel
+func PublishToChannel(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion, channel string) (*packages_model.PackageVersionChannel, error) {
+	pvc := &packages_model.PackageVersionChannel{
+		VersionID: pv.ID,
+		Channel:   channel,
+		CreatorID: doer.ID,
+	}
+	if err := packages_model.AddVersionToChannel(ctx, pvc); err != nil {
+		return nil, err
+	}
+	return pvc, nil
+}
+
+// PromoteVersion publishes the version, which must be published to the source channel, to the target channel.
+// Only the channel is recorded, the files of the version are not copied.
+func PromoteVersion(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion, from, to string) (*packages_model.PackageVersionChannel, error) {
+	if from == to {
+		return nil, util.NewInvalidArgumentErrorf("the source and the target channel must be different")
+	}
+	if _, err := packages_model.GetVersionChannel(ctx, pv.ID, from); err != nil {
+		return nil, err
+	}
+
+	pvc := &packages_model.PackageVersionChannel{
+		VersionID:    pv.ID,
+		Channel:      to,
+		PromotedFrom: from,
+		CreatorID:    doer.ID,
+	}
+	if err := packages_model.AddVersionToChannel(ctx, pvc); err != nil {
+		return nil, err
+	}
+	return pvc, nil
+}
@@ -21,6 +21,7 @@ import (
 	container_service "code.gitea.io/gitea/services/packages/container"
 	debian_service "code.gitea.io/gitea/services/packages/debian"
 	maven_service "code.gitea.io/gitea/services/packages/maven"
+	nix_service "code.gitea.io/gitea/services/packages/nix"
 	rpm_service "code.gitea.io/gitea/services/packages/rpm"
 )
 
@@ -33,15 +34,25 @@ func CleanupTask(ctx context.Context, olderThan time.Duration) error {
 	return CleanupExpiredData(ctx, olderThan)
 }
 
-func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package) (versionDeleted bool, err error) {
-	olderThan := time.Now().AddDate(0, 0, -pcr.RemoveDays)
+// findVersionsToRemove returns the versions of the package which get removed by the rule
+func findVersionsToRemove(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package) ([]*packages_model.PackageVersion, error) {
+	now := time.Now()
+	olderThan := now.AddDate(0, 0, -pcr.RemoveDays)
+	unusedSince := now.AddDate(0, 0, -pcr.RemoveUnusedDays)
+	downloadedSince := now.AddDate(0, 0, -pcr.KeepDownloadedDays)
+
 	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
 		PackageID:  p.ID,
 		IsInternal: optional.Some(false),
 		Sort:       packages_model.SortCreatedDesc,
 	})
 	if err != nil {
-		return false, fmt.Errorf("CleanupRule [%d]: SearchVersions failed: %w", pcr.ID, err)
+		return nil, fmt.Errorf("CleanupRule [%d]: SearchVersions failed: %w", pcr.ID, err)
+	}
+	// released versions of an owner with immutable versions can't be deleted, not even by a cleanup rule
+	immutable, err := packages_service.IsImmutableVersionsEnabled(ctx, p.OwnerID)
+	if err != nil {
+		return nil, fmt.Errorf("CleanupRule [%d]: IsImmutableVersionsEnabled failed: %w", pcr.ID, err)
 	}
 	if pcr.KeepCount > 0 {
 		if pcr.KeepCount < len(pvs) {
@@ -50,10 +61,16 @@ func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.Packa
 			pvs = nil
 		}
 	}
+
+	toRemove := make([]*packages_model.PackageVersion, 0, len(pvs))
 	for _, pv := range pvs {
+		if immutable && packages_service.IsReleasedVersion(p, pv) {
+			log.Debug("Rule[%d]: keep '%s/%s' (immutable)", pcr.ID, p.Name, pv.Version)
+			continue
+		}
 		if pcr.Type == packages_model.TypeContainer {
 			if skip, err := container_service.ShouldBeSkipped(ctx, pcr, p, pv); err != nil {
-				return false, fmt.Errorf("CleanupRule [%d]: container.ShouldBeSkipped failed: %w", pcr.ID, err)
+				return nil, fmt.Errorf("CleanupRule [%d]: container.ShouldBeSkipped failed: %w", pcr.ID, err)
 			} else if skip {
 				log.Debug("Rule[%d]: keep '%s/%s' (container)", pcr.ID, p.Name, pv.Version)
 				continue
@@ -67,14 +84,33 @@ func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.Packa
 			log.Debug("Rule[%d]: keep '%s/%s' (keep pattern)", pcr.ID, p.Name, pv.Version)
 			continue
 		}
+		if pcr.KeepDownloadedDays > 0 && pv.LastDownloadUnix.AsLocalTime().After(downloadedSince) {
+			log.Debug("Rule[%d]: keep '%s/%s' (keep downloaded days) %v", pcr.ID, p.Name, pv.Version, pv.LastDownloadUnix.FormatDate())
+			continue
+		}
 		if pv.CreatedUnix.AsLocalTime().After(olderThan) {
 			log.Debug("Rule[%d]: keep '%s/%s' (remove days) %v", pcr.ID, p.Name, pv.Version, pv.CreatedUnix.FormatDate())
 			continue
 		}
+		if pcr.RemoveUnusedDays > 0 && pv.LastUsedUnix().AsLocalTime().After(unusedSince) {
+			log.Debug("Rule[%d]: keep '%s/%s' (remove unused days) %v", pcr.ID, p.Name, pv.Version, pv.LastUsedUnix().FormatDate())
+			continue
+		}
 		if pcr.RemovePatternMatcher != nil && !pcr.RemovePatternMatcher.MatchString(toMatch) {
 			log.Debug("Rule[%d]: keep '%s/%s' (remove pattern)", pcr.ID, p.Name, pv.Version)
 			continue
 		}
+		toRemove = append(toRemove, pv)
+	}
+	return toRemove, nil
+}
+
+func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.PackageCleanupRule, p *packages_model.Package) (versionDeleted bool, err error) {
+	pvs, err := findVersionsToRemove(ctx, pcr, p)
+	if err != nil {
+		return false, err
+	}
+	for _, pv := range pvs {
 		log.Debug("Rule[%d]: remove '%s/%s'", pcr.ID, p.Name, pv.Version)
 		if err := packages_service.DeletePackageVersionAndReferences(ctx, pv); err != nil {
 			log.Error("CleanupRule [%d]: DeletePackageVersionAndReferences failed: %v", pcr.ID, err)
@@ -85,6 +121,35 @@ func executeCleanupOneRulePackage(ctx context.Context, pcr *packages_model.Packa
 	return versionDeleted, nil
 }
 
+// PreviewCleanupRule returns the package versions which would be removed if the rule gets executed now.
+// Nothing is removed.
+func PreviewCleanupRule(ctx context.Context, pcr *packages_model.PackageCleanupRule) ([]*packages_model.PackageDescriptor, error) {
+	if err := pcr.CompiledPattern(); err != nil {
+		return nil, fmt.Errorf("CleanupRule [%d]: CompilePattern failed: %w", pcr.ID, err)
+	}
+
+	packages, err := packages_model.GetPackagesByType(ctx, pcr.OwnerID, pcr.Type)
+	if err != nil {
+		return nil, fmt.Errorf("CleanupRule [%d]: GetPackagesByType failed: %w", pcr.ID, err)
+	}
+
+	pds := make([]*packages_model.PackageDescriptor, 0, 10)
+	for _, p := range packages {
+		pvs, err := findVersionsToRemove(ctx, pcr, p)
+		if err != nil {
+			return nil, err
+		}
+		for _, pv := range pvs {
+			pd, err := packages_model.GetPackageDescriptor(ctx, pv)
+			if err != nil {
+				return nil, err
+			}
+			pds = append(pds, pd)
+		}
+	}
+	return pds, nil
+}
+
 func executeCleanupOneRule(ctx context.Context, pcr *packages_model.PackageCleanupRule) error {
 	if err := pcr.CompiledPattern(); err != nil {
 		return fmt.Errorf("CleanupRule [%d]: CompilePattern failed: %w", pcr.ID, err)
@@ -172,6 +237,10 @@ func CleanupExpiredData(ctx context.Context, olderThan time.Duration) error {
 			return err
 		}
 
+		if err := nix_service.Cleanup(ctx, olderThan); err != nil {
+			return err
+		}
+
 		if err := maven_service.CleanupSnapshotVersions(ctx); err != nil {
 			log.Error("Error during maven snapshot versions cleanup: %v", err)
 		}
@@ -104,5 +104,25 @@ func ShouldBeSkipped(ctx context.Context, pcr *packages_model.PackageCleanupRule
 		}
 	}
 
+	// Skip referrers (signatures, SBOMs, attestations) as long as the manifest they refer to exists
+	pps, err := packages_model.GetPropertiesByName(ctx, packages_model.PropertyTypeVersion, pv.ID, container_module.PropertyManifestSubject)
+	if err != nil {
+		return false, err
+	}
+	for _, pp := range pps {
+		pvs, err := container_model.GetManifestVersions(ctx, &container_model.BlobSearchOptions{
+			OwnerID:    p.OwnerID,
+			Image:      p.LowerName,
+			Digest:     pp.Value,
+			IsManifest: true,
+		})
+		if err != nil {
+			return false, err
+		}
+		if len(pvs) > 0 {
+			return true, nil
+		}
+	}
+
 	return false, nil
 }
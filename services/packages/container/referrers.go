@@ -0,0 +1,71 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package container
+
+import (
+	"context"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	container_model "code.gitea.io/gitea/models/packages/container"
+	"code.gitea.io/gitea/modules/container"
+	container_module "code.gitea.io/gitea/modules/packages/container"
+	"code.gitea.io/gitea/modules/timeutil"
+)
+
+// Referrer is a manifest (signature, SBOM, attestation, ...) which refers to another manifest
+type Referrer struct {
+	Subject      string
+	Digest       string
+	MediaType    string
+	ArtifactType string
+	Kind         container_module.ArtifactKind
+	Size         int64
+	Annotations  map[string]string
+	Version      string
+	CreatedUnix  timeutil.TimeStamp
+}
+
+// GetReferrers gets all manifests of an image which refer to the subject digest
+func GetReferrers(ctx context.Context, ownerID int64, image, subject string) ([]*Referrer, error) {
+	pvs, err := container_model.GetReferrerVersions(ctx, ownerID, image, subject)
+	if err != nil {
+		return nil, err
+	}
+
+	pds, err := packages_model.GetPackageDescriptors(ctx, pvs)
+	if err != nil {
+		return nil, err
+	}
+
+	// a manifest may be stored multiple times (pushed by digest and by tag)
+	seen := make(container.Set[string])
+
+	referrers := make([]*Referrer, 0, len(pds))
+	for _, pd := range pds {
+		metadata := pd.Metadata.(*container_module.Metadata)
+		for _, pfd := range pd.Files {
+			if !pfd.File.IsLead {
+				continue
+			}
+
+			d := pfd.Properties.GetByName(container_module.PropertyDigest)
+			if !seen.Add(d) {
+				continue
+			}
+
+			referrers = append(referrers, &Referrer{
+				Subject:      subject,
+				Digest:       d,
+				MediaType:    pfd.Properties.GetByName(container_module.PropertyMediaType),
+				ArtifactType: metadata.ArtifactType,
+				Kind:         container_module.GetArtifactKind(metadata.ArtifactType),
+				Size:         pfd.Blob.Size,
+				Annotations:  metadata.Annotations,
+				Version:      pd.Version.LowerVersion,
+				CreatedUnix:  pd.Version.CreatedUnix,
+			})
+		}
+	}
+	return referrers, nil
+}
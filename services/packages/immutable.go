@@ -0,0 +1,76 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package packages
+
+import (
+	"context"
+	"slices"
+	"strconv"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/util"
+)
+
+// ImmutableTypeList are the package types whose released versions can be made immutable
+var ImmutableTypeList = []packages_model.Type{
+	packages_model.TypeGeneric,
+	packages_model.TypeMaven,
+	packages_model.TypeNpm,
+}
+
+// ErrImmutableVersion is returned if a released version of an owner with immutable versions gets overwritten or deleted
+var ErrImmutableVersion = util.ErrorWrapTranslatable(
+	util.ErrorWrap(util.ErrPermissionDenied, "released package versions are immutable and cannot be overwritten or deleted"),
+	"packages.immutable_versions.error",
+)
+
+// IsImmutableVersionsEnabled returns whether the released package versions of the owner can't be overwritten or deleted
+func IsImmutableVersionsEnabled(ctx context.Context, ownerID int64) (bool, error) {
+	value, err := user_model.GetUserSetting(ctx, ownerID, user_model.SettingsKeyPackagesImmutableVersions, "false")
+	if err != nil {
+		return false, err
+	}
+	enabled, _ := strconv.ParseBool(value)
+	return enabled, nil
+}
+
+// SetImmutableVersionsEnabled changes whether the released package versions of the owner can't be overwritten or deleted
+func SetImmutableVersionsEnabled(ctx context.Context, ownerID int64, enabled bool) error {
+	if !enabled {
+		return user_model.DeleteUserSetting(ctx, ownerID, user_model.SettingsKeyPackagesImmutableVersions)
+	}
+	return user_model.SetUserSetting(ctx, ownerID, user_model.SettingsKeyPackagesImmutableVersions, "true")
+}
+
+// IsReleasedVersion returns whether the version is a release. Maven snapshots are updated in place and are never released.
+func IsReleasedVersion(p *packages_model.Package, pv *packages_model.PackageVersion) bool {
+	if pv.IsInternal || !slices.Contains(ImmutableTypeList, p.Type) {
+		return false
+	}
+	if p.Type == packages_model.TypeMaven && strings.HasSuffix(pv.Version, "-SNAPSHOT") {
+		return false
+	}
+	return true
+}
+
+// CheckVersionMutable returns ErrImmutableVersion if the version is released and the owner has immutable versions enabled.
+// Site administrators are not restricted.
+func CheckVersionMutable(ctx context.Context, doer *user_model.User, p *packages_model.Package, pv *packages_model.PackageVersion) error {
+	if doer != nil && doer.IsAdmin {
+		return nil
+	}
+	if !IsReleasedVersion(p, pv) {
+		return nil
+	}
+	enabled, err := IsImmutableVersionsEnabled(ctx, p.OwnerID)
+	if err != nil {
+		return err
+	}
+	if enabled {
+		return ErrImmutableVersion
+	}
+	return nil
+}
@@ -0,0 +1,99 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package nix
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/optional"
+	nix_module "code.gitea.io/gitea/modules/packages/nix"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// GetOrCreateUploadVersion gets or creates the internal package version which stores the uploaded NAR files
+// until they get referenced by a narinfo file.
+func GetOrCreateUploadVersion(ctx context.Context, ownerID int64) (*packages_model.PackageVersion, error) {
+	return packages_service.GetOrCreateInternalPackageVersion(ctx, ownerID, packages_model.TypeNix, nix_module.UploadPackage, nix_module.UploadVersion)
+}
+
+// GetOrCreateKeyPair gets or creates the ed25519 keys used to sign the narinfo files
+func GetOrCreateKeyPair(ctx context.Context, owner *user_model.User) (string, string, error) {
+	priv, err := user_model.GetSetting(ctx, owner.ID, nix_module.SettingKeyPrivate)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	pub, err := user_model.GetSetting(ctx, owner.ID, nix_module.SettingKeyPublic)
+	if err != nil && !errors.Is(err, util.ErrNotExist) {
+		return "", "", err
+	}
+
+	if priv == "" || pub == "" {
+		// the key name identifies the key in the "trusted-public-keys" setting of the clients
+		priv, pub, err = nix_module.GenerateKeyPair(fmt.Sprintf("%s-%s-1", setting.Domain, owner.LowerName))
+		if err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, owner.ID, nix_module.SettingKeyPrivate, priv); err != nil {
+			return "", "", err
+		}
+
+		if err := user_model.SetUserSetting(ctx, owner.ID, nix_module.SettingKeyPublic, pub); err != nil {
+			return "", "", err
+		}
+	}
+
+	return priv, pub, nil
+}
+
+// SignStorePath signs the store path with the key of the owner
+func SignStorePath(ctx context.Context, owner *user_model.User, m *nix_module.Metadata) (string, error) {
+	priv, _, err := GetOrCreateKeyPair(ctx, owner)
+	if err != nil {
+		return "", err
+	}
+
+	return nix_module.Sign(priv, m.Fingerprint())
+}
+
+// Cleanup removes uploaded NAR files which were not referenced by a narinfo file
+func Cleanup(ctx context.Context, olderThan time.Duration) error {
+	pvs, _, err := packages_model.SearchVersions(ctx, &packages_model.PackageSearchOptions{
+		Type: packages_model.TypeNix,
+		Version: packages_model.SearchValue{
+			ExactMatch: true,
+			Value:      nix_module.UploadVersion,
+		},
+		IsInternal: optional.Some(true),
+	})
+	if err != nil {
+		return err
+	}
+
+	for _, pv := range pvs {
+		pfs, _, err := packages_model.SearchFiles(ctx, &packages_model.PackageFileSearchOptions{
+			VersionID: pv.ID,
+			OlderThan: olderThan,
+		})
+		if err != nil {
+			return err
+		}
+
+		for _, pf := range pfs {
+			if err := packages_service.DeletePackageFile(ctx, pf); err != nil {
+				return err
+			}
+		}
+	}
+
+	return nil
+}
@@ -16,6 +16,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	packages_model "code.gitea.io/gitea/models/packages"
 	repo_model "code.gitea.io/gitea/models/repo"
+	sbom_model "code.gitea.io/gitea/models/sbom"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
@@ -356,73 +357,85 @@ func CheckSizeQuotaExceeded(ctx context.Context, doer, owner *user_model.User, p
 		return nil
 	}
 
-	var typeSpecificSize int64
+	if typeSpecificSize := GetTypeSizeLimit(packageType); typeSpecificSize > -1 && typeSpecificSize < uploadSize {
+		return ErrQuotaTypeSize
+	}
+
+	if setting.Packages.LimitTotalOwnerSize > -1 {
+		totalSize, err := packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{
+			OwnerID: owner.ID,
+		})
+		if err != nil {
+			log.Error("CalculateFileSize failed: %v", err)
+			return err
+		}
+		if totalSize+uploadSize > setting.Packages.LimitTotalOwnerSize {
+			return ErrQuotaTotalSize
+		}
+	}
+
+	return nil
+}
+
+// GetTypeSizeLimit returns the maximum size of a file of the package type, -1 means no limit
+func GetTypeSizeLimit(packageType packages_model.Type) int64 {
 	switch packageType {
 	case packages_model.TypeAlpine:
-		typeSpecificSize = setting.Packages.LimitSizeAlpine
+		return setting.Packages.LimitSizeAlpine
+	case packages_model.TypeAnsible:
+		return setting.Packages.LimitSizeAnsible
 	case packages_model.TypeArch:
-		typeSpecificSize = setting.Packages.LimitSizeArch
+		return setting.Packages.LimitSizeArch
 	case packages_model.TypeCargo:
-		typeSpecificSize = setting.Packages.LimitSizeCargo
+		return setting.Packages.LimitSizeCargo
 	case packages_model.TypeChef:
-		typeSpecificSize = setting.Packages.LimitSizeChef
+		return setting.Packages.LimitSizeChef
 	case packages_model.TypeComposer:
-		typeSpecificSize = setting.Packages.LimitSizeComposer
+		return setting.Packages.LimitSizeComposer
 	case packages_model.TypeConan:
-		typeSpecificSize = setting.Packages.LimitSizeConan
+		return setting.Packages.LimitSizeConan
 	case packages_model.TypeConda:
-		typeSpecificSize = setting.Packages.LimitSizeConda
+		return setting.Packages.LimitSizeConda
 	case packages_model.TypeContainer:
-		typeSpecificSize = setting.Packages.LimitSizeContainer
+		return setting.Packages.LimitSizeContainer
 	case packages_model.TypeCran:
-		typeSpecificSize = setting.Packages.LimitSizeCran
+		return setting.Packages.LimitSizeCran
 	case packages_model.TypeDebian:
-		typeSpecificSize = setting.Packages.LimitSizeDebian
+		return setting.Packages.LimitSizeDebian
 	case packages_model.TypeGeneric:
-		typeSpecificSize = setting.Packages.LimitSizeGeneric
+		return setting.Packages.LimitSizeGeneric
 	case packages_model.TypeGo:
-		typeSpecificSize = setting.Packages.LimitSizeGo
+		return setting.Packages.LimitSizeGo
 	case packages_model.TypeHelm:
-		typeSpecificSize = setting.Packages.LimitSizeHelm
+		return setting.Packages.LimitSizeHelm
+	case packages_model.TypeHex:
+		return setting.Packages.LimitSizeHex
 	case packages_model.TypeMaven:
-		typeSpecificSize = setting.Packages.LimitSizeMaven
+		return setting.Packages.LimitSizeMaven
+	case packages_model.TypeNix:
+		return setting.Packages.LimitSizeNix
 	case packages_model.TypeNpm:
-		typeSpecificSize = setting.Packages.LimitSizeNpm
+		return setting.Packages.LimitSizeNpm
 	case packages_model.TypeNuGet:
-		typeSpecificSize = setting.Packages.LimitSizeNuGet
+		return setting.Packages.LimitSizeNuGet
 	case packages_model.TypePub:
-		typeSpecificSize = setting.Packages.LimitSizePub
+		return setting.Packages.LimitSizePub
 	case packages_model.TypePyPI:
-		typeSpecificSize = setting.Packages.LimitSizePyPI
+		return setting.Packages.LimitSizePyPI
 	case packages_model.TypeRpm:
-		typeSpecificSize = setting.Packages.LimitSizeRpm
+		return setting.Packages.LimitSizeRpm
 	case packages_model.TypeRubyGems:
-		typeSpecificSize = setting.Packages.LimitSizeRubyGems
+		return setting.Packages.LimitSizeRubyGems
 	case packages_model.TypeSwift:
-		typeSpecificSize = setting.Packages.LimitSizeSwift
+		return setting.Packages.LimitSizeSwift
 	case packages_model.TypeTerraformState:
-		typeSpecificSize = setting.Packages.LimitSizeTerraformState
+		return setting.Packages.LimitSizeTerraformState
 	case packages_model.TypeVagrant:
-		typeSpecificSize = setting.Packages.LimitSizeVagrant
-	}
-	if typeSpecificSize > -1 && typeSpecificSize < uploadSize {
-		return ErrQuotaTypeSize
-	}
-
-	if setting.Packages.LimitTotalOwnerSize > -1 {
-		totalSize, err := packages_model.CalculateFileSize(ctx, &packages_model.PackageFileSearchOptions{
-			OwnerID: owner.ID,
-		})
-		if err != nil {
-			log.Error("CalculateFileSize failed: %v", err)
-			return err
-		}
-		if totalSize+uploadSize > setting.Packages.LimitTotalOwnerSize {
-			return ErrQuotaTotalSize
-		}
+		return setting.Packages.LimitSizeVagrant
+	case packages_model.TypeVsix:
+		return setting.Packages.LimitSizeVsix
 	}
-
-	return nil
+	return -1
 }
 
 // GetOrCreateInternalPackageVersion gets or creates an internal package
@@ -481,6 +494,9 @@ func RemovePackageVersion(ctx context.Context, doer *user_model.User, pv *packag
 	if err != nil {
 		return err
 	}
+	if err := CheckVersionMutable(ctx, doer, pd.Package, pv); err != nil {
+		return err
+	}
 	if err := GetSpecManager().Get(pd.Package.Type).OnBeforeRemovePackageVersion(ctx, doer, pd); err != nil {
 		return err
 	}
@@ -550,6 +566,12 @@ func DeletePackageVersionAndReferences(ctx context.Context, pv *packages_model.P
 	if err := packages_model.DeleteFilesByVersionID(ctx, pv.ID); err != nil {
 		return err
 	}
+	if err := sbom_model.DeleteDocumentByPackageVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
+	if err := packages_model.DeleteChannelsByVersionID(ctx, pv.ID); err != nil {
+		return err
+	}
 
 	return packages_model.DeleteVersionByID(ctx, pv.ID)
 }
@@ -644,6 +666,11 @@ func RemovePackage(ctx context.Context, doer *user_model.User, p *packages_model
 	if err != nil {
 		return err
 	}
+	for _, pd := range pds {
+		if err := CheckVersionMutable(ctx, doer, p, pd.Version); err != nil {
+			return err
+		}
+	}
 	if err := GetSpecManager().Get(p.Type).OnBeforeRemovePackageAll(ctx, doer, p, pds); err != nil {
 		return err
 	}
@@ -666,6 +693,14 @@ func RemovePackage(ctx context.Context, doer *user_model.User, p *packages_model
 		if err != nil {
 			return err
 		}
+		err = sbom_model.DeleteDocumentsByPackageID(ctx, p.ID)
+		if err != nil {
+			return err
+		}
+		err = packages_model.DeleteChannelsByPackageID(ctx, p.ID)
+		if err != nil {
+			return err
+		}
 		err = packages_model.DeleteVersionsByPackageID(ctx, p.ID)
 		if err != nil {
 			return err
@@ -0,0 +1,279 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package remote
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+
+	packages_model "code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/perm"
+	"code.gitea.io/gitea/modules/globallock"
+	"code.gitea.io/gitea/modules/hostmatcher"
+	"code.gitea.io/gitea/modules/log"
+	packages_module "code.gitea.io/gitea/modules/packages"
+	"code.gitea.io/gitea/modules/proxy"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/modules/util"
+	packages_service "code.gitea.io/gitea/services/packages"
+)
+
+// maxMetadataSize limits the size of the upstream metadata which is read into the memory and cached in the database
+const maxMetadataSize = 64 * 1024 * 1024
+
+// GetRemote returns the remote of the package type of the owner, it returns nil if the owner has no remote for the type
+func GetRemote(ctx context.Context, ownerID int64, packageType packages_model.Type) (*packages_model.PackageRemote, error) {
+	if !packages_model.IsRemoteType(packageType) {
+		return nil, nil
+	}
+	r, err := packages_model.GetRemoteByOwnerAndType(ctx, ownerID, packageType)
+	if errors.Is(err, packages_model.ErrPackageRemoteNotExist) {
+		return nil, nil
+	}
+	return r, err
+}
+
+func newHTTPClient() *http.Client {
+	allowedHostListValue := setting.Packages.RemoteAllowedHostList
+	if allowedHostListValue == "" {
+		allowedHostListValue = hostmatcher.MatchBuiltinExternal
+	}
+	allowedHostMatcher := hostmatcher.ParseHostMatchList("packages.REMOTE_ALLOWED_HOST_LIST", allowedHostListValue)
+
+	return &http.Client{
+		Timeout: setting.Packages.RemoteFetchTimeout,
+		Transport: &http.Transport{
+			Proxy:       proxy.Proxy(),
+			DialContext: hostmatcher.NewDialContext("package remote", allowedHostMatcher, nil, setting.Proxy.ProxyURLFixed),
+		},
+	}
+}
+
+// resolveURL returns the absolute url of the path, the path can be an absolute url (like the tarball urls in the npm metadata) or relative to the upstream url
+func resolveURL(r *packages_model.PackageRemote, p string) (*url.URL, error) {
+	if strings.HasPrefix(p, "http://") || strings.HasPrefix(p, "https://") {
+		return url.Parse(p)
+	}
+	return url.Parse(r.URL + "/" + strings.TrimPrefix(p, "/"))
+}
+
+// fetch requests the url from the upstream registry, the response body must be closed by the caller
+func fetch(ctx context.Context, r *packages_model.PackageRemote, p, accept string) (*http.Response, error) {
+	u, err := resolveURL(r, p)
+	if err != nil {
+		return nil, err
+	}
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("User-Agent", "Gitea "+setting.AppVer)
+	if accept != "" {
+		req.Header.Set("Accept", accept)
+	}
+	if r.Username != "" || r.PasswordEncrypted != "" {
+		// the credentials are only sent to the upstream registry itself, not to the hosts which the files are linked to
+		if upstream, err := url.Parse(r.URL); err == nil && upstream.Host == u.Host {
+			password, err := r.Password()
+			if err != nil {
+				return nil, err
+			}
+			req.SetBasicAuth(r.Username, password)
+		}
+	}
+
+	resp, err := newHTTPClient().Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("fetch %s: %w", u.Redacted(), err)
+	}
+	switch {
+	case resp.StatusCode == http.StatusNotFound || resp.StatusCode == http.StatusGone:
+		resp.Body.Close()
+		return nil, util.NewNotExistErrorf("%s doesn't exist in the upstream registry", p)
+	case resp.StatusCode != http.StatusOK:
+		resp.Body.Close()
+		return nil, fmt.Errorf("fetch %s: upstream registry responded %s", u.Redacted(), resp.Status)
+	}
+	return resp, nil
+}
+
+// FetchMetadata returns the upstream metadata at the path, like a npm packument or a Maven metadata file.
+// The metadata is cached for the TTL of the remote, and the stale one is returned if the upstream registry can't be reached.
+func FetchMetadata(ctx context.Context, r *packages_model.PackageRemote, p, accept string) ([]byte, error) {
+	cacheable := len(p) <= 255
+	var cached *packages_model.PackageRemoteMetadata
+	if cacheable {
+		var err error
+		cached, err = packages_model.GetRemoteMetadata(ctx, r.ID, p)
+		if err != nil {
+			return nil, err
+		}
+		if cached != nil && cached.FetchedUnix.Add(r.GetMetadataTTL()) > timeutil.TimeStampNow() {
+			return cached.Content, nil
+		}
+	}
+
+	content, err := fetchMetadata(ctx, r, p, accept)
+	if err != nil {
+		if cached != nil && !errors.Is(err, util.ErrNotExist) {
+			log.Warn("Unable to refresh the metadata %s of package remote %d, use the stale one: %v", p, r.ID, err)
+			return cached.Content, nil
+		}
+		return nil, err
+	}
+
+	if cacheable {
+		if err := packages_model.SetRemoteMetadata(ctx, r.ID, p, content); err != nil {
+			return nil, err
+		}
+	}
+	return content, nil
+}
+
+func fetchMetadata(ctx context.Context, r *packages_model.PackageRemote, p, accept string) ([]byte, error) {
+	resp, err := fetch(ctx, r, p, accept)
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	content, err := io.ReadAll(io.LimitReader(resp.Body, maxMetadataSize+1))
+	if err != nil {
+		return nil, err
+	}
+	if len(content) > maxMetadataSize {
+		return nil, fmt.Errorf("metadata %s of the upstream registry is too large", p)
+	}
+	return content, nil
+}
+
+// FileDigest is a checksum of an upstream file published in the upstream metadata
+type FileDigest struct {
+	Algorithm string // "md5", "sha1", "sha256" or "sha512"
+	Sum       []byte
+}
+
+// fileSizeLimit returns the maximum size of an upstream file of the remote, -1 means no limit
+func fileSizeLimit(r *packages_model.PackageRemote) int64 {
+	limit := packages_service.GetTypeSizeLimit(r.Type)
+	if remoteLimit := setting.Packages.RemoteLimitSize; remoteLimit > -1 && (limit < 0 || remoteLimit < limit) {
+		limit = remoteLimit
+	}
+	return limit
+}
+
+// FetchFile downloads the upstream file at the path into a hashed buffer, which must be closed by the caller.
+// The file is rejected if it exceeds the size limit or doesn't match the digests from the upstream metadata,
+// so a broken upstream registry can neither fill nor poison the package store.
+func FetchFile(ctx context.Context, r *packages_model.PackageRemote, p string, digests ...FileDigest) (*packages_module.HashedBuffer, error) {
+	resp, err := fetch(ctx, r, p, "")
+	if err != nil {
+		return nil, err
+	}
+	defer resp.Body.Close()
+
+	limit := fileSizeLimit(r)
+	var body io.Reader = resp.Body
+	if limit > -1 {
+		if resp.ContentLength > limit {
+			return nil, util.ErrorWrap(packages_service.ErrQuotaTypeSize, "upstream file %s is larger than %d bytes", p, limit)
+		}
+		body = io.LimitReader(resp.Body, limit+1)
+	}
+
+	buf, err := packages_module.NewHashedBuffer()
+	if err != nil {
+		return nil, err
+	}
+	if _, err := io.Copy(buf, body); err != nil {
+		buf.Close()
+		return nil, err
+	}
+	if limit > -1 && buf.Size() > limit {
+		buf.Close()
+		return nil, util.ErrorWrap(packages_service.ErrQuotaTypeSize, "upstream file %s is larger than %d bytes", p, limit)
+	}
+	if err := verifyDigests(buf, digests); err != nil {
+		buf.Close()
+		return nil, fmt.Errorf("upstream file %s: %w", p, err)
+	}
+	if _, err := buf.Seek(0, io.SeekStart); err != nil {
+		buf.Close()
+		return nil, err
+	}
+	return buf, nil
+}
+
+func verifyDigests(buf *packages_module.HashedBuffer, digests []FileDigest) error {
+	hashMD5, hashSHA1, hashSHA256, hashSHA512 := buf.Sums()
+	for _, digest := range digests {
+		var sum []byte
+		switch digest.Algorithm {
+		case "md5":
+			sum = hashMD5
+		case "sha1":
+			sum = hashSHA1
+		case "sha256":
+			sum = hashSHA256
+		case "sha512":
+			sum = hashSHA512
+		default:
+			continue
+		}
+		if !bytes.Equal(sum, digest.Sum) {
+			return fmt.Errorf("%s digest mismatch", digest.Algorithm)
+		}
+	}
+	return nil
+}
+
+// ErrCachePermissionDenied is returned if the doer isn't allowed to cache the upstream files
+var ErrCachePermissionDenied = util.NewPermissionDeniedErrorf("caching the upstream files requires the write permission to the packages")
+
+// CheckCachePermission checks whether the doer with the access mode to the packages of the owner can cache the upstream files.
+// Caching creates package versions which count toward the quotas of the owner, so it requires the write permission like uploading,
+// unless [packages] REMOTE_CACHE_ON_READ allows the readers to do it.
+func CheckCachePermission(accessMode perm.AccessMode) error {
+	if accessMode >= perm.AccessModeWrite || (setting.Packages.RemoteCacheOnRead && accessMode >= perm.AccessModeRead) {
+		return nil
+	}
+	return ErrCachePermissionDenied
+}
+
+// CachePackageFile stores an upstream file as a file of the package version, the version is created if it doesn't exist.
+// The cached versions are created by the owner, so they are listed in the package UI and count toward the quotas of the owner like the uploaded ones.
+// The caller must check the permission of the doer by CheckCachePermission before fetching the file.
+// If the file has been cached by a concurrent request, the existing file is returned.
+func CachePackageFile(ctx context.Context, pvci *packages_service.PackageCreationInfo, pfci *packages_service.PackageFileCreationInfo) (*packages_model.PackageVersion, *packages_model.PackageFile, error) {
+	pvci.Creator = pvci.Owner
+	pfci.Creator = pvci.Owner
+
+	key := fmt.Sprintf("pkg_remote_%d_%s_%s_%s", pvci.Owner.ID, pvci.PackageType, strings.ToLower(pvci.Name), strings.ToLower(pvci.Version))
+	releaser, err := globallock.Lock(ctx, key)
+	if err != nil {
+		return nil, nil, err
+	}
+	defer releaser()
+
+	pv, err := packages_model.GetVersionByNameAndVersion(ctx, pvci.Owner.ID, pvci.PackageType, pvci.Name, pvci.Version)
+	if err == nil {
+		pf, err := packages_model.GetFileForVersionByName(ctx, pv.ID, pfci.Filename, pfci.CompositeKey)
+		if err == nil {
+			return pv, pf, nil
+		} else if !errors.Is(err, packages_model.ErrPackageFileNotExist) {
+			return nil, nil, err
+		}
+	} else if !errors.Is(err, packages_model.ErrPackageNotExist) {
+		return nil, nil, err
+	}
+
+	return packages_service.CreatePackageOrAddFileToExisting(ctx, pvci, pfci)
+}
@@ -9,6 +9,7 @@ import (
 	"errors"
 	"fmt"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
@@ -72,7 +73,17 @@ func IsPullCommitStatusPass(ctx context.Context, pr *issues_model.PullRequest) (
 		return false, fmt.Errorf("GetLatestCommitStatus: %w", err)
 	}
 	if pb == nil || !pb.EnableStatusCheck {
-		return true, nil
+		if err := pr.LoadBaseRepo(ctx); err != nil {
+			return false, fmt.Errorf("LoadBaseRepo: %w", err)
+		}
+		// the status checks of the required workflows of the org are enforced even if the branch isn't protected
+		requiredWorkflowContexts, err := actions_model.GetRequiredWorkflowStatusCheckContexts(ctx, pr.BaseRepo, pr.BaseBranch)
+		if err != nil {
+			return false, fmt.Errorf("GetRequiredWorkflowStatusCheckContexts: %w", err)
+		}
+		if len(requiredWorkflowContexts) == 0 {
+			return true, nil
+		}
 	}
 
 	state, err := GetPullRequestCommitStatusState(ctx, pr)
@@ -130,10 +141,31 @@ func GetPullRequestCommitStatusState(ctx context.Context, pr *issues_model.PullR
 	if err != nil {
 		return "", fmt.Errorf("LoadProtectedBranch: %w", err)
 	}
+	requiredWorkflowContexts, err := actions_model.GetRequiredWorkflowStatusCheckContexts(ctx, pr.BaseRepo, pr.BaseBranch)
+	if err != nil {
+		return "", fmt.Errorf("GetRequiredWorkflowStatusCheckContexts: %w", err)
+	}
+
+	return MergeBranchRequiredCommitStatus(commitStatuses, pb, requiredWorkflowContexts), nil
+}
+
+// MergeBranchRequiredCommitStatus returns a commit status state for the required contexts of the protected branch rule (can be nil)
+// and the status check contexts of the required workflows of the org
+func MergeBranchRequiredCommitStatus(commitStatuses []*git_model.CommitStatus, pb *git_model.ProtectedBranch, requiredWorkflowContexts []string) commitstatus.CommitStatusState {
 	var requiredContexts []string
 	if pb != nil {
 		requiredContexts = pb.StatusCheckContexts
 	}
+	if len(requiredWorkflowContexts) == 0 {
+		return MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts)
+	}
 
-	return MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts), nil
+	requiredWorkflowsState := MergeRequiredContextsCommitStatus(commitStatuses, requiredWorkflowContexts)
+	if pb == nil || !pb.EnableStatusCheck {
+		return requiredWorkflowsState
+	}
+	return commitstatus.CommitStatusStates{
+		MergeRequiredContextsCommitStatus(commitStatuses, requiredContexts),
+		requiredWorkflowsState,
+	}.Combine()
 }
@@ -90,3 +90,25 @@ func TestMergeRequiredContextsCommitStatus(t *testing.T) {
 		assert.Equal(t, c.expected, MergeRequiredContextsCommitStatus(c.commitStatuses, c.requiredContexts), "case %d", i)
 	}
 }
+
+func TestMergeBranchRequiredCommitStatus(t *testing.T) {
+	commitStatuses := []*git_model.CommitStatus{
+		{Context: "Build 1", State: commitstatus.CommitStatusSuccess},
+		{Context: "required-workflow/1: compliance / license-scan (pull_request)", State: commitstatus.CommitStatusFailure},
+	}
+	workflowContexts := []string{"required-workflow/1: compliance / *"}
+
+	// the required workflows are enforced even if the branch isn't protected
+	assert.Equal(t, commitstatus.CommitStatusFailure, MergeBranchRequiredCommitStatus(commitStatuses, nil, workflowContexts))
+	assert.Equal(t, commitstatus.CommitStatusFailure, MergeBranchRequiredCommitStatus(commitStatuses, &git_model.ProtectedBranch{}, workflowContexts))
+	assert.Equal(t, commitstatus.CommitStatusSuccess, MergeBranchRequiredCommitStatus(commitStatuses, &git_model.ProtectedBranch{EnableStatusCheck: true, StatusCheckContexts: []string{"Build*"}}, nil))
+	assert.Equal(t, commitstatus.CommitStatusFailure, MergeBranchRequiredCommitStatus(commitStatuses, &git_model.ProtectedBranch{EnableStatusCheck: true, StatusCheckContexts: []string{"Build*"}}, workflowContexts))
+	assert.Equal(t, commitstatus.CommitStatusPending, MergeBranchRequiredCommitStatus(commitStatuses[:1], nil, workflowContexts))
+
+	// a status with the same workflow name, from a workflow of the repository or the API, doesn't satisfy the required workflow
+	spoofed := []*git_model.CommitStatus{
+		commitStatuses[0],
+		{Context: "compliance / license-scan (pull_request)", State: commitstatus.CommitStatusSuccess},
+	}
+	assert.Equal(t, commitstatus.CommitStatusPending, MergeBranchRequiredCommitStatus(spoofed, nil, workflowContexts))
+}
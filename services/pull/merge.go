@@ -576,10 +576,8 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 	if err != nil {
 		return fmt.Errorf("LoadProtectedBranch: %v", err)
 	}
-	if pb == nil {
-		return nil
-	}
 
+	// the status checks are checked even if the branch isn't protected, because the required workflows of the org are always enforced
 	isPass, err := IsPullCommitStatusPass(ctx, pr)
 	if err != nil {
 		return err
@@ -587,6 +585,9 @@ func CheckPullBranchProtections(ctx context.Context, pr *issues_model.PullReques
 	if !isPass {
 		return util.ErrorWrap(ErrNotReadyToMerge, "Not all required status checks successful")
 	}
+	if pb == nil {
+		return nil
+	}
 
 	if !issues_model.HasEnoughApprovals(ctx, pb, pr) {
 		return util.ErrorWrap(ErrNotReadyToMerge, "Does not have enough approvals")
@@ -12,6 +12,7 @@ import (
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
+	sbom_model "code.gitea.io/gitea/models/sbom"
 	user_model "code.gitea.io/gitea/models/user"
 	"code.gitea.io/gitea/modules/container"
 	"code.gitea.io/gitea/modules/git"
@@ -392,6 +393,9 @@ func DeleteReleaseByID(ctx context.Context, repo *repo_model.Repository, rel *re
 		if _, err := db.DeleteByID[repo_model.Release](ctx, rel.ID); err != nil {
 			return fmt.Errorf("DeleteReleaseByID: %w", err)
 		}
+		if err := sbom_model.DeleteDocumentByReleaseID(ctx, rel.ID); err != nil {
+			return fmt.Errorf("DeleteDocumentByReleaseID: %w", err)
+		}
 	} else {
 		rel.IsTag = true
 
@@ -9,6 +9,7 @@ import (
 	"fmt"
 	"slices"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	git_model "code.gitea.io/gitea/models/git"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -20,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/json"
 	"code.gitea.io/gitea/modules/log"
 	repo_module "code.gitea.io/gitea/modules/repository"
+	"code.gitea.io/gitea/modules/util"
 	"code.gitea.io/gitea/services/notify"
 )
 
@@ -68,7 +70,20 @@ func deleteCommitStatusCache(repoID int64, branchName string) error {
 // CreateCommitStatus creates a new CommitStatus given a bunch of parameters
 // NOTE: All text-values will be trimmed from whitespaces.
 // Requires: Repo, Creator, SHA
+// The contexts reserved for the required workflows are rejected, use CreateRequiredWorkflowCommitStatus for them.
 func CreateCommitStatus(ctx context.Context, repo *repo_model.Repository, creator *user_model.User, sha string, status *git_model.CommitStatus) error {
+	if actions_model.IsRequiredWorkflowStatusContext(status.Context) {
+		return util.NewInvalidArgumentErrorf("commit status context %q is reserved for the required workflows", status.Context)
+	}
+	return createCommitStatus(ctx, repo, creator, sha, status)
+}
+
+// CreateRequiredWorkflowCommitStatus creates the commit status of a job of a required workflow, its context could use the reserved prefix
+func CreateRequiredWorkflowCommitStatus(ctx context.Context, repo *repo_model.Repository, creator *user_model.User, sha string, status *git_model.CommitStatus) error {
+	return createCommitStatus(ctx, repo, creator, sha, status)
+}
+
+func createCommitStatus(ctx context.Context, repo *repo_model.Repository, creator *user_model.User, sha string, status *git_model.CommitStatus) error {
 	// confirm that commit is exist
 	gitRepo, closer, err := gitrepo.RepositoryFromContextOrOpen(ctx, repo)
 	if err != nil {
@@ -0,0 +1,161 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package sbom
+
+import (
+	"context"
+	"io"
+	"strings"
+
+	"code.gitea.io/gitea/models/db"
+	packages_model "code.gitea.io/gitea/models/packages"
+	repo_model "code.gitea.io/gitea/models/repo"
+	sbom_model "code.gitea.io/gitea/models/sbom"
+	user_model "code.gitea.io/gitea/models/user"
+	sbom_module "code.gitea.io/gitea/modules/sbom"
+)
+
+// Inventory is a document with its components
+type Inventory struct {
+	Document   *sbom_model.Document
+	Components []*sbom_model.Component
+}
+
+// ComponentMatch is a component found by a search with the package version or repository its document is attached to
+type ComponentMatch struct {
+	Component         *sbom_model.Component
+	Document          *sbom_model.Document
+	PackageDescriptor *packages_model.PackageDescriptor
+	Repository        *repo_model.Repository
+}
+
+// IngestForPackageVersion parses the document and attaches it to the package version
+func IngestForPackageVersion(ctx context.Context, doer *user_model.User, pv *packages_model.PackageVersion, r io.Reader) (*Inventory, error) {
+	return ingest(ctx, doer, &sbom_model.Document{PackageVersionID: pv.ID}, r)
+}
+
+// IngestForCommit parses the document and attaches it to the commit of the repository
+func IngestForCommit(ctx context.Context, doer *user_model.User, repo *repo_model.Repository, commitSHA string, r io.Reader) (*Inventory, error) {
+	return ingest(ctx, doer, &sbom_model.Document{RepoID: repo.ID, CommitSHA: commitSHA}, r)
+}
+
+// IngestForRelease parses the document and attaches it to the release
+func IngestForRelease(ctx context.Context, doer *user_model.User, rel *repo_model.Release, r io.Reader) (*Inventory, error) {
+	return ingest(ctx, doer, &sbom_model.Document{RepoID: rel.RepoID, ReleaseID: rel.ID}, r)
+}
+
+func ingest(ctx context.Context, doer *user_model.User, doc *sbom_model.Document, r io.Reader) (*Inventory, error) {
+	parsed, err := sbom_module.Parse(r)
+	if err != nil {
+		return nil, err
+	}
+
+	doc.Format = parsed.Format
+	doc.SpecVersion = parsed.SpecVersion
+	doc.Name = parsed.Name
+	doc.CreatorID = doer.ID
+
+	components := make([]*sbom_model.Component, 0, len(parsed.Components))
+	for _, c := range parsed.Components {
+		purlBase, _ := sbom_module.SplitPURL(c.PURL)
+		components = append(components, &sbom_model.Component{
+			Name:     c.Name,
+			Version:  c.Version,
+			PURL:     c.PURL,
+			PURLBase: purlBase,
+			License:  c.License,
+		})
+	}
+
+	if err := sbom_model.ReplaceDocument(ctx, doc, components); err != nil {
+		return nil, err
+	}
+
+	return &Inventory{
+		Document:   doc,
+		Components: components,
+	}, nil
+}
+
+// GetInventory loads the components of the document
+func GetInventory(ctx context.Context, doc *sbom_model.Document) (*Inventory, error) {
+	components, err := sbom_model.GetComponentsByDocumentID(ctx, doc.ID)
+	if err != nil {
+		return nil, err
+	}
+	return &Inventory{
+		Document:   doc,
+		Components: components,
+	}, nil
+}
+
+// SearchComponents searches the components of the documents attached to the package versions and repositories of the owner.
+// A package url with a version matches only the components of this version.
+func SearchComponents(ctx context.Context, opts *sbom_model.ComponentSearchOptions) ([]*ComponentMatch, int64, error) {
+	if opts.PURL != "" {
+		base, version := sbom_module.SplitPURL(opts.PURL)
+		opts.PURL = base
+		if version != "" && opts.Version == "" {
+			opts.Version = version
+		}
+	}
+	opts.Keyword = strings.TrimSpace(opts.Keyword)
+
+	components, count, err := db.FindAndCount[sbom_model.Component](ctx, opts)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	documentIDs := make([]int64, 0, len(components))
+	for _, c := range components {
+		documentIDs = append(documentIDs, c.DocumentID)
+	}
+	docs, err := sbom_model.GetDocumentsByIDs(ctx, documentIDs)
+	if err != nil {
+		return nil, 0, err
+	}
+
+	pds := make(map[int64]*packages_model.PackageDescriptor)
+	repos := make(map[int64]*repo_model.Repository)
+
+	matches := make([]*ComponentMatch, 0, len(components))
+	for _, c := range components {
+		doc, ok := docs[c.DocumentID]
+		if !ok {
+			continue
+		}
+
+		m := &ComponentMatch{
+			Component: c,
+			Document:  doc,
+		}
+		if doc.PackageVersionID != 0 {
+			pd, ok := pds[doc.PackageVersionID]
+			if !ok {
+				pv, err := packages_model.GetVersionByID(ctx, doc.PackageVersionID)
+				if err != nil {
+					return nil, 0, err
+				}
+				pd, err = packages_model.GetPackageDescriptor(ctx, pv)
+				if err != nil {
+					return nil, 0, err
+				}
+				pds[doc.PackageVersionID] = pd
+			}
+			m.PackageDescriptor = pd
+		} else {
+			repo, ok := repos[doc.RepoID]
+			if !ok {
+				repo, err = repo_model.GetRepositoryByID(ctx, doc.RepoID)
+				if err != nil {
+					return nil, 0, err
+				}
+				repos[doc.RepoID] = repo
+			}
+			m.Repository = repo
+		}
+		matches = append(matches, m)
+	}
+	return matches, count, nil
+}
@@ -6,6 +6,7 @@ package secrets
 import (
 	"context"
 
+	actions_model "code.gitea.io/gitea/models/actions"
 	"code.gitea.io/gitea/models/db"
 	secret_model "code.gitea.io/gitea/models/secret"
 )
@@ -77,3 +78,50 @@ func deleteSecret(ctx context.Context, s *secret_model.Secret) error {
 	}
 	return nil
 }
+
+// CreateOrUpdateEnvironmentSecret creates or updates a secret of the environment
+func CreateOrUpdateEnvironmentSecret(ctx context.Context, env *actions_model.ActionEnvironment, name, data, description string) (*secret_model.Secret, bool, error) {
+	if err := ValidateName(name); err != nil {
+		return nil, false, err
+	}
+
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          name,
+	})
+	if err != nil {
+		return nil, false, err
+	}
+
+	if len(s) == 0 {
+		s, err := secret_model.InsertEncryptedEnvironmentSecret(ctx, env, name, data, description)
+		if err != nil {
+			return nil, false, err
+		}
+		return s, true, nil
+	}
+
+	if err := secret_model.UpdateSecret(ctx, s[0].ID, data, description); err != nil {
+		return nil, false, err
+	}
+
+	return s[0], false, nil
+}
+
+// DeleteEnvironmentSecretByName deletes a secret of the environment
+func DeleteEnvironmentSecretByName(ctx context.Context, env *actions_model.ActionEnvironment, name string) error {
+	s, err := db.Find[secret_model.Secret](ctx, secret_model.FindSecretsOptions{
+		RepoID:        env.RepoID,
+		EnvironmentID: env.ID,
+		Name:          name,
+	})
+	if err != nil {
+		return err
+	}
+	if len(s) != 1 {
+		return secret_model.ErrSecretNotFound{}
+	}
+
+	return deleteSecret(ctx, s[0])
+}
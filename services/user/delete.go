@@ -18,6 +18,7 @@ import (
 	git_model "code.gitea.io/gitea/models/git"
 	issues_model "code.gitea.io/gitea/models/issues"
 	"code.gitea.io/gitea/models/organization"
+	packages_model "code.gitea.io/gitea/models/packages"
 	access_model "code.gitea.io/gitea/models/perm/access"
 	pull_model "code.gitea.io/gitea/models/pull"
 	repo_model "code.gitea.io/gitea/models/repo"
@@ -95,6 +96,8 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		&user_model.Blocking{BlockerID: u.ID},
 		&user_model.Blocking{BlockeeID: u.ID},
 		&actions_model.ActionRunnerToken{OwnerID: u.ID},
+		&actions_model.ActionRunnerGroup{OwnerID: u.ID},
+		&actions_model.ActionUsageQuota{OwnerID: u.ID},
 	); err != nil {
 		return fmt.Errorf("deleteBeans: %w", err)
 	}
@@ -103,6 +106,14 @@ func deleteUser(ctx context.Context, u *user_model.User, purge bool) (err error)
 		return err
 	}
 
+	if err := packages_model.DeleteRemotesByOwner(ctx, u.ID); err != nil {
+		return err
+	}
+
+	if err := packages_model.DeleteVirtualMembersByOwner(ctx, u.ID); err != nil {
+		return err
+	}
+
 	if purge || (setting.Service.UserDeleteWithCommentsMaxTime != 0 &&
 		u.CreatedUnix.AsTime().Add(setting.Service.UserDeleteWithCommentsMaxTime).After(time.Now())) {
 		// Delete Comments
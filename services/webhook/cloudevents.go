@@ -0,0 +1,246 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"bytes"
+	"cmp"
+	"context"
+	"fmt"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// The content modes of CloudEvents over HTTP, see https://github.com/cloudevents/spec/blob/v1.0.2/cloudevents/bindings/http-protocol-binding.md
+const (
+	// CloudEventsModeStructured sends each event in a request whose body is the JSON encoded event including its attributes
+	CloudEventsModeStructured = "structured"
+	// CloudEventsModeBinary sends each event in a request whose body is the Gitea payload and whose headers are the event attributes
+	CloudEventsModeBinary = "binary"
+	// CloudEventsModeBatched sends the pending events of the webhook together in a request whose body is a JSON array of events
+	CloudEventsModeBatched = "batched"
+
+	// CloudEventsDefaultBatchSize is the maximum number of events sent in one batched request if none is configured
+	CloudEventsDefaultBatchSize = 10
+	// CloudEventsMaxBatchSize is the upper limit of the configurable batch size
+	CloudEventsMaxBatchSize = 100
+
+	cloudEventsSpecVersion     = "1.0"
+	cloudEventsTypePrefix      = "io.gitea."
+	cloudEventsConfigMode      = "content_mode"
+	cloudEventsConfigBatchSize = "batch_size"
+)
+
+// CloudEventsMeta contains the metadata for the CloudEvents webhook
+type CloudEventsMeta struct {
+	ContentMode string `json:"content_mode"`
+	BatchSize   int    `json:"batch_size,omitempty"`
+}
+
+// GetCloudEventsHook returns CloudEvents metadata
+func GetCloudEventsHook(w *webhook_model.Webhook) *CloudEventsMeta {
+	s := &CloudEventsMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetCloudEventsHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// IsValidCloudEventsMode returns whether the content mode is supported
+func IsValidCloudEventsMode(mode string) bool {
+	return mode == CloudEventsModeStructured || mode == CloudEventsModeBinary || mode == CloudEventsModeBatched
+}
+
+// Validate checks the content mode and the batch size
+func (m *CloudEventsMeta) Validate() error {
+	if !IsValidCloudEventsMode(m.ContentMode) {
+		return util.NewInvalidArgumentErrorf("invalid content mode %q, expected %s, %s or %s", m.ContentMode, CloudEventsModeStructured, CloudEventsModeBinary, CloudEventsModeBatched)
+	}
+	if m.BatchSize < 0 || m.BatchSize > CloudEventsMaxBatchSize {
+		return util.NewInvalidArgumentErrorf("invalid batch size %d, expected a value between 1 and %d", m.BatchSize, CloudEventsMaxBatchSize)
+	}
+	return nil
+}
+
+// ApplyConfig updates the metadata from the API hook config
+func (m *CloudEventsMeta) ApplyConfig(config map[string]string) error {
+	if mode, ok := config[cloudEventsConfigMode]; ok {
+		m.ContentMode = strings.TrimSpace(mode)
+	}
+	if m.ContentMode == "" {
+		m.ContentMode = CloudEventsModeStructured
+	}
+	if size, ok := config[cloudEventsConfigBatchSize]; ok {
+		if size = strings.TrimSpace(size); size == "" {
+			m.BatchSize = 0
+		} else {
+			n, err := strconv.Atoi(size)
+			if err != nil {
+				return util.NewInvalidArgumentErrorf("invalid batch size %q", size)
+			}
+			m.BatchSize = n
+		}
+	}
+	return m.Validate()
+}
+
+// ToConfig adds the metadata to the API hook config
+func (m *CloudEventsMeta) ToConfig(config map[string]string) {
+	config[cloudEventsConfigMode] = m.ContentMode
+	if m.ContentMode == CloudEventsModeBatched {
+		config[cloudEventsConfigBatchSize] = strconv.Itoa(m.maxBatchSize())
+	}
+}
+
+// maxBatchSize returns the maximum number of events sent in one request
+func (m *CloudEventsMeta) maxBatchSize() int {
+	if m.ContentMode != CloudEventsModeBatched {
+		return 1
+	}
+	if m.BatchSize <= 0 {
+		return CloudEventsDefaultBatchSize
+	}
+	return m.BatchSize
+}
+
+// CloudEvent is a CloudEvents 1.0 event in the JSON event format
+type CloudEvent struct {
+	SpecVersion     string     `json:"specversion"`
+	ID              string     `json:"id"`
+	Source          string     `json:"source"`
+	Type            string     `json:"type"`
+	DataContentType string     `json:"datacontenttype"`
+	Data            json.Value `json:"data"`
+}
+
+// cloudEventsPayload contains the fields of the Gitea payloads the event attributes are derived from
+type cloudEventsPayload struct {
+	Action     string `json:"action"`
+	Repository *struct {
+		HTMLURL string `json:"html_url"`
+	} `json:"repository"`
+}
+
+// newCloudEvent wraps the payload of the hook task in an event. The event type is derived from the event type
+// of the task and the action of the payload, e.g. "io.gitea.pull_request.opened", the id is the UUID of the task,
+// which is kept by the retries, and the source is the URL of the repository.
+func newCloudEvent(t *webhook_model.HookTask) (*CloudEvent, error) {
+	var p cloudEventsPayload
+	if err := json.Unmarshal([]byte(t.PayloadContent), &p); err != nil {
+		return nil, fmt.Errorf("newCloudEvent payload json: %w", err)
+	}
+
+	eventType := cloudEventsTypePrefix + string(t.EventType)
+	if p.Action != "" {
+		eventType += "." + p.Action
+	}
+	source := setting.AppURL
+	if p.Repository != nil && p.Repository.HTMLURL != "" {
+		source = p.Repository.HTMLURL
+	}
+
+	return &CloudEvent{
+		SpecVersion:     cloudEventsSpecVersion,
+		ID:              t.UUID,
+		Source:          source,
+		Type:            eventType,
+		DataContentType: "application/json",
+		Data:            json.Value(t.PayloadContent),
+	}, nil
+}
+
+func newCloudEventsHTTPRequest(w *webhook_model.Webhook, contentType string, body []byte) (*http.Request, error) {
+	method := w.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	return req, nil
+}
+
+func newCloudEventsRequest(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &CloudEventsMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newCloudEventsRequest meta json: %w", err)
+	}
+	if meta.ContentMode == CloudEventsModeBatched {
+		return newCloudEventsBatchRequest(ctx, w, []*webhook_model.HookTask{t})
+	}
+
+	event, err := newCloudEvent(t)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var (
+		req  *http.Request
+		body []byte
+	)
+	if meta.ContentMode == CloudEventsModeBinary {
+		body = event.Data
+		if req, err = newCloudEventsHTTPRequest(w, event.DataContentType, body); err != nil {
+			return nil, nil, err
+		}
+		req.Header.Set("ce-specversion", event.SpecVersion)
+		req.Header.Set("ce-id", event.ID)
+		req.Header.Set("ce-source", event.Source)
+		req.Header.Set("ce-type", event.Type)
+	} else {
+		if body, err = json.MarshalIndent(event, "", "  "); err != nil {
+			return nil, nil, err
+		}
+		if req, err = newCloudEventsHTTPRequest(w, "application/cloudevents+json", body); err != nil {
+			return nil, nil, err
+		}
+	}
+	return req, body, addDefaultHeaders(req, []byte(w.Secret), w, t, body)
+}
+
+// newCloudEventsBatchRequest creates a request delivering the hook tasks as a batch of events, the first task
+// is the one the delivery headers refer to
+func newCloudEventsBatchRequest(_ context.Context, w *webhook_model.Webhook, tasks []*webhook_model.HookTask) (*http.Request, []byte, error) {
+	// the receivers get the events in the order they happened
+	sorted := slices.SortedFunc(slices.Values(tasks), func(a, b *webhook_model.HookTask) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+	events := make([]*CloudEvent, 0, len(sorted))
+	for _, t := range sorted {
+		event, err := newCloudEvent(t)
+		if err != nil {
+			return nil, nil, err
+		}
+		events = append(events, event)
+	}
+
+	body, err := json.MarshalIndent(events, "", "  ")
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := newCloudEventsHTTPRequest(w, "application/cloudevents-batch+json", body)
+	if err != nil {
+		return nil, nil, err
+	}
+	return req, body, addDefaultHeaders(req, []byte(w.Secret), w, tasks[0], body)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.CLOUDEVENTS, newCloudEventsRequest)
+	RegisterWebhookBatchRequester(webhook_module.CLOUDEVENTS, BatchRequester{
+		MaxBatchSize: func(w *webhook_model.Webhook) int { return GetCloudEventsHook(w).maxBatchSize() },
+		NewRequest:   newCloudEventsBatchRequest,
+	})
+}
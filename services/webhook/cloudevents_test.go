@@ -0,0 +1,165 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"code.gitea.io/gitea/models/unittest"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCloudEventsMetaApplyConfig(t *testing.T) {
+	meta := &CloudEventsMeta{}
+	require.NoError(t, meta.ApplyConfig(map[string]string{}))
+	assert.Equal(t, CloudEventsModeStructured, meta.ContentMode)
+	assert.Equal(t, 1, meta.maxBatchSize())
+
+	require.NoError(t, meta.ApplyConfig(map[string]string{"content_mode": "batched"}))
+	assert.Equal(t, CloudEventsDefaultBatchSize, meta.maxBatchSize())
+
+	require.NoError(t, meta.ApplyConfig(map[string]string{"batch_size": "25"}))
+	assert.Equal(t, &CloudEventsMeta{ContentMode: CloudEventsModeBatched, BatchSize: 25}, meta)
+
+	config := map[string]string{}
+	meta.ToConfig(config)
+	assert.Equal(t, map[string]string{"content_mode": "batched", "batch_size": "25"}, config)
+
+	assert.Error(t, (&CloudEventsMeta{}).ApplyConfig(map[string]string{"content_mode": "xml"}))
+	assert.Error(t, (&CloudEventsMeta{}).ApplyConfig(map[string]string{"batch_size": "many"}))
+	assert.Error(t, (&CloudEventsMeta{}).ApplyConfig(map[string]string{"batch_size": "1000"}))
+}
+
+func TestCloudEventsRequest(t *testing.T) {
+	p := pullRequestTestPayload()
+	p.Action = api.HookIssueOpened
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	task := &webhook_model.HookTask{
+		UUID:           "a7bc1e2f-4d45-4c6e-9c1a-0b2f7f1e3d54",
+		EventType:      webhook_module.HookEventPullRequest,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+	newHook := func(mode string) *webhook_model.Webhook {
+		return &webhook_model.Webhook{
+			RepoID: 3,
+			Type:   webhook_module.CLOUDEVENTS,
+			URL:    "https://example.com/events",
+			Meta:   `{"content_mode":"` + mode + `"}`,
+		}
+	}
+
+	t.Run("Structured", func(t *testing.T) {
+		req, body, err := newCloudEventsRequest(t.Context(), newHook(CloudEventsModeStructured), task)
+		require.NoError(t, err)
+		assert.Equal(t, "application/cloudevents+json", req.Header.Get("Content-Type"))
+		assert.Empty(t, req.Header.Get("ce-id"))
+		assert.Equal(t, task.UUID, req.Header.Get("X-Gitea-Delivery"))
+
+		var event CloudEvent
+		require.NoError(t, json.Unmarshal(body, &event))
+		assert.Equal(t, "1.0", event.SpecVersion)
+		assert.Equal(t, task.UUID, event.ID)
+		assert.Equal(t, "http://localhost:3000/test/repo", event.Source)
+		assert.Equal(t, "io.gitea.pull_request.opened", event.Type)
+		assert.Equal(t, "application/json", event.DataContentType)
+		assert.JSONEq(t, string(data), string(event.Data))
+	})
+
+	t.Run("Binary", func(t *testing.T) {
+		req, body, err := newCloudEventsRequest(t.Context(), newHook(CloudEventsModeBinary), task)
+		require.NoError(t, err)
+		assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+		assert.Equal(t, "1.0", req.Header.Get("ce-specversion"))
+		assert.Equal(t, task.UUID, req.Header.Get("ce-id"))
+		assert.Equal(t, "http://localhost:3000/test/repo", req.Header.Get("ce-source"))
+		assert.Equal(t, "io.gitea.pull_request.opened", req.Header.Get("ce-type"))
+		assert.Equal(t, string(data), string(body))
+	})
+
+	t.Run("Batched", func(t *testing.T) {
+		req, body, err := newCloudEventsRequest(t.Context(), newHook(CloudEventsModeBatched), task)
+		require.NoError(t, err)
+		assert.Equal(t, "application/cloudevents-batch+json", req.Header.Get("Content-Type"))
+
+		var events []*CloudEvent
+		require.NoError(t, json.Unmarshal(body, &events))
+		require.Len(t, events, 1)
+		assert.Equal(t, task.UUID, events[0].ID)
+	})
+}
+
+func TestWebhookDeliverCloudEventsBatch(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	requests := make(chan []byte, 10)
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "application/cloudevents-batch+json", r.Header.Get("Content-Type"))
+		body, _ := io.ReadAll(r.Body)
+		requests <- body
+		w.WriteHeader(http.StatusAccepted)
+	}))
+	t.Cleanup(s.Close)
+
+	hook := &webhook_model.Webhook{
+		RepoID:      3,
+		URL:         s.URL + "/events",
+		ContentType: webhook_model.ContentTypeJSON,
+		IsActive:    true,
+		Type:        webhook_module.CLOUDEVENTS,
+		Meta:        `{"content_mode":"batched","batch_size":2}`,
+	}
+	require.NoError(t, webhook_model.CreateWebhook(t.Context(), hook))
+
+	data, err := pushTestPayload().JSONPayload()
+	require.NoError(t, err)
+	tasks := make([]*webhook_model.HookTask, 3)
+	for i := range tasks {
+		tasks[i], err = webhook_model.CreateHookTask(t.Context(), &webhook_model.HookTask{
+			HookID:         hook.ID,
+			EventType:      webhook_module.HookEventPush,
+			PayloadContent: string(data),
+			PayloadVersion: 2,
+		})
+		require.NoError(t, err)
+	}
+
+	// the first delivery sends the oldest pending task with the task being delivered
+	require.NoError(t, Deliver(t.Context(), tasks[1]))
+	var events []*CloudEvent
+	require.NoError(t, json.Unmarshal(<-requests, &events))
+	require.Len(t, events, 2)
+	assert.Equal(t, tasks[0].UUID, events[0].ID)
+	assert.Equal(t, tasks[1].UUID, events[1].ID)
+	assert.Equal(t, "io.gitea.push", events[0].Type)
+
+	for _, task := range tasks[:2] {
+		task, err := webhook_model.GetHookTaskByID(t.Context(), task.ID)
+		require.NoError(t, err)
+		assert.True(t, task.IsDelivered)
+		assert.True(t, task.IsSucceed)
+		assert.Equal(t, 1, task.Attempts)
+		assert.Equal(t, http.StatusAccepted, task.ResponseInfo.Status)
+	}
+
+	// the batched task has been delivered already
+	require.NoError(t, Deliver(t.Context(), tasks[0]))
+	assert.Empty(t, requests)
+
+	require.NoError(t, Deliver(t.Context(), tasks[2]))
+	require.NoError(t, json.Unmarshal(<-requests, &events))
+	require.Len(t, events, 1)
+	assert.Equal(t, tasks[2].UUID, events[0].ID)
+}
@@ -0,0 +1,558 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"reflect"
+	"slices"
+	"strings"
+	"text/template"
+	"text/template/parse"
+	"time"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"golang.org/x/net/http/httpguts"
+)
+
+const (
+	// CustomDefaultContentType is the content type of a custom webhook request if no template is configured
+	CustomDefaultContentType = "application/json"
+
+	customTemplateMaxSize      = 64 * 1024
+	customOutputMaxSize        = 1024 * 1024
+	customRangeMaxIterations   = 10000
+	customConfigBodyTemplate   = "body_template"
+	customConfigContentType    = "body_content_type"
+	customConfigHeaderPrefix   = "header."
+	customConfigTemplatePrefix = "body_template."
+)
+
+// CustomMeta contains the templates of a custom webhook
+type CustomMeta struct {
+	BodyTemplate    string            `json:"body_template"`
+	ContentType     string            `json:"content_type,omitempty"`
+	HeaderTemplates map[string]string `json:"header_templates,omitempty"`
+	// EventTemplates overrides the body template for an event type (e.g. "pull_request_sync") or an event group (e.g. "pull_request")
+	EventTemplates map[string]string `json:"event_templates,omitempty"`
+}
+
+// GetCustomHook returns custom metadata
+func GetCustomHook(w *webhook_model.Webhook) *CustomMeta {
+	s := &CustomMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetCustomHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// bodyTemplate returns the template used to render the body of the event
+func (m *CustomMeta) bodyTemplate(event webhook_module.HookEventType) string {
+	if tmpl, ok := m.EventTemplates[string(event)]; ok {
+		return tmpl
+	}
+	if tmpl, ok := m.EventTemplates[event.Event()]; ok {
+		return tmpl
+	}
+	return m.BodyTemplate
+}
+
+func isValidCustomEventName(name string) bool {
+	for _, event := range webhook_module.AllEvents() {
+		if name == string(event) || name == event.Event() {
+			return true
+		}
+	}
+	return false
+}
+
+func isReservedCustomHeader(name string) bool {
+	name = strings.ToLower(name)
+	switch name {
+	case "host", "content-length", "content-type", "authorization":
+		return true
+	}
+	for _, prefix := range []string{"x-gitea-", "x-gogs-", "x-hub-", "x-github-"} {
+		if strings.HasPrefix(name, prefix) {
+			return true
+		}
+	}
+	return false
+}
+
+// Validate checks that all templates of the webhook are valid
+func (m *CustomMeta) Validate() error {
+	if strings.TrimSpace(m.BodyTemplate) == "" {
+		return util.NewInvalidArgumentErrorf("body template is required")
+	}
+	if _, err := parseCustomTemplate("body", m.BodyTemplate); err != nil {
+		return err
+	}
+	if _, err := parseCustomTemplate("content type", m.ContentType); err != nil {
+		return err
+	}
+	for name, tmpl := range m.HeaderTemplates {
+		if !httpguts.ValidHeaderFieldName(name) {
+			return util.NewInvalidArgumentErrorf("invalid header name %q", name)
+		}
+		if isReservedCustomHeader(name) {
+			return util.NewInvalidArgumentErrorf("header %q can't be set by a template", name)
+		}
+		if _, err := parseCustomTemplate("header "+name, tmpl); err != nil {
+			return err
+		}
+	}
+	for event, tmpl := range m.EventTemplates {
+		if !isValidCustomEventName(event) {
+			return util.NewInvalidArgumentErrorf("invalid event %q", event)
+		}
+		if _, err := parseCustomTemplate("body "+event, tmpl); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// ApplyConfig updates the templates from the API hook config. Header and event template keys with an empty value are removed.
+func (m *CustomMeta) ApplyConfig(config map[string]string) {
+	for key, value := range config {
+		switch {
+		case key == customConfigBodyTemplate:
+			m.BodyTemplate = value
+		case key == customConfigContentType:
+			m.ContentType = value
+		case strings.HasPrefix(key, customConfigHeaderPrefix):
+			m.HeaderTemplates = applyCustomConfigValue(m.HeaderTemplates, strings.TrimPrefix(key, customConfigHeaderPrefix), value)
+		case strings.HasPrefix(key, customConfigTemplatePrefix):
+			m.EventTemplates = applyCustomConfigValue(m.EventTemplates, strings.TrimPrefix(key, customConfigTemplatePrefix), value)
+		}
+	}
+}
+
+func applyCustomConfigValue(values map[string]string, key, value string) map[string]string {
+	if value == "" {
+		delete(values, key)
+		return values
+	}
+	if values == nil {
+		values = make(map[string]string)
+	}
+	values[key] = value
+	return values
+}
+
+// ToConfig adds the templates to the API hook config
+func (m *CustomMeta) ToConfig(config map[string]string) {
+	config[customConfigBodyTemplate] = m.BodyTemplate
+	if m.ContentType != "" {
+		config[customConfigContentType] = m.ContentType
+	}
+	for name, value := range m.HeaderTemplates {
+		config[customConfigHeaderPrefix+name] = value
+	}
+	for event, value := range m.EventTemplates {
+		config[customConfigTemplatePrefix+event] = value
+	}
+}
+
+// ParseCustomHeaderLines parses header templates given as one "Name: template" per line
+func ParseCustomHeaderLines(text string) (map[string]string, error) {
+	headers := make(map[string]string)
+	for line := range strings.SplitSeq(text, "\n") {
+		line = strings.TrimSpace(line)
+		if line == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		if !ok {
+			return nil, util.NewInvalidArgumentErrorf("invalid header line %q, expected \"Name: template\"", line)
+		}
+		headers[strings.TrimSpace(name)] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// HeaderLines returns the header templates as one "Name: template" per line, ordered by name
+func (m *CustomMeta) HeaderLines() string {
+	lines := make([]string, 0, len(m.HeaderTemplates))
+	for name, value := range m.HeaderTemplates {
+		lines = append(lines, name+": "+value)
+	}
+	slices.Sort(lines)
+	return strings.Join(lines, "\n")
+}
+
+// customRenderBudget is the number of bytes which the functions of a template can still produce during a render.
+// The output is limited when it's written, but nested calls like replace or join could build large strings
+// without writing anything, so every function which creates a string draws from the budget of the render.
+type customRenderBudget int
+
+func (b *customRenderBudget) draw(n int) error {
+	if n < 0 || n > int(*b) {
+		*b = 0
+		return fmt.Errorf("function results exceed the maximum size of %d bytes", customOutputMaxSize)
+	}
+	*b -= customRenderBudget(n)
+	return nil
+}
+
+// drawString draws the size of a string which has been created
+func (b *customRenderBudget) drawString(s string) (string, error) {
+	if err := b.draw(len(s)); err != nil {
+		return "", err
+	}
+	return s, nil
+}
+
+// customFormatPadding returns the sum of the widths and precisions in the printf format, they could pad the result to any size.
+// The widths and precisions from the arguments are rejected, their sizes are unknown before formatting.
+func customFormatPadding(format string) (int, error) {
+	padding := 0
+	for i := 0; i < len(format); i++ {
+		if format[i] != '%' {
+			continue
+		}
+	verb:
+		for i++; i < len(format); i++ {
+			switch c := format[i]; {
+			case c == '*':
+				return 0, errors.New("printf: widths and precisions from the arguments are not allowed")
+			case c >= '0' && c <= '9':
+				n := 0
+				for ; i < len(format) && format[i] >= '0' && format[i] <= '9'; i++ {
+					if n = n*10 + int(format[i]-'0'); n > customOutputMaxSize {
+						return 0, fmt.Errorf("function results exceed the maximum size of %d bytes", customOutputMaxSize)
+					}
+				}
+				padding += n
+				i--
+			case strings.IndexByte("+-# .[]", c) >= 0:
+				// flags, precisions and argument indexes
+			default:
+				break verb
+			}
+		}
+	}
+	return padding, nil
+}
+
+// customTemplateFuncs returns the functions available in the templates. Templates run with the payload as data,
+// they must not be able to reach anything else and their runtime and memory must be bounded.
+func customTemplateFuncs(event webhook_module.HookEventType) template.FuncMap {
+	iterations := 0
+	budget := customRenderBudget(customOutputMaxSize)
+	return template.FuncMap{
+		// "call" could invoke arbitrary function values reachable from the payload
+		"call": func(...any) (any, error) {
+			return nil, errors.New("call is not allowed")
+		},
+		// rangeable is added to the pipeline of every range action by sandboxCustomTemplateNode
+		"rangeable": func(v any) (any, error) {
+			rv := reflect.ValueOf(v)
+			for rv.Kind() == reflect.Pointer || rv.Kind() == reflect.Interface {
+				if rv.IsNil() {
+					return v, nil
+				}
+				rv = rv.Elem()
+			}
+			switch rv.Kind() {
+			case reflect.Invalid:
+				return v, nil
+			case reflect.Array, reflect.Slice, reflect.Map:
+				iterations += rv.Len()
+				if iterations > customRangeMaxIterations {
+					return nil, fmt.Errorf("range exceeds the limit of %d iterations", customRangeMaxIterations)
+				}
+				return v, nil
+			}
+			return nil, fmt.Errorf("range over %s is not allowed", rv.Kind())
+		},
+		// the builtin functions which create strings are replaced to draw from the budget
+		"print": func(args ...any) (string, error) {
+			return budget.drawString(fmt.Sprint(args...))
+		},
+		"println": func(args ...any) (string, error) {
+			return budget.drawString(fmt.Sprintln(args...))
+		},
+		"printf": func(format string, args ...any) (string, error) {
+			padding, err := customFormatPadding(format)
+			if err != nil {
+				return "", err
+			}
+			if padding > int(budget) {
+				return "", budget.draw(padding)
+			}
+			return budget.drawString(fmt.Sprintf(format, args...))
+		},
+		"html": func(args ...any) (string, error) {
+			return budget.drawString(template.HTMLEscaper(args...))
+		},
+		"js": func(args ...any) (string, error) {
+			return budget.drawString(template.JSEscaper(args...))
+		},
+		"urlquery": func(args ...any) (string, error) {
+			return budget.drawString(template.URLQueryEscaper(args...))
+		},
+		"event": func() string {
+			return string(event)
+		},
+		"json": func(v any) (string, error) {
+			data, err := json.Marshal(v)
+			if err != nil {
+				return "", err
+			}
+			return budget.drawString(string(data))
+		},
+		"upper":      func(s string) (string, error) { return budget.drawString(strings.ToUpper(s)) },
+		"lower":      func(s string) (string, error) { return budget.drawString(strings.ToLower(s)) },
+		"trim":       strings.TrimSpace,
+		"trimPrefix": func(prefix, s string) string { return strings.TrimPrefix(s, prefix) },
+		"trimSuffix": func(suffix, s string) string { return strings.TrimSuffix(s, suffix) },
+		"replace": func(old, new, s string) (string, error) {
+			// check the size before replacing, the result could be much larger than the arguments
+			n := strings.Count(s, old)
+			if err := budget.draw(len(s) + n*(len(new)-len(old))); err != nil {
+				return "", err
+			}
+			return strings.ReplaceAll(s, old, new), nil
+		},
+		"contains":  func(substr, s string) bool { return strings.Contains(s, substr) },
+		"hasPrefix": func(prefix, s string) bool { return strings.HasPrefix(s, prefix) },
+		"hasSuffix": func(suffix, s string) bool { return strings.HasSuffix(s, suffix) },
+		"split": func(sep, s string) ([]string, error) {
+			// the parts share the memory of the string, but each of them has a header
+			if err := budget.draw(len(s) + strings.Count(s, sep)); err != nil {
+				return nil, err
+			}
+			return strings.Split(s, sep), nil
+		},
+		"join": func(sep string, s []string) (string, error) {
+			size := len(sep) * max(len(s)-1, 0)
+			for _, part := range s {
+				size += len(part)
+			}
+			if err := budget.draw(size); err != nil {
+				return "", err
+			}
+			return strings.Join(s, sep), nil
+		},
+		"firstLine": func(s string) string {
+			before, _, _ := strings.Cut(s, "\n")
+			return strings.TrimRight(before, "\r")
+		},
+		"truncate": func(length int, s string) string {
+			r := []rune(s)
+			if length < 0 || len(r) <= length {
+				return s
+			}
+			return string(r[:length])
+		},
+		"default": func(def, v any) any {
+			rv := reflect.ValueOf(v)
+			if !rv.IsValid() || rv.IsZero() {
+				return def
+			}
+			return v
+		},
+		"date": func(layout string, t time.Time) (string, error) {
+			return budget.drawString(t.Format(layout))
+		},
+	}
+}
+
+// parseCustomTemplate parses and sandboxes a template. Range actions are guarded, defining and invoking other templates is rejected.
+func parseCustomTemplate(name, text string) (*template.Template, error) {
+	if len(text) > customTemplateMaxSize {
+		return nil, util.NewInvalidArgumentErrorf("%s template exceeds the maximum size of %d bytes", name, customTemplateMaxSize)
+	}
+	tmpl, err := template.New(name).Option("missingkey=zero").Funcs(customTemplateFuncs("")).Parse(text)
+	if err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid %s template: %v", name, err)
+	}
+	if len(tmpl.Templates()) > 1 {
+		return nil, util.NewInvalidArgumentErrorf("invalid %s template: defining templates is not allowed", name)
+	}
+	if err := sandboxCustomTemplateNode(tmpl.Tree, tmpl.Tree.Root); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid %s template: %v", name, err)
+	}
+	return tmpl, nil
+}
+
+func sandboxCustomTemplateNode(tree *parse.Tree, node parse.Node) error {
+	switch n := node.(type) {
+	case *parse.ListNode:
+		if n == nil {
+			return nil
+		}
+		for _, child := range n.Nodes {
+			if err := sandboxCustomTemplateNode(tree, child); err != nil {
+				return err
+			}
+		}
+	case *parse.IfNode:
+		return sandboxCustomTemplateBranch(tree, &n.BranchNode)
+	case *parse.WithNode:
+		return sandboxCustomTemplateBranch(tree, &n.BranchNode)
+	case *parse.RangeNode:
+		n.Pipe.Cmds = append(n.Pipe.Cmds, &parse.CommandNode{
+			NodeType: parse.NodeCommand,
+			Pos:      n.Pos,
+			Args:     []parse.Node{parse.NewIdentifier("rangeable").SetTree(tree).SetPos(n.Pos)},
+		})
+		return sandboxCustomTemplateBranch(tree, &n.BranchNode)
+	case *parse.TemplateNode:
+		return errors.New("invoking templates is not allowed")
+	}
+	return nil
+}
+
+func sandboxCustomTemplateBranch(tree *parse.Tree, n *parse.BranchNode) error {
+	if err := sandboxCustomTemplateNode(tree, n.List); err != nil {
+		return err
+	}
+	return sandboxCustomTemplateNode(tree, n.ElseList)
+}
+
+type customLimitedWriter struct {
+	sb strings.Builder
+}
+
+func (w *customLimitedWriter) Write(p []byte) (int, error) {
+	if w.sb.Len()+len(p) > customOutputMaxSize {
+		return 0, fmt.Errorf("output exceeds the maximum size of %d bytes", customOutputMaxSize)
+	}
+	return w.sb.Write(p)
+}
+
+var _ io.Writer = (*customLimitedWriter)(nil)
+
+// renderCustomTemplate renders the template with the payload of the event
+func renderCustomTemplate(name, text string, event webhook_module.HookEventType, payload api.Payloader) (string, error) {
+	tmpl, err := parseCustomTemplate(name, text)
+	if err != nil {
+		return "", err
+	}
+	w := &customLimitedWriter{}
+	if err := tmpl.Funcs(customTemplateFuncs(event)).Execute(w, payload); err != nil {
+		return "", util.NewInvalidArgumentErrorf("failed to render %s template: %v", name, err)
+	}
+	return w.sb.String(), nil
+}
+
+// customConvertor passes the payloads to the templates unchanged
+type customConvertor struct{}
+
+var _ payloadConvertor[api.Payloader] = customConvertor{}
+
+// Create implements PayloadConvertor Create method
+func (customConvertor) Create(p *api.CreatePayload) (api.Payloader, error) { return p, nil }
+
+// Delete implements PayloadConvertor Delete method
+func (customConvertor) Delete(p *api.DeletePayload) (api.Payloader, error) { return p, nil }
+
+// Fork implements PayloadConvertor Fork method
+func (customConvertor) Fork(p *api.ForkPayload) (api.Payloader, error) { return p, nil }
+
+// Issue implements PayloadConvertor Issue method
+func (customConvertor) Issue(p *api.IssuePayload) (api.Payloader, error) { return p, nil }
+
+// IssueComment implements PayloadConvertor IssueComment method
+func (customConvertor) IssueComment(p *api.IssueCommentPayload) (api.Payloader, error) { return p, nil }
+
+// Push implements PayloadConvertor Push method
+func (customConvertor) Push(p *api.PushPayload) (api.Payloader, error) { return p, nil }
+
+// PullRequest implements PayloadConvertor PullRequest method
+func (customConvertor) PullRequest(p *api.PullRequestPayload) (api.Payloader, error) { return p, nil }
+
+// Review implements PayloadConvertor Review method
+func (customConvertor) Review(p *api.PullRequestPayload, _ webhook_module.HookEventType) (api.Payloader, error) {
+	return p, nil
+}
+
+// Repository implements PayloadConvertor Repository method
+func (customConvertor) Repository(p *api.RepositoryPayload) (api.Payloader, error) { return p, nil }
+
+// Release implements PayloadConvertor Release method
+func (customConvertor) Release(p *api.ReleasePayload) (api.Payloader, error) { return p, nil }
+
+// Wiki implements PayloadConvertor Wiki method
+func (customConvertor) Wiki(p *api.WikiPayload) (api.Payloader, error) { return p, nil }
+
+func (customConvertor) Package(p *api.PackagePayload) (api.Payloader, error) { return p, nil }
+
+func (customConvertor) Status(p *api.CommitStatusPayload) (api.Payloader, error) { return p, nil }
+
+func (customConvertor) WorkflowRun(p *api.WorkflowRunPayload) (api.Payloader, error) { return p, nil }
+
+func (customConvertor) WorkflowJob(p *api.WorkflowJobPayload) (api.Payloader, error) { return p, nil }
+
+func newCustomRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &CustomMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newCustomRequest meta json: %w", err)
+	}
+
+	payload, err := newPayload[api.Payloader](customConvertor{}, []byte(t.PayloadContent), t.EventType)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	body, err := renderCustomTemplate("body", meta.bodyTemplate(t.EventType), t.EventType, payload)
+	if err != nil {
+		return nil, nil, err
+	}
+	contentType := CustomDefaultContentType
+	if meta.ContentType != "" {
+		if contentType, err = renderCustomHeader("content type", meta.ContentType, t.EventType, payload); err != nil {
+			return nil, nil, err
+		}
+	}
+	headers := make(map[string]string, len(meta.HeaderTemplates))
+	for name, tmpl := range meta.HeaderTemplates {
+		if headers[name], err = renderCustomHeader("header "+name, tmpl, t.EventType, payload); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	method := w.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, w.URL, strings.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", contentType)
+	for name, value := range headers {
+		req.Header.Set(name, value)
+	}
+	return req, []byte(body), addDefaultHeaders(req, []byte(w.Secret), w, t, []byte(body))
+}
+
+func renderCustomHeader(name, text string, event webhook_module.HookEventType, payload api.Payloader) (string, error) {
+	value, err := renderCustomTemplate(name, text, event, payload)
+	if err != nil {
+		return "", err
+	}
+	value = strings.TrimSpace(value)
+	if !httpguts.ValidHeaderFieldValue(value) {
+		return "", util.NewInvalidArgumentErrorf("%s template rendered an invalid header value", name)
+	}
+	return value, nil
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.CUSTOM, newCustomRequest)
+}
@@ -0,0 +1,199 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"io"
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCustomPayload(t *testing.T) {
+	p := pushTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	meta := &CustomMeta{
+		BodyTemplate: `{"text": {{json (printf "%s pushed %d commits to %s" .Pusher.UserName .TotalCommits .Repo.FullName)}}, "commits": [{{range $i, $c := .Commits}}{{if $i}},{{end}}{{json (firstLine $c.Message)}}{{end}}]}`,
+		ContentType:  `application/vnd.{{event}}+json`,
+		HeaderTemplates: map[string]string{
+			"X-Ref": `{{trimPrefix "refs/heads/" .Ref}}`,
+		},
+	}
+	require.NoError(t, meta.Validate())
+	metaJSON, err := json.Marshal(meta)
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.CUSTOM,
+		URL:        "https://example.com/hook",
+		Meta:       string(metaJSON),
+		HTTPMethod: "PUT",
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newCustomRequest(t.Context(), hook, task)
+	require.NoError(t, err)
+	require.NotNil(t, req)
+
+	assert.Equal(t, "PUT", req.Method)
+	assert.Equal(t, "https://example.com/hook", req.URL.String())
+	assert.Equal(t, "application/vnd.push+json", req.Header.Get("Content-Type"))
+	assert.Equal(t, "test", req.Header.Get("X-Ref"))
+	assert.Equal(t, "push", req.Header.Get("X-Gitea-Event"))
+	assert.Equal(t, "sha256=", req.Header.Get("X-Hub-Signature-256"))
+	assert.JSONEq(t, `{"text": "user1 pushed 2 commits to test/repo", "commits": ["commit message", "commit message"]}`, string(reqBody))
+
+	body, err := io.ReadAll(req.Body)
+	require.NoError(t, err)
+	assert.Equal(t, reqBody, body)
+
+	t.Run("EventTemplate", func(t *testing.T) {
+		meta := &CustomMeta{
+			BodyTemplate: "default",
+			EventTemplates: map[string]string{
+				"issues":            "issue group {{.Issue.Title}}",
+				"issue_comment":     "comment",
+				"pull_request_sync": "sync",
+			},
+		}
+		require.NoError(t, meta.Validate())
+
+		assert.Equal(t, "issue group {{.Issue.Title}}", meta.bodyTemplate(webhook_module.HookEventIssueLabel))
+		assert.Equal(t, "comment", meta.bodyTemplate(webhook_module.HookEventPullRequestComment))
+		assert.Equal(t, "sync", meta.bodyTemplate(webhook_module.HookEventPullRequestSync))
+		assert.Equal(t, "default", meta.bodyTemplate(webhook_module.HookEventPullRequest))
+		assert.Equal(t, "default", meta.bodyTemplate(webhook_module.HookEventPush))
+
+		out, err := renderCustomTemplate("body", meta.bodyTemplate(webhook_module.HookEventIssues), webhook_module.HookEventIssues, issueTestPayload())
+		require.NoError(t, err)
+		assert.Equal(t, "issue group crash", out)
+	})
+
+	t.Run("Functions", func(t *testing.T) {
+		cases := map[string]string{
+			`{{upper "a"}}{{lower "B"}}{{trim " c "}}`:               "abc",
+			`{{"a-b-c" | replace "-" "+"}}`:                          "a+b+c",
+			`{{join "," (split "/" "a/b")}}`:                         "a,b",
+			`{{truncate 3 "abcdef"}}`:                                "abc",
+			`{{default "none" ""}} {{default "none" "x"}}`:           "none x",
+			`{{hasPrefix "a" "abc"}} {{contains "z" "abc"}}`:         "true false",
+			`{{firstLine "title\r\nbody"}}|{{trimSuffix "c" "abc"}}`: "title|ab",
+			`{{event}}`: "push",
+		}
+		for tmpl, expected := range cases {
+			out, err := renderCustomTemplate("body", tmpl, webhook_module.HookEventPush, p)
+			require.NoError(t, err, tmpl)
+			assert.Equal(t, expected, out, tmpl)
+		}
+	})
+
+	t.Run("Sandbox", func(t *testing.T) {
+		invalid := []string{
+			`{{define "x"}}x{{end}}`,
+			`{{template "body"}}`,
+			`{{block "x" .}}x{{end}}`,
+			`{{.Unclosed`,
+		}
+		for _, tmpl := range invalid {
+			_, err := parseCustomTemplate("body", tmpl)
+			assert.Error(t, err, tmpl)
+		}
+
+		failing := []string{
+			`{{range 1000000000}}{{end}}`,
+			`{{$n := 1000000000}}{{range $n}}{{end}}`,
+			`{{with 1000000000}}{{range .}}{{end}}{{end}}`,
+			`{{range .TotalCommits}}{{end}}`,
+			`{{call .JSONPayload}}`,
+			`{{range .Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{range $.Commits}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}{{end}}`,
+		}
+		for _, tmpl := range failing {
+			_, err := renderCustomTemplate("body", tmpl, webhook_module.HookEventPush, p)
+			assert.Error(t, err, tmpl)
+		}
+
+		_, err := renderCustomTemplate("body", `{{range .Commits}}{{printf "%01000000d" 0}}{{end}}`, webhook_module.HookEventPush, p)
+		assert.ErrorContains(t, err, "maximum size")
+
+		// the intermediate strings are limited even if nothing is written
+		exceeding := []string{
+			`{{$s := "a"}}{{range split "," "1,2,3,4,5,6,7,8,9,10"}}{{$s = replace "a" "aaaaaaaa" $s}}{{end}}{{len $s}}`,
+			`{{"a" | replace "a" "aaaaaaaa" | replace "a" "aaaaaaaa" | replace "a" "aaaaaaaa" | replace "a" "aaaaaaaa" | replace "a" "aaaaaaaa" | replace "a" "aaaaaaaa" | replace "a" "aaaaaaaa" | replace "a" "aaaaaaaa" | len}}`,
+			`{{$s := printf "%0100000d" 0}}{{range split "," "1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20"}}{{$s = upper $s}}{{end}}`,
+			`{{$s := printf "%0100000d" 0}}{{range split "," "1,2,3,4,5,6,7,8,9,10,11,12,13,14,15,16,17,18,19,20"}}{{$s = join "" (split "" $s)}}{{end}}`,
+			`{{printf "%0*d" 100000000 0}}`,
+		}
+		for _, tmpl := range exceeding {
+			_, err := renderCustomTemplate("body", tmpl, webhook_module.HookEventPush, p)
+			assert.Error(t, err, tmpl)
+		}
+		_, err = renderCustomTemplate("body", `{{$s := "a"}}{{range split "," "1,2,3,4,5,6,7,8,9,10"}}{{$s = replace "a" "aaaaaaaa" $s}}{{end}}`, webhook_module.HookEventPush, p)
+		assert.ErrorContains(t, err, "maximum size")
+	})
+
+	t.Run("Validate", func(t *testing.T) {
+		assert.Error(t, (&CustomMeta{}).Validate())
+		assert.Error(t, (&CustomMeta{BodyTemplate: "x", HeaderTemplates: map[string]string{"Content-Length": "1"}}).Validate())
+		assert.Error(t, (&CustomMeta{BodyTemplate: "x", HeaderTemplates: map[string]string{"X-Gitea-Event": "x"}}).Validate())
+		assert.Error(t, (&CustomMeta{BodyTemplate: "x", HeaderTemplates: map[string]string{"Invalid Name": "x"}}).Validate())
+		assert.Error(t, (&CustomMeta{BodyTemplate: "x", EventTemplates: map[string]string{"unknown": "x"}}).Validate())
+	})
+
+	t.Run("Config", func(t *testing.T) {
+		meta := &CustomMeta{}
+		meta.ApplyConfig(map[string]string{
+			"url":                    "https://example.com",
+			"body_template":          "body",
+			"body_content_type":      "text/plain",
+			"header.X-Ref":           "{{.Ref}}",
+			"body_template.push":     "push",
+			"body_template.issues":   "issues",
+			"body_template.release":  "",
+			"header.X-Removed":       "",
+			"unrelated_config_value": "x",
+		})
+		assert.Equal(t, &CustomMeta{
+			BodyTemplate:    "body",
+			ContentType:     "text/plain",
+			HeaderTemplates: map[string]string{"X-Ref": "{{.Ref}}"},
+			EventTemplates:  map[string]string{"push": "push", "issues": "issues"},
+		}, meta)
+
+		meta.ApplyConfig(map[string]string{"body_template.issues": ""})
+		assert.Equal(t, map[string]string{"push": "push"}, meta.EventTemplates)
+
+		config := map[string]string{}
+		meta.ToConfig(config)
+		assert.Equal(t, map[string]string{
+			"body_template":      "body",
+			"body_content_type":  "text/plain",
+			"header.X-Ref":       "{{.Ref}}",
+			"body_template.push": "push",
+		}, config)
+	})
+
+	t.Run("HeaderLines", func(t *testing.T) {
+		headers, err := ParseCustomHeaderLines("X-B: {{event}}\n\n  X-A : a:b \n")
+		require.NoError(t, err)
+		assert.Equal(t, map[string]string{"X-A": "a:b", "X-B": "{{event}}"}, headers)
+		assert.Equal(t, "X-A: a:b\nX-B: {{event}}", (&CustomMeta{HeaderTemplates: headers}).HeaderLines())
+
+		_, err = ParseCustomHeaderLines("X-A")
+		assert.Error(t, err)
+	})
+}
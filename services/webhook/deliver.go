@@ -172,30 +172,23 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		newRequest = newDefaultRequest
 	}
 
-	req, body, err := newRequest(ctx, w, t)
-	if err != nil {
-		return fmt.Errorf("cannot create http request for webhook %s[%d %s]: %w", w.Type, w.ID, w.URL, err)
-	}
-
-	// Record delivery information.
-	t.RequestInfo = &webhook_model.HookRequest{
-		URL:        req.URL.String(),
-		HTTPMethod: req.Method,
-		Headers:    map[string]string{},
-		Body:       string(body),
-	}
-	for k, vals := range req.Header {
-		t.RequestInfo.Headers[k] = strings.Join(vals, ",")
+	// The webhooks which batch their deliveries send the other pending tasks in the same request,
+	// they are only delivered with this task if they can be marked as delivered after it.
+	var batch []*webhook_model.HookTask
+	if batchRequester, ok := webhookBatchRequesters[w.Type]; ok && t.PayloadVersion != 1 {
+		if size := batchRequester.MaxBatchSize(w); size > 1 {
+			if batch, err = webhook_model.FindPendingHookTasksOfWebhook(ctx, w.ID, t.ID, size-1); err != nil {
+				return fmt.Errorf("unable to find the pending tasks of webhook %s[%d %s]: %w", w.Type, w.ID, w.URL, err)
+			}
+			newRequest = func(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+				return batchRequester.NewRequest(ctx, w, append([]*webhook_model.HookTask{t}, batch...))
+			}
+		}
 	}
 
-	// Add Authorization Header
-	authorization, err := w.HeaderAuthorization()
+	req, err := newDeliveryRequest(ctx, w, t, newRequest)
 	if err != nil {
-		return fmt.Errorf("cannot get Authorization header for webhook %s[%d %s]: %w", w.Type, w.ID, w.URL, err)
-	}
-	if authorization != "" {
-		req.Header.Set("Authorization", authorization)
-		t.RequestInfo.Headers["Authorization"] = "******"
+		return err
 	}
 
 	t.ResponseInfo = &webhook_model.HookResponse{
@@ -215,13 +208,23 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		return nil
 	}
 
+	// whether the delivery was attempted, the skipped deliveries are neither retried nor counted as failures
+	attempted := false
+	// the tasks of the batch which have been marked as delivered, they share the result of this task
+	var batched []*webhook_model.HookTask
+
 	// All code from this point will update the hook task
 	defer func() {
 		t.Delivered = timeutil.TimeStampNanoNow()
+		if attempted {
+			recordHookTaskAttempt(t)
+		}
 		if t.IsSucceed {
 			log.Trace("Hook delivered: %s", t.UUID)
 		} else if !w.IsActive {
 			log.Trace("Hook delivery skipped as webhook is inactive: %s", t.UUID)
+		} else if t.IsDeadLettered {
+			log.Trace("Hook delivery failed after %d attempts, dead-lettered: %s", t.Attempts, t.UUID)
 		} else {
 			log.Trace("Hook delivery failed: %s", t.UUID)
 		}
@@ -229,6 +232,19 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		if err := webhook_model.UpdateHookTask(ctx, t); err != nil {
 			log.Error("UpdateHookTask [%d]: %v", t.ID, err)
 		}
+		for _, bt := range batched {
+			bt.IsDelivered = true
+			bt.Delivered = t.Delivered
+			bt.IsSucceed = t.IsSucceed
+			bt.RequestInfo = t.RequestInfo
+			bt.ResponseInfo = t.ResponseInfo
+			if attempted {
+				recordHookTaskAttempt(bt)
+			}
+			if err := webhook_model.UpdateHookTask(ctx, bt); err != nil {
+				log.Error("UpdateHookTask [%d]: %v", bt.ID, err)
+			}
+		}
 
 		// Update webhook last delivery status.
 		if t.IsSucceed {
@@ -240,6 +256,12 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 			log.Error("UpdateWebhookLastStatus: %v", err)
 			return
 		}
+
+		if attempted {
+			if err := updateWebhookConsecutiveFailures(ctx, w, t); err != nil {
+				log.Error("updateWebhookConsecutiveFailures [%d]: %v", w.ID, err)
+			}
+		}
 	}()
 
 	if setting.DisableWebhooks {
@@ -251,6 +273,27 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 		return nil
 	}
 
+	attempted = true
+
+	if len(batch) > 0 {
+		for _, bt := range batch {
+			updated, err := webhook_model.MarkTaskDelivered(ctx, bt)
+			if err != nil {
+				log.Error("MarkTaskDelivered[%d]: %v", bt.ID, err)
+			} else if updated {
+				batched = append(batched, bt)
+			}
+		}
+		if len(batched) < len(batch) {
+			// some tasks have been delivered in the meantime, the request must not contain them
+			batch = batched
+			if req, err = newDeliveryRequest(ctx, w, t, newRequest); err != nil {
+				t.ResponseInfo.Body = fmt.Sprintf("Delivery: %v", err)
+				return err
+			}
+		}
+	}
+
 	resp, err := webhookHTTPClient.Do(req.WithContext(ctx))
 	if err != nil {
 		t.ResponseInfo.Body = fmt.Sprintf("Delivery: %v", err)
@@ -274,6 +317,37 @@ func Deliver(ctx context.Context, t *webhook_model.HookTask) error {
 	return nil
 }
 
+// newDeliveryRequest creates the request of the hook task with the webhook's authorization header,
+// and records it in the hook task
+func newDeliveryRequest(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask, newRequest Requester) (*http.Request, error) {
+	req, body, err := newRequest(ctx, w, t)
+	if err != nil {
+		return nil, fmt.Errorf("cannot create http request for webhook %s[%d %s]: %w", w.Type, w.ID, w.URL, err)
+	}
+
+	// Record delivery information.
+	t.RequestInfo = &webhook_model.HookRequest{
+		URL:        req.URL.String(),
+		HTTPMethod: req.Method,
+		Headers:    map[string]string{},
+		Body:       string(body),
+	}
+	for k, vals := range req.Header {
+		t.RequestInfo.Headers[k] = strings.Join(vals, ",")
+	}
+
+	// Add Authorization Header
+	authorization, err := w.HeaderAuthorization()
+	if err != nil {
+		return nil, fmt.Errorf("cannot get Authorization header for webhook %s[%d %s]: %w", w.Type, w.ID, w.URL, err)
+	}
+	if authorization != "" {
+		req.Header.Set("Authorization", authorization)
+		t.RequestInfo.Headers["Authorization"] = "******"
+	}
+	return req, nil
+}
+
 var (
 	webhookHTTPClient *http.Client
 	once              sync.Once
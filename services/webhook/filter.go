@@ -0,0 +1,100 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"slices"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// filterPayload contains the fields of the payloads a filter expression can test,
+// the payloads of the different events share their names
+type filterPayload struct {
+	Action string `json:"action"`
+	Sender *struct {
+		UserName string `json:"login"`
+	} `json:"sender"`
+	Issue *struct {
+		Labels []filterPayloadLabel `json:"labels"`
+	} `json:"issue"`
+	PullRequest *struct {
+		Labels []filterPayloadLabel `json:"labels"`
+		Base   *struct {
+			Ref string `json:"ref"`
+		} `json:"base"`
+	} `json:"pull_request"`
+	Commits []struct {
+		Added    []string `json:"added"`
+		Removed  []string `json:"removed"`
+		Modified []string `json:"modified"`
+	} `json:"commits"`
+}
+
+type filterPayloadLabel struct {
+	Name string `json:"name"`
+}
+
+// newFilterContext extracts the fields a filter expression can test from the JSON payload of the event
+func newFilterContext(event webhook_module.HookEventType, payload []byte) (*webhook_module.FilterContext, error) {
+	var p filterPayload
+	if err := json.Unmarshal(payload, &p); err != nil {
+		return nil, err
+	}
+
+	c := &webhook_module.FilterContext{
+		Event:  string(event),
+		Action: p.Action,
+	}
+	if p.Sender != nil {
+		c.Sender = p.Sender.UserName
+	}
+
+	var labels []filterPayloadLabel
+	if p.PullRequest != nil {
+		labels = p.PullRequest.Labels
+		if p.PullRequest.Base != nil {
+			c.BaseBranch = p.PullRequest.Base.Ref
+		}
+	} else if p.Issue != nil {
+		labels = p.Issue.Labels
+	}
+	for _, label := range labels {
+		c.Labels = append(c.Labels, label.Name)
+	}
+
+	for _, commit := range p.Commits {
+		c.Paths = append(c.Paths, commit.Added...)
+		c.Paths = append(c.Paths, commit.Removed...)
+		c.Paths = append(c.Paths, commit.Modified...)
+	}
+	slices.Sort(c.Paths)
+	c.Paths = slices.Compact(c.Paths)
+
+	return c, nil
+}
+
+// matchFilterExpression reports whether the event matches the filter expression of the webhook.
+// An invalid expression doesn't match any event, so the misconfiguration shows up in the delivery history.
+func matchFilterExpression(w *webhook_model.Webhook, event webhook_module.HookEventType, payload []byte) bool {
+	if w.FilterExpression == "" {
+		return true
+	}
+
+	filter, err := webhook_module.ParseFilter(w.FilterExpression)
+	if err != nil {
+		// should not really happen as the expression is validated
+		log.Error("Invalid filter expression of webhook[%d]: %v", w.ID, err)
+		return false
+	}
+	c, err := newFilterContext(event, payload)
+	if err != nil {
+		log.Error("Unable to read the %s payload for the filter expression of webhook[%d]: %v", event, w.ID, err)
+		return false
+	}
+	return filter.Match(c)
+}
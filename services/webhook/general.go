@@ -30,6 +30,11 @@ func htmlLinkFormatter(url, text string) string {
 	return fmt.Sprintf(`<a href="%s">%s</a>`, html.EscapeString(url), html.EscapeString(text))
 }
 
+// markdownLinkFormatter creates a Markdown link
+func markdownLinkFormatter(url, text string) string {
+	return fmt.Sprintf("[%s](%s)", text, url)
+}
+
 // getPullRequestInfo gets the information for a pull request
 func getPullRequestInfo(p *api.PullRequestPayload) (title, link, by, operator, operateResult, assignees string) {
 	title = fmt.Sprintf("[PullRequest-%s #%d]: %s\n%s", p.Repository.FullName, p.PullRequest.Index, p.Action, p.PullRequest.Title)
@@ -403,6 +408,21 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		config["icon_url"] = s.IconURL
 		config["color"] = s.Color
 	}
+	if w.Type == webhook_module.CUSTOM {
+		GetCustomHook(w).ToConfig(config)
+	}
+	if w.Type == webhook_module.CLOUDEVENTS {
+		GetCloudEventsHook(w).ToConfig(config)
+	}
+	if w.Type == webhook_module.MATTERMOST {
+		GetMattermostHook(w).ToConfig(config)
+	}
+	if w.Type == webhook_module.ROCKETCHAT {
+		GetRocketChatHook(w).ToConfig(config)
+	}
+	if w.Type == webhook_module.ZULIP {
+		GetZulipHook(w).ToConfig(config)
+	}
 
 	authorizationHeader, err := w.HeaderAuthorization()
 	if err != nil {
@@ -421,5 +441,25 @@ func ToHook(repoLink string, w *webhook_model.Webhook) (*api.Hook, error) {
 		Updated:             w.UpdatedUnix.AsTime(),
 		Created:             w.CreatedUnix.AsTime(),
 		BranchFilter:        w.BranchFilter,
+		FilterExpression:    w.FilterExpression,
 	}, nil
 }
+
+// ToHookDelivery convert models.HookTask to api.HookDelivery
+func ToHookDelivery(t *webhook_model.HookTask) *api.HookDelivery {
+	delivery := &api.HookDelivery{
+		ID:             t.ID,
+		HookID:         t.HookID,
+		UUID:           t.UUID,
+		Event:          string(t.EventType),
+		Succeeded:      t.IsSucceed,
+		Attempts:       t.Attempts,
+		IsDeadLettered: t.IsDeadLettered,
+		Delivered:      t.Delivered.AsTime(),
+	}
+	if t.ResponseInfo != nil {
+		delivery.ResponseStatus = t.ResponseInfo.Status
+		delivery.ResponseBody = t.ResponseInfo.Body
+	}
+	return delivery
+}
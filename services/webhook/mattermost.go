@@ -0,0 +1,307 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// mattermostAttachmentTextLimit is the length of the attachment text, a longer text is shown completely in the message card
+const mattermostAttachmentTextLimit = 1000
+
+// MattermostMeta contains the Mattermost metadata
+type MattermostMeta struct {
+	Channel  string `json:"channel"`
+	Username string `json:"username"`
+	IconURL  string `json:"icon_url"`
+}
+
+// GetMattermostHook returns Mattermost metadata
+func GetMattermostHook(w *webhook_model.Webhook) *MattermostMeta {
+	s := &MattermostMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetMattermostHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// Validate checks the icon URL
+func (m *MattermostMeta) Validate() error {
+	if m.IconURL != "" && !validation.IsValidURL(m.IconURL) {
+		return util.NewInvalidArgumentErrorf("invalid icon url %q", m.IconURL)
+	}
+	return nil
+}
+
+// ApplyConfig updates the metadata from the API hook config
+func (m *MattermostMeta) ApplyConfig(config map[string]string) {
+	if channel, ok := config["channel"]; ok {
+		m.Channel = strings.TrimSpace(channel)
+	}
+	if username, ok := config["username"]; ok {
+		m.Username = strings.TrimSpace(username)
+	}
+	if iconURL, ok := config["icon_url"]; ok {
+		m.IconURL = strings.TrimSpace(iconURL)
+	}
+}
+
+// ToConfig adds the metadata to the API hook config
+func (m *MattermostMeta) ToConfig(config map[string]string) {
+	config["channel"] = m.Channel
+	config["username"] = m.Username
+	config["icon_url"] = m.IconURL
+}
+
+// MattermostPayload is the payload of a Mattermost incoming webhook
+// see: https://developers.mattermost.com/integrate/webhooks/incoming/
+type MattermostPayload struct {
+	Channel     string                 `json:"channel,omitempty"`
+	Username    string                 `json:"username,omitempty"`
+	IconURL     string                 `json:"icon_url,omitempty"`
+	Text        string                 `json:"text"`
+	Attachments []MattermostAttachment `json:"attachments,omitempty"`
+	Props       *MattermostProps       `json:"props,omitempty"`
+}
+
+// MattermostAttachment contains the details of the event
+type MattermostAttachment struct {
+	Fallback   string `json:"fallback"`
+	Color      string `json:"color"`
+	AuthorName string `json:"author_name"`
+	AuthorLink string `json:"author_link"`
+	AuthorIcon string `json:"author_icon"`
+	Title      string `json:"title"`
+	TitleLink  string `json:"title_link"`
+	Text       string `json:"text"`
+}
+
+// MattermostProps contains the message properties
+type MattermostProps struct {
+	// Card is shown in the sidebar when the message is selected
+	Card string `json:"card,omitempty"`
+}
+
+type mattermostConvertor struct {
+	Channel  string
+	Username string
+	IconURL  string
+}
+
+// Create implements PayloadConvertor Create method
+func (m mattermostConvertor) Create(p *api.CreatePayload) (MattermostPayload, error) {
+	refName := git.RefName(p.Ref)
+	repoLink := markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName)
+	refLink := markdownLinkFormatter(p.Repo.HTMLURL+"/src/"+refName.RefWebLinkPath(), refName.ShortName())
+	text := fmt.Sprintf("[%s:%s] %s created by %s", repoLink, refLink, p.RefType, p.Sender.UserName)
+
+	return m.createPayload(text, nil, "", "", "", greenColor), nil
+}
+
+// Delete implements PayloadConvertor Delete method
+func (m mattermostConvertor) Delete(p *api.DeletePayload) (MattermostPayload, error) {
+	text := fmt.Sprintf("[%s:%s] %s deleted by %s", markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName), git.RefName(p.Ref).ShortName(), p.RefType, p.Sender.UserName)
+
+	return m.createPayload(text, nil, "", "", "", redColor), nil
+}
+
+// Fork implements PayloadConvertor Fork method
+func (m mattermostConvertor) Fork(p *api.ForkPayload) (MattermostPayload, error) {
+	text := fmt.Sprintf("%s is forked to %s", markdownLinkFormatter(p.Forkee.HTMLURL, p.Forkee.FullName), markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName))
+
+	return m.createPayload(text, nil, "", "", "", greenColor), nil
+}
+
+// Push implements PayloadConvertor Push method
+func (m mattermostConvertor) Push(p *api.PushPayload) (MattermostPayload, error) {
+	commitDesc := "1 new commit"
+	if p.TotalCommits != 1 {
+		commitDesc = fmt.Sprintf("%d new commits", p.TotalCommits)
+	}
+	if p.CompareURL != "" {
+		commitDesc = markdownLinkFormatter(p.CompareURL, commitDesc)
+	}
+
+	repoLink := markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName)
+	refName := git.RefName(p.Ref)
+	branchLink := markdownLinkFormatter(p.Repo.HTMLURL+"/src/"+refName.RefWebLinkPath(), refName.ShortName())
+	text := fmt.Sprintf("[%s:%s] %s pushed by %s", repoLink, branchLink, commitDesc, p.Pusher.UserName)
+
+	var details strings.Builder
+	for i, commit := range p.Commits {
+		fmt.Fprintf(&details, "%s: %s - %s", markdownLinkFormatter(commit.URL, commit.ID[:7]), strings.SplitN(commit.Message, "\n", 2)[0], commit.Author.Name)
+		if i < len(p.Commits)-1 {
+			details.WriteString("\n")
+		}
+	}
+
+	return m.createPayload(text, p.Sender, p.Repo.FullName, p.Repo.HTMLURL, details.String(), greenColor), nil
+}
+
+// Issue implements PayloadConvertor Issue method
+func (m mattermostConvertor) Issue(p *api.IssuePayload) (MattermostPayload, error) {
+	text, issueTitle, extraMarkdown, color := getIssuesPayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, p.Sender, issueTitle, p.Issue.HTMLURL, extraMarkdown, color), nil
+}
+
+// IssueComment implements PayloadConvertor IssueComment method
+func (m mattermostConvertor) IssueComment(p *api.IssueCommentPayload) (MattermostPayload, error) {
+	text, issueTitle, color := getIssueCommentPayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, p.Sender, issueTitle, p.Comment.HTMLURL, p.Comment.Body, color), nil
+}
+
+// PullRequest implements PayloadConvertor PullRequest method
+func (m mattermostConvertor) PullRequest(p *api.PullRequestPayload) (MattermostPayload, error) {
+	text, issueTitle, extraMarkdown, color := getPullRequestPayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, p.Sender, issueTitle, p.PullRequest.HTMLURL, extraMarkdown, color), nil
+}
+
+// Review implements PayloadConvertor Review method
+func (m mattermostConvertor) Review(p *api.PullRequestPayload, event webhook_module.HookEventType) (MattermostPayload, error) {
+	var text, details string
+	color := yellowColor
+	switch p.Action {
+	case api.HookIssueReviewed:
+		action, err := parseHookPullRequestEventType(event)
+		if err != nil {
+			return MattermostPayload{}, err
+		}
+
+		title := markdownLinkFormatter(p.PullRequest.HTMLURL, fmt.Sprintf("#%d %s", p.Index, p.PullRequest.Title))
+		senderLink := markdownLinkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName)
+		text = fmt.Sprintf("[%s] Pull request review %s: %s by %s", markdownLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName), action, title, senderLink)
+		details = p.Review.Content
+
+		switch event {
+		case webhook_module.HookEventPullRequestReviewApproved:
+			color = greenColor
+		case webhook_module.HookEventPullRequestReviewRejected:
+			color = redColor
+		case webhook_module.HookEventPullRequestReviewComment:
+			color = greyColor
+		}
+	}
+
+	return m.createPayload(text, p.Sender, "", "", details, color), nil
+}
+
+// Repository implements PayloadConvertor Repository method
+func (m mattermostConvertor) Repository(p *api.RepositoryPayload) (MattermostPayload, error) {
+	senderLink := markdownLinkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName)
+	var text string
+	color := greenColor
+	switch p.Action {
+	case api.HookRepoCreated:
+		text = fmt.Sprintf("[%s] Repository created by %s", markdownLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName), senderLink)
+	case api.HookRepoDeleted:
+		text = fmt.Sprintf("[%s] Repository deleted by %s", p.Repository.FullName, senderLink)
+		color = redColor
+	}
+
+	return m.createPayload(text, nil, "", "", "", color), nil
+}
+
+// Wiki implements PayloadConvertor Wiki method
+func (m mattermostConvertor) Wiki(p *api.WikiPayload) (MattermostPayload, error) {
+	text, color, _ := getWikiPayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, nil, "", "", "", color), nil
+}
+
+// Release implements PayloadConvertor Release method
+func (m mattermostConvertor) Release(p *api.ReleasePayload) (MattermostPayload, error) {
+	text, color := getReleasePayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, p.Sender, p.Release.TagName, p.Release.HTMLURL, p.Release.Note, color), nil
+}
+
+func (m mattermostConvertor) Package(p *api.PackagePayload) (MattermostPayload, error) {
+	text, color := getPackagePayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, nil, "", "", "", color), nil
+}
+
+func (m mattermostConvertor) Status(p *api.CommitStatusPayload) (MattermostPayload, error) {
+	text, color := getStatusPayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, nil, "", "", "", color), nil
+}
+
+func (m mattermostConvertor) WorkflowRun(p *api.WorkflowRunPayload) (MattermostPayload, error) {
+	text, color := getWorkflowRunPayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, nil, "", "", "", color), nil
+}
+
+func (m mattermostConvertor) WorkflowJob(p *api.WorkflowJobPayload) (MattermostPayload, error) {
+	text, color := getWorkflowJobPayloadInfo(p, markdownLinkFormatter, true)
+
+	return m.createPayload(text, nil, "", "", "", color), nil
+}
+
+// createPayload creates the message, the details are shown in an attachment authored by the sender.
+// If the details are too long for the attachment, they are shown completely in the message card.
+func (m mattermostConvertor) createPayload(text string, sender *api.User, title, titleLink, details string, color int) MattermostPayload {
+	payload := MattermostPayload{
+		Channel:  m.Channel,
+		Username: m.Username,
+		IconURL:  m.IconURL,
+		Text:     text,
+	}
+	if title == "" && details == "" {
+		return payload
+	}
+
+	attachment := MattermostAttachment{
+		Fallback:  text,
+		Color:     fmt.Sprintf("#%06x", color),
+		Title:     title,
+		TitleLink: titleLink,
+		Text:      util.TruncateRunes(details, mattermostAttachmentTextLimit),
+	}
+	if sender != nil {
+		attachment.AuthorName = sender.UserName
+		attachment.AuthorLink = setting.AppURL + url.PathEscape(sender.UserName)
+		attachment.AuthorIcon = sender.AvatarURL
+	}
+	payload.Attachments = []MattermostAttachment{attachment}
+	if attachment.Text != details {
+		payload.Props = &MattermostProps{Card: details}
+	}
+	return payload
+}
+
+func newMattermostRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &MattermostMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newMattermostRequest meta json: %w", err)
+	}
+	var pc payloadConvertor[MattermostPayload] = mattermostConvertor{
+		Channel:  meta.Channel,
+		Username: meta.Username,
+		IconURL:  meta.IconURL,
+	}
+	return newJSONRequest(pc, w, t, true)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.MATTERMOST, newMattermostRequest)
+}
@@ -0,0 +1,231 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"strings"
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMattermostPayload(t *testing.T) {
+	mc := mattermostConvertor{Channel: "town-square", Username: "Gitea", IconURL: "https://example.com/gitea.png"}
+
+	t.Run("Create", func(t *testing.T) {
+		p := createTestPayload()
+
+		pl, err := mc.Create(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "town-square", pl.Channel)
+		assert.Equal(t, "Gitea", pl.Username)
+		assert.Equal(t, "https://example.com/gitea.png", pl.IconURL)
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):[test](http://localhost:3000/test/repo/src/branch/test)] branch created by user1", pl.Text)
+		assert.Empty(t, pl.Attachments)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		p := deleteTestPayload()
+
+		pl, err := mc.Delete(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):test] branch deleted by user1", pl.Text)
+	})
+
+	t.Run("Fork", func(t *testing.T) {
+		p := forkTestPayload()
+
+		pl, err := mc.Fork(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[test/repo2](http://localhost:3000/test/repo2) is forked to [test/repo](http://localhost:3000/test/repo)", pl.Text)
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		p := pushTestPayload()
+
+		pl, err := mc.Push(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):[test](http://localhost:3000/test/repo/src/branch/test)] 2 new commits pushed by user1", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, "test/repo", pl.Attachments[0].Title)
+		assert.Equal(t, "http://localhost:3000/test/repo", pl.Attachments[0].TitleLink)
+		assert.Equal(t, "[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778): commit message - user1\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778): commit message - user1", pl.Attachments[0].Text)
+		assert.Nil(t, pl.Props)
+	})
+
+	t.Run("Issue", func(t *testing.T) {
+		p := issueTestPayload()
+
+		p.Action = api.HookIssueOpened
+		pl, err := mc.Issue(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Issue opened: [#2 crash](http://localhost:3000/test/repo/issues/2) by [user1](https://try.gitea.io/user1)", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, MattermostAttachment{
+			Fallback:   pl.Text,
+			Color:      "#eb6420",
+			AuthorName: "user1",
+			AuthorLink: "https://try.gitea.io/user1",
+			AuthorIcon: "http://localhost:3000/user1/avatar",
+			Title:      "#2 crash",
+			TitleLink:  "http://localhost:3000/test/repo/issues/2",
+			Text:       "issue body",
+		}, pl.Attachments[0])
+
+		p.Action = api.HookIssueClosed
+		pl, err = mc.Issue(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Issue closed: [#2 crash](http://localhost:3000/test/repo/issues/2) by [user1](https://try.gitea.io/user1)", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, "#ff3232", pl.Attachments[0].Color)
+		assert.Empty(t, pl.Attachments[0].Text)
+	})
+
+	t.Run("IssueComment", func(t *testing.T) {
+		p := issueCommentTestPayload()
+
+		pl, err := mc.IssueComment(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] New comment on issue [#2 crash](http://localhost:3000/test/repo/issues/2) by [user1](https://try.gitea.io/user1)", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, "more info needed", pl.Attachments[0].Text)
+	})
+
+	t.Run("PullRequest", func(t *testing.T) {
+		p := pullRequestTestPayload()
+
+		pl, err := mc.PullRequest(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Pull request opened: [#12 Fix bug](http://localhost:3000/test/repo/pulls/12) by [user1](https://try.gitea.io/user1)", pl.Text)
+	})
+
+	t.Run("PullRequestComment", func(t *testing.T) {
+		p := pullRequestCommentTestPayload()
+
+		pl, err := mc.IssueComment(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] New comment on pull request [#12 Fix bug](http://localhost:3000/test/repo/pulls/12) by [user1](https://try.gitea.io/user1)", pl.Text)
+	})
+
+	t.Run("Review", func(t *testing.T) {
+		p := pullRequestTestPayload()
+		p.Action = api.HookIssueReviewed
+
+		pl, err := mc.Review(p, webhook_module.HookEventPullRequestReviewApproved)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Pull request review approved: [#12 Fix bug](http://localhost:3000/test/repo/pulls/12) by [user1](https://try.gitea.io/user1)", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, "#1ac600", pl.Attachments[0].Color)
+	})
+
+	t.Run("Repository", func(t *testing.T) {
+		p := repositoryTestPayload()
+
+		pl, err := mc.Repository(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Repository created by [user1](https://try.gitea.io/user1)", pl.Text)
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		p := packageTestPayload()
+
+		pl, err := mc.Package(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "Package created: [GiteaContainer:latest](http://localhost:3000/user1/-/packages/container/GiteaContainer/latest) by [user1](https://try.gitea.io/user1)", pl.Text)
+	})
+
+	t.Run("Wiki", func(t *testing.T) {
+		p := wikiTestPayload()
+
+		p.Action = api.HookWikiCreated
+		pl, err := mc.Wiki(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] New wiki page '[index](http://localhost:3000/test/repo/wiki/index)' (Wiki change comment) by [user1](https://try.gitea.io/user1)", pl.Text)
+
+		p.Action = api.HookWikiDeleted
+		pl, err = mc.Wiki(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Wiki page '[index](http://localhost:3000/test/repo/wiki/index)' deleted by [user1](https://try.gitea.io/user1)", pl.Text)
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		p := pullReleaseTestPayload()
+
+		pl, err := mc.Release(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Release created: [v1.0](http://localhost:3000/test/repo/releases/tag/v1.0) by [user1](https://try.gitea.io/user1)", pl.Text)
+	})
+
+	t.Run("LongDetails", func(t *testing.T) {
+		p := issueTestPayload()
+		p.Action = api.HookIssueOpened
+		p.Issue.Body = strings.Repeat("a", mattermostAttachmentTextLimit+1)
+
+		pl, err := mc.Issue(p)
+		require.NoError(t, err)
+
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, strings.Repeat("a", mattermostAttachmentTextLimit), pl.Attachments[0].Text)
+		require.NotNil(t, pl.Props)
+		assert.Equal(t, p.Issue.Body, pl.Props.Card)
+	})
+}
+
+func TestMattermostJSONPayload(t *testing.T) {
+	p := pushTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.MATTERMOST,
+		URL:        "https://mattermost.example.com/hooks/xxx",
+		Meta:       `{"channel":"town-square","username":"Gitea"}`,
+		HTTPMethod: "POST",
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newMattermostRequest(t.Context(), hook, task)
+	require.NotNil(t, req)
+	require.NotNil(t, reqBody)
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "https://mattermost.example.com/hooks/xxx", req.URL.String())
+	assert.Equal(t, "sha256=", req.Header.Get("X-Hub-Signature-256"))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	var body MattermostPayload
+	err = json.NewDecoder(req.Body).Decode(&body)
+	assert.NoError(t, err)
+	assert.Equal(t, "town-square", body.Channel)
+	assert.Equal(t, "Gitea", body.Username)
+	assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):[test](http://localhost:3000/test/repo/src/branch/test)] 2 new commits pushed by user1", body.Text)
+}
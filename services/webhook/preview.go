@@ -0,0 +1,127 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"code.gitea.io/gitea/models/perm"
+	access_model "code.gitea.io/gitea/models/perm/access"
+	repo_model "code.gitea.io/gitea/models/repo"
+	user_model "code.gitea.io/gitea/models/user"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+	"code.gitea.io/gitea/services/convert"
+
+	gouuid "github.com/google/uuid"
+)
+
+// previewSamplePayload creates a minimal payload for the event which contains the repository of the webhook
+// (or an example repository of its owner) and the doer as sender
+func previewSamplePayload(ctx context.Context, doer *user_model.User, w *webhook_model.Webhook, event webhook_module.HookEventType) ([]byte, error) {
+	sender := convert.ToUserWithAccessMode(ctx, doer, perm.AccessModeNone)
+
+	var repo *api.Repository
+	if w.RepoID != 0 {
+		r, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+		if err != nil {
+			return nil, err
+		}
+		repo = convert.ToRepo(ctx, r, access_model.Permission{AccessMode: perm.AccessModeNone})
+	} else {
+		owner := sender
+		if w.OwnerID != 0 {
+			u, err := user_model.GetUserByID(ctx, w.OwnerID)
+			if err != nil {
+				return nil, err
+			}
+			owner = convert.ToUserWithAccessMode(ctx, u, perm.AccessModeNone)
+		}
+		repo = &api.Repository{
+			Name:          "example",
+			FullName:      owner.UserName + "/example",
+			Owner:         owner,
+			DefaultBranch: "main",
+		}
+	}
+
+	payload := map[string]any{
+		"repository": repo,
+		"sender":     sender,
+	}
+	if event == webhook_module.HookEventPush {
+		commitID := strings.Repeat("0", 40)
+		payload["ref"] = git.BranchPrefix + repo.DefaultBranch
+		payload["before"] = commitID
+		payload["after"] = commitID
+		payload["commits"] = []*api.PayloadCommit{}
+		payload["pusher"] = sender
+	}
+	return json.Marshal(payload)
+}
+
+// PreviewRequest creates the request the webhook would send for the event and the payload, without delivering it.
+// If no payload is provided a sample payload is used. The signatures and the authorization header are masked.
+func PreviewRequest(ctx context.Context, doer *user_model.User, w *webhook_model.Webhook, event webhook_module.HookEventType, payloadContent []byte) (_ *webhook_model.HookRequest, err error) {
+	if !slices.Contains(webhook_module.AllEvents(), event) {
+		return nil, util.NewInvalidArgumentErrorf("invalid event %q", event)
+	}
+
+	defer func() {
+		// the convertors expect complete payloads, a provided payload may lack fields they dereference
+		if r := recover(); r != nil {
+			err = util.NewInvalidArgumentErrorf("failed to create the request, the payload is probably incomplete: %v", r)
+		}
+	}()
+
+	if len(payloadContent) == 0 {
+		if payloadContent, err = previewSamplePayload(ctx, doer, w, event); err != nil {
+			return nil, err
+		}
+	} else if _, err := newPayload[api.Payloader](customConvertor{}, payloadContent, event); err != nil {
+		return nil, util.NewInvalidArgumentErrorf("invalid payload: %v", err)
+	}
+
+	t := &webhook_model.HookTask{
+		HookID:         w.ID,
+		UUID:           gouuid.New().String(),
+		PayloadContent: string(payloadContent),
+		EventType:      event,
+		PayloadVersion: 2,
+	}
+
+	newRequest := webhookRequesters[w.Type]
+	if newRequest == nil {
+		newRequest = newDefaultRequest
+	}
+	req, body, err := newRequest(ctx, w, t)
+	if err != nil {
+		return nil, err
+	}
+
+	preview := &webhook_model.HookRequest{
+		URL:        req.URL.String(),
+		HTTPMethod: req.Method,
+		Headers:    map[string]string{},
+		Body:       string(body),
+	}
+	for k, vals := range req.Header {
+		switch k {
+		case "X-Gitea-Signature", "X-Gogs-Signature", "X-Hub-Signature", "X-Hub-Signature-256":
+			preview.Headers[k] = "******"
+		default:
+			preview.Headers[k] = strings.Join(vals, ",")
+		}
+	}
+	if w.HeaderAuthorizationEncrypted != "" {
+		preview.Headers["Authorization"] = "******"
+	}
+	return preview, nil
+}
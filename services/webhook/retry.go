@@ -0,0 +1,195 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"math/rand/v2"
+	"net/url"
+	"strconv"
+	"time"
+
+	"code.gitea.io/gitea/models/db"
+	repo_model "code.gitea.io/gitea/models/repo"
+	system_model "code.gitea.io/gitea/models/system"
+	user_model "code.gitea.io/gitea/models/user"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/timeutil"
+	"code.gitea.io/gitea/services/mailer"
+)
+
+// maxRetryBackoffShift limits the exponential growth of the retry backoff
+const maxRetryBackoffShift = 10
+
+// retryBackoff returns the delay before the next retry of a delivery which has failed the given number of times.
+// The delay doubles with each attempt up to [webhook] MAX_RETRY_BACKOFF, and a random jitter of up to half of it
+// is subtracted, so the retries of the deliveries which failed during the same outage are spread out.
+func retryBackoff(attempts int) time.Duration {
+	backoff := setting.Webhook.RetryBackoff << min(max(attempts-1, 0), maxRetryBackoffShift)
+	if backoff <= 0 || backoff > setting.Webhook.MaxRetryBackoff {
+		backoff = setting.Webhook.MaxRetryBackoff
+	}
+	if backoff < 2 {
+		return backoff
+	}
+	return backoff - rand.N(backoff/2)
+}
+
+// recordHookTaskAttempt records a delivery attempt of the hook task and schedules the next one if it failed.
+// Test deliveries and replays are only attempted once.
+func recordHookTaskAttempt(t *webhook_model.HookTask) {
+	t.Attempts++
+	switch {
+	case t.IsSucceed:
+		t.NextRetryUnix = 0
+	case !t.IsManual:
+		scheduleHookTaskRetry(t)
+	}
+}
+
+// scheduleHookTaskRetry schedules the next delivery attempt of the failed hook task,
+// or dead-letters the task if it has used up the retries.
+func scheduleHookTaskRetry(t *webhook_model.HookTask) {
+	if t.Attempts > setting.Webhook.MaxRetries {
+		t.NextRetryUnix = 0
+		t.IsDeadLettered = true
+		return
+	}
+	t.IsDelivered = false
+	t.NextRetryUnix = timeutil.TimeStampNow().AddDuration(retryBackoff(t.Attempts))
+}
+
+// updateWebhookConsecutiveFailures counts the consecutive failed deliveries of the webhook after an attempt to deliver
+// the hook task, and deactivates it once they reach [webhook] DISABLE_AFTER_FAILURES. A delivery has only failed once
+// its task has been dead-lettered, so an event failing through all its retries is counted once.
+// Test deliveries and replays are not counted.
+func updateWebhookConsecutiveFailures(ctx context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) error {
+	if t.IsManual {
+		return nil
+	}
+	if t.IsSucceed {
+		if w.ConsecutiveFailures == 0 {
+			return nil
+		}
+		return webhook_model.ResetWebhookConsecutiveFailures(ctx, w)
+	}
+	if !t.IsDeadLettered {
+		// the delivery is retried
+		return nil
+	}
+
+	if err := webhook_model.IncreaseWebhookConsecutiveFailures(ctx, w); err != nil {
+		return err
+	}
+	if setting.Webhook.DisableAfterFailures <= 0 || w.ConsecutiveFailures < setting.Webhook.DisableAfterFailures {
+		return nil
+	}
+
+	disabled, err := webhook_model.DisableFailingWebhook(ctx, w)
+	if err != nil || !disabled {
+		return err
+	}
+	log.Info("Webhook[%d] has been deactivated after %d consecutive failed deliveries", w.ID, setting.Webhook.DisableAfterFailures)
+
+	return notifyWebhookDisabled(ctx, w)
+}
+
+// notifyWebhookDisabled notifies the owner of the webhook that it has been deactivated.
+// System and default webhooks are managed by the admins, they get a system notice.
+func notifyWebhookDisabled(ctx context.Context, w *webhook_model.Webhook) error {
+	hookID := strconv.FormatInt(w.ID, 10)
+
+	var (
+		owner *user_model.User
+		link  string
+	)
+	switch {
+	case w.RepoID != 0:
+		repo, err := repo_model.GetRepositoryByID(ctx, w.RepoID)
+		if err != nil {
+			return err
+		}
+		if err := repo.LoadOwner(ctx); err != nil {
+			return err
+		}
+		owner = repo.Owner
+		link = repo.HTMLURL(ctx) + "/settings/hooks/" + hookID
+	case w.OwnerID != 0:
+		var err error
+		if owner, err = user_model.GetUserByID(ctx, w.OwnerID); err != nil {
+			return err
+		}
+		if owner.IsOrganization() {
+			link = setting.AppURL + "org/" + url.PathEscape(owner.Name) + "/settings/hooks/" + hookID
+		} else {
+			link = setting.AppURL + "user/settings/hooks/" + hookID
+		}
+	default:
+		return system_model.CreateNotice(ctx, system_model.NoticeTask, fmt.Sprintf("Webhook %d (%s) has been deactivated after %d consecutive failed deliveries", w.ID, w.HostName(), setting.Webhook.DisableAfterFailures))
+	}
+
+	return mailer.SendWebhookDisabledMail(ctx, owner, w, setting.Webhook.DisableAfterFailures, link)
+}
+
+// RetryDueHookTasks enqueues the failed hook tasks whose retry backoff has elapsed
+func RetryDueHookTasks(ctx context.Context) error {
+	lowerID := int64(0)
+	for {
+		taskIDs, err := webhook_model.FindDueHookTaskRetryIDs(ctx, lowerID)
+		if err != nil {
+			return err
+		}
+		if len(taskIDs) == 0 {
+			return nil
+		}
+		lowerID = taskIDs[len(taskIDs)-1]
+
+		for _, taskID := range taskIDs {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			if err := enqueueHookTask(taskID); err != nil {
+				return fmt.Errorf("unable to push HookTask[%d] to the Webhook Sending queue: %w", taskID, err)
+			}
+		}
+	}
+}
+
+// RedeliverDeadLetteredHookTasks redelivers the dead-lettered hook tasks matching the options as new hook tasks.
+// If the options don't select tasks by ID, all matching tasks are redelivered. It returns the new hook tasks.
+func RedeliverDeadLetteredHookTasks(ctx context.Context, opts webhook_model.FindDeadLetteredHookTasksOptions) ([]*webhook_model.HookTask, error) {
+	const batchSize = 100
+
+	// the redelivered tasks aren't dead-lettered anymore, so the first page always contains the next tasks
+	opts.ListOptions = db.ListOptions{Page: 1, PageSize: batchSize}
+
+	var redelivered []*webhook_model.HookTask
+	for {
+		tasks, err := db.Find[webhook_model.HookTask](ctx, opts)
+		if err != nil {
+			return redelivered, err
+		}
+		if len(tasks) == 0 {
+			return redelivered, nil
+		}
+
+		for _, task := range tasks {
+			newTask, err := webhook_model.RedeliverDeadLetteredHookTask(ctx, task)
+			if webhook_model.IsErrHookTaskNotExist(err) {
+				// redelivered concurrently
+				continue
+			} else if err != nil {
+				return redelivered, err
+			}
+			redelivered = append(redelivered, newTask)
+
+			if err := enqueueHookTask(newTask.ID); err != nil {
+				return redelivered, err
+			}
+		}
+	}
+}
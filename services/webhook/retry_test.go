@@ -0,0 +1,155 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"code.gitea.io/gitea/models/unittest"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/setting"
+	"code.gitea.io/gitea/modules/test"
+	"code.gitea.io/gitea/modules/timeutil"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRetryBackoff(t *testing.T) {
+	defer test.MockVariableValue(&setting.Webhook.RetryBackoff, time.Minute)()
+	defer test.MockVariableValue(&setting.Webhook.MaxRetryBackoff, 10*time.Minute)()
+
+	cases := []struct {
+		attempts int
+		max      time.Duration
+	}{
+		{attempts: 1, max: time.Minute},
+		{attempts: 2, max: 2 * time.Minute},
+		{attempts: 3, max: 4 * time.Minute},
+		{attempts: 5, max: 10 * time.Minute},
+		{attempts: 100, max: 10 * time.Minute},
+	}
+	for _, c := range cases {
+		for range 10 {
+			backoff := retryBackoff(c.attempts)
+			assert.LessOrEqual(t, backoff, c.max, "attempts: %d", c.attempts)
+			assert.Greater(t, backoff, c.max/2, "attempts: %d", c.attempts)
+		}
+	}
+}
+
+func TestWebhookDeliverRetries(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	defer test.MockVariableValue(&setting.Webhook.MaxRetries, 1)()
+	defer test.MockVariableValue(&setting.Webhook.RetryBackoff, time.Hour)()
+	defer test.MockVariableValue(&setting.Webhook.MaxRetryBackoff, time.Hour)()
+	defer test.MockVariableValue(&setting.Webhook.DisableAfterFailures, 2)()
+
+	s := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}))
+	t.Cleanup(s.Close)
+
+	hook := &webhook_model.Webhook{
+		RepoID:      3,
+		URL:         s.URL + "/webhook",
+		ContentType: webhook_model.ContentTypeJSON,
+		IsActive:    true,
+		Type:        webhook_module.GITEA,
+	}
+	require.NoError(t, webhook_model.CreateWebhook(t.Context(), hook))
+
+	hookTask, err := webhook_model.CreateHookTask(t.Context(), &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadVersion: 2,
+	})
+	require.NoError(t, err)
+
+	// the first failure schedules a retry, the delivery hasn't failed yet
+	require.NoError(t, Deliver(t.Context(), hookTask))
+
+	hookTask, err = webhook_model.GetHookTaskByID(t.Context(), hookTask.ID)
+	require.NoError(t, err)
+	assert.False(t, hookTask.IsSucceed)
+	assert.False(t, hookTask.IsDelivered)
+	assert.False(t, hookTask.IsDeadLettered)
+	assert.Equal(t, 1, hookTask.Attempts)
+	assert.Greater(t, hookTask.NextRetryUnix, timeutil.TimeStampNow())
+
+	hook = unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+	assert.True(t, hook.IsActive)
+	assert.Zero(t, hook.ConsecutiveFailures)
+
+	ids, err := webhook_model.FindDueHookTaskRetryIDs(t.Context(), 0)
+	require.NoError(t, err)
+	assert.NotContains(t, ids, hookTask.ID)
+
+	// the last retry fails too, the task is dead-lettered and the event is counted once
+	require.NoError(t, Deliver(t.Context(), hookTask))
+
+	hookTask, err = webhook_model.GetHookTaskByID(t.Context(), hookTask.ID)
+	require.NoError(t, err)
+	assert.True(t, hookTask.IsDelivered)
+	assert.True(t, hookTask.IsDeadLettered)
+	assert.Equal(t, 2, hookTask.Attempts)
+	assert.Zero(t, hookTask.NextRetryUnix)
+	assert.Equal(t, http.StatusServiceUnavailable, hookTask.ResponseInfo.Status)
+
+	hook = unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+	assert.True(t, hook.IsActive)
+	assert.Equal(t, 1, hook.ConsecutiveFailures)
+
+	// a replay is only sent once and isn't counted
+	replayed, err := webhook_model.ReplayHookTask(t.Context(), hook.ID, hookTask.UUID)
+	require.NoError(t, err)
+	require.NoError(t, Deliver(t.Context(), replayed))
+
+	replayed, err = webhook_model.GetHookTaskByID(t.Context(), replayed.ID)
+	require.NoError(t, err)
+	assert.True(t, replayed.IsManual)
+	assert.True(t, replayed.IsDelivered)
+	assert.False(t, replayed.IsDeadLettered)
+	assert.Equal(t, 1, replayed.Attempts)
+	assert.Zero(t, replayed.NextRetryUnix)
+
+	hook = unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+	assert.True(t, hook.IsActive)
+	assert.Equal(t, 1, hook.ConsecutiveFailures)
+
+	// the failure of another event deactivates the webhook
+	defer test.MockVariableValue(&setting.Webhook.MaxRetries, 0)()
+	otherTask, err := webhook_model.CreateHookTask(t.Context(), &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadVersion: 2,
+	})
+	require.NoError(t, err)
+	require.NoError(t, Deliver(t.Context(), otherTask))
+
+	otherTask, err = webhook_model.GetHookTaskByID(t.Context(), otherTask.ID)
+	require.NoError(t, err)
+	assert.True(t, otherTask.IsDeadLettered)
+
+	hook = unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: hook.ID})
+	assert.False(t, hook.IsActive)
+	assert.Zero(t, hook.ConsecutiveFailures)
+
+	// a redelivery is a new task, it's skipped as the webhook is inactive
+	redelivered, err := RedeliverDeadLetteredHookTasks(t.Context(), webhook_model.FindDeadLetteredHookTasksOptions{HookID: hook.ID, IDs: []int64{hookTask.ID}})
+	require.NoError(t, err)
+	require.Len(t, redelivered, 1)
+	assert.NotEqual(t, hookTask.ID, redelivered[0].ID)
+	assert.Equal(t, hookTask.PayloadContent, redelivered[0].PayloadContent)
+	assert.False(t, redelivered[0].IsManual)
+
+	hookTask, err = webhook_model.GetHookTaskByID(t.Context(), hookTask.ID)
+	require.NoError(t, err)
+	assert.False(t, hookTask.IsDeadLettered)
+}
@@ -0,0 +1,297 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	"code.gitea.io/gitea/modules/validation"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+// rocketChatCollapseLines is the number of lines of the attachment text, a longer attachment is collapsed
+const rocketChatCollapseLines = 5
+
+// RocketChatMeta contains the Rocket.Chat metadata
+type RocketChatMeta struct {
+	Channel  string `json:"channel"`
+	Username string `json:"username"`
+	IconURL  string `json:"icon_url"`
+}
+
+// GetRocketChatHook returns Rocket.Chat metadata
+func GetRocketChatHook(w *webhook_model.Webhook) *RocketChatMeta {
+	s := &RocketChatMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetRocketChatHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// Validate checks the icon URL
+func (m *RocketChatMeta) Validate() error {
+	if m.IconURL != "" && !validation.IsValidURL(m.IconURL) {
+		return util.NewInvalidArgumentErrorf("invalid icon url %q", m.IconURL)
+	}
+	return nil
+}
+
+// ApplyConfig updates the metadata from the API hook config
+func (m *RocketChatMeta) ApplyConfig(config map[string]string) {
+	if channel, ok := config["channel"]; ok {
+		m.Channel = strings.TrimSpace(channel)
+	}
+	if username, ok := config["username"]; ok {
+		m.Username = strings.TrimSpace(username)
+	}
+	if iconURL, ok := config["icon_url"]; ok {
+		m.IconURL = strings.TrimSpace(iconURL)
+	}
+}
+
+// ToConfig adds the metadata to the API hook config
+func (m *RocketChatMeta) ToConfig(config map[string]string) {
+	config["channel"] = m.Channel
+	config["username"] = m.Username
+	config["icon_url"] = m.IconURL
+}
+
+// RocketChatPayload is the payload of a Rocket.Chat incoming webhook
+// see: https://docs.rocket.chat/docs/integrations
+type RocketChatPayload struct {
+	Channel     string                 `json:"channel,omitempty"`
+	Alias       string                 `json:"alias,omitempty"`
+	Avatar      string                 `json:"avatar,omitempty"`
+	Text        string                 `json:"text"`
+	Attachments []RocketChatAttachment `json:"attachments,omitempty"`
+}
+
+// RocketChatAttachment contains the details of the event
+type RocketChatAttachment struct {
+	Color      string `json:"color"`
+	AuthorName string `json:"author_name"`
+	AuthorLink string `json:"author_link"`
+	AuthorIcon string `json:"author_icon"`
+	Title      string `json:"title"`
+	TitleLink  string `json:"title_link"`
+	Text       string `json:"text"`
+	Collapsed  bool   `json:"collapsed"`
+}
+
+type rocketChatConvertor struct {
+	Channel  string
+	Username string
+	IconURL  string
+}
+
+// Create implements PayloadConvertor Create method
+func (r rocketChatConvertor) Create(p *api.CreatePayload) (RocketChatPayload, error) {
+	refName := git.RefName(p.Ref)
+	repoLink := markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName)
+	refLink := markdownLinkFormatter(p.Repo.HTMLURL+"/src/"+refName.RefWebLinkPath(), refName.ShortName())
+	text := fmt.Sprintf("[%s:%s] %s created by %s", repoLink, refLink, p.RefType, p.Sender.UserName)
+
+	return r.createPayload(text, nil, "", "", "", greenColor), nil
+}
+
+// Delete implements PayloadConvertor Delete method
+func (r rocketChatConvertor) Delete(p *api.DeletePayload) (RocketChatPayload, error) {
+	text := fmt.Sprintf("[%s:%s] %s deleted by %s", markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName), git.RefName(p.Ref).ShortName(), p.RefType, p.Sender.UserName)
+
+	return r.createPayload(text, nil, "", "", "", redColor), nil
+}
+
+// Fork implements PayloadConvertor Fork method
+func (r rocketChatConvertor) Fork(p *api.ForkPayload) (RocketChatPayload, error) {
+	text := fmt.Sprintf("%s is forked to %s", markdownLinkFormatter(p.Forkee.HTMLURL, p.Forkee.FullName), markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName))
+
+	return r.createPayload(text, nil, "", "", "", greenColor), nil
+}
+
+// Push implements PayloadConvertor Push method
+func (r rocketChatConvertor) Push(p *api.PushPayload) (RocketChatPayload, error) {
+	commitDesc := "1 new commit"
+	if p.TotalCommits != 1 {
+		commitDesc = fmt.Sprintf("%d new commits", p.TotalCommits)
+	}
+	if p.CompareURL != "" {
+		commitDesc = markdownLinkFormatter(p.CompareURL, commitDesc)
+	}
+
+	repoLink := markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName)
+	refName := git.RefName(p.Ref)
+	branchLink := markdownLinkFormatter(p.Repo.HTMLURL+"/src/"+refName.RefWebLinkPath(), refName.ShortName())
+	text := fmt.Sprintf("[%s:%s] %s pushed by %s", repoLink, branchLink, commitDesc, p.Pusher.UserName)
+
+	var details strings.Builder
+	for i, commit := range p.Commits {
+		fmt.Fprintf(&details, "%s: %s - %s", markdownLinkFormatter(commit.URL, commit.ID[:7]), strings.SplitN(commit.Message, "\n", 2)[0], commit.Author.Name)
+		if i < len(p.Commits)-1 {
+			details.WriteString("\n")
+		}
+	}
+
+	return r.createPayload(text, p.Sender, p.Repo.FullName, p.Repo.HTMLURL, details.String(), greenColor), nil
+}
+
+// Issue implements PayloadConvertor Issue method
+func (r rocketChatConvertor) Issue(p *api.IssuePayload) (RocketChatPayload, error) {
+	text, issueTitle, extraMarkdown, color := getIssuesPayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, p.Sender, issueTitle, p.Issue.HTMLURL, extraMarkdown, color), nil
+}
+
+// IssueComment implements PayloadConvertor IssueComment method
+func (r rocketChatConvertor) IssueComment(p *api.IssueCommentPayload) (RocketChatPayload, error) {
+	text, issueTitle, color := getIssueCommentPayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, p.Sender, issueTitle, p.Comment.HTMLURL, p.Comment.Body, color), nil
+}
+
+// PullRequest implements PayloadConvertor PullRequest method
+func (r rocketChatConvertor) PullRequest(p *api.PullRequestPayload) (RocketChatPayload, error) {
+	text, issueTitle, extraMarkdown, color := getPullRequestPayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, p.Sender, issueTitle, p.PullRequest.HTMLURL, extraMarkdown, color), nil
+}
+
+// Review implements PayloadConvertor Review method
+func (r rocketChatConvertor) Review(p *api.PullRequestPayload, event webhook_module.HookEventType) (RocketChatPayload, error) {
+	var text, details string
+	color := yellowColor
+	switch p.Action {
+	case api.HookIssueReviewed:
+		action, err := parseHookPullRequestEventType(event)
+		if err != nil {
+			return RocketChatPayload{}, err
+		}
+
+		title := markdownLinkFormatter(p.PullRequest.HTMLURL, fmt.Sprintf("#%d %s", p.Index, p.PullRequest.Title))
+		senderLink := markdownLinkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName)
+		text = fmt.Sprintf("[%s] Pull request review %s: %s by %s", markdownLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName), action, title, senderLink)
+		details = p.Review.Content
+
+		switch event {
+		case webhook_module.HookEventPullRequestReviewApproved:
+			color = greenColor
+		case webhook_module.HookEventPullRequestReviewRejected:
+			color = redColor
+		case webhook_module.HookEventPullRequestReviewComment:
+			color = greyColor
+		}
+	}
+
+	return r.createPayload(text, p.Sender, "", "", details, color), nil
+}
+
+// Repository implements PayloadConvertor Repository method
+func (r rocketChatConvertor) Repository(p *api.RepositoryPayload) (RocketChatPayload, error) {
+	senderLink := markdownLinkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName)
+	var text string
+	color := greenColor
+	switch p.Action {
+	case api.HookRepoCreated:
+		text = fmt.Sprintf("[%s] Repository created by %s", markdownLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName), senderLink)
+	case api.HookRepoDeleted:
+		text = fmt.Sprintf("[%s] Repository deleted by %s", p.Repository.FullName, senderLink)
+		color = redColor
+	}
+
+	return r.createPayload(text, nil, "", "", "", color), nil
+}
+
+// Wiki implements PayloadConvertor Wiki method
+func (r rocketChatConvertor) Wiki(p *api.WikiPayload) (RocketChatPayload, error) {
+	text, color, _ := getWikiPayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, nil, "", "", "", color), nil
+}
+
+// Release implements PayloadConvertor Release method
+func (r rocketChatConvertor) Release(p *api.ReleasePayload) (RocketChatPayload, error) {
+	text, color := getReleasePayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, p.Sender, p.Release.TagName, p.Release.HTMLURL, p.Release.Note, color), nil
+}
+
+func (r rocketChatConvertor) Package(p *api.PackagePayload) (RocketChatPayload, error) {
+	text, color := getPackagePayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, nil, "", "", "", color), nil
+}
+
+func (r rocketChatConvertor) Status(p *api.CommitStatusPayload) (RocketChatPayload, error) {
+	text, color := getStatusPayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, nil, "", "", "", color), nil
+}
+
+func (r rocketChatConvertor) WorkflowRun(p *api.WorkflowRunPayload) (RocketChatPayload, error) {
+	text, color := getWorkflowRunPayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, nil, "", "", "", color), nil
+}
+
+func (r rocketChatConvertor) WorkflowJob(p *api.WorkflowJobPayload) (RocketChatPayload, error) {
+	text, color := getWorkflowJobPayloadInfo(p, markdownLinkFormatter, true)
+
+	return r.createPayload(text, nil, "", "", "", color), nil
+}
+
+// createPayload creates the message, the details are shown in an attachment authored by the sender.
+// Long details are collapsed to keep the channel readable.
+func (r rocketChatConvertor) createPayload(text string, sender *api.User, title, titleLink, details string, color int) RocketChatPayload {
+	payload := RocketChatPayload{
+		Channel: r.Channel,
+		Alias:   r.Username,
+		Avatar:  r.IconURL,
+		Text:    text,
+	}
+	if title == "" && details == "" {
+		return payload
+	}
+
+	attachment := RocketChatAttachment{
+		Color:     fmt.Sprintf("#%06x", color),
+		Title:     title,
+		TitleLink: titleLink,
+		Text:      details,
+		Collapsed: strings.Count(details, "\n") >= rocketChatCollapseLines,
+	}
+	if sender != nil {
+		attachment.AuthorName = sender.UserName
+		attachment.AuthorLink = setting.AppURL + url.PathEscape(sender.UserName)
+		attachment.AuthorIcon = sender.AvatarURL
+	}
+	payload.Attachments = []RocketChatAttachment{attachment}
+	return payload
+}
+
+func newRocketChatRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &RocketChatMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newRocketChatRequest meta json: %w", err)
+	}
+	var pc payloadConvertor[RocketChatPayload] = rocketChatConvertor{
+		Channel:  meta.Channel,
+		Username: meta.Username,
+		IconURL:  meta.IconURL,
+	}
+	return newJSONRequest(pc, w, t, true)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.ROCKETCHAT, newRocketChatRequest)
+}
@@ -0,0 +1,162 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/json"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRocketChatPayload(t *testing.T) {
+	rc := rocketChatConvertor{Channel: "#general", Username: "Gitea", IconURL: "https://example.com/gitea.png"}
+
+	t.Run("Create", func(t *testing.T) {
+		p := createTestPayload()
+
+		pl, err := rc.Create(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "#general", pl.Channel)
+		assert.Equal(t, "Gitea", pl.Alias)
+		assert.Equal(t, "https://example.com/gitea.png", pl.Avatar)
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):[test](http://localhost:3000/test/repo/src/branch/test)] branch created by user1", pl.Text)
+		assert.Empty(t, pl.Attachments)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		p := deleteTestPayload()
+
+		pl, err := rc.Delete(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):test] branch deleted by user1", pl.Text)
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		p := pushTestPayload()
+
+		pl, err := rc.Push(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):[test](http://localhost:3000/test/repo/src/branch/test)] 2 new commits pushed by user1", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, "[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778): commit message - user1\n[2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778): commit message - user1", pl.Attachments[0].Text)
+		assert.False(t, pl.Attachments[0].Collapsed)
+
+		commit := p.Commits[0]
+		p.Commits = []*api.PayloadCommit{commit, commit, commit, commit, commit, commit}
+		pl, err = rc.Push(p)
+		require.NoError(t, err)
+
+		require.Len(t, pl.Attachments, 1)
+		assert.True(t, pl.Attachments[0].Collapsed)
+	})
+
+	t.Run("Issue", func(t *testing.T) {
+		p := issueTestPayload()
+
+		p.Action = api.HookIssueOpened
+		pl, err := rc.Issue(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Issue opened: [#2 crash](http://localhost:3000/test/repo/issues/2) by [user1](https://try.gitea.io/user1)", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, RocketChatAttachment{
+			Color:      "#eb6420",
+			AuthorName: "user1",
+			AuthorLink: "https://try.gitea.io/user1",
+			AuthorIcon: "http://localhost:3000/user1/avatar",
+			Title:      "#2 crash",
+			TitleLink:  "http://localhost:3000/test/repo/issues/2",
+			Text:       "issue body",
+		}, pl.Attachments[0])
+	})
+
+	t.Run("IssueComment", func(t *testing.T) {
+		p := issueCommentTestPayload()
+
+		pl, err := rc.IssueComment(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] New comment on issue [#2 crash](http://localhost:3000/test/repo/issues/2) by [user1](https://try.gitea.io/user1)", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, "more info needed", pl.Attachments[0].Text)
+	})
+
+	t.Run("PullRequest", func(t *testing.T) {
+		p := pullRequestTestPayload()
+
+		pl, err := rc.PullRequest(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Pull request opened: [#12 Fix bug](http://localhost:3000/test/repo/pulls/12) by [user1](https://try.gitea.io/user1)", pl.Text)
+	})
+
+	t.Run("Review", func(t *testing.T) {
+		p := pullRequestTestPayload()
+		p.Action = api.HookIssueReviewed
+
+		pl, err := rc.Review(p, webhook_module.HookEventPullRequestReviewRejected)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Pull request review requested changes: [#12 Fix bug](http://localhost:3000/test/repo/pulls/12) by [user1](https://try.gitea.io/user1)", pl.Text)
+		require.Len(t, pl.Attachments, 1)
+		assert.Equal(t, "#ff3232", pl.Attachments[0].Color)
+		assert.Equal(t, "good job", pl.Attachments[0].Text)
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		p := pullReleaseTestPayload()
+
+		pl, err := rc.Release(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Release created: [v1.0](http://localhost:3000/test/repo/releases/tag/v1.0) by [user1](https://try.gitea.io/user1)", pl.Text)
+	})
+}
+
+func TestRocketChatJSONPayload(t *testing.T) {
+	p := pushTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	hook := &webhook_model.Webhook{
+		RepoID:     3,
+		IsActive:   true,
+		Type:       webhook_module.ROCKETCHAT,
+		URL:        "https://rocketchat.example.com/hooks/xxx",
+		Meta:       `{"channel":"#general","username":"Gitea"}`,
+		HTTPMethod: "POST",
+	}
+	task := &webhook_model.HookTask{
+		HookID:         hook.ID,
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+
+	req, reqBody, err := newRocketChatRequest(t.Context(), hook, task)
+	require.NotNil(t, req)
+	require.NotNil(t, reqBody)
+	require.NoError(t, err)
+
+	assert.Equal(t, "POST", req.Method)
+	assert.Equal(t, "https://rocketchat.example.com/hooks/xxx", req.URL.String())
+	assert.Equal(t, "sha256=", req.Header.Get("X-Hub-Signature-256"))
+	assert.Equal(t, "application/json", req.Header.Get("Content-Type"))
+	var body RocketChatPayload
+	err = json.NewDecoder(req.Body).Decode(&body)
+	assert.NoError(t, err)
+	assert.Equal(t, "#general", body.Channel)
+	assert.Equal(t, "Gitea", body.Alias)
+	assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):[test](http://localhost:3000/test/repo/src/branch/test)] 2 new commits pushed by user1", body.Text)
+	assert.Contains(t, string(reqBody), `"alias": "Gitea"`)
+}
@@ -21,6 +21,7 @@ import (
 	"code.gitea.io/gitea/modules/queue"
 	"code.gitea.io/gitea/modules/setting"
 	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/timeutil"
 	"code.gitea.io/gitea/modules/util"
 	webhook_module "code.gitea.io/gitea/modules/webhook"
 )
@@ -33,6 +34,20 @@ func RegisterWebhookRequester(hookType webhook_module.HookType, requester Reques
 	webhookRequesters[hookType] = requester
 }
 
+// BatchRequester creates one request delivering several hook tasks of a webhook
+type BatchRequester struct {
+	// MaxBatchSize returns the maximum number of hook tasks the webhook delivers in one request, below 2 disables the batching
+	MaxBatchSize func(w *webhook_model.Webhook) int
+	NewRequest   func(ctx context.Context, w *webhook_model.Webhook, tasks []*webhook_model.HookTask) (req *http.Request, body []byte, err error)
+}
+
+var webhookBatchRequesters = map[webhook_module.HookType]BatchRequester{}
+
+// RegisterWebhookBatchRequester registers the requester of the webhook type which delivers the pending hook tasks of a webhook together
+func RegisterWebhookBatchRequester(hookType webhook_module.HookType, requester BatchRequester) {
+	webhookBatchRequesters[hookType] = requester
+}
+
 // IsValidHookTaskType returns true if a webhook registered
 func IsValidHookTaskType(name string) bool {
 	if name == webhook_module.GITEA || name == webhook_module.GOGS {
@@ -95,6 +110,12 @@ func handler(items ...int64) []int64 {
 			continue
 		}
 
+		if task.NextRetryUnix > timeutil.TimeStampNow() {
+			// The retry is enqueued again once its backoff has elapsed
+			log.Trace("Task[%d] is retried later", task.ID)
+			continue
+		}
+
 		if err := Deliver(ctx, task); err != nil {
 			log.Error("Unable to deliver webhook task[%d]: %v", task.ID, err)
 		}
@@ -133,6 +154,16 @@ func checkBranchFilter(branchFilter string, ref git.RefName) bool {
 // The payload is saved as-is. The adjustments depending on the webhook type happen
 // right before delivery, in the [Deliver] method.
 func PrepareWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook_module.HookEventType, p api.Payloader) error {
+	return prepareWebhook(ctx, w, event, p, false)
+}
+
+// PrepareTestWebhook creates a hook task for a test delivery and enqueues it for processing.
+// A test delivery is sent once, it's neither retried nor counted as a failure of the webhook.
+func PrepareTestWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook_module.HookEventType, p api.Payloader) error {
+	return prepareWebhook(ctx, w, event, p, true)
+}
+
+func prepareWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook_module.HookEventType, p api.Payloader, isManual bool) error {
 	// Skip sending if webhooks are disabled.
 	if setting.DisableWebhooks {
 		return nil
@@ -145,7 +176,7 @@ func PrepareWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook
 	// Avoid sending "0 new commits" to non-integration relevant webhooks (e.g. slack, discord, etc.).
 	// Integration webhooks (e.g. drone) still receive the required data.
 	if pushEvent, ok := p.(*api.PushPayload); ok &&
-		w.Type != webhook_module.GITEA && w.Type != webhook_module.GOGS &&
+		w.Type != webhook_module.GITEA && w.Type != webhook_module.GOGS && w.Type != webhook_module.CUSTOM && w.Type != webhook_module.CLOUDEVENTS &&
 		len(pushEvent.Commits) == 0 {
 		return nil
 	}
@@ -163,15 +194,24 @@ func PrepareWebhook(ctx context.Context, w *webhook_model.Webhook, event webhook
 		return fmt.Errorf("JSONPayload for %s: %w", event, err)
 	}
 
+	// The events filtered out are recorded as delivered, so they show up in the delivery history.
+	filtered := !matchFilterExpression(w, event, payload)
+
 	task, err := webhook_model.CreateHookTask(ctx, &webhook_model.HookTask{
 		HookID:         w.ID,
 		PayloadContent: string(payload),
 		EventType:      event,
 		PayloadVersion: 2,
+		IsDelivered:    filtered,
+		IsFiltered:     filtered,
+		IsManual:       isManual,
 	})
 	if err != nil {
 		return fmt.Errorf("CreateHookTask for %s: %w", event, err)
 	}
+	if filtered {
+		return nil
+	}
 
 	return enqueueHookTask(task.ID)
 }
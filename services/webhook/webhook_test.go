@@ -19,6 +19,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"xorm.io/builder"
 )
 
 func TestWebhook_GetSlackHook(t *testing.T) {
@@ -83,6 +84,65 @@ func TestPrepareWebhooksBranchFilterNoMatch(t *testing.T) {
 	}
 }
 
+func TestPrepareWebhooksFilterExpression(t *testing.T) {
+	assert.NoError(t, unittest.PrepareTestDatabase())
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	w := unittest.AssertExistsAndLoadBean(t, &webhook_model.Webhook{ID: 1})
+	w.HookEvent.FilterExpression = `paths matches "docs/**" && sender != "user5"`
+	require.NoError(t, w.UpdateEvent())
+	require.NoError(t, webhook_model.UpdateWebhook(t.Context(), w))
+
+	payload := func(sender string, modified ...string) *api.PushPayload {
+		return &api.PushPayload{
+			Commits: []*api.PayloadCommit{{Modified: modified}},
+			Sender:  &api.User{UserName: sender},
+		}
+	}
+
+	assert.NoError(t, PrepareWebhooks(t.Context(), EventSource{Repository: repo}, webhook_module.HookEventPush, payload("user2", "README.md")))
+	assert.NoError(t, PrepareWebhooks(t.Context(), EventSource{Repository: repo}, webhook_module.HookEventPush, payload("user5", "docs/usage.md")))
+	unittest.AssertCountByCond(t, "hook_task", builder.Eq{"hook_id": 1, "is_filtered": true, "is_delivered": true}, 2)
+	unittest.AssertCountByCond(t, "hook_task", builder.Eq{"hook_id": 1, "is_delivered": false}, 0)
+
+	assert.NoError(t, PrepareWebhooks(t.Context(), EventSource{Repository: repo}, webhook_module.HookEventPush, payload("user2", "README.md", "docs/usage.md")))
+	unittest.AssertCountByCond(t, "hook_task", builder.Eq{"hook_id": 1, "is_filtered": false, "is_delivered": false}, 1)
+}
+
+func TestNewFilterContext(t *testing.T) {
+	payload, err := (&api.PullRequestPayload{
+		Action: api.HookIssueLabelUpdated,
+		PullRequest: &api.PullRequest{
+			Labels: []*api.Label{{Name: "kind/bug"}, {Name: "deploy"}},
+			Base:   &api.PRBranchInfo{Ref: "main"},
+		},
+		Sender: &api.User{UserName: "user2"},
+	}).JSONPayload()
+	require.NoError(t, err)
+
+	c, err := newFilterContext(webhook_module.HookEventPullRequestLabel, payload)
+	require.NoError(t, err)
+	assert.Equal(t, &webhook_module.FilterContext{
+		Event:      "pull_request_label",
+		Action:     "label_updated",
+		Sender:     "user2",
+		BaseBranch: "main",
+		Labels:     []string{"kind/bug", "deploy"},
+	}, c)
+
+	payload, err = (&api.PushPayload{
+		Commits: []*api.PayloadCommit{
+			{Added: []string{"b.go"}, Modified: []string{"a.go"}},
+			{Removed: []string{"c.go"}, Modified: []string{"a.go"}},
+		},
+	}).JSONPayload()
+	require.NoError(t, err)
+
+	c, err = newFilterContext(webhook_module.HookEventPush, payload)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"a.go", "b.go", "c.go"}, c.Paths)
+}
+
 func TestWebhookUserMail(t *testing.T) {
 	require.NoError(t, unittest.PrepareTestDatabase())
 	defer test.MockVariableValue(&setting.Service.NoReplyAddress, "no-reply.com")()
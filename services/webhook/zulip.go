@@ -0,0 +1,310 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strings"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	"code.gitea.io/gitea/modules/git"
+	"code.gitea.io/gitea/modules/json"
+	"code.gitea.io/gitea/modules/log"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/util"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+)
+
+const (
+	// ZulipNameMaxLength is the maximum length of Zulip stream and topic names
+	ZulipNameMaxLength = 60
+
+	zulipContentMaxLength = 10000
+)
+
+// ZulipMeta contains the Zulip metadata, the stream and the topic are templates evaluated against the event payload
+type ZulipMeta struct {
+	Stream string `json:"stream"`
+	Topic  string `json:"topic"`
+}
+
+// GetZulipHook returns Zulip metadata
+func GetZulipHook(w *webhook_model.Webhook) *ZulipMeta {
+	s := &ZulipMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), s); err != nil {
+		log.Error("webhook.GetZulipHook(%d): %v", w.ID, err)
+	}
+	return s
+}
+
+// Validate checks the stream and the topic templates
+func (m *ZulipMeta) Validate() error {
+	if m.Stream == "" {
+		return util.NewInvalidArgumentErrorf("stream is required")
+	}
+	if _, err := parseCustomTemplate("stream", m.Stream); err != nil {
+		return err
+	}
+	if _, err := parseCustomTemplate("topic", m.Topic); err != nil {
+		return err
+	}
+	return nil
+}
+
+// ApplyConfig updates the metadata from the API hook config
+func (m *ZulipMeta) ApplyConfig(config map[string]string) {
+	if stream, ok := config["stream"]; ok {
+		m.Stream = strings.TrimSpace(stream)
+	}
+	if topic, ok := config["topic"]; ok {
+		m.Topic = strings.TrimSpace(topic)
+	}
+}
+
+// ToConfig adds the metadata to the API hook config
+func (m *ZulipMeta) ToConfig(config map[string]string) {
+	config["stream"] = m.Stream
+	config["topic"] = m.Topic
+}
+
+// ZulipPayload is a message sent to a stream with the Zulip API
+// see: https://zulip.com/api/send-message
+type ZulipPayload struct {
+	Type    string `json:"type"`
+	To      string `json:"to"`
+	Topic   string `json:"topic"`
+	Content string `json:"content"`
+}
+
+type zulipConvertor struct{}
+
+// Create implements PayloadConvertor Create method
+func (z zulipConvertor) Create(p *api.CreatePayload) (ZulipPayload, error) {
+	refName := git.RefName(p.Ref)
+	repoLink := markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName)
+	refLink := markdownLinkFormatter(p.Repo.HTMLURL+"/src/"+refName.RefWebLinkPath(), refName.ShortName())
+	text := fmt.Sprintf("[%s:%s] %s created by %s", repoLink, refLink, p.RefType, p.Sender.UserName)
+
+	return z.createPayload(text, ""), nil
+}
+
+// Delete implements PayloadConvertor Delete method
+func (z zulipConvertor) Delete(p *api.DeletePayload) (ZulipPayload, error) {
+	text := fmt.Sprintf("[%s:%s] %s deleted by %s", markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName), git.RefName(p.Ref).ShortName(), p.RefType, p.Sender.UserName)
+
+	return z.createPayload(text, ""), nil
+}
+
+// Fork implements PayloadConvertor Fork method
+func (z zulipConvertor) Fork(p *api.ForkPayload) (ZulipPayload, error) {
+	text := fmt.Sprintf("%s is forked to %s", markdownLinkFormatter(p.Forkee.HTMLURL, p.Forkee.FullName), markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName))
+
+	return z.createPayload(text, ""), nil
+}
+
+// Push implements PayloadConvertor Push method
+func (z zulipConvertor) Push(p *api.PushPayload) (ZulipPayload, error) {
+	commitDesc := "1 new commit"
+	if p.TotalCommits != 1 {
+		commitDesc = fmt.Sprintf("%d new commits", p.TotalCommits)
+	}
+	if p.CompareURL != "" {
+		commitDesc = markdownLinkFormatter(p.CompareURL, commitDesc)
+	}
+
+	refName := git.RefName(p.Ref)
+	repoLink := markdownLinkFormatter(p.Repo.HTMLURL, p.Repo.FullName)
+	branchLink := markdownLinkFormatter(p.Repo.HTMLURL+"/src/"+refName.RefWebLinkPath(), refName.ShortName())
+	text := fmt.Sprintf("[%s:%s] %s pushed by %s", repoLink, branchLink, commitDesc, p.Pusher.UserName)
+
+	// the commits are a bulleted list instead of a quote to keep their links
+	var content strings.Builder
+	content.WriteString(text)
+	for _, commit := range p.Commits {
+		fmt.Fprintf(&content, "\n* %s: %s - %s", markdownLinkFormatter(commit.URL, commit.ID[:7]), strings.SplitN(commit.Message, "\n", 2)[0], commit.Author.Name)
+	}
+
+	return ZulipPayload{Content: util.TruncateRunes(content.String(), zulipContentMaxLength)}, nil
+}
+
+// Issue implements PayloadConvertor Issue method
+func (z zulipConvertor) Issue(p *api.IssuePayload) (ZulipPayload, error) {
+	text, _, extraMarkdown, _ := getIssuesPayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, extraMarkdown), nil
+}
+
+// IssueComment implements PayloadConvertor IssueComment method
+func (z zulipConvertor) IssueComment(p *api.IssueCommentPayload) (ZulipPayload, error) {
+	text, _, _ := getIssueCommentPayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, p.Comment.Body), nil
+}
+
+// PullRequest implements PayloadConvertor PullRequest method
+func (z zulipConvertor) PullRequest(p *api.PullRequestPayload) (ZulipPayload, error) {
+	text, _, extraMarkdown, _ := getPullRequestPayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, extraMarkdown), nil
+}
+
+// Review implements PayloadConvertor Review method
+func (z zulipConvertor) Review(p *api.PullRequestPayload, event webhook_module.HookEventType) (ZulipPayload, error) {
+	var text, details string
+	switch p.Action {
+	case api.HookIssueReviewed:
+		action, err := parseHookPullRequestEventType(event)
+		if err != nil {
+			return ZulipPayload{}, err
+		}
+
+		title := markdownLinkFormatter(p.PullRequest.HTMLURL, fmt.Sprintf("#%d %s", p.Index, p.PullRequest.Title))
+		senderLink := markdownLinkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName)
+		text = fmt.Sprintf("[%s] Pull request review %s: %s by %s", markdownLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName), action, title, senderLink)
+		details = p.Review.Content
+	}
+
+	return z.createPayload(text, details), nil
+}
+
+// Repository implements PayloadConvertor Repository method
+func (z zulipConvertor) Repository(p *api.RepositoryPayload) (ZulipPayload, error) {
+	senderLink := markdownLinkFormatter(setting.AppURL+url.PathEscape(p.Sender.UserName), p.Sender.UserName)
+	var text string
+	switch p.Action {
+	case api.HookRepoCreated:
+		text = fmt.Sprintf("[%s] Repository created by %s", markdownLinkFormatter(p.Repository.HTMLURL, p.Repository.FullName), senderLink)
+	case api.HookRepoDeleted:
+		text = fmt.Sprintf("[%s] Repository deleted by %s", p.Repository.FullName, senderLink)
+	}
+
+	return z.createPayload(text, ""), nil
+}
+
+// Wiki implements PayloadConvertor Wiki method
+func (z zulipConvertor) Wiki(p *api.WikiPayload) (ZulipPayload, error) {
+	text, _, _ := getWikiPayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, ""), nil
+}
+
+// Release implements PayloadConvertor Release method
+func (z zulipConvertor) Release(p *api.ReleasePayload) (ZulipPayload, error) {
+	text, _ := getReleasePayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, p.Release.Note), nil
+}
+
+func (z zulipConvertor) Package(p *api.PackagePayload) (ZulipPayload, error) {
+	text, _ := getPackagePayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, ""), nil
+}
+
+func (z zulipConvertor) Status(p *api.CommitStatusPayload) (ZulipPayload, error) {
+	text, _ := getStatusPayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, ""), nil
+}
+
+func (z zulipConvertor) WorkflowRun(p *api.WorkflowRunPayload) (ZulipPayload, error) {
+	text, _ := getWorkflowRunPayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, ""), nil
+}
+
+func (z zulipConvertor) WorkflowJob(p *api.WorkflowJobPayload) (ZulipPayload, error) {
+	text, _ := getWorkflowJobPayloadInfo(p, markdownLinkFormatter, true)
+
+	return z.createPayload(text, ""), nil
+}
+
+// createPayload creates the message content, the details are quoted below the text
+func (z zulipConvertor) createPayload(text, details string) ZulipPayload {
+	content := text
+	if details != "" {
+		content += "\n```quote\n" + details + "\n```"
+	}
+	return ZulipPayload{Content: util.TruncateRunes(content, zulipContentMaxLength)}
+}
+
+// zulipPayloadRepository contains the fields of the Gitea payloads the default topic is derived from
+type zulipPayloadRepository struct {
+	Repository *struct {
+		FullName string `json:"full_name"`
+	} `json:"repository"`
+}
+
+// renderZulipName renders the stream or topic template, the name is truncated to the length allowed by Zulip
+func renderZulipName(name, text string, event webhook_module.HookEventType, payload api.Payloader) (string, error) {
+	value, err := renderCustomTemplate(name, text, event, payload)
+	if err != nil {
+		return "", err
+	}
+	return util.TruncateRunes(strings.TrimSpace(value), ZulipNameMaxLength), nil
+}
+
+func newZulipRequest(_ context.Context, w *webhook_model.Webhook, t *webhook_model.HookTask) (*http.Request, []byte, error) {
+	meta := &ZulipMeta{}
+	if err := json.Unmarshal([]byte(w.Meta), meta); err != nil {
+		return nil, nil, fmt.Errorf("newZulipRequest meta json: %w", err)
+	}
+
+	payload, err := newPayload[ZulipPayload](zulipConvertor{}, []byte(t.PayloadContent), t.EventType)
+	if err != nil {
+		return nil, nil, err
+	}
+	data, err := newPayload[api.Payloader](customConvertor{}, []byte(t.PayloadContent), t.EventType)
+	if err != nil {
+		return nil, nil, err
+	}
+	payload.Type = "stream"
+	if payload.To, err = renderZulipName("stream", meta.Stream, t.EventType, data); err != nil {
+		return nil, nil, err
+	} else if payload.To == "" {
+		return nil, nil, util.NewInvalidArgumentErrorf("stream template rendered an empty name")
+	}
+	if payload.Topic, err = renderZulipName("topic", meta.Topic, t.EventType, data); err != nil {
+		// a topic template usually refers to fields which not all events have, e.g. the issue
+		log.Debug("webhook.newZulipRequest(%d): %v, the default topic is used", w.ID, err)
+		payload.Topic = ""
+	}
+	if payload.Topic == "" {
+		// the messages of a repository are grouped in a topic named after it by default
+		var p zulipPayloadRepository
+		if err := json.Unmarshal([]byte(t.PayloadContent), &p); err != nil {
+			return nil, nil, fmt.Errorf("newZulipRequest payload json: %w", err)
+		}
+		if p.Repository != nil {
+			payload.Topic = util.TruncateRunes(p.Repository.FullName, ZulipNameMaxLength)
+		}
+	}
+
+	body := []byte(url.Values{
+		"type":    {payload.Type},
+		"to":      {payload.To},
+		"topic":   {payload.Topic},
+		"content": {payload.Content},
+	}.Encode())
+
+	method := w.HTTPMethod
+	if method == "" {
+		method = http.MethodPost
+	}
+	req, err := http.NewRequest(method, w.URL, bytes.NewReader(body))
+	if err != nil {
+		return nil, nil, err
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	return req, body, addDefaultHeaders(req, []byte(w.Secret), w, t, body)
+}
+
+func init() {
+	RegisterWebhookRequester(webhook_module.ZULIP, newZulipRequest)
+}
@@ -0,0 +1,188 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package webhook
+
+import (
+	"io"
+	"net/url"
+	"strings"
+	"testing"
+
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestZulipPayload(t *testing.T) {
+	zc := zulipConvertor{}
+
+	t.Run("Create", func(t *testing.T) {
+		p := createTestPayload()
+
+		pl, err := zc.Create(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):[test](http://localhost:3000/test/repo/src/branch/test)] branch created by user1", pl.Content)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		p := deleteTestPayload()
+
+		pl, err := zc.Delete(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):test] branch deleted by user1", pl.Content)
+	})
+
+	t.Run("Push", func(t *testing.T) {
+		p := pushTestPayload()
+
+		pl, err := zc.Push(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo):[test](http://localhost:3000/test/repo/src/branch/test)] 2 new commits pushed by user1\n"+
+			"* [2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778): commit message - user1\n"+
+			"* [2020558](http://localhost:3000/test/repo/commit/2020558fe2e34debb818a514715839cabd25e778): commit message - user1", pl.Content)
+	})
+
+	t.Run("Issue", func(t *testing.T) {
+		p := issueTestPayload()
+
+		p.Action = api.HookIssueOpened
+		pl, err := zc.Issue(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Issue opened: [#2 crash](http://localhost:3000/test/repo/issues/2) by [user1](https://try.gitea.io/user1)\n```quote\nissue body\n```", pl.Content)
+
+		p.Action = api.HookIssueClosed
+		pl, err = zc.Issue(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Issue closed: [#2 crash](http://localhost:3000/test/repo/issues/2) by [user1](https://try.gitea.io/user1)", pl.Content)
+	})
+
+	t.Run("IssueComment", func(t *testing.T) {
+		p := issueCommentTestPayload()
+
+		pl, err := zc.IssueComment(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] New comment on issue [#2 crash](http://localhost:3000/test/repo/issues/2) by [user1](https://try.gitea.io/user1)\n```quote\nmore info needed\n```", pl.Content)
+	})
+
+	t.Run("PullRequest", func(t *testing.T) {
+		p := pullRequestTestPayload()
+
+		pl, err := zc.PullRequest(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Pull request opened: [#12 Fix bug](http://localhost:3000/test/repo/pulls/12) by [user1](https://try.gitea.io/user1)\n```quote\nfixes bug #2\n```", pl.Content)
+	})
+
+	t.Run("Review", func(t *testing.T) {
+		p := pullRequestTestPayload()
+		p.Action = api.HookIssueReviewed
+
+		pl, err := zc.Review(p, webhook_module.HookEventPullRequestReviewApproved)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Pull request review approved: [#12 Fix bug](http://localhost:3000/test/repo/pulls/12) by [user1](https://try.gitea.io/user1)\n```quote\ngood job\n```", pl.Content)
+	})
+
+	t.Run("Repository", func(t *testing.T) {
+		p := repositoryTestPayload()
+
+		pl, err := zc.Repository(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Repository created by [user1](https://try.gitea.io/user1)", pl.Content)
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		p := pullReleaseTestPayload()
+
+		pl, err := zc.Release(p)
+		require.NoError(t, err)
+
+		assert.Equal(t, "[[test/repo](http://localhost:3000/test/repo)] Release created: [v1.0](http://localhost:3000/test/repo/releases/tag/v1.0) by [user1](https://try.gitea.io/user1)\n```quote\nNote of first stable release\n```", pl.Content)
+	})
+}
+
+func TestZulipMetaValidate(t *testing.T) {
+	assert.NoError(t, (&ZulipMeta{Stream: "gitea"}).Validate())
+	assert.NoError(t, (&ZulipMeta{Stream: "gitea", Topic: "{{.Repository.Name}}"}).Validate())
+	assert.Error(t, (&ZulipMeta{Topic: "{{.Repository.Name}}"}).Validate())
+	assert.Error(t, (&ZulipMeta{Stream: "{{.Repository.Name"}).Validate())
+	assert.Error(t, (&ZulipMeta{Stream: "gitea", Topic: `{{template "x"}}`}).Validate())
+
+	meta := &ZulipMeta{}
+	meta.ApplyConfig(map[string]string{"stream": " gitea ", "topic": "{{.Repo.Name}}"})
+	assert.Equal(t, &ZulipMeta{Stream: "gitea", Topic: "{{.Repo.Name}}"}, meta)
+
+	config := map[string]string{}
+	meta.ToConfig(config)
+	assert.Equal(t, map[string]string{"stream": "gitea", "topic": "{{.Repo.Name}}"}, config)
+}
+
+func TestZulipRequest(t *testing.T) {
+	p := pushTestPayload()
+	data, err := p.JSONPayload()
+	require.NoError(t, err)
+
+	task := &webhook_model.HookTask{
+		EventType:      webhook_module.HookEventPush,
+		PayloadContent: string(data),
+		PayloadVersion: 2,
+	}
+	newRequest := func(t *testing.T, meta string) url.Values {
+		hook := &webhook_model.Webhook{
+			RepoID:     3,
+			IsActive:   true,
+			Type:       webhook_module.ZULIP,
+			URL:        "https://zulip.example.com/api/v1/messages",
+			Meta:       meta,
+			HTTPMethod: "POST",
+		}
+		req, reqBody, err := newZulipRequest(t.Context(), hook, task)
+		require.NoError(t, err)
+
+		assert.Equal(t, "POST", req.Method)
+		assert.Equal(t, "https://zulip.example.com/api/v1/messages", req.URL.String())
+		assert.Equal(t, "application/x-www-form-urlencoded", req.Header.Get("Content-Type"))
+		body, err := io.ReadAll(req.Body)
+		require.NoError(t, err)
+		assert.Equal(t, reqBody, body)
+		values, err := url.ParseQuery(string(body))
+		require.NoError(t, err)
+		return values
+	}
+
+	t.Run("DefaultTopic", func(t *testing.T) {
+		values := newRequest(t, `{"stream":"gitea"}`)
+		assert.Equal(t, "stream", values.Get("type"))
+		assert.Equal(t, "gitea", values.Get("to"))
+		assert.Equal(t, "test/repo", values.Get("topic"))
+		assert.True(t, strings.HasPrefix(values.Get("content"), "[[test/repo](http://localhost:3000/test/repo):"))
+	})
+
+	t.Run("Templates", func(t *testing.T) {
+		values := newRequest(t, `{"stream":"{{upper .Repo.Name}}","topic":"{{event}} to {{.Repo.Name}}"}`)
+		assert.Equal(t, "REPO", values.Get("to"))
+		assert.Equal(t, "push to repo", values.Get("topic"))
+	})
+
+	t.Run("TopicFallback", func(t *testing.T) {
+		// push payloads have no issue, the default topic is used
+		values := newRequest(t, `{"stream":"gitea","topic":"#{{.Issue.Index}} {{.Issue.Title}}"}`)
+		assert.Equal(t, "test/repo", values.Get("topic"))
+	})
+
+	t.Run("TopicLength", func(t *testing.T) {
+		values := newRequest(t, `{"stream":"gitea","topic":"{{.Repo.Name}}`+strings.Repeat("x", 100)+`"}`)
+		assert.Equal(t, "repo"+strings.Repeat("x", ZulipNameMaxLength-4), values.Get("topic"))
+	})
+}
@@ -0,0 +1,102 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"net/http"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	webhook_model "code.gitea.io/gitea/models/webhook"
+	api "code.gitea.io/gitea/modules/structs"
+	webhook_module "code.gitea.io/gitea/modules/webhook"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPIDeadLetteredHookDeliveries(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	createDeadLetter := func(hookID int64) *webhook_model.HookTask {
+		task, err := webhook_model.CreateHookTask(t.Context(), &webhook_model.HookTask{
+			HookID:         hookID,
+			EventType:      webhook_module.HookEventPush,
+			PayloadContent: "{}",
+			PayloadVersion: 2,
+			IsDelivered:    true,
+			IsDeadLettered: true,
+			Attempts:       6,
+		})
+		require.NoError(t, err)
+		return task
+	}
+	orgTask := createDeadLetter(3)  // webhook of org3 and repo3
+	repoTask := createDeadLetter(2) // inactive webhook of user2/repo1
+
+	t.Run("Organization", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, "user2", auth_model.AccessTokenScopeWriteOrganization)
+
+		req := NewRequest(t, "GET", "/api/v1/orgs/org3/hooks/dead_letters").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var deliveries []*api.HookDelivery
+		DecodeJSON(t, resp, &deliveries)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, orgTask.ID, deliveries[0].ID)
+		assert.Equal(t, orgTask.UUID, deliveries[0].UUID)
+		assert.Equal(t, 6, deliveries[0].Attempts)
+		assert.True(t, deliveries[0].IsDeadLettered)
+
+		// deliveries of other owners can't be redelivered
+		req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/hooks/dead_letters/redeliver", &api.RedeliverHookDeliveriesOption{IDs: []int64{repoTask.ID}}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+		DecodeJSON(t, resp, &deliveries)
+		assert.Empty(t, deliveries)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/orgs/org3/hooks/dead_letters/redeliver", &api.RedeliverHookDeliveriesOption{IDs: []int64{orgTask.ID}}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+		DecodeJSON(t, resp, &deliveries)
+		require.Len(t, deliveries, 1)
+		assert.NotEqual(t, orgTask.ID, deliveries[0].ID)
+		assert.Equal(t, int64(3), deliveries[0].HookID)
+
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/hooks/dead_letters").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &deliveries)
+		assert.Empty(t, deliveries)
+
+		token = getUserToken(t, "user4", auth_model.AccessTokenScopeWriteOrganization)
+		req = NewRequest(t, "GET", "/api/v1/orgs/org3/hooks/dead_letters").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+
+	t.Run("Admin", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		token := getUserToken(t, "user1", auth_model.AccessTokenScopeWriteAdmin)
+
+		req := NewRequest(t, "GET", "/api/v1/admin/hooks/dead_letters?hook_id=2").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var deliveries []*api.HookDelivery
+		DecodeJSON(t, resp, &deliveries)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, repoTask.ID, deliveries[0].ID)
+
+		req = NewRequestWithJSON(t, "POST", "/api/v1/admin/hooks/dead_letters/redeliver", &api.RedeliverHookDeliveriesOption{HookID: 2}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusCreated)
+		DecodeJSON(t, resp, &deliveries)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, int64(2), deliveries[0].HookID)
+
+		req = NewRequest(t, "GET", "/api/v1/admin/hooks/dead_letters").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &deliveries)
+		assert.Empty(t, deliveries)
+	})
+}
@@ -0,0 +1,263 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	ansible_module "code.gitea.io/gitea/modules/packages/ansible"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageAnsible(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	packageNamespace := "gitea"
+	packageName := "utils"
+	packageVersion := "1.0.1"
+	packageDescription := "Collection Description"
+	filename := fmt.Sprintf("%s-%s-%s.tar.gz", packageNamespace, packageName, packageVersion)
+
+	createCollection := func(version string) []byte {
+		manifest := `{"collection_info":{"namespace":"` + packageNamespace + `","name":"` + packageName + `","version":"` + version + `","authors":["Gitea"],"readme":"README.md","description":"` + packageDescription + `","license":["MIT"],"dependencies":{"community.general":">=7.0.0"}},"format":1}`
+
+		var buf bytes.Buffer
+		zw := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(zw)
+		for _, file := range []struct {
+			Name    string
+			Content string
+		}{
+			{"MANIFEST.json", manifest},
+			{"README.md", "# Utils"},
+		} {
+			tw.WriteHeader(&tar.Header{Name: file.Name, Mode: 0o600, Size: int64(len(file.Content))})
+			tw.Write([]byte(file.Content))
+		}
+		tw.Close()
+		zw.Close()
+		return buf.Bytes()
+	}
+
+	// ansible-galaxy uses a "file" content disposition for the collection part
+	createUploadBody := func(content []byte, checksum string) (*bytes.Buffer, string) {
+		boundary := "gitea-boundary"
+		var body bytes.Buffer
+		fmt.Fprintf(&body, "--%s\r\nContent-Disposition: form-data; name=\"sha256\"\r\n\r\n%s\r\n", boundary, checksum)
+		fmt.Fprintf(&body, "--%s\r\nContent-Disposition: file; name=\"file\"; filename=\"%s\"\r\nContent-Type: application/octet-stream\r\n\r\n", boundary, filename)
+		body.Write(content)
+		fmt.Fprintf(&body, "\r\n--%s--\r\n", boundary)
+		return &body, "multipart/form-data; boundary=" + boundary
+	}
+
+	content := createCollection(packageVersion)
+	sum := sha256.Sum256(content)
+	checksum := hex.EncodeToString(sum[:])
+
+	root := fmt.Sprintf("/api/packages/%s/ansible", user.Name)
+	apiURL := root + "/api/v3"
+
+	var taskURL string
+
+	t.Run("Discovery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/")
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", root+"/api/")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			AvailableVersions map[string]string `json:"available_versions"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, "v3/", result.AvailableVersions["v3"])
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadURL := apiURL + "/artifacts/collections/"
+
+		body, contentType := createUploadBody(content, checksum)
+		req := NewRequestWithBody(t, "POST", uploadURL, body)
+		req.Header.Set("Content-Type", contentType)
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		body, contentType = createUploadBody(content, strings.Repeat("0", 64))
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			AddTokenAuth(token)
+		req.Header.Set("Content-Type", contentType)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		body, contentType = createUploadBody([]byte("invalid"), "")
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			AddTokenAuth(token)
+		req.Header.Set("Content-Type", contentType)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		body, contentType = createUploadBody(content, checksum)
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			AddTokenAuth(token)
+		req.Header.Set("Content-Type", contentType)
+		resp := MakeRequest(t, req, http.StatusAccepted)
+
+		var result struct {
+			Task string `json:"task"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Contains(t, result.Task, "/api/v3/imports/collections/")
+		taskURL = result.Task
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeAnsible)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &ansible_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageNamespace+"."+packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+		assert.Equal(t, packageDescription, pd.Metadata.(*ansible_module.Metadata).Description)
+		assert.Equal(t, "# Utils", pd.Metadata.(*ansible_module.Metadata).Readme)
+
+		pfs, err := packages.GetFilesByVersionID(t.Context(), pvs[0].ID)
+		require.NoError(t, err)
+		require.Len(t, pfs, 1)
+		assert.Equal(t, filename, pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		body, contentType = createUploadBody(content, checksum)
+		req = NewRequestWithBody(t, "POST", uploadURL, body).
+			AddTokenAuth(token)
+		req.Header.Set("Content-Type", contentType)
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("ImportTask", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		_, path, _ := strings.Cut(taskURL, "/api/packages/")
+		req := NewRequest(t, "GET", "/api/packages/"+path)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			State      string `json:"state"`
+			FinishedAt string `json:"finished_at"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, "completed", result.State)
+		assert.NotEmpty(t, result.FinishedAt)
+
+		req = NewRequest(t, "GET", apiURL+"/imports/collections/999999/")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/collections/%s/%s/", apiURL, packageNamespace, packageName))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var info struct {
+			HighestVersion struct {
+				Version string `json:"version"`
+			} `json:"highest_version"`
+		}
+		DecodeJSON(t, resp, &info)
+		assert.Equal(t, packageVersion, info.HighestVersion.Version)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/collections/%s/%s/versions/?limit=100", apiURL, packageNamespace, packageName))
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var list struct {
+			Meta struct {
+				Count int `json:"count"`
+			} `json:"meta"`
+			Links struct {
+				Next *string `json:"next"`
+			} `json:"links"`
+			Data []struct {
+				Version string `json:"version"`
+			} `json:"data"`
+		}
+		DecodeJSON(t, resp, &list)
+		assert.Equal(t, 1, list.Meta.Count)
+		assert.Nil(t, list.Links.Next)
+		require.Len(t, list.Data, 1)
+		assert.Equal(t, packageVersion, list.Data[0].Version)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/collections/%s/unknown/versions/", apiURL, packageNamespace))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("VersionMetadata", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/collections/%s/%s/versions/%s/", apiURL, packageNamespace, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			Namespace struct {
+				Name string `json:"name"`
+			} `json:"namespace"`
+			Collection struct {
+				Name string `json:"name"`
+			} `json:"collection"`
+			Version     string `json:"version"`
+			DownloadURL string `json:"download_url"`
+			Artifact    struct {
+				Filename string `json:"filename"`
+				Sha256   string `json:"sha256"`
+				Size     int64  `json:"size"`
+			} `json:"artifact"`
+			Metadata struct {
+				Dependencies map[string]string `json:"dependencies"`
+			} `json:"metadata"`
+		}
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, packageNamespace, result.Namespace.Name)
+		assert.Equal(t, packageName, result.Collection.Name)
+		assert.Equal(t, packageVersion, result.Version)
+		assert.True(t, strings.HasSuffix(result.DownloadURL, root+"/download/"+filename))
+		assert.Equal(t, filename, result.Artifact.Filename)
+		assert.Equal(t, checksum, result.Artifact.Sha256)
+		assert.EqualValues(t, len(content), result.Artifact.Size)
+		assert.Equal(t, map[string]string{"community.general": ">=7.0.0"}, result.Metadata.Dependencies)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/collections/%s/%s/versions/9.9.9/", apiURL, packageNamespace, packageName))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/download/"+filename)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/download/gitea-utils-9.9.9.tar.gz")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
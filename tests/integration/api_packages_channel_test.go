@@ -0,0 +1,189 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageChannels(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "channel-package"
+
+	uploadURL := fmt.Sprintf("/api/packages/%s/generic/%s", user.Name, packageName)
+	for _, version := range []string{"1.0.0", "1.1.0"} {
+		req := NewRequestWithBody(t, "PUT", uploadURL+"/"+version+"/file.bin", strings.NewReader(version)).
+			AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	packageURL := fmt.Sprintf("/api/v1/packages/%s/generic/%s", user.Name, packageName)
+
+	t.Run("Publish", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "PUT", packageURL+"/1.0.0/channels/Invalid!").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		for _, version := range []string{"1.0.0", "1.1.0"} {
+			req = NewRequest(t, "PUT", packageURL+"/"+version+"/channels/staging").AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusCreated)
+
+			var channel *api.PackageVersionChannel
+			DecodeJSON(t, resp, &channel)
+			assert.Equal(t, "staging", channel.Channel)
+			assert.Empty(t, channel.PromotedFrom)
+		}
+
+		req = NewRequest(t, "PUT", packageURL+"/1.0.0/channels/staging").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequest(t, "GET", packageURL+"/-/channels/staging").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var versions []*api.Package
+		DecodeJSON(t, resp, &versions)
+		require.Len(t, versions, 2)
+		assert.ElementsMatch(t, []string{"1.0.0", "1.1.0"}, []string{versions[0].Version, versions[1].Version})
+	})
+
+	t.Run("Promote", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "POST", packageURL+"/1.0.0/promote", &api.PromotePackageVersionOption{From: "beta", To: "stable"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithJSON(t, "POST", packageURL+"/1.0.0/promote", &api.PromotePackageVersionOption{From: "staging", To: "staging"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "POST", packageURL+"/1.0.0/promote", &api.PromotePackageVersionOption{From: "staging", To: "stable"}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var channel *api.PackageVersionChannel
+		DecodeJSON(t, resp, &channel)
+		assert.Equal(t, "stable", channel.Channel)
+		assert.Equal(t, "staging", channel.PromotedFrom)
+
+		req = NewRequestWithJSON(t, "POST", packageURL+"/1.0.0/promote", &api.PromotePackageVersionOption{From: "staging", To: "stable"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		req = NewRequest(t, "GET", packageURL+"/1.0.0/channels").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var channels []*api.PackageVersionChannel
+		DecodeJSON(t, resp, &channels)
+		require.Len(t, channels, 2)
+		assert.Equal(t, "stable", channels[0].Channel)
+		assert.Equal(t, "staging", channels[1].Channel)
+
+		req = NewRequest(t, "GET", packageURL+"/-/channels/stable").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+
+		var versions []*api.Package
+		DecodeJSON(t, resp, &versions)
+		require.Len(t, versions, 1)
+		assert.Equal(t, "1.0.0", versions[0].Version)
+	})
+
+	t.Run("Remove", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", packageURL+"/1.1.0/channels/staging").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", packageURL+"/1.1.0/channels/staging").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("ImmutableVersions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		settingsURL := fmt.Sprintf("/api/v1/packages/%s/-/settings", user.Name)
+
+		req := NewRequest(t, "GET", settingsURL).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var settings *api.PackageSettings
+		DecodeJSON(t, resp, &settings)
+		assert.False(t, settings.ImmutableVersions)
+
+		req = NewRequestWithJSON(t, "PATCH", settingsURL, &api.EditPackageSettingsOption{ImmutableVersions: new(true)}).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &settings)
+		assert.True(t, settings.ImmutableVersions)
+
+		req = NewRequest(t, "DELETE", uploadURL+"/1.0.0/file.bin").AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", uploadURL+"/1.0.0").AddBasicAuth(user.Name)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", packageURL+"/1.0.0").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequest(t, "DELETE", packageURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		// channels are not affected
+		req = NewRequest(t, "PUT", packageURL+"/1.1.0/channels/staging").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		t.Run("Maven", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			pom := `<?xml version="1.0"?><project><groupId>com.gitea</groupId><artifactId>immutable</artifactId><version>%s</version></project>`
+			for _, version := range []string{"1.0", "1.0-SNAPSHOT"} {
+				url := fmt.Sprintf("/api/packages/%s/maven/com/gitea/immutable/%s/immutable-%s.pom", user.Name, version, version)
+
+				req := NewRequestWithBody(t, "PUT", url, strings.NewReader(fmt.Sprintf(pom, version))).AddBasicAuth(user.Name)
+				MakeRequest(t, req, http.StatusCreated)
+
+				expected := http.StatusForbidden
+				if strings.HasSuffix(version, "-SNAPSHOT") {
+					expected = http.StatusConflict
+				}
+				req = NewRequestWithBody(t, "PUT", url, strings.NewReader(fmt.Sprintf(pom, version))).AddBasicAuth(user.Name)
+				MakeRequest(t, req, expected)
+			}
+		})
+
+		t.Run("Admin", func(t *testing.T) {
+			defer tests.PrintCurrentTest(t)()
+
+			req := NewRequest(t, "DELETE", uploadURL+"/1.0.0").AddBasicAuth("user1")
+			MakeRequest(t, req, http.StatusNoContent)
+		})
+
+		req = NewRequestWithJSON(t, "PATCH", settingsURL, &api.EditPackageSettingsOption{ImmutableVersions: new(false)}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "DELETE", packageURL+"/1.1.0").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+	})
+
+	t.Run("Settings", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		readToken := getUserToken(t, "user4", auth_model.AccessTokenScopeWritePackage)
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/-/settings", user.Name)).AddTokenAuth(readToken)
+		MakeRequest(t, req, http.StatusForbidden)
+	})
+}
@@ -24,6 +24,7 @@ import (
 	api "code.gitea.io/gitea/modules/structs"
 	"code.gitea.io/gitea/modules/test"
 	package_service "code.gitea.io/gitea/services/packages"
+	container_service "code.gitea.io/gitea/services/packages/container"
 	"code.gitea.io/gitea/tests"
 
 	oci "github.com/opencontainers/image-spec/specs-go/v1"
@@ -729,6 +730,76 @@ func TestPackageContainer(t *testing.T) {
 				assert.Len(t, apiPackages, 4) // "latest", "main", "multi", "sha256:..."
 			})
 
+			t.Run("Referrers", func(t *testing.T) {
+				defer tests.PrintCurrentTest(t)()
+
+				emptyConfigDigest := "sha256:44136fa355b3678a1146ad16f7e8649e94fb4fc21fe77e8310c060f61caaff8a"
+				req := NewRequestWithBody(t, "POST", fmt.Sprintf("%s/blobs/uploads?digest=%s", url, emptyConfigDigest), strings.NewReader("{}")).
+					AddTokenAuth(userToken)
+				MakeRequest(t, req, http.StatusCreated)
+
+				signatureArtifactType := "application/vnd.dev.cosign.artifact.sig.v1+json"
+				signatureContent := `{"schemaVersion":2,"mediaType":"` + oci.MediaTypeImageManifest + `","artifactType":"` + signatureArtifactType + `","config":{"mediaType":"application/vnd.oci.empty.v1+json","digest":"` + emptyConfigDigest + `","size":2},"layers":[{"mediaType":"application/vnd.dev.cosign.simplesigning.v1+json","digest":"` + blobDigest + `","size":32}],"subject":{"mediaType":"` + container_module.ContentTypeDockerDistributionManifestV2 + `","digest":"` + manifestDigest + `","size":` + strconv.Itoa(len(manifestContent)) + `},"annotations":{"dev.sigstore.cosign/signature":"signature"}}`
+				signatureSum := sha256.Sum256([]byte(signatureContent))
+				signatureDigest := "sha256:" + hex.EncodeToString(signatureSum[:])
+
+				req = NewRequestWithBody(t, "PUT", fmt.Sprintf("%s/manifests/%s", url, signatureDigest), strings.NewReader(signatureContent)).
+					AddTokenAuth(userToken).
+					SetHeader("Content-Type", oci.MediaTypeImageManifest)
+				resp := MakeRequest(t, req, http.StatusCreated)
+				assert.Equal(t, signatureDigest, resp.Header().Get("Docker-Content-Digest"))
+				assert.Equal(t, manifestDigest, resp.Header().Get("OCI-Subject"))
+
+				pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeContainer, image, signatureDigest)
+				require.NoError(t, err)
+				pd, err := packages_model.GetPackageDescriptor(t.Context(), pv)
+				require.NoError(t, err)
+				assert.Equal(t, []string{manifestDigest}, getAllByName(pd.VersionProperties, container_module.PropertyManifestSubject))
+				assert.Equal(t, signatureArtifactType, pd.Metadata.(*container_module.Metadata).ArtifactType)
+
+				// referrers are kept by the cleanup rules as long as their subject exists
+				skip, err := container_service.ShouldBeSkipped(t.Context(), &packages_model.PackageCleanupRule{}, pd.Package, pv)
+				require.NoError(t, err)
+				assert.True(t, skip)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", url, manifestDigest)).
+					AddTokenAuth(userToken)
+				resp = MakeRequest(t, req, http.StatusOK)
+				assert.Equal(t, oci.MediaTypeImageIndex, resp.Header().Get("Content-Type"))
+
+				var index oci.Index
+				DecodeJSON(t, resp, &index)
+				assert.Equal(t, oci.MediaTypeImageIndex, index.MediaType)
+				require.Len(t, index.Manifests, 1)
+				assert.Equal(t, oci.MediaTypeImageManifest, index.Manifests[0].MediaType)
+				assert.Equal(t, signatureDigest, string(index.Manifests[0].Digest))
+				assert.EqualValues(t, len(signatureContent), index.Manifests[0].Size)
+				assert.Equal(t, signatureArtifactType, index.Manifests[0].ArtifactType)
+				assert.Equal(t, map[string]string{"dev.sigstore.cosign/signature": "signature"}, index.Manifests[0].Annotations)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s?artifactType=%s", url, manifestDigest, "application/spdx%2Bjson")).
+					AddTokenAuth(userToken)
+				resp = MakeRequest(t, req, http.StatusOK)
+				assert.Equal(t, "artifactType", resp.Header().Get("OCI-Filters-Applied"))
+
+				index = oci.Index{}
+				DecodeJSON(t, resp, &index)
+				assert.Empty(t, index.Manifests)
+
+				req = NewRequest(t, "GET", fmt.Sprintf("%s/referrers/%s", url, unknownDigest)).
+					AddTokenAuth(userToken)
+				resp = MakeRequest(t, req, http.StatusOK)
+
+				index = oci.Index{}
+				DecodeJSON(t, resp, &index)
+				assert.NotNil(t, index.Manifests)
+				assert.Empty(t, index.Manifests)
+
+				req = NewRequest(t, "GET", url+"/referrers/invalid").
+					AddTokenAuth(userToken)
+				MakeRequest(t, req, http.StatusBadRequest)
+			})
+
 			t.Run("Delete", func(t *testing.T) {
 				t.Run("Blob", func(t *testing.T) {
 					defer tests.PrintCurrentTest(t)()
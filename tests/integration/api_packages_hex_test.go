@@ -0,0 +1,252 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"crypto"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/sha512"
+	"crypto/x509"
+	"encoding/hex"
+	"encoding/pem"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	hex_module "code.gitea.io/gitea/modules/packages/hex"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/protobuf/encoding/protowire"
+)
+
+func TestPackageHex(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "gitea_package"
+	packageVersion := "1.0.1"
+	packageDescription := "Package Description"
+
+	createPackage := func(description string) []byte {
+		metadata := `{<<"name">>,<<"` + packageName + `">>}.
+{<<"version">>,<<"` + packageVersion + `">>}.
+{<<"description">>,<<"` + description + `">>}.
+{<<"licenses">>,[<<"MIT">>]}.
+{<<"requirements">>,[[{<<"name">>,<<"jason">>},{<<"app">>,<<"jason">>},{<<"optional">>,false},{<<"repository">>,<<"hexpm">>},{<<"requirement">>,<<"~> 1.4">>}]]}.
+`
+		contents := []byte("contents")
+
+		h := sha256.New()
+		h.Write([]byte("3"))
+		h.Write([]byte(metadata))
+		h.Write(contents)
+
+		var buf bytes.Buffer
+		tw := tar.NewWriter(&buf)
+		for _, file := range []struct {
+			Name    string
+			Content []byte
+		}{
+			{"VERSION", []byte("3")},
+			{"CHECKSUM", []byte(strings.ToUpper(hex.EncodeToString(h.Sum(nil))))},
+			{"metadata.config", []byte(metadata)},
+			{"contents.tar.gz", contents},
+		} {
+			tw.WriteHeader(&tar.Header{Name: file.Name, Mode: 0o600, Size: int64(len(file.Content))})
+			tw.Write(file.Content)
+		}
+		tw.Close()
+		return buf.Bytes()
+	}
+
+	content := createPackage(packageDescription)
+
+	root := fmt.Sprintf("/api/packages/%s/hex", user.Name)
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadURL := root + "/api/publish"
+
+		req := NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, strings.NewReader("invalid")).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var result map[string]string
+		DecodeJSON(t, resp, &result)
+		outerChecksum := sha256.Sum256(content)
+		assert.Equal(t, hex.EncodeToString(outerChecksum[:]), result["checksum"])
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &hex_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+		assert.Equal(t, packageDescription, pd.Metadata.(*hex_module.Metadata).Description)
+
+		pfs, err := packages.GetFilesByVersionID(t.Context(), pvs[0].ID)
+		require.NoError(t, err)
+		require.Len(t, pfs, 1)
+		assert.Equal(t, fmt.Sprintf("%s-%s.tar", packageName, packageVersion), pfs[0].Name)
+		assert.True(t, pfs[0].IsLead)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+
+		// the Hex clients expect the responses in the external term format
+		req = NewRequestWithBody(t, "POST", uploadURL+"?replace=true", bytes.NewReader(createPackage("Replaced Description"))).
+			AddTokenAuth(token).
+			SetHeader("Accept", "application/vnd.hex+erlang")
+		resp = MakeRequest(t, req, http.StatusCreated)
+		assert.Equal(t, "application/vnd.hex+erlang", resp.Header().Get("Content-Type"))
+		assert.Equal(t, []byte{131, 116}, resp.Body.Bytes()[:2])
+
+		pvs, err = packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+		pd, err = packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.Equal(t, "Replaced Description", pd.Metadata.(*hex_module.Metadata).Description)
+
+		// restore the original package for the following tests
+		req = NewRequestWithBody(t, "POST", uploadURL+"?replace=true", bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+	})
+
+	var publicKey *rsa.PublicKey
+
+	t.Run("PublicKey", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/public_key")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		block, _ := pem.Decode(resp.Body.Bytes())
+		require.NotNil(t, block)
+		assert.Equal(t, "PUBLIC KEY", block.Type)
+
+		key, err := x509.ParsePKIXPublicKey(block.Bytes)
+		require.NoError(t, err)
+		publicKey = key.(*rsa.PublicKey)
+	})
+
+	// readResource verifies the signature of a registry resource and returns its payload
+	readResource := func(t *testing.T, url string) []byte {
+		req := NewRequest(t, "GET", url)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		zr, err := gzip.NewReader(resp.Body)
+		require.NoError(t, err)
+		signed, err := io.ReadAll(zr)
+		require.NoError(t, err)
+
+		var payload, signature []byte
+		for len(signed) > 0 {
+			num, typ, n := protowire.ConsumeTag(signed)
+			require.GreaterOrEqual(t, n, 0)
+			require.Equal(t, protowire.BytesType, typ)
+			signed = signed[n:]
+			value, n := protowire.ConsumeBytes(signed)
+			require.GreaterOrEqual(t, n, 0)
+			signed = signed[n:]
+
+			switch num {
+			case 1:
+				payload = value
+			case 2:
+				signature = value
+			}
+		}
+
+		hash := sha512.Sum512(payload)
+		require.NoError(t, rsa.VerifyPKCS1v15(publicKey, crypto.SHA512, hash[:], signature))
+
+		return payload
+	}
+
+	t.Run("Names", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		payload := readResource(t, root+"/names")
+		assert.Contains(t, string(payload), packageName)
+		assert.Contains(t, string(payload), user.Name)
+	})
+
+	t.Run("Versions", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		payload := readResource(t, root+"/versions")
+		assert.Equal(t, hex_module.EncodeVersions(user.Name, []*hex_module.VersionsEntry{
+			{Name: packageName, Versions: []string{packageVersion}},
+		}), payload)
+	})
+
+	t.Run("Package", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeHex)
+		require.NoError(t, err)
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		metadata := pd.Metadata.(*hex_module.Metadata)
+
+		innerChecksum, _ := hex.DecodeString(metadata.InnerChecksum)
+		outerChecksum := sha256.Sum256(content)
+
+		payload := readResource(t, root+"/packages/"+packageName)
+		assert.Equal(t, hex_module.EncodePackage(user.Name, packageName, []*hex_module.Release{
+			{
+				Version:       packageVersion,
+				InnerChecksum: innerChecksum,
+				OuterChecksum: outerChecksum[:],
+				Dependencies:  []*hex_module.Requirement{{Name: "jason", App: "jason", Requirement: "~> 1.4", Repository: "hexpm"}},
+			},
+		}), payload)
+
+		req := NewRequest(t, "GET", root+"/packages/unknown_package")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-%s.tar", root, packageName, packageVersion))
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s-2.0.0.tar", root, packageName))
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/tarballs/%s.tar", root, packageName))
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
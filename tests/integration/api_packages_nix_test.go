@@ -0,0 +1,170 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	nix_module "code.gitea.io/gitea/modules/packages/nix"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageNix(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	storeHash := "9q4ibwzv2xhhhrpamlfywa7kxbfd4ad2"
+	storeName := "hello-2.12.1"
+	storePath := nix_module.StoreDir + "/" + storeHash + "-" + storeName
+	narHash := "sha256:1bw8xbmqfbrmyy3k0ysfr1fc0ak4j64g0y6b6lrw6kd2a4lql9bk"
+
+	content := []byte("compressed nar content")
+	sum := sha256.Sum256(content)
+	fileHash := "sha256:" + nix_module.EncodeBase32(sum[:])
+	narFilename := nix_module.EncodeBase32(sum[:]) + ".nar.xz"
+
+	narInfo := `StorePath: ` + storePath + `
+URL: nar/` + narFilename + `
+Compression: xz
+FileHash: ` + fileHash + `
+FileSize: ` + fmt.Sprint(len(content)) + `
+NarHash: ` + narHash + `
+NarSize: 226560
+References: ` + storeHash + `-` + storeName + `
+System: x86_64-linux
+`
+
+	root := fmt.Sprintf("/api/packages/%s/nix", user.Name)
+
+	t.Run("CacheInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/nix-cache-info")
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "StoreDir: /nix/store\n")
+	})
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		narURL := root + "/nar/" + narFilename
+		narInfoURL := root + "/" + storeHash + ".narinfo"
+
+		req := NewRequestWithBody(t, "PUT", narURL, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "PUT", narURL, strings.NewReader("other content")).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		// the NAR file must be uploaded before the narinfo file
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narURL, bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequestWithBody(t, "PUT", root+"/0000000000000000000000000000000a.narinfo", strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader("invalid")).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeNix)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.Nil(t, pd.SemVer)
+		assert.IsType(t, &nix_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, storeName, pd.Package.Name)
+		assert.Equal(t, storeHash, pd.Version.Version)
+		assert.Equal(t, storePath, pd.Metadata.(*nix_module.Metadata).StorePath)
+
+		require.Len(t, pd.Files, 1)
+		assert.Equal(t, narFilename, pd.Files[0].File.Name)
+		assert.True(t, pd.Files[0].File.IsLead)
+
+		// the uploaded NAR file is moved to the store path
+		pv, err := packages.GetInternalVersionByNameAndVersion(t.Context(), user.ID, packages.TypeNix, nix_module.UploadPackage, nix_module.UploadVersion)
+		require.NoError(t, err)
+		pfs, err := packages.GetFilesByVersionID(t.Context(), pv.ID)
+		require.NoError(t, err)
+		assert.Empty(t, pfs)
+
+		req = NewRequestWithBody(t, "PUT", narInfoURL, strings.NewReader(narInfo)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("NarInfo", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/public_key")
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		keyName, encodedKey, ok := strings.Cut(resp.Body.String(), ":")
+		require.True(t, ok)
+		publicKey, err := base64.StdEncoding.DecodeString(encodedKey)
+		require.NoError(t, err)
+
+		req = NewRequest(t, "HEAD", root+"/"+storeHash+".narinfo")
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", root+"/"+storeHash+".narinfo")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "text/x-nix-narinfo", resp.Header().Get("Content-Type"))
+
+		ni, err := nix_module.ParseNarInfo(resp.Body)
+		require.NoError(t, err)
+		assert.Equal(t, "nar/"+narFilename, ni.URL)
+		assert.Equal(t, fileHash, ni.Metadata.FileHash)
+		require.Len(t, ni.Metadata.Signatures, 1)
+
+		name, encodedSig, _ := strings.Cut(ni.Metadata.Signatures[0], ":")
+		assert.Equal(t, keyName, name)
+		signature, err := base64.StdEncoding.DecodeString(encodedSig)
+		require.NoError(t, err)
+		assert.True(t, ed25519.Verify(publicKey, []byte(ni.Metadata.Fingerprint()), signature))
+
+		req = NewRequest(t, "HEAD", root+"/0000000000000000000000000000000a.narinfo")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/nar/"+narFilename)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/nar/unknown.nar.xz")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,262 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"crypto/sha1"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/db"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/modules/test"
+	packages_service "code.gitea.io/gitea/services/packages"
+	remote_service "code.gitea.io/gitea/services/packages/remote"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageRemote(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+	defer test.MockVariableValue(&setting.Packages.RemoteAllowedHostList, "loopback")()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := "Bearer " + getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	npmData, _ := base64.StdEncoding.DecodeString("H4sIAAAAAAAA/ytITM5OTE/VL4DQelnF+XkMVAYGBgZmJiYK2MRBwNDcSIHB2NTMwNDQzMwAqA7IMDUxA9LUdgg2UFpcklgEdAql5kD8ogCnhwio5lJQUMpLzE1VslJQcihOzi9I1S9JLS7RhSYIJR2QgrLUouLM/DyQGkM9Az1D3YIiqExKanFyUWZBCVQ2BKhVwQVJDKwosbQkI78IJO/tZ+LsbRykxFXLNdA+HwWjYBSMgpENACgAbtAACAAA")
+	goZip := test.WriteZipArchive(map[string]string{
+		"gitea.com/remote/module@v1.0.0/go.mod": "module gitea.com/remote/module",
+	}).Bytes()
+
+	var metadataRequests, fileRequests atomic.Int64
+	var upstreamBroken atomic.Bool
+
+	mux := http.NewServeMux()
+	var upstream *httptest.Server
+	mux.HandleFunc("GET /npm/remote-package", func(w http.ResponseWriter, r *http.Request) {
+		metadataRequests.Add(1)
+		if upstreamBroken.Load() {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprintf(w, `{
+			"name": "remote-package",
+			"dist-tags": {"latest": "1.0.0"},
+			"versions": {
+				"1.0.0": {
+					"name": "remote-package",
+					"version": "1.0.0",
+					"dist": {
+						"shasum": "aaa7eaf852a948b0aa05afeda35b1badca155d90",
+						"tarball": "%s/tarballs/remote-package-1.0.0.tgz"
+					}
+				}
+			}
+		}`, upstream.URL)
+	})
+	mux.HandleFunc("GET /tarballs/remote-package-1.0.0.tgz", func(w http.ResponseWriter, r *http.Request) {
+		fileRequests.Add(1)
+		_, _ = w.Write(npmData)
+	})
+	mux.HandleFunc("GET /go/gitea.com/remote/module/@v/list", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprintln(w, "v1.0.0")
+	})
+	mux.HandleFunc("GET /go/gitea.com/remote/module/@v/v1.0.0.mod", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "module gitea.com/remote/module")
+	})
+	mux.HandleFunc("GET /go/gitea.com/remote/module/@v/v1.0.0.zip", func(w http.ResponseWriter, r *http.Request) {
+		_, _ = w.Write(goZip)
+	})
+	upstream = httptest.NewServer(mux)
+	defer upstream.Close()
+
+	remotesRoot := fmt.Sprintf("/api/v1/packages/%s/-/remotes", user.Name)
+
+	t.Run("Settings", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "PUT", remotesRoot+"/npm", &api.SetPackageRemoteOption{URL: upstream.URL + "/npm"})
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithJSON(t, "PUT", remotesRoot+"/npm", &api.SetPackageRemoteOption{URL: "ftp://example.com"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", remotesRoot+"/generic", &api.SetPackageRemoteOption{URL: upstream.URL}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", remotesRoot+"/npm", &api.SetPackageRemoteOption{
+			URL:      upstream.URL + "/npm/",
+			Username: "user",
+			Password: "secret",
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		remote := DecodeJSON(t, resp, &api.PackageRemote{})
+		assert.Equal(t, "npm", remote.Type)
+		assert.Equal(t, upstream.URL+"/npm", remote.URL)
+		assert.True(t, remote.HasPassword)
+		assert.EqualValues(t, packages.DefaultRemoteMetadataTTL, remote.MetadataTTL)
+
+		req = NewRequestWithJSON(t, "PUT", remotesRoot+"/go", &api.SetPackageRemoteOption{URL: upstream.URL + "/go"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", remotesRoot).AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		remotes := DecodeJSON(t, resp, []*api.PackageRemote{})
+		assert.Len(t, remotes, 2)
+
+		req = NewRequest(t, "GET", remotesRoot+"/pypi").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Npm", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		root := fmt.Sprintf("/api/packages/%s/npm", user.Name)
+
+		req := NewRequest(t, "GET", root+"/remote-package").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		result := DecodeJSON(t, resp, &npm.PackageMetadata{})
+		require.Contains(t, result.Versions, "1.0.0")
+		assert.Equal(t, "1.0.0", result.DistTags["latest"])
+		assert.Equal(t, fmt.Sprintf("%s%s/remote-package/-/1.0.0/remote-package-1.0.0.tgz", setting.AppURL, root[1:]), result.Versions["1.0.0"].Dist.Tarball)
+
+		// the metadata is cached for the TTL
+		MakeRequest(t, NewRequest(t, "GET", root+"/remote-package").AddTokenAuth(token), http.StatusOK)
+		assert.EqualValues(t, 1, metadataRequests.Load())
+
+		// the readers can't cache the upstream files by default
+		req = NewRequest(t, "GET", root+"/remote-package/-/1.0.0/remote-package-1.0.0.tgz")
+		MakeRequest(t, req, http.StatusForbidden)
+		assert.EqualValues(t, 0, fileRequests.Load())
+
+		req = NewRequest(t, "GET", root+"/remote-package/-/1.0.0/remote-package-1.0.0.tgz").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, npmData, resp.Body.Bytes())
+
+		pvs, err := packages.GetVersionsByPackageName(t.Context(), user.ID, packages.TypeNpm, "remote-package")
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+		assert.Equal(t, "1.0.0", pvs[0].Version)
+		assert.Equal(t, user.ID, pvs[0].CreatorID)
+
+		// the cached file is served without fetching it again, the readers can download it
+		MakeRequest(t, NewRequest(t, "GET", root+"/remote-package/-/1.0.0/remote-package-1.0.0.tgz").AddTokenAuth(token), http.StatusOK)
+		MakeRequest(t, NewRequest(t, "GET", root+"/remote-package/-/1.0.0/remote-package-1.0.0.tgz"), http.StatusOK)
+		assert.EqualValues(t, 1, fileRequests.Load())
+
+		req = NewRequest(t, "GET", root+"/remote-package/-/2.0.0/remote-package-2.0.0.tgz").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", root+"/unknown-package").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("FetchFile", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		r, err := packages.GetRemoteByOwnerAndType(t.Context(), user.ID, packages.TypeNpm)
+		require.NoError(t, err)
+		tarballURL := upstream.URL + "/tarballs/remote-package-1.0.0.tgz"
+
+		sha1Sum := sha1.Sum(npmData)
+		buf, err := remote_service.FetchFile(t.Context(), r, tarballURL, remote_service.FileDigest{Algorithm: "sha1", Sum: sha1Sum[:]})
+		require.NoError(t, err)
+		buf.Close()
+
+		// the file is rejected before it is cached if it doesn't match the upstream metadata
+		_, err = remote_service.FetchFile(t.Context(), r, tarballURL, remote_service.FileDigest{Algorithm: "sha256", Sum: make([]byte, sha256.Size)})
+		assert.ErrorContains(t, err, "digest mismatch")
+
+		defer test.MockVariableValue(&setting.Packages.RemoteLimitSize, int64(len(npmData)-1))()
+		_, err = remote_service.FetchFile(t.Context(), r, tarballURL)
+		assert.ErrorIs(t, err, packages_service.ErrQuotaTypeSize)
+	})
+
+	t.Run("StaleMetadata", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		defer upstreamBroken.Store(false)
+		upstreamBroken.Store(true)
+
+		r, err := packages.GetRemoteByOwnerAndType(t.Context(), user.ID, packages.TypeNpm)
+		require.NoError(t, err)
+
+		_, err = db.GetEngine(t.Context()).Where("remote_id = ?", r.ID).Cols("fetched_unix").Update(&packages.PackageRemoteMetadata{FetchedUnix: 0})
+		require.NoError(t, err)
+
+		metadataRequestsBefore := metadataRequests.Load()
+
+		// the expired metadata is refreshed, but the stale one is used if the upstream registry fails
+		content, err := remote_service.FetchMetadata(t.Context(), r, "remote-package", "application/json")
+		require.NoError(t, err)
+		assert.Contains(t, string(content), `"remote-package"`)
+		assert.Equal(t, metadataRequestsBefore+1, metadataRequests.Load())
+
+		_, err = remote_service.FetchMetadata(t.Context(), r, "unknown-package", "application/json")
+		assert.Error(t, err)
+	})
+
+	t.Run("Go", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		root := fmt.Sprintf("/api/packages/%s/go/gitea.com/remote/module", user.Name)
+
+		req := NewRequest(t, "GET", root+"/@v/list").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "v1.0.0\n", resp.Body.String())
+
+		req = NewRequest(t, "GET", root+"/@v/v1.0.0.mod").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "module gitea.com/remote/module", resp.Body.String())
+
+		// REMOTE_CACHE_ON_READ allows the readers to cache the upstream files
+		defer test.MockVariableValue(&setting.Packages.RemoteCacheOnRead, true)()
+		req = NewRequest(t, "GET", root+"/@v/v1.0.0.zip")
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, goZip, resp.Body.Bytes())
+
+		pv, err := packages.GetVersionByNameAndVersion(t.Context(), user.ID, packages.TypeGo, "gitea.com/remote/module", "v1.0.0")
+		require.NoError(t, err)
+		assert.Equal(t, user.ID, pv.CreatorID)
+
+		// the cached version is listed once
+		req = NewRequest(t, "GET", root+"/@v/list").AddTokenAuth(token)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "v1.0.0\n", resp.Body.String())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		r, err := packages.GetRemoteByOwnerAndType(t.Context(), user.ID, packages.TypeNpm)
+		require.NoError(t, err)
+
+		req := NewRequest(t, "DELETE", remotesRoot+"/npm").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", remotesRoot+"/npm").AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		// the cached packages are kept
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/packages/%s/npm/remote-package", user.Name)).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		result := DecodeJSON(t, resp, &npm.PackageMetadata{})
+		assert.Contains(t, result.Versions, "1.0.0")
+
+		unittest.AssertNotExistsBean(t, &packages.PackageRemoteMetadata{RemoteID: r.ID})
+	})
+}
@@ -549,6 +549,7 @@ func TestPackageCleanup(t *testing.T) {
 	defer tests.PrepareTestEnv(t)()
 
 	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getUserToken(t, user.Name, auth_model.AccessTokenScopeWritePackage)
 
 	duration, _ := time.ParseDuration("-1h")
 
@@ -594,15 +595,18 @@ func TestPackageCleanup(t *testing.T) {
 		defer tests.PrintCurrentTest(t)()
 
 		type version struct {
-			Version     string
-			ShouldExist bool
-			Created     int64
+			Version      string
+			ShouldExist  bool
+			Created      int64
+			LastDownload int64
+			Download     bool
 		}
 
 		cases := []struct {
-			Name     string
-			Versions []version
-			Rule     *packages_model.PackageCleanupRule
+			Name      string
+			Versions  []version
+			Rule      *packages_model.PackageCleanupRule
+			Immutable bool
 		}{
 			{
 				Name: "Disabled",
@@ -648,6 +652,31 @@ func TestPackageCleanup(t *testing.T) {
 					RemoveDays: 60,
 				},
 			},
+			{
+				Name: "KeepDownloadedDays",
+				Versions: []version{
+					{Version: "keep", ShouldExist: true, Created: 1, Download: true},
+					{Version: "test-3", ShouldExist: false, Created: 1, LastDownload: time.Now().AddDate(0, 0, -30).Unix()},
+					{Version: "test-4", ShouldExist: false, Created: 1},
+				},
+				Rule: &packages_model.PackageCleanupRule{
+					Enabled:            true,
+					KeepDownloadedDays: 7,
+				},
+			},
+			{
+				Name: "RemoveUnusedDays",
+				Versions: []version{
+					{Version: "keep", ShouldExist: true},
+					{Version: "v1.0", ShouldExist: true, Created: 1, LastDownload: time.Now().AddDate(0, 0, -7).Unix()},
+					{Version: "test-3", ShouldExist: false, Created: 1, LastDownload: time.Now().AddDate(0, 0, -30).Unix()},
+					{Version: "test-4", ShouldExist: false, Created: 1},
+				},
+				Rule: &packages_model.PackageCleanupRule{
+					Enabled:          true,
+					RemoveUnusedDays: 14,
+				},
+			},
 			{
 				Name: "RemovePattern",
 				Versions: []version{
@@ -692,6 +721,20 @@ func TestPackageCleanup(t *testing.T) {
 					RemovePattern: `t[e]+st-\d+`,
 				},
 			},
+			{
+				Name: "ImmutableVersions",
+				Versions: []version{
+					{Version: "keep", ShouldExist: true},
+					{Version: "v1.0", ShouldExist: true, Created: 1},
+					{Version: "test-3", ShouldExist: true, Created: 1},
+				},
+				Rule: &packages_model.PackageCleanupRule{
+					Enabled:    true,
+					KeepCount:  1,
+					RemoveDays: 60,
+				},
+				Immutable: true,
+			},
 		}
 
 		for _, c := range cases {
@@ -704,20 +747,60 @@ func TestPackageCleanup(t *testing.T) {
 						AddBasicAuth(user.Name)
 					MakeRequest(t, req, http.StatusCreated)
 
-					if v.Created != 0 {
+					if v.Created != 0 || v.LastDownload != 0 {
 						pv, err := packages_model.GetVersionByNameAndVersion(t.Context(), user.ID, packages_model.TypeGeneric, "package", v.Version)
 						assert.NoError(t, err)
-						_, err = db.GetEngine(t.Context()).Exec("UPDATE package_version SET created_unix = ? WHERE id = ?", v.Created, pv.ID)
-						assert.NoError(t, err)
+						if v.Created != 0 {
+							_, err = db.GetEngine(t.Context()).Exec("UPDATE package_version SET created_unix = ? WHERE id = ?", v.Created, pv.ID)
+							assert.NoError(t, err)
+						}
+						if v.LastDownload != 0 {
+							_, err = db.GetEngine(t.Context()).Exec("UPDATE package_version SET last_download_unix = ? WHERE id = ?", v.LastDownload, pv.ID)
+							assert.NoError(t, err)
+						}
+					}
+
+					if v.Download {
+						req = NewRequest(t, "GET", url).
+							AddBasicAuth(user.Name)
+						MakeRequest(t, req, http.StatusOK)
 					}
 				}
 
+				if c.Immutable {
+					assert.NoError(t, packages_service.SetImmutableVersionsEnabled(t.Context(), user.ID, true))
+					defer func() {
+						assert.NoError(t, packages_service.SetImmutableVersionsEnabled(t.Context(), user.ID, false))
+					}()
+				}
+
 				c.Rule.OwnerID = user.ID
 				c.Rule.Type = packages_model.TypeGeneric
 
 				pcr, err := packages_model.InsertCleanupRule(t.Context(), c.Rule)
 				assert.NoError(t, err)
 
+				if c.Rule.Enabled {
+					req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/-/cleanup_rules/generic/preview", user.Name)).
+						AddTokenAuth(token)
+					resp := MakeRequest(t, req, http.StatusOK)
+
+					var candidates []*api.PackageCleanupCandidate
+					DecodeJSON(t, resp, &candidates)
+
+					expected := make([]string, 0, len(c.Versions))
+					for _, v := range c.Versions {
+						if !v.ShouldExist {
+							expected = append(expected, v.Version)
+						}
+					}
+					previewed := make([]string, 0, len(candidates))
+					for _, candidate := range candidates {
+						previewed = append(previewed, candidate.Package.Version)
+					}
+					assert.ElementsMatch(t, expected, previewed)
+				}
+
 				err = packages_cleanup_service.CleanupTask(t.Context(), duration)
 				assert.NoError(t, err)
 
@@ -735,5 +818,9 @@ func TestPackageCleanup(t *testing.T) {
 				assert.NoError(t, packages_model.DeleteCleanupRuleByID(t.Context(), pcr.ID))
 			})
 		}
+
+		req := NewRequest(t, "GET", fmt.Sprintf("/api/v1/packages/%s/-/cleanup_rules/npm/preview", user.Name)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
 	})
 }
@@ -0,0 +1,189 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	"code.gitea.io/gitea/modules/packages/npm"
+	"code.gitea.io/gitea/modules/setting"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestPackageVirtual(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	admin := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 1})
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	publicOrg := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 3})
+	privateOrg := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 35}) // user2 is a member
+	otherUser := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 4})
+
+	userToken := getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+	otherToken := getTokenForLoggedInUser(t, loginUser(t, otherUser.Name), auth_model.AccessTokenScopeReadPackage)
+
+	packageName := "virtual-package"
+	data := "H4sIAAAAAAAA/ytITM5OTE/VL4DQelnF+XkMVAYGBgZmJiYK2MRBwNDcSIHB2NTMwNDQzMwAqA7IMDUxA9LUdgg2UFpcklgEdAql5kD8ogCnhwio5lJQUMpLzE1VslJQcihOzi9I1S9JLS7RhSYIJR2QgrLUouLM/DyQGkM9Az1D3YIiqExKanFyUWZBCVQ2BKhVwQVJDKwosbQkI78IJO/tZ+LsbRykxFXLNdA+HwWjYBSMgpENACgAbtAACAAA"
+
+	uploadNpm := func(t *testing.T, owner *user_model.User, version string) {
+		body := `{
+			"_id": "` + packageName + `",
+			"name": "` + packageName + `",
+			"dist-tags": {"latest": "` + version + `"},
+			"versions": {
+				"` + version + `": {
+					"name": "` + packageName + `",
+					"version": "` + version + `",
+					"dist": {
+						"integrity": "sha512-yA4FJsVhetynGfOC1jFf79BuS+jrHbm0fhh+aHzCQkOaOBXKf9oBnC4a6DnLLnEsHQDRLYd00cwj8sCXpC+wIg=="
+					}
+				}
+			},
+			"_attachments": {
+				"` + packageName + `-` + version + `.tgz": {"data": "` + data + `"}
+			}
+		}`
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/npm/%s", owner.Name, packageName), strings.NewReader(body)).
+			AddBasicAuth(admin.Name)
+		MakeRequest(t, req, http.StatusCreated)
+	}
+
+	uploadNpm(t, publicOrg, "1.0.0")
+	uploadNpm(t, privateOrg, "1.0.0")
+	uploadNpm(t, privateOrg, "2.0.0")
+
+	virtualsRoot := fmt.Sprintf("/api/v1/packages/%s/-/virtuals", user.Name)
+
+	t.Run("Settings", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "PUT", virtualsRoot+"/npm", &api.SetPackageVirtualRegistryOption{Members: []string{publicOrg.Name}}).
+			AddTokenAuth(otherToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithJSON(t, "PUT", virtualsRoot+"/generic", &api.SetPackageVirtualRegistryOption{Members: []string{publicOrg.Name}}).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", virtualsRoot+"/npm", &api.SetPackageVirtualRegistryOption{Members: []string{user.Name}}).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", virtualsRoot+"/npm", &api.SetPackageVirtualRegistryOption{Members: []string{"unknown-owner"}}).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		req = NewRequestWithJSON(t, "PUT", virtualsRoot+"/npm", &api.SetPackageVirtualRegistryOption{Members: []string{publicOrg.Name, privateOrg.Name}}).
+			AddTokenAuth(userToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		registry := DecodeJSON(t, resp, &api.PackageVirtualRegistry{})
+		assert.Equal(t, "npm", registry.Type)
+		assert.Equal(t, []string{publicOrg.Name, privateOrg.Name}, registry.Members)
+
+		req = NewRequest(t, "GET", virtualsRoot).AddTokenAuth(userToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		registries := DecodeJSON(t, resp, []*api.PackageVirtualRegistry{})
+		assert.Len(t, registries, 1)
+
+		req = NewRequest(t, "GET", virtualsRoot+"/maven").AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	root := fmt.Sprintf("/api/packages/%s/npm/%s", user.Name, packageName)
+	tarballURL := func(owner *user_model.User, version string) string {
+		return fmt.Sprintf("%sapi/packages/%s/npm/%s/-/%s/%s-%s.tgz", setting.AppURL, owner.Name, packageName, version, packageName, version)
+	}
+
+	t.Run("Resolve", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root).AddTokenAuth(userToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		result := DecodeJSON(t, resp, &npm.PackageMetadata{})
+		assert.Len(t, result.Versions, 2)
+		// the version of the member which is resolved first takes precedence
+		assert.Equal(t, tarballURL(publicOrg, "1.0.0"), result.Versions["1.0.0"].Dist.Tarball)
+		assert.Equal(t, tarballURL(privateOrg, "2.0.0"), result.Versions["2.0.0"].Dist.Tarball)
+		assert.Equal(t, "1.0.0", result.DistTags["latest"])
+
+		req = NewRequest(t, "GET", strings.TrimPrefix(tarballURL(privateOrg, "2.0.0"), setting.AppURL)).AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusOK)
+	})
+
+	t.Run("Permission", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		// the packages of the private member are ignored for the users who can't read them
+		for _, req := range []*RequestWrapper{
+			NewRequest(t, "GET", root),
+			NewRequest(t, "GET", root).AddTokenAuth(otherToken),
+		} {
+			resp := MakeRequest(t, req, http.StatusOK)
+			result := DecodeJSON(t, resp, &npm.PackageMetadata{})
+			assert.Len(t, result.Versions, 1)
+			assert.Contains(t, result.Versions, "1.0.0")
+		}
+	})
+
+	t.Run("Priority", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithJSON(t, "PUT", virtualsRoot+"/npm", &api.SetPackageVirtualRegistryOption{Members: []string{privateOrg.Name, publicOrg.Name}}).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", root).AddTokenAuth(userToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		result := DecodeJSON(t, resp, &npm.PackageMetadata{})
+		assert.Equal(t, tarballURL(privateOrg, "1.0.0"), result.Versions["1.0.0"].Dist.Tarball)
+		assert.Equal(t, "2.0.0", result.DistTags["latest"])
+	})
+
+	t.Run("Maven", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/maven/com/gitea/virtual/1.0.0/virtual-1.0.0.jar", publicOrg.Name), strings.NewReader("content")).
+			AddBasicAuth(admin.Name)
+		MakeRequest(t, req, http.StatusCreated)
+
+		mavenRoot := fmt.Sprintf("/api/packages/%s/maven/com/gitea/virtual", user.Name)
+
+		req = NewRequest(t, "GET", mavenRoot+"/maven-metadata.xml").AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithJSON(t, "PUT", virtualsRoot+"/maven", &api.SetPackageVirtualRegistryOption{Members: []string{publicOrg.Name}}).
+			AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusOK)
+
+		req = NewRequest(t, "GET", mavenRoot+"/maven-metadata.xml").AddTokenAuth(userToken)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Contains(t, resp.Body.String(), "<version>1.0.0</version>")
+
+		req = NewRequest(t, "GET", mavenRoot+"/1.0.0/virtual-1.0.0.jar").AddTokenAuth(userToken)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, "content", resp.Body.String())
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", virtualsRoot+"/npm").AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "DELETE", virtualsRoot+"/npm").AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequest(t, "GET", root).AddTokenAuth(userToken)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+}
@@ -0,0 +1,265 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"bytes"
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/packages"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	vsix_module "code.gitea.io/gitea/modules/packages/vsix"
+	"code.gitea.io/gitea/modules/test"
+	vsix_router "code.gitea.io/gitea/routers/api/packages/vsix"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPackageVsix(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	token := getTokenForLoggedInUser(t, loginUser(t, user.Name), auth_model.AccessTokenScopeWritePackage)
+
+	namespace := "gitea"
+	extension := "test-extension"
+	packageName := namespace + "." + extension
+	packageVersion := "1.0.1"
+	packageDescription := "Extension Description"
+	icon := "icon"
+
+	createPackage := func(version string) []byte {
+		return test.WriteZipArchive(map[string]string{
+			"extension.vsixmanifest": `<?xml version="1.0" encoding="utf-8"?>
+<PackageManifest Version="2.0.0" xmlns="http://schemas.microsoft.com/developer/vsx-schema/2011">
+	<Metadata>
+		<Identity Language="en-US" Id="` + extension + `" Version="` + version + `" Publisher="` + namespace + `" />
+		<DisplayName>Test Extension</DisplayName>
+		<Description xml:space="preserve">` + packageDescription + `</Description>
+		<Tags>gitea</Tags>
+		<Categories>Other</Categories>
+		<Properties>
+			<Property Id="Microsoft.VisualStudio.Code.Engine" Value="^1.80.0" />
+		</Properties>
+	</Metadata>
+	<Assets>
+		<Asset Type="Microsoft.VisualStudio.Code.Manifest" Path="extension/package.json" Addressable="true" />
+		<Asset Type="Microsoft.VisualStudio.Services.Icons.Default" Path="extension/icon.png" Addressable="true" />
+	</Assets>
+</PackageManifest>`,
+			"extension/package.json": `{"name":"` + extension + `","license":"MIT"}`,
+			"extension/icon.png":     icon,
+		}).Bytes()
+	}
+
+	content := createPackage(packageVersion)
+
+	root := fmt.Sprintf("/api/packages/%s/vsix", user.Name)
+	packageFilename := fmt.Sprintf("%s-%s.vsix", packageName, packageVersion)
+
+	t.Run("Upload", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		uploadURL := root + "/api/-/publish"
+
+		req := NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content))
+		MakeRequest(t, req, http.StatusUnauthorized)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, strings.NewReader("invalid")).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content)).
+			AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusCreated)
+
+		var result vsix_router.Extension
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, namespace, result.Namespace)
+		assert.Equal(t, extension, result.Name)
+		assert.Equal(t, packageVersion, result.Version)
+		assert.Equal(t, "MIT", result.License)
+		assert.Contains(t, result.Files, "download")
+		assert.Contains(t, result.Files, "icon")
+
+		pvs, err := packages.GetVersionsByPackageType(t.Context(), user.ID, packages.TypeVsix)
+		require.NoError(t, err)
+		require.Len(t, pvs, 1)
+
+		pd, err := packages.GetPackageDescriptor(t.Context(), pvs[0])
+		require.NoError(t, err)
+		assert.NotNil(t, pd.SemVer)
+		assert.IsType(t, &vsix_module.Metadata{}, pd.Metadata)
+		assert.Equal(t, packageName, pd.Package.Name)
+		assert.Equal(t, packageVersion, pd.Version.Version)
+		assert.Equal(t, packageDescription, pd.Metadata.(*vsix_module.Metadata).Description)
+
+		pfs, err := packages.GetFilesByVersionID(t.Context(), pvs[0].ID)
+		require.NoError(t, err)
+		require.Len(t, pfs, 3)
+		for _, pf := range pfs {
+			switch pf.Name {
+			case packageFilename:
+				assert.True(t, pf.IsLead)
+			case vsix_module.AssetTypeManifest, vsix_module.AssetTypeIcon:
+				assert.False(t, pf.IsLead)
+			default:
+				assert.Failf(t, "unexpected file", "%s", pf.Name)
+			}
+		}
+
+		req = NewRequestWithBody(t, "POST", uploadURL, bytes.NewReader(content)).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusConflict)
+	})
+
+	t.Run("Namespace", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", root+"/api/"+namespace)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result vsix_router.Namespace
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, namespace, result.Name)
+		assert.Contains(t, result.Extensions, extension)
+
+		req = NewRequest(t, "GET", root+"/api/unknown")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Extension", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "POST", root+"/api/-/publish", bytes.NewReader(createPackage("1.1.0-beta"))).
+			AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// the pre-release is not the latest version
+		req = NewRequest(t, "GET", root+"/api/"+namespace+"/"+extension)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result vsix_router.Extension
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, packageVersion, result.Version)
+		assert.Len(t, result.AllVersions, 2)
+
+		req = NewRequest(t, "GET", root+"/api/"+namespace+"/"+extension+"/1.1.0-beta")
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &result)
+		assert.Equal(t, "1.1.0-beta", result.Version)
+
+		req = NewRequest(t, "GET", root+"/api/"+namespace+"/"+extension+"/2.0.0")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Query", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		var result vsix_router.QueryResult
+
+		req := NewRequest(t, "GET", root+"/api/-/query?extensionId="+packageName)
+		resp := MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &result)
+		require.Len(t, result.Extensions, 1)
+		assert.Equal(t, packageVersion, result.Extensions[0].Version)
+
+		req = NewRequest(t, "GET", root+"/api/-/query?namespaceName="+namespace+"&includeAllVersions=true")
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &result)
+		assert.Len(t, result.Extensions, 2)
+
+		req = NewRequest(t, "GET", root+"/api/-/query?extensionId=gitea.unknown")
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &result)
+		assert.Empty(t, result.Extensions)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		var result vsix_router.SearchResult
+
+		req := NewRequest(t, "GET", root+"/api/-/search?query=description")
+		resp := MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &result)
+		assert.EqualValues(t, 1, result.TotalSize)
+		require.Len(t, result.Extensions, 1)
+		assert.Equal(t, extension, result.Extensions[0].Name)
+
+		req = NewRequest(t, "GET", root+"/api/-/search?category=Unknown")
+		resp = MakeRequest(t, req, http.StatusOK)
+		DecodeJSON(t, resp, &result)
+		assert.EqualValues(t, 0, result.TotalSize)
+	})
+
+	t.Run("Download", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		versionURL := root + "/api/" + namespace + "/" + extension + "/" + packageVersion
+
+		req := NewRequest(t, "GET", versionURL+"/file/"+packageFilename)
+		resp := MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", versionURL+"/file/"+vsix_module.AssetTypeIcon)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, icon, resp.Body.String())
+
+		req = NewRequest(t, "GET", versionURL+"/file/unknown")
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Gallery", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "POST", root+"/vscode/gallery/extensionquery", strings.NewReader(`{"filters":[{"criteria":[{"filterType":7,"value":"`+packageName+`"}],"pageNumber":1,"pageSize":10}],"flags":512}`))
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var result struct {
+			Results []struct {
+				Extensions []struct {
+					ExtensionName string `json:"extensionName"`
+					Publisher     struct {
+						PublisherName string `json:"publisherName"`
+					} `json:"publisher"`
+					Versions []struct {
+						Version  string `json:"version"`
+						AssetURI string `json:"assetUri"`
+					} `json:"versions"`
+				} `json:"extensions"`
+			} `json:"results"`
+		}
+		DecodeJSON(t, resp, &result)
+		require.Len(t, result.Results, 1)
+		require.Len(t, result.Results[0].Extensions, 1)
+		e := result.Results[0].Extensions[0]
+		assert.Equal(t, extension, e.ExtensionName)
+		assert.Equal(t, namespace, e.Publisher.PublisherName)
+		require.Len(t, e.Versions, 1)
+		assert.Equal(t, packageVersion, e.Versions[0].Version)
+
+		req = NewRequest(t, "GET", e.Versions[0].AssetURI+"/"+vsix_module.AssetTypePackage)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", e.Versions[0].AssetURI+"/"+vsix_module.AssetTypeIcon)
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, icon, resp.Body.String())
+
+		req = NewRequest(t, "GET", fmt.Sprintf("%s/vscode/gallery/publishers/%s/vsextensions/%s/%s/vspackage", root, namespace, extension, packageVersion))
+		resp = MakeRequest(t, req, http.StatusOK)
+		assert.Equal(t, content, resp.Body.Bytes())
+
+		req = NewRequest(t, "GET", root+"/vscode/item?itemName="+packageName)
+		MakeRequest(t, req, http.StatusSeeOther)
+	})
+}
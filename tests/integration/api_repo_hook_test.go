@@ -6,6 +6,7 @@ package integration
 import (
 	"fmt"
 	"net/http"
+	"net/url"
 	"testing"
 
 	auth_model "code.gitea.io/gitea/models/auth"
@@ -16,6 +17,7 @@ import (
 	"code.gitea.io/gitea/tests"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func TestAPICreateHook(t *testing.T) {
@@ -88,3 +90,191 @@ func TestAPICreateHook(t *testing.T) {
 	cleared := DecodeJSON(t, clearResp, &api.Hook{})
 	assert.Empty(t, cleared.Name)
 }
+
+func TestAPICustomHook(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteRepository)
+	hooksURL := fmt.Sprintf("/api/v1/repos/%s/%s/hooks", owner.Name, repo.Name)
+
+	req := NewRequestWithJSON(t, "POST", hooksURL, api.CreateHookOption{
+		Type: "custom",
+		Config: api.CreateHookOptionConfig{
+			"content_type":  "json",
+			"url":           "http://example.com/",
+			"body_template": "{{range 1000}}{{end}",
+		},
+		Active: true,
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", hooksURL, api.CreateHookOption{
+		Type: "custom",
+		Config: api.CreateHookOptionConfig{
+			"content_type":         "json",
+			"url":                  "http://example.com/",
+			"body_template":        `{"repo": {{json .Repo.FullName}}, "ref": {{json .Ref}}}`,
+			"body_content_type":    "application/vnd.{{event}}+json",
+			"header.X-Sender":      "{{.Sender.UserName}}",
+			"body_template.issues": `{"issue": {{json .Issue.Title}}}`,
+		},
+		Events: []string{"push", "issues"},
+		Active: true,
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+
+	apiHook := DecodeJSON(t, resp, &api.Hook{})
+	assert.Equal(t, "custom", apiHook.Type)
+	assert.Equal(t, "{{.Sender.UserName}}", apiHook.Config["header.X-Sender"])
+	assert.Equal(t, `{"issue": {{json .Issue.Title}}}`, apiHook.Config["body_template.issues"])
+
+	previewURL := fmt.Sprintf("%s/%d/preview", hooksURL, apiHook.ID)
+
+	t.Run("Sample", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", previewURL, api.HookPreviewOption{Event: "push"}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		preview := DecodeJSON(t, resp, &api.HookPreview{})
+		assert.Equal(t, "http://example.com/", preview.URL)
+		assert.Equal(t, "POST", preview.HTTPMethod)
+		assert.Equal(t, "application/vnd.push+json", preview.Headers["Content-Type"])
+		assert.Equal(t, owner.Name, preview.Headers["X-Sender"])
+		assert.Equal(t, "******", preview.Headers["X-Hub-Signature-256"])
+		assert.JSONEq(t, fmt.Sprintf(`{"repo": %q, "ref": "refs/heads/%s"}`, repo.FullName(), repo.DefaultBranch), preview.Body)
+	})
+
+	t.Run("Payload", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", previewURL, api.HookPreviewOption{
+			Event:   "issue_label",
+			Payload: map[string]any{"issue": map[string]any{"title": "Preview"}, "sender": map[string]any{"login": "someone"}},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		preview := DecodeJSON(t, resp, &api.HookPreview{})
+		assert.Equal(t, "application/vnd.issue_label+json", preview.Headers["Content-Type"])
+		assert.Equal(t, "someone", preview.Headers["X-Sender"])
+		assert.JSONEq(t, `{"issue": "Preview"}`, preview.Body)
+	})
+
+	t.Run("Invalid", func(t *testing.T) {
+		req := NewRequestWithJSON(t, "POST", previewURL, api.HookPreviewOption{Event: "unknown"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+		// the issue template can't be rendered with a push payload without an issue
+		req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/%d", hooksURL, apiHook.ID), api.EditHookOption{
+			Config: map[string]string{"body_template.issues": "", "body_template": "{{.Issue.Title}}"},
+		}).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+		edited := DecodeJSON(t, resp, &api.Hook{})
+		assert.NotContains(t, edited.Config, "body_template.issues")
+
+		req = NewRequestWithJSON(t, "POST", previewURL, api.HookPreviewOption{Event: "push"}).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusUnprocessableEntity)
+	})
+}
+
+func TestAPICloudEventsHook(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteRepository)
+	hooksURL := fmt.Sprintf("/api/v1/repos/%s/%s/hooks", owner.Name, repo.Name)
+
+	req := NewRequestWithJSON(t, "POST", hooksURL, api.CreateHookOption{
+		Type: "cloudevents",
+		Config: api.CreateHookOptionConfig{
+			"content_type": "json",
+			"url":          "http://example.com/",
+			"content_mode": "xml",
+		},
+		Active: true,
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", hooksURL, api.CreateHookOption{
+		Type: "cloudevents",
+		Config: api.CreateHookOptionConfig{
+			"content_type": "json",
+			"url":          "http://example.com/",
+			"content_mode": "binary",
+		},
+		Events: []string{"push"},
+		Active: true,
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+
+	apiHook := DecodeJSON(t, resp, &api.Hook{})
+	assert.Equal(t, "cloudevents", apiHook.Type)
+	assert.Equal(t, "binary", apiHook.Config["content_mode"])
+
+	req = NewRequestWithJSON(t, "POST", fmt.Sprintf("%s/%d/preview", hooksURL, apiHook.ID), api.HookPreviewOption{Event: "push"}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	preview := DecodeJSON(t, resp, &api.HookPreview{})
+	assert.Equal(t, "io.gitea.push", preview.Headers["Ce-Type"])
+	assert.Equal(t, repo.HTMLURL(), preview.Headers["Ce-Source"])
+
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/%d", hooksURL, apiHook.ID), api.EditHookOption{
+		Config: map[string]string{"content_mode": "batched", "batch_size": "20"},
+	}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	apiHook = DecodeJSON(t, resp, &api.Hook{})
+	assert.Equal(t, "batched", apiHook.Config["content_mode"])
+	assert.Equal(t, "20", apiHook.Config["batch_size"])
+}
+
+func TestAPIZulipHook(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	repo := unittest.AssertExistsAndLoadBean(t, &repo_model.Repository{ID: 1})
+	owner := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: repo.OwnerID})
+
+	token := getUserToken(t, owner.Name, auth_model.AccessTokenScopeWriteRepository)
+	hooksURL := fmt.Sprintf("/api/v1/repos/%s/%s/hooks", owner.Name, repo.Name)
+
+	req := NewRequestWithJSON(t, "POST", hooksURL, api.CreateHookOption{
+		Type: "zulip",
+		Config: api.CreateHookOptionConfig{
+			"content_type": "form",
+			"url":          "http://example.com/api/v1/messages",
+			"topic":        "{{event}}",
+		},
+		Active: true,
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+
+	req = NewRequestWithJSON(t, "POST", hooksURL, api.CreateHookOption{
+		Type: "zulip",
+		Config: api.CreateHookOptionConfig{
+			"content_type": "form",
+			"url":          "http://example.com/api/v1/messages",
+			"stream":       "gitea",
+			"topic":        "{{event}}",
+		},
+		Events: []string{"push"},
+		Active: true,
+	}).AddTokenAuth(token)
+	resp := MakeRequest(t, req, http.StatusCreated)
+
+	apiHook := DecodeJSON(t, resp, &api.Hook{})
+	assert.Equal(t, "zulip", apiHook.Type)
+	assert.Equal(t, "gitea", apiHook.Config["stream"])
+	assert.Equal(t, "{{event}}", apiHook.Config["topic"])
+
+	req = NewRequestWithJSON(t, "POST", fmt.Sprintf("%s/%d/preview", hooksURL, apiHook.ID), api.HookPreviewOption{Event: "push"}).AddTokenAuth(token)
+	resp = MakeRequest(t, req, http.StatusOK)
+	preview := DecodeJSON(t, resp, &api.HookPreview{})
+	values, err := url.ParseQuery(preview.Body)
+	require.NoError(t, err)
+	assert.Equal(t, "gitea", values.Get("to"))
+	assert.Equal(t, "push", values.Get("topic"))
+
+	req = NewRequestWithJSON(t, "PATCH", fmt.Sprintf("%s/%d", hooksURL, apiHook.ID), api.EditHookOption{
+		Config: map[string]string{"topic": "{{.Repo.Name"},
+	}).AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusUnprocessableEntity)
+}
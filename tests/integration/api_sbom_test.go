@@ -0,0 +1,176 @@
+// Copyright 2026 The Gitea Authors. All rights reserved.
+// SPDX-License-Identifier: MIT
+
+package integration
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+	"testing"
+
+	auth_model "code.gitea.io/gitea/models/auth"
+	"code.gitea.io/gitea/models/unittest"
+	user_model "code.gitea.io/gitea/models/user"
+	api "code.gitea.io/gitea/modules/structs"
+	"code.gitea.io/gitea/tests"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAPISBOM(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	user := unittest.AssertExistsAndLoadBean(t, &user_model.User{ID: 2})
+	session := loginUser(t, user.Name)
+	token := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWritePackage, auth_model.AccessTokenScopeWriteRepository)
+	packageToken := getTokenForLoggedInUser(t, session, auth_model.AccessTokenScopeWritePackage)
+
+	packageName := "sbom-package"
+	packageVersion := "1.0.0"
+	commitSHA := "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+
+	cycloneDX := `{"bomFormat":"CycloneDX","specVersion":"1.5","metadata":{"component":{"name":"app"}},"components":[{"name":"lodash","version":"4.17.21","purl":"pkg:npm/lodash@4.17.21","licenses":[{"license":{"id":"MIT"}}]},{"name":"express","version":"4.18.2","purl":"pkg:npm/express@4.18.2"}]}`
+	spdx := `{"spdxVersion":"SPDX-2.3","name":"repo1","packages":[{"name":"lodash","versionInfo":"4.17.20","licenseConcluded":"MIT","externalRefs":[{"referenceType":"purl","referenceLocator":"pkg:npm/lodash@4.17.20"}]}]}`
+
+	req := NewRequestWithBody(t, "PUT", fmt.Sprintf("/api/packages/%s/generic/%s/%s/file.bin", user.Name, packageName, packageVersion), strings.NewReader("content")).
+		AddTokenAuth(token)
+	MakeRequest(t, req, http.StatusCreated)
+
+	packageSBOMURL := fmt.Sprintf("/api/v1/packages/%s/generic/%s/%s/sbom", user.Name, packageName, packageVersion)
+	commitSBOMURL := fmt.Sprintf("/api/v1/repos/%s/repo1/git/commits/master/sbom", user.Name)
+	releaseSBOMURL := fmt.Sprintf("/api/v1/repos/%s/repo1/releases/1/sbom", user.Name)
+	searchURL := fmt.Sprintf("/api/v1/packages/%s/-/sbom/components", user.Name)
+
+	t.Run("Package", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", packageSBOMURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithBody(t, "PUT", packageSBOMURL, strings.NewReader(`{"name":"unknown"}`)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusBadRequest)
+
+		req = NewRequestWithBody(t, "PUT", packageSBOMURL, strings.NewReader(cycloneDX)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", packageSBOMURL).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var doc *api.SBOMDocument
+		DecodeJSON(t, resp, &doc)
+		assert.Equal(t, "cyclonedx", doc.Format)
+		assert.Equal(t, "1.5", doc.SpecVersion)
+		assert.Equal(t, "app", doc.Name)
+		assert.Equal(t, 2, doc.ComponentCount)
+		require.Len(t, doc.Components, 2)
+		assert.Equal(t, "express", doc.Components[0].Name)
+		assert.Equal(t, "lodash", doc.Components[1].Name)
+		assert.Equal(t, "MIT", doc.Components[1].License)
+	})
+
+	t.Run("Commit", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "GET", commitSBOMURL).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+
+		req = NewRequestWithBody(t, "PUT", commitSBOMURL, strings.NewReader(spdx)).AddTokenAuth(packageToken)
+		MakeRequest(t, req, http.StatusForbidden)
+
+		req = NewRequestWithBody(t, "PUT", commitSBOMURL, strings.NewReader(spdx)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// the document is attached to the commit and not to the branch
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/repo1/git/commits/%s/sbom", user.Name, commitSHA)).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var doc *api.SBOMDocument
+		DecodeJSON(t, resp, &doc)
+		assert.Equal(t, "spdx", doc.Format)
+		require.Len(t, doc.Components, 1)
+		assert.Equal(t, "pkg:npm/lodash@4.17.20", doc.Components[0].PURL)
+	})
+
+	t.Run("Release", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequestWithBody(t, "PUT", releaseSBOMURL, strings.NewReader(cycloneDX)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		// uploading again replaces the document
+		req = NewRequestWithBody(t, "PUT", releaseSBOMURL, strings.NewReader(spdx)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusCreated)
+
+		req = NewRequest(t, "GET", releaseSBOMURL).AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var doc *api.SBOMDocument
+		DecodeJSON(t, resp, &doc)
+		assert.Equal(t, "spdx", doc.Format)
+		assert.Equal(t, 1, doc.ComponentCount)
+
+		req = NewRequest(t, "GET", fmt.Sprintf("/api/v1/repos/%s/repo1/releases/999999/sbom", user.Name)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNotFound)
+	})
+
+	t.Run("Search", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		search := func(t *testing.T, token, query string) []*api.SBOMComponentSearchResult {
+			req := NewRequest(t, "GET", searchURL+"?"+query).AddTokenAuth(token)
+			resp := MakeRequest(t, req, http.StatusOK)
+
+			var results []*api.SBOMComponentSearchResult
+			DecodeJSON(t, resp, &results)
+			return results
+		}
+
+		results := search(t, token, "purl=pkg:npm/lodash")
+		require.Len(t, results, 3)
+		for _, r := range results {
+			assert.Equal(t, "lodash", r.Component.Name)
+		}
+
+		results = search(t, token, "purl=pkg:npm/lodash@4.17.21")
+		require.Len(t, results, 1)
+		require.NotNil(t, results[0].Package)
+		assert.Equal(t, packageName, results[0].Package.Name)
+		assert.Nil(t, results[0].Repository)
+
+		results = search(t, token, "purl=pkg:npm/lodash&version=4.17.20")
+		require.Len(t, results, 2)
+		for _, r := range results {
+			require.NotNil(t, r.Repository)
+			assert.Equal(t, "repo1", r.Repository.Name)
+		}
+		assert.ElementsMatch(t, []string{commitSHA, ""}, []string{results[0].CommitSHA, results[1].CommitSHA})
+
+		results = search(t, token, "q=expr")
+		require.Len(t, results, 1)
+		assert.Equal(t, "express", results[0].Component.Name)
+
+		results = search(t, token, "license=MIT")
+		assert.Len(t, results, 3)
+
+		// the repositories are not searched without the repository scope
+		results = search(t, packageToken, "purl=pkg:npm/lodash")
+		require.Len(t, results, 1)
+		assert.NotNil(t, results[0].Package)
+	})
+
+	t.Run("Delete", func(t *testing.T) {
+		defer tests.PrintCurrentTest(t)()
+
+		req := NewRequest(t, "DELETE", fmt.Sprintf("/api/v1/packages/%s/generic/%s/%s", user.Name, packageName, packageVersion)).AddTokenAuth(token)
+		MakeRequest(t, req, http.StatusNoContent)
+
+		req = NewRequest(t, "GET", searchURL+"?purl=pkg:npm/express").AddTokenAuth(token)
+		resp := MakeRequest(t, req, http.StatusOK)
+
+		var results []*api.SBOMComponentSearchResult
+		DecodeJSON(t, resp, &results)
+		assert.Empty(t, results)
+	})
+}
@@ -219,3 +219,15 @@ func TestRepoCommitsStatusMultiple(t *testing.T) {
 	sel := doc.doc.Find(`#commits-table .message [data-global-init="initCommitStatuses"] .commit-status`)
 	assert.Equal(t, 1, sel.Length())
 }
+
+func TestRepoCommitsStatusReservedContext(t *testing.T) {
+	defer tests.PrepareTestEnv(t)()
+
+	commitID := "65f1bf27bc3bf70f64657658635e66094edbcb4d"
+	ctx := NewAPITestContext(t, "user2", "repo1", auth_model.AccessTokenScopeWriteRepository)
+	// the contexts of the required workflows can't be spoofed by the API
+	ctx.ExpectedCode = http.StatusBadRequest
+	t.Run("CreateReservedStatus", doAPICreateCommitStatusTest(ctx, commitID, commitstatus.CommitStatusSuccess, "required-workflow/1: compliance / scan (pull_request)"))
+	ctx.ExpectedCode = http.StatusCreated
+	t.Run("CreateStatus", doAPICreateCommitStatusTest(ctx, commitID, commitstatus.CommitStatusSuccess, "compliance / scan (pull_request)"))
+}
@@ -68,6 +68,7 @@ import octiconRepoTemplate from '../../public/assets/img/svg/octicon-repo-templa
 import octiconRss from '../../public/assets/img/svg/octicon-rss.svg';
 import octiconScreenFull from '../../public/assets/img/svg/octicon-screen-full.svg';
 import octiconSearch from '../../public/assets/img/svg/octicon-search.svg';
+import octiconShieldLock from '../../public/assets/img/svg/octicon-shield-lock.svg';
 import octiconSidebarCollapse from '../../public/assets/img/svg/octicon-sidebar-collapse.svg';
 import octiconSidebarExpand from '../../public/assets/img/svg/octicon-sidebar-expand.svg';
 import octiconSkip from '../../public/assets/img/svg/octicon-skip.svg';
@@ -152,6 +153,7 @@ const svgs = {
   'octicon-rss': octiconRss,
   'octicon-screen-full': octiconScreenFull,
   'octicon-search': octiconSearch,
+  'octicon-shield-lock': octiconShieldLock,
   'octicon-sidebar-collapse': octiconSidebarCollapse,
   'octicon-sidebar-expand': octiconSidebarExpand,
   'octicon-skip': octiconSkip,